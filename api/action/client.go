@@ -4,7 +4,10 @@
 package action
 
 import (
+	"net/url"
+
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
@@ -118,3 +121,66 @@ func (c *Client) ApplicationCharmActions(arg params.Entity) (map[string]params.A
 	}
 	return result.Actions, nil
 }
+
+// ScheduleActions schedules actions to be enqueued on their receivers
+// once at a given time, or repeatedly on a schedule.
+func (c *Client) ScheduleActions(arg params.ActionScheduleArgs) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("scheduling actions on this version of Juju")
+	}
+	err := c.facade.FacadeCall("ScheduleActions", arg, &results)
+	return results, err
+}
+
+// ListSchedules returns the scheduled actions of the model, ordered by
+// the time they next run.
+func (c *Client) ListSchedules() ([]params.ActionSchedule, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("scheduling actions on this version of Juju")
+	}
+	results := params.ActionSchedules{}
+	if err := c.facade.FacadeCall("ListSchedules", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Schedules, nil
+}
+
+// RemoveSchedules removes the scheduled actions with the given ids.
+func (c *Client) RemoveSchedules(ids []string) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("scheduling actions on this version of Juju")
+	}
+	err := c.facade.FacadeCall("RemoveSchedules", params.ActionScheduleIds{Ids: ids}, &results)
+	return results, err
+}
+
+// WatchActionOutput streams the output of the actions with the given
+// tags as it is written. A message is sent on the returned channel for
+// each chunk of output, and once more for each action when it finishes.
+// The channel is closed when all of the actions have finished, or when
+// the stream fails.
+func (c *Client) WatchActionOutput(tags []names.ActionTag) (<-chan params.ActionOutputMessage, error) {
+	attrs := url.Values{}
+	for _, tag := range tags {
+		attrs.Add("action", tag.String())
+	}
+	stream, err := c.facade.RawAPICaller().ConnectStream("/action-output", attrs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot stream action output")
+	}
+	messages := make(chan params.ActionOutputMessage)
+	go func() {
+		defer close(messages)
+		defer stream.Close()
+		for {
+			var msg params.ActionOutputMessage
+			if err := stream.ReadJSON(&msg); err != nil {
+				return
+			}
+			messages <- msg
+		}
+	}()
+	return messages, nil
+}
@@ -5,6 +5,7 @@ package action_test
 
 import (
 	"errors"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -12,6 +13,8 @@ import (
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type actionSuite struct {
@@ -127,3 +130,83 @@ func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults [
 		},
 	)
 }
+
+func (s *actionSuite) TestListSchedules(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "ListSchedules")
+			c.Check(paramsIn, gc.IsNil)
+			result := resp.(*params.ActionSchedules)
+			result.Schedules = []params.ActionSchedule{{Id: "0", Name: "backup"}}
+			return nil
+		},
+	)
+	defer cleanup()
+	schedules, err := s.client.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedules, jc.DeepEquals, []params.ActionSchedule{{Id: "0", Name: "backup"}})
+}
+
+func (s *actionSuite) TestRemoveSchedules(c *gc.C) {
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "RemoveSchedules")
+			c.Check(paramsIn, jc.DeepEquals, params.ActionScheduleIds{Ids: []string{"0", "1"}})
+			result := resp.(*params.ErrorResults)
+			result.Results = []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}}
+			return nil
+		},
+	)
+	defer cleanup()
+	results, err := s.client.RemoveSchedules([]string{"0", "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *actionSuite) TestWatchActionOutput(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	a, err := unit.AddAction("juju-run", map[string]interface{}{
+		"command": "hostname",
+		"timeout": 0,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	err = a.AddOutput(state.ActionOutput{Seq: 0, Stream: state.ActionStdout, Data: "web-0\n", Timestamp: now})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	messages, err := s.client.WatchActionOutput([]names.ActionTag{a.ActionTag()})
+	c.Assert(err, jc.ErrorIsNil)
+	var received []params.ActionOutputMessage
+	for {
+		select {
+		case msg, ok := <-messages:
+			if ok {
+				received = append(received, msg)
+				continue
+			}
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for action output")
+		}
+		break
+	}
+	c.Assert(received, gc.HasLen, 2)
+	c.Check(received[0], jc.DeepEquals, params.ActionOutputMessage{
+		ActionTag: a.ActionTag().String(),
+		Receiver:  unit.Tag().String(),
+		Stream:    "stdout",
+		Data:      "web-0\n",
+		Timestamp: now,
+	})
+	c.Check(received[1].ActionTag, gc.Equals, a.ActionTag().String())
+	c.Check(received[1].Status, gc.Equals, params.ActionCompleted)
+}
+
+func (s *actionSuite) TestWatchActionOutputNotFound(c *gc.C) {
+	_, err := s.client.WatchActionOutput([]names.ActionTag{
+		names.NewActionTag("f47ac10b-58cc-4372-a567-0e02b2c3d479"),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot stream action output: action "f47ac10b-58cc-4372-a567-0e02b2c3d479" not found`)
+}
@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides access to the ActionScheduler API
+// facade, used by the action scheduler worker.
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+const actionSchedulerFacade = "ActionScheduler"
+
+// API provides access to the ActionScheduler API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side ActionScheduler facade.
+func NewAPI(caller base.APICaller) *API {
+	facadeCaller := base.NewFacadeCaller(caller, actionSchedulerFacade)
+	return &API{facade: facadeCaller}
+}
+
+// WatchActionSchedules returns a watcher that notifies when action
+// schedules are added, removed or run.
+func (api *API) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	err := api.facade.FacadeCall("WatchActionSchedules", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(api.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// ActionSchedules returns the action schedules of the model, ordered
+// by the time they next run.
+func (api *API) ActionSchedules() ([]params.ActionSchedule, error) {
+	var result params.ActionSchedules
+	if err := api.facade.FacadeCall("ActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Schedules, nil
+}
+
+// RunActionSchedules enqueues the actions of the due action schedules
+// with the given ids. It returns an error for each schedule, which is
+// nil if the schedule ran successfully.
+func (api *API) RunActionSchedules(ids []string) ([]error, error) {
+	var results params.ErrorResults
+	args := params.ActionScheduleIds{Ids: ids}
+	if err := api.facade.FacadeCall("RunActionSchedules", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(ids) {
+		return nil, errors.Errorf("expected %d results, got %d", len(ids), len(results.Results))
+	}
+	errs := make([]error, len(ids))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			errs[i] = result.Error
+		}
+	}
+	return errs, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type actionSchedulerSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&actionSchedulerSuite{})
+
+func (s *actionSchedulerSuite) TestWatchActionSchedulesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ActionScheduler")
+			c.Check(request, gc.Equals, "WatchActionSchedules")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+				Error: &params.Error{Message: "boom"},
+			}
+			return nil
+		},
+	)
+	api := actionscheduler.NewAPI(apiCaller)
+	_, err := api.WatchActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *actionSchedulerSuite) TestActionSchedules(c *gc.C) {
+	nextRun := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ActionScheduler")
+			c.Check(request, gc.Equals, "ActionSchedules")
+			c.Check(a, gc.IsNil)
+			*(result.(*params.ActionSchedules)) = params.ActionSchedules{
+				Schedules: []params.ActionSchedule{{Id: "0", Name: "backup", NextRun: nextRun}},
+			}
+			return nil
+		},
+	)
+	api := actionscheduler.NewAPI(apiCaller)
+	schedules, err := api.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, jc.DeepEquals, []params.ActionSchedule{{Id: "0", Name: "backup", NextRun: nextRun}})
+}
+
+func (s *actionSchedulerSuite) TestRunActionSchedules(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ActionScheduler")
+			c.Check(request, gc.Equals, "RunActionSchedules")
+			c.Check(a, jc.DeepEquals, params.ActionScheduleIds{Ids: []string{"0", "1"}})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "not due"}}},
+			}
+			return nil
+		},
+	)
+	api := actionscheduler.NewAPI(apiCaller)
+	errs, err := api.RunActionSchedules([]string{"0", "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], jc.ErrorIsNil)
+	c.Check(errs[1], gc.ErrorMatches, "not due")
+}
+
+func (s *actionSchedulerSuite) TestRunActionSchedulesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(string, int, string, string, interface{}, interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	api := actionscheduler.NewAPI(apiCaller)
+	_, err := api.RunActionSchedules([]string{"0"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -102,6 +102,10 @@ type DeployArgs struct {
 	// value being the unique ID of a pre-uploaded resources in
 	// storage.
 	Resources map[string]string
+
+	// Trust reports whether the application may access the model's
+	// cloud credential.
+	Trust bool
 }
 
 // Deploy obtains the charm, either locally or from the charm store, and deploys
@@ -111,6 +115,9 @@ func (c *Client) Deploy(args DeployArgs) error {
 	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
 		return errors.New("cannot attach existing storage when more than one unit is requested")
 	}
+	if args.Trust && c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("deploying trusted applications on this controller")
+	}
 	attachStorage := make([]string, len(args.AttachStorage))
 	for i, id := range args.AttachStorage {
 		if !names.IsValidStorage(id) {
@@ -132,6 +139,7 @@ func (c *Client) Deploy(args DeployArgs) error {
 			AttachStorage:    attachStorage,
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
+			Trust:            args.Trust,
 		}},
 	}
 	var results params.ErrorResults
@@ -398,6 +406,43 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// SetTrust grants or revokes the application's access to the model's
+// cloud credential.
+func (c *Client) SetTrust(application string, trust bool) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("trusting applications on this controller")
+	}
+	args := params.ApplicationTrust{ApplicationName: application, Trust: trust}
+	return c.facade.FacadeCall("SetTrust", args, nil)
+}
+
+// UnitsHookRuns returns up to count of the most recent hook runs of
+// each of the named units, newest first. If count is zero, all of the
+// recorded runs are returned.
+func (c *Client) UnitsHookRuns(unitNames []string, count int) ([]params.HookRunsResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("showing hook runs on this controller")
+	}
+	args := params.HookRunsArgs{
+		Entities: make([]params.Entity, len(unitNames)),
+		Count:    count,
+	}
+	for i, name := range unitNames {
+		if !names.IsValidUnit(name) {
+			return nil, errors.NotValidf("unit name %q", name)
+		}
+		args.Entities[i].Tag = names.NewUnitTag(name).String()
+	}
+	var results params.HookRunsResults
+	if err := c.facade.FacadeCall("UnitsHookRuns", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(unitNames) {
+		return nil, errors.Errorf("expected %d results, got %d", len(unitNames), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
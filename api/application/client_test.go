@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -239,6 +241,72 @@ func (s *applicationSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestSetTrust(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Application")
+			c.Check(request, gc.Equals, "SetTrust")
+			c.Check(a, jc.DeepEquals, params.ApplicationTrust{
+				ApplicationName: "foo",
+				Trust:           true,
+			})
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetTrust("foo", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetTrustNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.SetTrust("foo", true)
+	c.Assert(err, gc.ErrorMatches, "trusting applications on this controller not supported")
+}
+
+func (s *applicationSuite) TestUnitsHookRuns(c *gc.C) {
+	started := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(objType, gc.Equals, "Application")
+			c.Check(request, gc.Equals, "UnitsHookRuns")
+			c.Check(a, jc.DeepEquals, params.HookRunsArgs{
+				Entities: []params.Entity{{Tag: "unit-foo-0"}},
+				Count:    5,
+			})
+			*(response.(*params.HookRunsResults)) = params.HookRunsResults{
+				Results: []params.HookRunsResult{{
+					Runs: []params.HookRun{{Hook: "install", Started: started, Duration: time.Second, Result: "success"}},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := application.NewClient(apiCaller)
+	results, err := client.UnitsHookRuns([]string{"foo/0"}, 5)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.HookRunsResult{{
+		Runs: []params.HookRun{{Hook: "install", Started: started, Duration: time.Second, Result: "success"}},
+	}})
+}
+
+func (s *applicationSuite) TestUnitsHookRunsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.UnitsHookRuns([]string{"foo/0"}, 5)
+	c.Assert(err, gc.ErrorMatches, "showing hook runs on this controller not supported")
+}
+
 func (s *applicationSuite) TestDestroyDeprecated(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	gitjujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	t0 := time.Date(2017, time.September, 26, 10, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Query")
+			c.Check(a, jc.DeepEquals, params.AuditLogQuery{
+				UserTag: "user-bob",
+				Facade:  "Application",
+				After:   t0,
+				Limit:   10,
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.AuditLogEntries{})
+			*(result.(*params.AuditLogEntries)) = params.AuditLogEntries{
+				Entries: []params.AuditLogEntry{{
+					JujuServerVersion: version.MustParse("2.3.0"),
+					ModelUUID:         coretesting.ModelTag.Id(),
+					Timestamp:         t0,
+					RemoteAddress:     "10.0.0.1",
+					OriginType:        "API request",
+					OriginName:        "user-bob",
+					Operation:         "Application:v4 - Destroy",
+				}},
+			}
+			return nil
+		},
+	)
+	client := auditlog.NewClient(apiCaller)
+	entries, err := client.Query(audit.Query{
+		OriginName: "user-bob",
+		Facade:     "Application",
+		After:      t0,
+		Limit:      10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, jc.DeepEquals, []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         t0,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "Application:v4 - Destroy",
+	}})
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(string, int, string, string, interface{}, interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Query(audit.Query{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
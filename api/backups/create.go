@@ -19,3 +19,22 @@ func (c *Client) Create(notes string) (*params.BackupsMetadataResult, error) {
 	}
 	return &result, nil
 }
+
+// CreateEncrypted sends a request to create a backup of juju's state
+// whose archive is encrypted with the given key. If key is empty, the
+// controller's backup-encryption-key is used.
+func (c *Client) CreateEncrypted(notes, key string) (*params.BackupsMetadataResult, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("encrypted backups on this controller")
+	}
+	var result params.BackupsMetadataResult
+	args := params.BackupsCreateArgs{
+		Notes:   notes,
+		Encrypt: true,
+		Key:     key,
+	}
+	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
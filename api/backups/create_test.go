@@ -4,6 +4,7 @@
 package backups_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -45,3 +46,41 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateEncrypted(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 3,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+			c.Check(paramsIn, jc.DeepEquals, params.BackupsCreateArgs{
+				Notes:   "important",
+				Encrypt: true,
+				Key:     "sekrit",
+			})
+
+			result := resp.(*params.BackupsMetadataResult)
+			*result = apiserverbackups.ResultFromMetadata(s.Meta)
+			result.Notes = "important"
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.CreateEncrypted("important", "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta := backupstesting.UpdateNotes(s.Meta, "important")
+	s.checkMetadataResult(c, result, meta)
+}
+
+func (s *createSuite) TestCreateEncryptedNotSupported(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 2,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %q", req)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.CreateEncrypted("important", "sekrit")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -18,3 +18,17 @@ func (c *Client) List() (*params.BackupsListResult, error) {
 	}
 	return &result, nil
 }
+
+// ListTarget returns the metadata of the backups stored on the
+// controller's backup target.
+func (c *Client) ListTarget() (*params.BackupsListResult, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("listing backups on the backup target on this controller")
+	}
+	var result params.BackupsListResult
+	args := params.BackupsListArgs{Target: true}
+	if err := c.facade.FacadeCall("List", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
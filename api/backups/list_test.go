@@ -4,6 +4,7 @@
 package backups_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -43,3 +44,38 @@ func (s *listSuite) TestList(c *gc.C) {
 	resultItem := result.List[0]
 	s.checkMetadataResult(c, &resultItem, s.Meta)
 }
+
+func (s *listSuite) TestListTarget(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 2,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "List")
+			c.Check(paramsIn, jc.DeepEquals, params.BackupsListArgs{Target: true})
+
+			result := resp.(*params.BackupsListResult)
+			result.List = []params.BackupsMetadataResult{
+				apiserverbackups.ResultFromMetadata(s.Meta),
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.ListTarget()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(result.List, gc.HasLen, 1)
+	s.checkMetadataResult(c, &result.List[0], s.Meta)
+}
+
+func (s *listSuite) TestListTargetNotSupported(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 1,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %q", req)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.ListTarget()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type bundleMockSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&bundleMockSuite{})
+
+func newClient(f basetesting.APICallerFunc, version int) *bundle.Client {
+	return bundle.NewClient(basetesting.BestVersionCaller{f, version})
+}
+
+func (s *bundleMockSuite) TestGetChanges(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Bundle")
+		c.Check(request, gc.Equals, "GetChanges")
+		c.Check(a, jc.DeepEquals, params.BundleChangesParams{BundleDataYAML: "applications: {}"})
+		c.Assert(result, gc.FitsTypeOf, &params.BundleChangesResults{})
+		*(result.(*params.BundleChangesResults)) = params.BundleChangesResults{
+			Errors: []string{"boom"},
+		}
+		return nil
+	}, 2)
+	result, err := client.GetChanges("applications: {}")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Errors, jc.DeepEquals, []string{"boom"})
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *bundleMockSuite) TestExportBundle(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Bundle")
+		c.Check(request, gc.Equals, "ExportBundle")
+		c.Check(a, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.StringResult{})
+		*(result.(*params.StringResult)) = params.StringResult{
+			Result: "applications: {}\n",
+		}
+		return nil
+	}, 2)
+	result, err := client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, "applications: {}\n")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *bundleMockSuite) TestExportBundleError(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		*(result.(*params.StringResult)) = params.StringResult{
+			Error: &params.Error{Message: "applications in model not found", Code: params.CodeNotFound},
+		}
+		return nil
+	}, 2)
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "applications in model not found")
+}
+
+func (s *bundleMockSuite) TestExportBundleNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	}, 1)
+	_, err := client.ExportBundle()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
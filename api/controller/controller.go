@@ -142,6 +142,17 @@ func (c *Client) ListBlockedModels() ([]params.ModelBlockInfo, error) {
 	return result.Models, err
 }
 
+// BackupStatus returns the controller's backup schedule, and the most
+// recent backup stored on the controller.
+func (c *Client) BackupStatus() (params.BackupStatus, error) {
+	var result params.BackupStatus
+	if c.BestAPIVersion() < 4 {
+		return result, errors.NotSupportedf("showing backup status on this controller")
+	}
+	err := c.facade.FacadeCall("BackupStatus", nil, &result)
+	return result, errors.Trace(err)
+}
+
 // RemoveBlocks removes all the blocks in the controller.
 func (c *Client) RemoveBlocks() error {
 	args := params.RemoveBlocksArgs{All: true}
@@ -254,16 +265,99 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// PrecheckMigration runs the migration prechecks for the model on
+// both the source and target controllers without starting a
+// migration. It returns a description of every precondition that is
+// not met; if there are none, the migration is expected to start.
+func (c *Client) PrecheckMigration(spec MigrationSpec) ([]string, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("checking a migration without starting it on this controller")
+	}
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	response := params.MigrationPrecheckResults{}
+	if err := c.facade.FacadeCall("PrecheckMigration", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Failures, nil
+}
+
+// CloneModel copies the model given in the spec, under the given name,
+// to the target controller described by the spec, which may be the
+// model's own controller. The copy has the model's config,
+// constraints, applications and relations, but no machines or units.
+// It returns the UUID of the new model.
+func (c *Client) CloneModel(spec MigrationSpec, name string) (string, error) {
+	if c.BestAPIVersion() < 6 {
+		return "", errors.NotSupportedf("cloning models on this controller")
+	}
+	specArgs, err := migrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	args := params.CloneModelArgs{
+		Specs: []params.CloneModelSpec{{
+			ModelTag:   specArgs.Specs[0].ModelTag,
+			Name:       name,
+			TargetInfo: specArgs.Specs[0].TargetInfo,
+		}},
+	}
+	response := params.CloneModelResults{}
+	if err := c.facade.FacadeCall("CloneModel", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	modelTag, err := names.ParseModelTag(result.ModelTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return modelTag.Id(), nil
+}
+
+func migrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -275,19 +369,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:     string(macsJSON),
 			},
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -6,6 +6,7 @@ package controller_test
 import (
 	"encoding/json"
 	"errors"
+	"time"
 
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -118,6 +119,161 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestBackupStatus(c *gc.C) {
+	started := time.Date(2017, 6, 1, 2, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "BackupStatus")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.BackupStatus)) = params.BackupStatus{
+				Schedule:          "@daily",
+				LastBackupID:      "backup-id",
+				LastBackupStarted: started,
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := controller.NewClient(apiCaller)
+	status, err := client.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, params.BackupStatus{
+		Schedule:          "@daily",
+		LastBackupID:      "backup-id",
+		LastBackupStarted: started,
+	})
+}
+
+func (s *Suite) TestBackupStatusNotSupported(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupStatus()
+	c.Assert(err, gc.ErrorMatches, "showing backup status on this controller not supported")
+}
+
+func (s *Suite) TestPrecheckMigration(c *gc.C) {
+	spec := makeSpec()
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.MigrationPrecheckResults)) = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					Failures: []string{"source: cleanup needed", "target: upgrade in progress"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := controller.NewClient(apiCaller)
+	failures, err := client.PrecheckMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(failures, jc.DeepEquals, []string{"source: cleanup needed", "target: upgrade in progress"})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.PrecheckMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestPrecheckMigrationError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.MigrationPrecheckResults)) = params.MigrationPrecheckResults{
+				Results: []params.MigrationPrecheckResult{{
+					Error: common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := controller.NewClient(apiCaller)
+	failures, err := client.PrecheckMigration(makeSpec())
+	c.Check(failures, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestPrecheckMigrationNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.PrecheckMigration(makeSpec())
+	c.Assert(err, gc.ErrorMatches, "checking a migration without starting it on this controller not supported")
+}
+
+func (s *Suite) TestCloneModel(c *gc.C) {
+	spec := makeSpec()
+	newUUID := randomUUID()
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			*(result.(*params.CloneModelResults)) = params.CloneModelResults{
+				Results: []params.CloneModelResult{{
+					ModelTag: names.NewModelTag(newUUID).String(),
+				}},
+			}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := controller.NewClient(apiCaller)
+	uuid, err := client.CloneModel(spec, "staging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(uuid, gc.Equals, newUUID)
+
+	migrationArgs := specToArgs(spec)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.CloneModel", []interface{}{params.CloneModelArgs{
+			Specs: []params.CloneModelSpec{{
+				ModelTag:   migrationArgs.Specs[0].ModelTag,
+				Name:       "staging",
+				TargetInfo: migrationArgs.Specs[0].TargetInfo,
+			}},
+		}}},
+	})
+}
+
+func (s *Suite) TestCloneModelError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*(result.(*params.CloneModelResults)) = params.CloneModelResults{
+				Results: []params.CloneModelResult{{
+					Error: common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	client := controller.NewClient(apiCaller)
+	uuid, err := client.CloneModel(makeSpec(), "staging")
+	c.Check(uuid, gc.Equals, "")
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestCloneModelNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.CloneModel(makeSpec(), "staging")
+	c.Assert(err, gc.ErrorMatches, "cloning models on this controller not supported")
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
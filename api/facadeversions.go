@@ -11,24 +11,26 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"Action":                       3,
+	"ActionScheduler":              1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  5,
+	"Application":                  6,
 	"ApplicationOffers":            1,
 	"ApplicationScaler":            1,
-	"Backups":                      1,
+	"AuditLog":                     1,
+	"Backups":                      3,
 	"Block":                        2,
-	"Bundle":                       1,
+	"Bundle":                       2,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
 	"Client":                       1,
 	"Cloud":                        1,
-	"Controller":                   3,
+	"Controller":                   6,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
 	"DiskManager":                  2,
@@ -46,7 +48,7 @@ var facadeVersions = map[string]int{
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
 	"Logger":                       1,
-	"MachineActions":               1,
+	"MachineActions":               2,
 	"MachineManager":               3,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
@@ -58,7 +60,7 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              2,
 	"ModelConfig":                  1,
 	"ModelManager":                 3,
 	"NotifyWatcher":                1,
@@ -75,6 +77,7 @@ var facadeVersions = map[string]int{
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Secrets":                      1,
 	"Singular":                     1,
 	"Spaces":                       3,
 	"SSHClient":                    2,
@@ -85,7 +88,7 @@ var facadeVersions = map[string]int{
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       5,
+	"Uniter":                       6,
 	"Upgrader":                     1,
 	"UserManager":                  1,
 	"VolumeAttachmentsWatcher":     2,
@@ -175,6 +175,20 @@ func originFromAPI(apiRec params.LogStreamRecord, controllerUUID string) (logfwd
 		return origin, errors.Annotatef(err, "invalid version %q", apiRec.Version)
 	}
 
+	// Audit and status history records are written to the logs by
+	// the controller so that they are forwarded, and are marked with
+	// their kind. Agents cannot set the kind, so the module of a
+	// record is not trusted to identify it.
+	switch apiRec.Kind {
+	case "":
+	case logfwd.OriginTypeAudit.String():
+		return logfwd.OriginForAudit(tag, controllerUUID, apiRec.ModelUUID, ver), nil
+	case logfwd.OriginTypeStatusHistory.String():
+		return logfwd.OriginForStatusHistory(tag, controllerUUID, apiRec.ModelUUID, ver), nil
+	default:
+		return origin, errors.Errorf("unrecognized record kind %q", apiRec.Kind)
+	}
+
 	switch tag := tag.(type) {
 	case names.MachineTag:
 		origin = logfwd.OriginForMachineAgent(tag, controllerUUID, apiRec.ModelUUID, ver)
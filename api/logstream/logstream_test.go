@@ -17,7 +17,9 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/logstream"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
 )
@@ -141,6 +143,93 @@ func (s *LogReaderSuite) TestNextOneRecord(c *gc.C) {
 	}
 }
 
+func (s *LogReaderSuite) TestNextStatusHistoryRecord(c *gc.C) {
+	record := s.nextRecord(c, params.LogStreamRecord{
+		ModelUUID: "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Entity:    "unit-mysql-0",
+		Version:   version.Current.String(),
+		Timestamp: time.Now(),
+		Module:    status.HistoryLogModule,
+		Level:     loggo.ERROR.String(),
+		Kind:      "status-history",
+		Message:   `{"kind":"workload","status":"error","message":"hook failed: \"install\""}`,
+	})
+	c.Check(record.Origin, jc.DeepEquals, logfwd.Origin{
+		ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Type:           logfwd.OriginTypeStatusHistory,
+		Name:           "unit-mysql-0",
+		Software: logfwd.Software{
+			PrivateEnterpriseNumber: 28978,
+			Name:    "juju",
+			Version: version.Current,
+		},
+	})
+	c.Check(record.Level, gc.Equals, loggo.ERROR)
+	c.Check(record.Location.Module, gc.Equals, status.HistoryLogModule)
+}
+
+func (s *LogReaderSuite) TestNextAuditRecord(c *gc.C) {
+	record := s.nextRecord(c, params.LogStreamRecord{
+		ModelUUID: "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Entity:    "user-bob",
+		Version:   version.Current.String(),
+		Timestamp: time.Now(),
+		Module:    audit.LogModule,
+		Level:     loggo.INFO.String(),
+		Message:   `{"operation":"Client:v1 - FullStatus"}`,
+		Kind:      "audit",
+	})
+	c.Check(record.Origin.Type, gc.Equals, logfwd.OriginTypeAudit)
+	c.Check(record.Origin.Name, gc.Equals, "user-bob")
+	c.Check(record.Location.Module, gc.Equals, audit.LogModule)
+}
+
+func (s *LogReaderSuite) TestNextAuditModuleFromAgent(c *gc.C) {
+	// An agent logging under the audit module does not produce
+	// audit records.
+	record := s.nextRecord(c, params.LogStreamRecord{
+		ModelUUID: "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Entity:    "unit-mysql-0",
+		Version:   version.Current.String(),
+		Timestamp: time.Now(),
+		Module:    audit.LogModule,
+		Level:     loggo.INFO.String(),
+		Message:   `{"operation":"Client:v1 - FullStatus"}`,
+	})
+	c.Check(record.Origin.Type, gc.Equals, logfwd.OriginTypeUnit)
+	c.Check(record.Origin.Name, gc.Equals, "mysql/0")
+}
+
+func (s *LogReaderSuite) nextRecord(c *gc.C, apiRec params.LogStreamRecord) logfwd.Record {
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	jsonReader := mockStream{stub: stub}
+	logsCh := make(chan params.LogStreamRecords, 1)
+	logsCh <- params.LogStreamRecords{
+		Records: []params.LogStreamRecord{apiRec},
+	}
+	jsonReader.ReturnReadJSON = logsCh
+	conn.ReturnConnectStream = jsonReader
+	stream, err := logstream.Open(conn, params.LogStreamConfig{}, "feebdaed-2f18-4fd2-967d-db9663db7bea")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var records []logfwd.Record
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		records, err = stream.Next()
+	}()
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for record")
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 1)
+	return records[0]
+}
+
 func (s *LogReaderSuite) TestNextError(c *gc.C) {
 	cUUID := "feebdaed-2f18-4fd2-967d-db9663db7bea"
 	stub := &testing.Stub{}
@@ -4,6 +4,8 @@
 
 package machineactions
 
+import "time"
+
 // Action represents a single instance of an Action call, by name and params.
 // TODO(bogdantelega): This is currently copied from uniter.Actions,
 // but until the implementations converge, it's saner to duplicate the code since
@@ -27,3 +29,19 @@ func (a *Action) Name() string {
 func (a *Action) Params() map[string]interface{} {
 	return a.params
 }
+
+// ActionOutput is a chunk of the output written by a running action.
+// Like Action, it is copied from the uniter.
+type ActionOutput struct {
+	// Seq orders the chunks written by an action, starting from zero.
+	Seq int
+
+	// Stream is either "stdout" or "stderr".
+	Stream string
+
+	// Data holds the output.
+	Data string
+
+	// Timestamp is the time at which the output was written.
+	Timestamp time.Time
+}
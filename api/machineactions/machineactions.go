@@ -124,6 +124,31 @@ func (c *Client) ActionFinish(tag names.ActionTag, status string, actionResults
 	return results.OneError()
 }
 
+// AddActionOutput records output written by the running action
+// identified by the given tag, so that it can be streamed to clients.
+func (c *Client) AddActionOutput(tag names.ActionTag, output []ActionOutput) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("streaming machine action output on this controller")
+	}
+	args := params.ActionOutputs{
+		Outputs: make([]params.ActionOutput, len(output)),
+	}
+	for i, out := range output {
+		args.Outputs[i] = params.ActionOutput{
+			ActionTag: tag.String(),
+			Seq:       out.Seq,
+			Stream:    out.Stream,
+			Data:      out.Data,
+			Timestamp: out.Timestamp,
+		}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddActionsOutput", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
 // RunningActions returns a list of actions running for the given machine tag.
 func (c *Client) RunningActions(agent names.MachineTag) ([]params.ActionResult, error) {
 	var results params.ActionsByReceivers
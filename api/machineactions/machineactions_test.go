@@ -5,6 +5,8 @@
 package machineactions_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -240,6 +242,58 @@ func (s *ClientSuite) TestActionFinishSuccess(c *gc.C) {
 	stub.CheckCalls(c, expectedCalls)
 }
 
+func (s *ClientSuite) TestAddActionOutputSuccess(c *gc.C) {
+	tag := names.NewActionTag(utils.MustNewUUID().String())
+	now := time.Now()
+	expectedCalls := []jujutesting.StubCall{{
+		"MachineActions.AddActionsOutput",
+		[]interface{}{"", params.ActionOutputs{
+			Outputs: []params.ActionOutput{{
+				ActionTag: tag.String(),
+				Seq:       0,
+				Stream:    "stdout",
+				Data:      "hello\n",
+				Timestamp: now,
+			}},
+		}},
+	}}
+	var stub jujutesting.Stub
+
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			c.Check(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+
+	client := machineactions.NewClient(apiCaller)
+	err := client.AddActionOutput(tag, []machineactions.ActionOutput{{
+		Seq:       0,
+		Stream:    "stdout",
+		Data:      "hello\n",
+		Timestamp: now,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, expectedCalls)
+}
+
+func (s *ClientSuite) TestAddActionOutputNotSupported(c *gc.C) {
+	tag := names.NewActionTag(utils.MustNewUUID().String())
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s.%s", objType, request)
+		return nil
+	})
+
+	client := machineactions.NewClient(apiCaller)
+	err := client.AddActionOutput(tag, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *ClientSuite) TestActionFinishError(c *gc.C) {
 	tag := names.NewActionTag(utils.MustNewUUID().String())
 	expectedCalls := []jujutesting.StubCall{{
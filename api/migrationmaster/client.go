@@ -141,12 +141,30 @@ func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 	if err != nil {
 		return migration.ModelInfo{}, errors.Trace(err)
 	}
+	var cloud string
+	if info.CloudTag != "" {
+		cloudTag, err := names.ParseCloudTag(info.CloudTag)
+		if err != nil {
+			return migration.ModelInfo{}, errors.Trace(err)
+		}
+		cloud = cloudTag.Id()
+	}
+	var credential names.CloudCredentialTag
+	if info.CloudCredentialTag != "" {
+		credential, err = names.ParseCloudCredentialTag(info.CloudCredentialTag)
+		if err != nil {
+			return migration.ModelInfo{}, errors.Trace(err)
+		}
+	}
 	return migration.ModelInfo{
 		UUID:                   info.UUID,
 		Name:                   info.Name,
 		Owner:                  owner,
 		AgentVersion:           info.AgentVersion,
 		ControllerAgentVersion: info.ControllerAgentVersion,
+		Cloud:                  cloud,
+		CloudRegion:            info.CloudRegion,
+		CloudCredential:        credential,
 	}, nil
 }
 
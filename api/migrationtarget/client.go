@@ -44,14 +44,42 @@ type Client struct {
 }
 
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
-	args := params.MigrationModelInfo{
+	return c.caller.FacadeCall("Prechecks", modelInfoParams(model), nil)
+}
+
+// PrecheckFailures runs the same checks as Prechecks on the target
+// controller, but returns every precondition for the migration that
+// is not met rather than only the first.
+func (c *Client) PrecheckFailures(model coremigration.ModelInfo) ([]string, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("listing precheck failures on this controller")
+	}
+	var result params.StringsResult
+	if err := c.caller.FacadeCall("PrecheckFailures", modelInfoParams(model), &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+func modelInfoParams(model coremigration.ModelInfo) params.MigrationModelInfo {
+	info := params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
+		CloudRegion:            model.CloudRegion,
+	}
+	if model.Cloud != "" {
+		info.CloudTag = names.NewCloudTag(model.Cloud).String()
+	}
+	if model.CloudCredential != (names.CloudCredentialTag{}) {
+		info.CloudCredentialTag = model.CloudCredential.String()
 	}
-	return c.caller.FacadeCall("Prechecks", args, nil)
+	return info
 }
 
 // Import takes a serialized model and imports it into the target
@@ -55,6 +55,7 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	ownerTag := names.NewUserTag("owner")
 	vers := version.MustParse("1.2.3")
 	controllerVers := version.MustParse("1.2.5")
+	credTag := names.NewCloudCredentialTag("dummy/owner/cred")
 
 	err := client.Prechecks(coremigration.ModelInfo{
 		UUID:                   "uuid",
@@ -62,6 +63,9 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 		Name:                   "name",
 		AgentVersion:           vers,
 		ControllerAgentVersion: controllerVers,
+		Cloud:                  "dummy",
+		CloudRegion:            "dummy-region",
+		CloudCredential:        credTag,
 	})
 	c.Assert(err, gc.ErrorMatches, "boom")
 
@@ -71,12 +75,60 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 		OwnerTag:               ownerTag.String(),
 		AgentVersion:           vers,
 		ControllerAgentVersion: controllerVers,
+		CloudTag:               names.NewCloudTag("dummy").String(),
+		CloudRegion:            "dummy-region",
+		CloudCredentialTag:     credTag.String(),
 	}
 	stub.CheckCalls(c, []jujutesting.StubCall{
 		{"MigrationTarget.Prechecks", []interface{}{"", expectedArg}},
 	})
 }
 
+func (s *ClientSuite) TestPrecheckFailures(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			*(result.(*params.StringsResult)) = params.StringsResult{
+				Result: []string{"upgrade in progress", `model named "name" already exists`},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	failures, err := client.PrecheckFailures(coremigration.ModelInfo{
+		UUID:                   "uuid",
+		Owner:                  ownerTag,
+		Name:                   "name",
+		AgentVersion:           vers,
+		ControllerAgentVersion: vers,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(failures, jc.DeepEquals, []string{"upgrade in progress", `model named "name" already exists`})
+
+	expectedArg := params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "name",
+		OwnerTag:               ownerTag.String(),
+		AgentVersion:           vers,
+		ControllerAgentVersion: vers,
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.PrecheckFailures", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestPrecheckFailuresNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.PrecheckFailures(coremigration.ModelInfo{UUID: "uuid"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -121,6 +121,21 @@ func (c *Client) RelationUnitSettings(relationUnits []params.RelationUnit) ([]pa
 	return results.Results, nil
 }
 
+// RelationApplicationSettings returns the application settings for the given
+// applications and relations in the local model.
+func (c *Client) RelationApplicationSettings(relationApplications []params.RelationApplication) ([]params.SettingsResult, error) {
+	args := params.RelationApplications{relationApplications}
+	var results params.SettingsResults
+	err := c.facade.FacadeCall("RelationApplicationSettings", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(relationApplications) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(relationApplications), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // Relations returns information about the cross-model relations with the specified keys
 // in the local model.
 func (c *Client) Relations(keys []string) ([]params.RemoteRelationResult, error) {
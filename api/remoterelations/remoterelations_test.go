@@ -181,6 +181,31 @@ func (s *remoteRelationsSuite) TestRelationUnitSettingsResultsCount(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `expected 1 result\(s\), got 2`)
 }
 
+func (s *remoteRelationsSuite) TestRelationApplicationSettings(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "RemoteRelations")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RelationApplicationSettings")
+		c.Check(arg, gc.DeepEquals, params.RelationApplications{
+			RelationApplications: []params.RelationApplication{{Relation: "r", Application: "a"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.SettingsResults{})
+		*(result.(*params.SettingsResults)) = params.SettingsResults{
+			Results: []params.SettingsResult{{
+				Settings: params.Settings{"foo": "bar"},
+			}},
+		}
+		callCount++
+		return nil
+	})
+	client := remoterelations.NewClient(apiCaller)
+	result, err := client.RelationApplicationSettings([]params.RelationApplication{{Relation: "r", Application: "a"}})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, []params.SettingsResult{{Settings: params.Settings{"foo": "bar"}}})
+	c.Check(callCount, gc.Equals, 1)
+}
+
 func (s *remoteRelationsSuite) TestRelations(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -0,0 +1,47 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the client API for inspecting the secrets
+// owned by applications in a model.
+package secrets
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the secrets API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the secrets API.
+func NewClient(callCloser base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(callCloser, "Secrets")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ListSecrets returns the secrets in the model, restricted to those
+// owned by the named application if it is not empty. The values of the
+// secrets are included if showSecrets is true.
+func (c *Client) ListSecrets(owner string, showSecrets bool) ([]params.Secret, error) {
+	args := params.ListSecretsArgs{ShowSecrets: showSecrets}
+	if owner != "" {
+		if !names.IsValidApplication(owner) {
+			return nil, errors.NotValidf("application name %q", owner)
+		}
+		args.Owner = names.NewApplicationTag(owner).String()
+	}
+	var result params.SecretsResult
+	if err := c.facade.FacadeCall("ListSecrets", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Secrets, nil
+}
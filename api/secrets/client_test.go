@@ -0,0 +1,58 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type SecretsSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ListSecrets")
+		c.Check(arg, jc.DeepEquals, params.ListSecretsArgs{
+			Owner:       "application-mysql",
+			ShowSecrets: true,
+		})
+		*(result.(*params.SecretsResult)) = params.SecretsResult{
+			Secrets: []params.Secret{{Id: "0", Owner: "application-mysql", Revision: 2}},
+		}
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets("mysql", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []params.Secret{{Id: "0", Owner: "application-mysql", Revision: 2}})
+}
+
+func (s *SecretsSuite) TestListSecretsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.SecretsResult)) = params.SecretsResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	client := secrets.NewClient(apiCaller)
+	_, err := client.ListSecrets("", false)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *SecretsSuite) TestListSecretsInvalidOwner(c *gc.C) {
+	client := secrets.NewClient(apitesting.APICallerFunc(nil))
+	_, err := client.ListSecrets("Mysql!", false)
+	c.Assert(err, gc.ErrorMatches, `application name "Mysql!" not valid`)
+}
@@ -3,6 +3,8 @@
 
 package uniter
 
+import "time"
+
 // Action represents a single instance of an Action call, by name and params.
 type Action struct {
 	name   string
@@ -23,3 +25,18 @@ func (a *Action) Name() string {
 func (a *Action) Params() map[string]interface{} {
 	return a.params
 }
+
+// ActionOutput is a chunk of the output written by a running action.
+type ActionOutput struct {
+	// Seq orders the chunks written by an action, starting from zero.
+	Seq int
+
+	// Stream is either "stdout" or "stderr".
+	Stream string
+
+	// Data holds the output.
+	Data string
+
+	// Timestamp is the time at which the output was written.
+	Timestamp time.Time
+}
@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -122,3 +124,38 @@ func (s *actionSuite) TestActionFail(c *gc.C) {
 	c.Assert(res, gc.DeepEquals, map[string]interface{}{})
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
+
+func (s *actionSuite) TestAddActionOutput(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	err = s.uniter.AddActionOutput(action.ActionTag(), []uniter.ActionOutput{
+		{Seq: 0, Stream: "stdout", Data: "migrating\n", Timestamp: now},
+		{Seq: 1, Stream: "stderr", Data: "slow table\n", Timestamp: now},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	output, err := action.Output(-1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, jc.DeepEquals, []state.ActionOutput{
+		{Seq: 0, Stream: state.ActionStdout, Data: "migrating\n", Timestamp: now},
+		{Seq: 1, Stream: state.ActionStderr, Data: "slow table\n", Timestamp: now},
+	})
+}
+
+func (s *actionSuite) TestLogActionMessage(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.LogActionMessage(action.ActionTag(), "copying files")
+	c.Assert(err, jc.ErrorIsNil)
+
+	action, err = s.State.ActionByTag(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "copying files")
+}
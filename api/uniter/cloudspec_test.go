@@ -0,0 +1,48 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type cloudSpecSuite struct {
+	uniterSuite
+}
+
+var _ = gc.Suite(&cloudSpecSuite{})
+
+func (s *cloudSpecSuite) TestCloudSpecNotTrusted(c *gc.C) {
+	_, err := s.uniter.CloudSpec()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(params.IsCodeUnauthorized(err), jc.IsTrue)
+}
+
+func (s *cloudSpecSuite) TestCloudSpecTrusted(c *gc.C) {
+	err := s.wordpressApplication.SetTrust(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	spec, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec, gc.NotNil)
+	c.Check(spec.Type, gc.Equals, "dummy")
+}
+
+func (s *cloudSpecSuite) TestOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call %q", request)
+		return nil
+	})
+	st := uniter.NewStateV4(apiCaller, names.NewUnitTag("wordpress/0"))
+	_, err := st.CloudSpec()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	c.Assert(err, gc.ErrorMatches, `CloudSpec\(...\) requires v6\+ not implemented`)
+}
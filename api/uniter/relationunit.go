@@ -162,6 +162,55 @@ func (ru *RelationUnit) ReadSettings(uname string) (params.Settings, error) {
 	return result.Settings, nil
 }
 
+// ApplicationSettings returns a Settings which allows access to the
+// application-level settings of the unit's application within the
+// relation. Only the leader may write the settings.
+func (ru *RelationUnit) ApplicationSettings() (*Settings, error) {
+	if err := ErrIfNotVersionFn(6, ru.st.BestAPIVersion())("ApplicationSettings"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	appTag := ru.unit.ApplicationTag()
+	settings, err := ru.readApplicationSettings(appTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newApplicationSettings(ru.st, ru.relation.tag.String(), appTag.String(), settings), nil
+}
+
+// ReadApplicationSettings returns a map holding the application-level
+// settings of the named application within this relation.
+func (ru *RelationUnit) ReadApplicationSettings(appName string) (params.Settings, error) {
+	if err := ErrIfNotVersionFn(6, ru.st.BestAPIVersion())("ReadApplicationSettings"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !names.IsValidApplication(appName) {
+		return nil, errors.Errorf("%q is not a valid application", appName)
+	}
+	return ru.readApplicationSettings(names.NewApplicationTag(appName))
+}
+
+func (ru *RelationUnit) readApplicationSettings(appTag names.ApplicationTag) (params.Settings, error) {
+	var results params.SettingsResults
+	args := params.RelationApplications{
+		RelationApplications: []params.RelationApplication{{
+			Relation:    ru.relation.tag.String(),
+			Application: appTag.String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("ReadApplicationSettings", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Settings, nil
+}
+
 // Watch returns a watcher that notifies of changes to counterpart
 // units in the relation.
 func (ru *RelationUnit) Watch() (watcher.RelationUnitsWatcher, error) {
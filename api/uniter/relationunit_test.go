@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -256,6 +258,52 @@ func (s *relationUnitSuite) TestReadSettingsInvalidUnitTag(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "\"mysql\" is not a valid unit")
 }
 
+func (s *relationUnitSuite) TestApplicationSettings(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", s.wordpressUnit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	_, apiRelUnit := s.getRelationUnits(c)
+
+	settings, err := apiRelUnit.ApplicationSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), gc.HasLen, 0)
+	settings.Set("url", "http://wp")
+	err = settings.Write()
+	c.Assert(err, jc.ErrorIsNil)
+
+	stateSettings, err := s.stateRelation.ApplicationSettings("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stateSettings, jc.DeepEquals, map[string]interface{}{"url": "http://wp"})
+
+	settings, err = apiRelUnit.ApplicationSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings.Map(), jc.DeepEquals, params.Settings{"url": "http://wp"})
+}
+
+func (s *relationUnitSuite) TestApplicationSettingsNotLeader(c *gc.C) {
+	_, apiRelUnit := s.getRelationUnits(c)
+	settings, err := apiRelUnit.ApplicationSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	settings.Set("url", "http://wp")
+	err = settings.Write()
+	c.Assert(err, gc.ErrorMatches, `cannot write settings for application "wordpress" .*: prerequisites failed: .*`)
+}
+
+func (s *relationUnitSuite) TestReadApplicationSettings(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("mysql", s.mysqlUnit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	token := s.State.LeadershipChecker().LeadershipCheck("mysql", s.mysqlUnit.Name())
+	err = s.stateRelation.UpdateApplicationSettings("mysql", token, map[string]string{"host": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, apiRelUnit := s.getRelationUnits(c)
+	settings, err := apiRelUnit.ReadApplicationSettings("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, params.Settings{"host": "db"})
+
+	_, err = apiRelUnit.ReadApplicationSettings("mysql/0")
+	c.Assert(err, gc.ErrorMatches, `"mysql/0" is not a valid application`)
+}
+
 func (s *relationUnitSuite) TestWatchRelationUnits(c *gc.C) {
 	// Enter scope with mysqlUnit.
 	myRelUnit, err := s.stateRelation.Unit(s.mysqlUnit)
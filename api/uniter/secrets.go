@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+)
+
+// SecretCreateArgs holds the arguments for creating a secret.
+type SecretCreateArgs struct {
+	Label          string
+	Description    string
+	Data           map[string]string
+	RotateInterval time.Duration
+}
+
+func (st *State) checkSecretsAPI(fnName string) error {
+	return ErrIfNotVersionFn(6, st.BestAPIVersion())(fnName)
+}
+
+// CreateSecret creates a secret owned by the unit's application and
+// returns its id.
+func (st *State) CreateSecret(args SecretCreateArgs) (string, error) {
+	if err := st.checkSecretsAPI("CreateSecret"); err != nil {
+		return "", errors.Trace(err)
+	}
+	var results params.StringResults
+	err := st.facade.FacadeCall("CreateSecrets", params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			Label:          args.Label,
+			Description:    args.Description,
+			Data:           args.Data,
+			RotateInterval: args.RotateInterval,
+		}},
+	}, &results)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", errors.Trace(err)
+	}
+	return results.Results[0].Result, nil
+}
+
+// UpdateSecret stores a new value for the secret with the given id.
+func (st *State) UpdateSecret(id string, data map[string]string) error {
+	if err := st.checkSecretsAPI("UpdateSecret"); err != nil {
+		return errors.Trace(err)
+	}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("UpdateSecrets", params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{Id: id, Data: data}},
+	}, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// GrantSecret grants the applications in the relation access to the
+// secret with the given id.
+func (st *State) GrantSecret(id string, relation names.RelationTag) error {
+	if err := st.checkSecretsAPI("GrantSecret"); err != nil {
+		return errors.Trace(err)
+	}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("GrantSecrets", params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{Id: id, RelationTag: relation.String()}},
+	}, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// SecretValue returns the value of the secret with the given id.
+func (st *State) SecretValue(id string) (map[string]string, error) {
+	if err := st.checkSecretsAPI("SecretValue"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.SecretValueResults
+	err := st.facade.FacadeCall("GetSecretValues", params.GetSecretArgs{
+		Args: []params.GetSecretArg{{Id: id}},
+	}, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Data, nil
+}
+
+// Secrets returns the details of the secrets whose values the unit
+// may read.
+func (u *Unit) Secrets() ([]params.Secret, error) {
+	if err := u.st.checkSecretsAPI("Secrets"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.SecretsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("UnitSecrets", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results[0].Secrets, nil
+}
+
+// WatchSecrets returns a watcher that notifies of changes to the
+// secrets whose values the unit may read.
+func (u *Unit) WatchSecrets() (watcher.NotifyWatcher, error) {
+	if err := u.st.checkSecretsAPI("WatchSecrets"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("WatchUnitSecrets", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result), nil
+}
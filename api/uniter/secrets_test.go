@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/watcher/watchertest"
+)
+
+type secretsSuite struct {
+	uniterSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.uniterSuite.SetUpTest(c)
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", s.wordpressUnit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) TestCreateUpdateAndGet(c *gc.C) {
+	id, err := s.uniter.CreateSecret(uniter.SecretCreateArgs{
+		Label: "admin",
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.UpdateSecret(id, map[string]string{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	value, err := s.uniter.SecretValue(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "n3w"})
+
+	unit, err := s.uniter.Unit(s.wordpressUnit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	secrets, err := unit.Secrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 1)
+	c.Check(secrets[0].Id, gc.Equals, id)
+	c.Check(secrets[0].Label, gc.Equals, "admin")
+	c.Check(secrets[0].Revision, gc.Equals, 2)
+}
+
+func (s *secretsSuite) TestGrantSecret(c *gc.C) {
+	id, err := s.uniter.CreateSecret(uniter.SecretCreateArgs{
+		Data: map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	rel := s.addRelation(c, "wordpress", "mysql")
+
+	err = s.uniter.GrantSecret(id, rel.Tag().(names.RelationTag))
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.State.Secret(id)
+	c.Assert(err, jc.ErrorIsNil)
+	readable, err := secret.CanRead("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(readable, jc.IsTrue)
+}
+
+func (s *secretsSuite) TestSecretValuePermissionDenied(c *gc.C) {
+	mysql := s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	secret, err := s.State.AddSecret(state.SecretArgs{
+		Owner: mysql.ApplicationTag(),
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.uniter.SecretValue(secret.Id())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestWatchSecrets(c *gc.C) {
+	unit, err := s.uniter.Unit(s.wordpressUnit.UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := unit.WatchSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	_, err = s.uniter.CreateSecret(uniter.SecretCreateArgs{
+		Data: map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *secretsSuite) TestOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call %q", request)
+		return nil
+	})
+	st := uniter.NewStateV4(apiCaller, names.NewUnitTag("wordpress/0"))
+	_, err := st.SecretValue("0")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	c.Assert(err, gc.ErrorMatches, `SecretValue\(...\) requires v6\+ not implemented`)
+}
@@ -10,12 +10,14 @@ import (
 // This module implements a subset of the interface provided by
 // state.Settings, as needed by the uniter API.
 
-// Settings manages changes to unit settings in a relation.
+// Settings manages changes to unit or application settings in a
+// relation.
 type Settings struct {
-	st          *State
-	relationTag string
-	unitTag     string
-	settings    params.Settings
+	st             *State
+	relationTag    string
+	unitTag        string
+	applicationTag string
+	settings       params.Settings
 }
 
 func newSettings(st *State, relationTag, unitTag string, settings params.Settings) *Settings {
@@ -30,6 +32,12 @@ func newSettings(st *State, relationTag, unitTag string, settings params.Setting
 	}
 }
 
+func newApplicationSettings(st *State, relationTag, applicationTag string, settings params.Settings) *Settings {
+	s := newSettings(st, relationTag, "", settings)
+	s.applicationTag = applicationTag
+	return s
+}
+
 // Map returns all keys and values of the node.
 //
 // TODO(dimitern): This differes from state.Settings.Map() - it does
@@ -81,6 +89,19 @@ func (s *Settings) Write() error {
 	}
 
 	var result params.ErrorResults
+	if s.applicationTag != "" {
+		args := params.RelationApplicationsSettings{
+			RelationApplications: []params.RelationApplicationSettings{{
+				Relation:    s.relationTag,
+				Application: s.applicationTag,
+				Settings:    settingsCopy,
+			}},
+		}
+		if err := s.st.facade.FacadeCall("UpdateApplicationSettings", args, &result); err != nil {
+			return err
+		}
+		return result.OneError()
+	}
 	args := params.RelationUnitsSettings{
 		RelationUnits: []params.RelationUnitSettings{{
 			Relation: s.relationTag,
@@ -782,3 +782,87 @@ func (u *Unit) NetworkInfo(bindings []string) (map[string]params.NetworkInfoResu
 
 	return results.Results, nil
 }
+
+// GoalState returns the goal state of the unit's application: the
+// units expected for the application and for each of its relations.
+func (u *Unit) GoalState() (*params.GoalState, error) {
+	if err := ErrIfNotVersionFn(6, u.st.BestAPIVersion())("GoalState"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.GoalStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("GoalStates", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
+
+// CharmState returns the key/value state that the unit's charm has
+// stored.
+func (u *Unit) CharmState() (map[string]string, error) {
+	if err := ErrIfNotVersionFn(6, u.st.BestAPIVersion())("CharmState"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.CharmStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	if err := u.st.facade.FacadeCall("GetCharmState", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
+
+// SetCharmState replaces the key/value state that the unit's charm has
+// stored.
+func (u *Unit) SetCharmState(charmState map[string]string) error {
+	if err := ErrIfNotVersionFn(6, u.st.BestAPIVersion())("SetCharmState"); err != nil {
+		return errors.Trace(err)
+	}
+	var results params.ErrorResults
+	args := params.SetCharmStateArgs{
+		Args: []params.SetCharmStateArg{{
+			Tag:        u.tag.String(),
+			CharmState: charmState,
+		}},
+	}
+	if err := u.st.facade.FacadeCall("SetCharmState", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RecordHookRuns records runs of the unit's hooks, so that recent hook
+// durations and failures can be shown to users.
+func (u *Unit) RecordHookRuns(runs []params.HookRun) error {
+	if err := ErrIfNotVersionFn(6, u.st.BestAPIVersion())("RecordHookRuns"); err != nil {
+		return errors.Trace(err)
+	}
+	var results params.ErrorResults
+	args := params.RecordHookRunsArgs{
+		Args: []params.RecordHookRunsArg{{
+			Tag:  u.tag.String(),
+			Runs: runs,
+		}},
+	}
+	if err := u.st.facade.FacadeCall("RecordHookRuns", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
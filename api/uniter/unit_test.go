@@ -792,6 +792,68 @@ func (s *unitSuite) TestMeterStatusResultError(c *gc.C) {
 	c.Assert(statusInfo, gc.Equals, "")
 }
 
+func (s *unitSuite) TestGoalState(c *gc.C) {
+	now := time.Now()
+	err := s.wordpressUnit.SetStatus(status.StatusInfo{Status: status.Active, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+
+	goal, err := s.apiUnit.GoalState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(goal.Units, gc.HasLen, 1)
+	c.Assert(goal.Units["wordpress/0"].Status, gc.Equals, "active")
+	c.Assert(goal.Relations, gc.HasLen, 0)
+}
+
+func (s *unitSuite) TestGoalStateResultError(c *gc.C) {
+	uniter.PatchUnitResponse(s, s.apiUnit, "GoalStates",
+		func(results interface{}) error {
+			result := results.(*params.GoalStateResults)
+			result.Results = make([]params.GoalStateResult, 1)
+			result.Results[0].Error = &params.Error{Message: "no goal"}
+			return nil
+		},
+	)
+	goal, err := s.apiUnit.GoalState()
+	c.Assert(err, gc.ErrorMatches, "no goal")
+	c.Assert(goal, gc.IsNil)
+}
+
+func (s *unitSuite) TestCharmState(c *gc.C) {
+	charmState, err := s.apiUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+
+	err = s.apiUnit.SetCharmState(map[string]string{"cluster": "formed"})
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err = s.apiUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"cluster": "formed"})
+
+	charmState, err = s.wordpressUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"cluster": "formed"})
+}
+
+func (s *unitSuite) TestRecordHookRuns(c *gc.C) {
+	runs := []params.HookRun{{
+		Hook:     "install",
+		Started:  time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+		Duration: 5 * time.Second,
+		Result:   "success",
+	}}
+	err := s.apiUnit.RecordHookRuns(runs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	hookRuns, err := s.wordpressUnit.HookRuns(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hookRuns, jc.DeepEquals, []state.HookRun{{
+		Hook:     "install",
+		Started:  runs[0].Started,
+		Duration: 5 * time.Second,
+		Result:   "success",
+	}})
+}
+
 func (s *unitSuite) TestWatchMeterStatus(c *gc.C) {
 	w, err := s.apiUnit.WatchMeterStatus()
 	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
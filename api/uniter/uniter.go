@@ -69,12 +69,12 @@ func newStateForVersionFn(version int) func(base.APICaller, names.UnitTag) *Stat
 	}
 }
 
-// newStateV5 creates a new client-side Uniter facade, version 5.
-var newStateV5 = newStateForVersionFn(5)
+// newStateV6 creates a new client-side Uniter facade, version 6.
+var newStateV6 = newStateForVersionFn(6)
 
 // NewState creates a new client-side Uniter facade.
 // Defined like this to allow patching during tests.
-var NewState = newStateV5
+var NewState = newStateV6
 
 // BestAPIVersion returns the API version that we were able to
 // determine is supported by both the client and the API Server.
@@ -275,6 +275,50 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// AddActionOutput records output written by the running action
+// identified by the given tag, so that it can be streamed to clients.
+func (st *State) AddActionOutput(tag names.ActionTag, output []ActionOutput) error {
+	if err := ErrIfNotVersionFn(6, st.BestAPIVersion())("AddActionsOutput"); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.ActionOutputs{
+		Outputs: make([]params.ActionOutput, len(output)),
+	}
+	for i, out := range output {
+		args.Outputs[i] = params.ActionOutput{
+			ActionTag: tag.String(),
+			Seq:       out.Seq,
+			Stream:    out.Stream,
+			Data:      out.Data,
+			Timestamp: out.Timestamp,
+		}
+	}
+	var results params.ErrorResults
+	if err := st.facade.FacadeCall("AddActionsOutput", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// LogActionMessage records a progress message logged by the running
+// action identified by the given tag.
+func (st *State) LogActionMessage(tag names.ActionTag, message string) error {
+	if err := ErrIfNotVersionFn(6, st.BestAPIVersion())("LogActionsMessages"); err != nil {
+		return errors.Trace(err)
+	}
+	args := params.ActionLogMessages{
+		Messages: []params.ActionLogMessage{{
+			ActionTag: tag.String(),
+			Message:   message,
+		}},
+	}
+	var results params.ErrorResults
+	if err := st.facade.FacadeCall("LogActionsMessages", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
@@ -406,3 +450,19 @@ func (st *State) SLALevel() (string, error) {
 	}
 	return result.Result, nil
 }
+
+// CloudSpec returns the cloud spec, including the credential, of the
+// model the unit is in. The unit's application must be trusted.
+func (st *State) CloudSpec() (*params.CloudSpec, error) {
+	if err := ErrIfNotVersionFn(6, st.BestAPIVersion())("CloudSpec"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result params.CloudSpecResult
+	if err := st.facade.FacadeCall("CloudSpec", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := result.Error; err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Result, nil
+}
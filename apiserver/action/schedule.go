@@ -0,0 +1,132 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ScheduleActions schedules actions to be enqueued on their receivers
+// once at a given time, or repeatedly on a schedule.
+func (a *ActionAPI) ScheduleActions(args params.ActionScheduleArgs) (params.ActionScheduleResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	owner, ok := a.authorizer.GetAuthTag().(names.UserTag)
+	if !ok {
+		return params.ActionScheduleResults{}, common.ErrPerm
+	}
+	response := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		schedule, err := a.scheduleAction(owner, arg)
+		if err != nil {
+			response.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := makeActionSchedule(schedule)
+		response.Results[i].Result = &result
+	}
+	return response, nil
+}
+
+func (a *ActionAPI) scheduleAction(owner names.UserTag, arg params.ActionScheduleArg) (*state.ActionSchedule, error) {
+	receivers := make([]names.Tag, len(arg.Receivers))
+	for i, receiver := range arg.Receivers {
+		tag, err := names.ParseTag(receiver)
+		if err != nil {
+			return nil, common.ErrBadId
+		}
+		switch tag.(type) {
+		case names.ApplicationTag, names.UnitTag:
+		default:
+			return nil, common.ErrBadId
+		}
+		receivers[i] = tag
+	}
+	stateArgs := state.ActionScheduleArgs{
+		Receivers:  receivers,
+		Name:       arg.Name,
+		Parameters: arg.Parameters,
+		Schedule:   arg.Schedule,
+		Owner:      owner,
+	}
+	if arg.At != nil {
+		stateArgs.At = *arg.At
+	}
+	return a.state.AddActionSchedule(stateArgs)
+}
+
+// ListSchedules returns the scheduled actions of the model, ordered by
+// the time they next run.
+func (a *ActionAPI) ListSchedules() (params.ActionSchedules, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+
+	schedules, err := a.state.ActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	result := params.ActionSchedules{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result.Schedules[i] = makeActionSchedule(schedule)
+	}
+	return result, nil
+}
+
+// RemoveSchedules removes the scheduled actions with the given ids.
+// Actions already enqueued by the schedules are not affected.
+func (a *ActionAPI) RemoveSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		schedule, err := a.state.ActionSchedule(id)
+		if err == nil {
+			err = schedule.Remove()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// makeActionSchedule converts a state action schedule into its API
+// representation.
+func makeActionSchedule(schedule *state.ActionSchedule) params.ActionSchedule {
+	result := params.ActionSchedule{
+		Id:         schedule.Id(),
+		Receivers:  schedule.Receivers(),
+		Name:       schedule.Name(),
+		Parameters: schedule.Parameters(),
+		Schedule:   schedule.Schedule(),
+		NextRun:    schedule.NextRun(),
+		Owner:      schedule.Owner().String(),
+	}
+	if lastRun := schedule.LastRun(); !lastRun.IsZero() {
+		result.LastRun = &lastRun
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *actionSuite) TestBlockScheduleActions(c *gc.C) {
+	s.BlockAllChanges(c, "ScheduleActions")
+	_, err := s.action.ScheduleActions(params.ActionScheduleArgs{})
+	s.AssertBlocked(c, err, "ScheduleActions")
+}
+
+func (s *actionSuite) TestBlockRemoveSchedules(c *gc.C) {
+	s.BlockRemoveObject(c, "RemoveSchedules")
+	_, err := s.action.RemoveSchedules(params.ActionScheduleIds{})
+	s.AssertBlocked(c, err, "RemoveSchedules")
+}
+
+func (s *actionSuite) TestScheduleActions(c *gc.C) {
+	at := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	result, err := s.action.ScheduleActions(params.ActionScheduleArgs{
+		Schedules: []params.ActionScheduleArg{{
+			Receivers:  []string{s.dummy.Tag().String()},
+			Name:       "snapshot",
+			Parameters: map[string]interface{}{"outfile": "nightly.bz2"},
+			Schedule:   "0 0 1 1 *",
+		}, {
+			Receivers: []string{s.dummy.Tag().String()},
+			Name:      "snapshot",
+			At:        &at,
+		}, {
+			Receivers: []string{s.machine0.Tag().String()},
+			Name:      "snapshot",
+			Schedule:  "@daily",
+		}, {
+			Receivers: []string{s.dummy.Tag().String()},
+			Name:      "no-such-action",
+			Schedule:  "@daily",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[0].Result.Id, gc.Equals, "0")
+	c.Check(result.Results[0].Result.Receivers, jc.DeepEquals, []string{"application-dummy"})
+	c.Check(result.Results[0].Result.Schedule, gc.Equals, "0 0 1 1 *")
+	c.Check(result.Results[0].Result.Owner, gc.Equals, s.AdminUserTag(c).String())
+	c.Check(result.Results[0].Result.LastRun, gc.IsNil)
+
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Check(result.Results[1].Result.NextRun.Equal(at), jc.IsTrue)
+
+	c.Check(result.Results[2].Error, gc.ErrorMatches, "id not found")
+	c.Check(result.Results[3].Error, gc.ErrorMatches,
+		`cannot schedule action "no-such-action": action "no-such-action" not defined on application dummy`)
+
+	schedules, err := s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules.Schedules, gc.HasLen, 2)
+	c.Check(schedules.Schedules[0].Id, gc.Equals, "1")
+	c.Check(schedules.Schedules[1].Id, gc.Equals, "0")
+	c.Check(schedules.Schedules[1].Parameters, jc.DeepEquals, map[string]interface{}{"outfile": "nightly.bz2"})
+}
+
+func (s *actionSuite) TestRemoveSchedules(c *gc.C) {
+	result, err := s.action.ScheduleActions(params.ActionScheduleArgs{
+		Schedules: []params.ActionScheduleArg{{
+			Receivers: []string{s.dummy.Tag().String()},
+			Name:      "snapshot",
+			Schedule:  "@hourly",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	removed, err := s.action.RemoveSchedules(params.ActionScheduleIds{
+		Ids: []string{result.Results[0].Result.Id, "42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.Results, gc.HasLen, 2)
+	c.Check(removed.Results[0].Error, gc.IsNil)
+	c.Check(removed.Results[1].Error, gc.ErrorMatches, `action schedule "42" not found`)
+
+	schedules, err := s.action.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedules.Schedules, gc.HasLen, 0)
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// actionOutputPollInterval is how often the output of streamed
+// actions is checked for new output.
+var actionOutputPollInterval = 500 * time.Millisecond
+
+// actionOutputHandler takes requests to stream the output of running
+// actions.
+type actionOutputHandler struct {
+	ctxt httpContext
+}
+
+// actionOutputBackend provides the actions whose output is streamed.
+type actionOutputBackend interface {
+	ActionByTag(names.ActionTag) (state.Action, error)
+}
+
+// ServeHTTP will serve up connections as a websocket that streams the
+// output of the actions identified by the "action" query parameters,
+// which hold action tags. A params.ActionOutputMessage is sent for each
+// chunk of output written by an action, and once more when it finishes.
+// The connection is closed when all of the actions have finished.
+func (h *actionOutputHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		socket := &debugLogSocketImpl{conn}
+		defer conn.Close()
+
+		st, releaser, err := h.ctxt.stateForRequestAuthenticatedUser(req)
+		if err != nil {
+			socket.sendError(err)
+			return
+		}
+		defer releaser()
+
+		tags, err := readActionOutputParams(req.URL.Query())
+		if err != nil {
+			socket.sendError(err)
+			return
+		}
+		// Check that the actions exist before accepting the request.
+		for _, tag := range tags {
+			if _, err := st.ActionByTag(tag); err != nil {
+				socket.sendError(err)
+				return
+			}
+		}
+		socket.sendOk()
+
+		if err := streamActionOutput(st, tags, conn.WriteJSON, h.ctxt.stop()); err != nil {
+			if isBrokenPipe(err) {
+				logger.Tracef("action-output handler stopped (client disconnected)")
+			} else {
+				logger.Errorf("action-output handler error: %v", err)
+			}
+		}
+	}
+	websocketServer(w, req, handler)
+}
+
+func readActionOutputParams(queryMap url.Values) ([]names.ActionTag, error) {
+	values := queryMap["action"]
+	if len(values) == 0 {
+		return nil, errors.NotValidf("missing action")
+	}
+	tags := make([]names.ActionTag, len(values))
+	for i, value := range values {
+		tag, err := names.ParseActionTag(value)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tags[i] = tag
+	}
+	return tags, nil
+}
+
+// actionOutputStream records how much of an action's output has been
+// sent.
+type actionOutputStream struct {
+	tag  names.ActionTag
+	seq  int
+	done bool
+}
+
+// streamActionOutput sends the output of the actions with the given
+// tags as it is written, until all of the actions have finished or
+// stop is closed.
+func streamActionOutput(
+	backend actionOutputBackend,
+	tags []names.ActionTag,
+	send func(interface{}) error,
+	stop <-chan struct{},
+) error {
+	streams := make([]*actionOutputStream, len(tags))
+	for i, tag := range tags {
+		streams[i] = &actionOutputStream{tag: tag, seq: -1}
+	}
+	for {
+		running := false
+		for _, stream := range streams {
+			if stream.done {
+				continue
+			}
+			if err := sendActionOutput(backend, stream, send); err != nil {
+				return errors.Trace(err)
+			}
+			if !stream.done {
+				running = true
+			}
+		}
+		if !running {
+			return nil
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(actionOutputPollInterval):
+		}
+	}
+}
+
+// sendActionOutput sends any output of the stream's action that has
+// not yet been sent, followed by the action's final status if it has
+// finished.
+func sendActionOutput(backend actionOutputBackend, stream *actionOutputStream, send func(interface{}) error) error {
+	// The action's status is read before its output, as all of the
+	// output has been recorded by the time the action finishes.
+	action, err := backend.ActionByTag(stream.tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	receiver, err := names.ActionReceiverTag(action.Receiver())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output, err := action.Output(stream.seq)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, out := range output {
+		err := send(&params.ActionOutputMessage{
+			ActionTag: stream.tag.String(),
+			Receiver:  receiver.String(),
+			Stream:    out.Stream,
+			Data:      out.Data,
+			Timestamp: out.Timestamp,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		stream.seq = out.Seq
+	}
+	switch status := action.Status(); status {
+	case state.ActionCompleted, state.ActionFailed, state.ActionCancelled:
+		stream.done = true
+		return errors.Trace(send(&params.ActionOutputMessage{
+			ActionTag: stream.tag.String(),
+			Receiver:  receiver.String(),
+			Timestamp: action.Completed(),
+			Status:    string(status),
+		}))
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/gorilla/websocket"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type actionOutputSuite struct {
+	authHTTPSuite
+}
+
+var _ = gc.Suite(&actionOutputSuite{})
+
+func (s *actionOutputSuite) SetUpTest(c *gc.C) {
+	s.authHTTPSuite.SetUpTest(c)
+	s.PatchValue(apiserver.ActionOutputPollInterval, 10*time.Millisecond)
+}
+
+func (s *actionOutputSuite) TestNoAuth(c *gc.C) {
+	conn := s.dialWebsocket(c, nil, false)
+	assertJSONError(c, conn, "no credentials provided")
+	assertWebsocketClosed(c, conn)
+}
+
+func (s *actionOutputSuite) TestMachineLoginsRejected(c *gc.C) {
+	m, password := s.Factory.MakeMachineReturningPassword(c, &factory.MachineParams{
+		Nonce: "foo-nonce",
+	})
+	header := utils.BasicAuthHeader(m.Tag().String(), password)
+	header.Add(params.MachineNonceHeader, "foo-nonce")
+	conn := dialWebsocketFromURL(c, s.actionOutputURL(c, nil).String(), header)
+	defer conn.Close()
+
+	assertJSONError(c, conn, "tag kind machine not valid")
+	assertWebsocketClosed(c, conn)
+}
+
+func (s *actionOutputSuite) TestMissingAction(c *gc.C) {
+	conn := s.dialWebsocket(c, nil, true)
+	assertJSONError(c, conn, "missing action not valid")
+	assertWebsocketClosed(c, conn)
+}
+
+func (s *actionOutputSuite) TestActionNotFound(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{
+		"action": {"action-f47ac10b-58cc-4372-a567-0e02b2c3d479"},
+	}, true)
+	assertJSONError(c, conn, `action "f47ac10b-58cc-4372-a567-0e02b2c3d479" not found`)
+	assertWebsocketClosed(c, conn)
+}
+
+func (s *actionOutputSuite) TestStreamsOutput(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	a, err := unit.AddAction("juju-run", map[string]interface{}{
+		"command": "migrate",
+		"timeout": 0,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	err = a.AddOutput(state.ActionOutput{Seq: 0, Stream: state.ActionStdout, Data: "migrating\n", Timestamp: now})
+	c.Assert(err, jc.ErrorIsNil)
+
+	conn := s.dialWebsocket(c, url.Values{"action": {a.ActionTag().String()}}, true)
+	assertJSONInitialErrorNil(c, conn)
+	msg := s.readMessage(c, conn)
+	c.Assert(msg, jc.DeepEquals, params.ActionOutputMessage{
+		ActionTag: a.ActionTag().String(),
+		Receiver:  unit.Tag().String(),
+		Stream:    "stdout",
+		Data:      "migrating\n",
+		Timestamp: now,
+	})
+
+	// Output written while the client is connected is streamed.
+	err = a.AddOutput(state.ActionOutput{Seq: 1, Stream: state.ActionStderr, Data: "slow table\n", Timestamp: now})
+	c.Assert(err, jc.ErrorIsNil)
+	msg = s.readMessage(c, conn)
+	c.Assert(msg.Stream, gc.Equals, "stderr")
+	c.Assert(msg.Data, gc.Equals, "slow table\n")
+
+	_, err = a.Finish(state.ActionResults{Status: state.ActionFailed, Message: "exit status 1"})
+	c.Assert(err, jc.ErrorIsNil)
+	msg = s.readMessage(c, conn)
+	c.Assert(msg.ActionTag, gc.Equals, a.ActionTag().String())
+	c.Assert(msg.Status, gc.Equals, params.ActionFailed)
+	c.Assert(msg.Data, gc.Equals, "")
+	assertWebsocketClosed(c, conn)
+}
+
+func (s *actionOutputSuite) readMessage(c *gc.C, conn *websocket.Conn) params.ActionOutputMessage {
+	var msg params.ActionOutputMessage
+	err := conn.ReadJSON(&msg)
+	c.Assert(err, jc.ErrorIsNil)
+	return msg
+}
+
+func (s *actionOutputSuite) actionOutputURL(c *gc.C, queryParams url.Values) *url.URL {
+	path := fmt.Sprintf("/model/%s/action-output", s.State.ModelUUID())
+	return s.makeURL(c, "wss", path, queryParams)
+}
+
+func (s *actionOutputSuite) dialWebsocket(c *gc.C, queryParams url.Values, auth bool) *websocket.Conn {
+	header := utils.BasicAuthHeader(s.userTag.String(), s.password)
+	if !auth {
+		header = nil
+	}
+	conn := dialWebsocketFromURL(c, s.actionOutputURL(c, queryParams).String(), header)
+	s.AddCleanup(func(_ *gc.C) { conn.Close() })
+	return conn
+}
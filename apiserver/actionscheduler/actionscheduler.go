@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler implements the API used by the action
+// scheduler worker, which enqueues scheduled actions when they are due.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.actionscheduler")
+
+// API implements the API used by the action scheduler worker.
+type API struct {
+	st        StateInterface
+	resources facade.Resources
+	clock     clock.Clock
+}
+
+// NewFacade creates a new instance of the action scheduler API.
+func NewFacade(
+	st *state.State,
+	res facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	return newAPI(getState(st), res, authorizer, clock.WallClock)
+}
+
+func newAPI(
+	st StateInterface,
+	res facade.Resources,
+	authorizer facade.Authorizer,
+	clock clock.Clock,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:        st,
+		resources: res,
+		clock:     clock,
+	}, nil
+}
+
+// WatchActionSchedules watches for action schedules being added,
+// removed or run.
+func (api *API) WatchActionSchedules() (params.NotifyWatchResult, error) {
+	watch := api.st.WatchActionSchedules()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}, nil
+}
+
+// ActionSchedules returns the action schedules of the model, ordered
+// by the time they next run.
+func (api *API) ActionSchedules() (params.ActionSchedules, error) {
+	schedules, err := api.st.ActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	result := params.ActionSchedules{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result.Schedules[i] = params.ActionSchedule{
+			Id:         schedule.Id(),
+			Receivers:  schedule.Receivers(),
+			Name:       schedule.Name(),
+			Parameters: schedule.Parameters(),
+			Schedule:   schedule.Schedule(),
+			NextRun:    schedule.NextRun(),
+			Owner:      schedule.Owner().String(),
+		}
+		if lastRun := schedule.LastRun(); !lastRun.IsZero() {
+			result.Schedules[i].LastRun = &lastRun
+		}
+	}
+	return result, nil
+}
+
+// RunActionSchedules enqueues the actions of the action schedules with
+// the given ids. A schedule that is not yet due is not run.
+func (api *API) RunActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	now := api.clock.Now()
+	for i, id := range args.Ids {
+		result.Results[i].Error = common.ServerError(api.runActionSchedule(id, now))
+	}
+	return result, nil
+}
+
+func (api *API) runActionSchedule(id string, now time.Time) error {
+	schedule, err := api.st.ActionSchedule(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if schedule.NextRun().After(now) {
+		return errors.Errorf("action schedule %q not due until %s", id, schedule.NextRun().Format(time.RFC3339))
+	}
+	enqueued, err := schedule.Run(now)
+	for _, action := range enqueued {
+		logger.Debugf("action schedule %q enqueued action %s on %s", id, action.Id(), action.Receiver())
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,206 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/actionscheduler"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionSchedulerSuite struct {
+	coretesting.BaseSuite
+
+	st         *mockState
+	clock      *testing.Clock
+	api        *actionscheduler.API
+	authoriser apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&ActionSchedulerSuite{})
+
+func (s *ActionSchedulerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	s.authoriser = apiservertesting.FakeAuthorizer{
+		Controller: true,
+	}
+	s.clock = testing.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.st = &mockState{
+		Stub: &testing.Stub{},
+		schedules: []*mockSchedule{{
+			Stub:    &testing.Stub{},
+			id:      "0",
+			name:    "backup",
+			nextRun: s.clock.Now().Add(-time.Minute),
+		}, {
+			Stub:    &testing.Stub{},
+			id:      "1",
+			name:    "snapshot",
+			nextRun: s.clock.Now().Add(time.Hour),
+			lastRun: s.clock.Now().Add(-time.Hour),
+		}},
+	}
+	var err error
+	s.api, err = actionscheduler.NewAPI(s.st, common.NewResources(), s.authoriser, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSchedulerSuite) TestNewAPIRequiresController(c *gc.C) {
+	anAuthoriser := s.authoriser
+	anAuthoriser.Controller = false
+	api, err := actionscheduler.NewAPI(s.st, nil, anAuthoriser, s.clock)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(common.ServerError(err), jc.Satisfies, params.IsCodeUnauthorized)
+}
+
+func (s *ActionSchedulerSuite) TestWatchActionSchedules(c *gc.C) {
+	result, err := s.api.WatchActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	s.st.CheckCallNames(c, "WatchActionSchedules")
+}
+
+func (s *ActionSchedulerSuite) TestWatchActionSchedulesFailure(c *gc.C) {
+	s.st.SetErrors(errors.New("boom!"))
+	s.st.watchFails = true
+
+	result, err := s.api.WatchActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom!")
+}
+
+func (s *ActionSchedulerSuite) TestActionSchedules(c *gc.C) {
+	result, err := s.api.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	lastRun := s.clock.Now().Add(-time.Hour)
+	c.Assert(result, jc.DeepEquals, params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Id:      "0",
+			Name:    "backup",
+			NextRun: s.clock.Now().Add(-time.Minute),
+			Owner:   "user-bob",
+		}, {
+			Id:      "1",
+			Name:    "snapshot",
+			NextRun: s.clock.Now().Add(time.Hour),
+			LastRun: &lastRun,
+			Owner:   "user-bob",
+		}},
+	})
+}
+
+func (s *ActionSchedulerSuite) TestRunActionSchedules(c *gc.C) {
+	s.st.schedules[0].SetErrors(errors.New("cannot enqueue action"))
+	result, err := s.api.RunActionSchedules(params.ActionScheduleIds{
+		Ids: []string{"0", "1", "2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, "cannot enqueue action")
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `action schedule "1" not due until 2017-06-01T13:00:00Z`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `action schedule "2" not found`)
+	c.Check(result.Results[2].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	s.st.schedules[0].CheckCall(c, 0, "Run", s.clock.Now())
+	s.st.schedules[1].CheckNoCalls(c)
+}
+
+type mockState struct {
+	*testing.Stub
+	schedules  []*mockSchedule
+	watchFails bool
+}
+
+func (st *mockState) WatchActionSchedules() state.NotifyWatcher {
+	st.MethodCall(st, "WatchActionSchedules")
+	w := &mockWatcher{
+		out: make(chan struct{}, 1),
+		st:  st,
+	}
+	if st.watchFails {
+		close(w.out)
+	} else {
+		w.out <- struct{}{}
+	}
+	return w
+}
+
+func (st *mockState) ActionSchedules() ([]actionscheduler.ActionSchedule, error) {
+	st.MethodCall(st, "ActionSchedules")
+	result := make([]actionscheduler.ActionSchedule, len(st.schedules))
+	for i, schedule := range st.schedules {
+		result[i] = schedule
+	}
+	return result, st.NextErr()
+}
+
+func (st *mockState) ActionSchedule(id string) (actionscheduler.ActionSchedule, error) {
+	st.MethodCall(st, "ActionSchedule", id)
+	for _, schedule := range st.schedules {
+		if schedule.id == id {
+			return schedule, nil
+		}
+	}
+	return nil, errors.NotFoundf("action schedule %q", id)
+}
+
+type mockSchedule struct {
+	*testing.Stub
+	id      string
+	name    string
+	nextRun time.Time
+	lastRun time.Time
+}
+
+func (s *mockSchedule) Id() string                         { return s.id }
+func (s *mockSchedule) Receivers() []string                { return nil }
+func (s *mockSchedule) Name() string                       { return s.name }
+func (s *mockSchedule) Parameters() map[string]interface{} { return nil }
+func (s *mockSchedule) Schedule() string                   { return "" }
+func (s *mockSchedule) NextRun() time.Time                 { return s.nextRun }
+func (s *mockSchedule) LastRun() time.Time                 { return s.lastRun }
+func (s *mockSchedule) Owner() names.UserTag               { return names.NewUserTag("bob") }
+
+func (s *mockSchedule) Run(now time.Time) ([]state.Action, error) {
+	s.MethodCall(s, "Run", now)
+	return nil, s.NextErr()
+}
+
+type mockWatcher struct {
+	out chan struct{}
+	st  *mockState
+}
+
+func (w *mockWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *mockWatcher) Stop() error {
+	return nil
+}
+
+func (w *mockWatcher) Kill() {
+}
+
+func (w *mockWatcher) Wait() error {
+	return nil
+}
+
+func (w *mockWatcher) Err() error {
+	return w.st.NextErr()
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// StateInterface defines the state methods used by the action
+// scheduler facade.
+type StateInterface interface {
+	WatchActionSchedules() state.NotifyWatcher
+	ActionSchedules() ([]ActionSchedule, error)
+	ActionSchedule(id string) (ActionSchedule, error)
+}
+
+// ActionSchedule defines the methods of a state action schedule used
+// by the action scheduler facade.
+type ActionSchedule interface {
+	Id() string
+	Receivers() []string
+	Name() string
+	Parameters() map[string]interface{}
+	Schedule() string
+	NextRun() time.Time
+	LastRun() time.Time
+	Owner() names.UserTag
+	Run(now time.Time) ([]state.Action, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ActionSchedules() ([]ActionSchedule, error) {
+	schedules, err := s.State.ActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]ActionSchedule, len(schedules))
+	for i, schedule := range schedules {
+		result[i] = schedule
+	}
+	return result, nil
+}
+
+func (s stateShim) ActionSchedule(id string) (ActionSchedule, error) {
+	schedule, err := s.State.ActionSchedule(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return schedule, nil
+}
+
+var getState = func(st *state.State) StateInterface {
+	return stateShim{st}
+}
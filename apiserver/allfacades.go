@@ -11,12 +11,14 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/action"
+	"github.com/juju/juju/apiserver/actionscheduler"
 	"github.com/juju/juju/apiserver/agent" // ModelUser Write
 	"github.com/juju/juju/apiserver/agenttools"
 	"github.com/juju/juju/apiserver/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/application" // ModelUser Write
 	"github.com/juju/juju/apiserver/applicationoffers"
 	"github.com/juju/juju/apiserver/applicationscaler"
+	"github.com/juju/juju/apiserver/auditlog"
 	"github.com/juju/juju/apiserver/backups" // ModelUser Write
 	"github.com/juju/juju/apiserver/block"   // ModelUser Write
 	"github.com/juju/juju/apiserver/bundle"
@@ -68,6 +70,7 @@ import (
 	"github.com/juju/juju/apiserver/resourceshookcontext"
 	"github.com/juju/juju/apiserver/resumer"
 	"github.com/juju/juju/apiserver/retrystrategy"
+	"github.com/juju/juju/apiserver/secrets"
 	"github.com/juju/juju/apiserver/singular"
 	"github.com/juju/juju/apiserver/spaces"    // ModelUser Write
 	"github.com/juju/juju/apiserver/sshclient" // ModelUser Write
@@ -113,6 +116,8 @@ func AllFacades() *facade.Registry {
 	}
 
 	reg("Action", 2, action.NewActionAPI)
+	reg("Action", 3, action.NewActionAPI)
+	reg("ActionScheduler", 1, actionscheduler.NewFacade)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
@@ -122,17 +127,25 @@ func AllFacades() *facade.Registry {
 	reg("Application", 3, application.NewFacade)
 	reg("Application", 4, application.NewFacade)
 	reg("Application", 5, application.NewFacade)
+	reg("Application", 6, application.NewFacade)
 
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
+	reg("AuditLog", 1, auditlog.NewFacade)
 	reg("Backups", 1, backups.NewFacade)
+	reg("Backups", 2, backups.NewFacade) // Adds backup targets.
+	reg("Backups", 3, backups.NewFacade) // Adds encrypted backups.
 	reg("Block", 2, block.NewAPI)
-	reg("Bundle", 1, bundle.NewFacade)
+	reg("Bundle", 1, bundle.NewFacadeV1)
+	reg("Bundle", 2, bundle.NewFacade) // Adds ExportBundle.
 	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacade)
 	reg("Cloud", 1, cloud.NewFacade)
 	reg("Controller", 3, controller.NewControllerAPI)
+	reg("Controller", 4, controller.NewControllerAPI) // Adds BackupStatus.
+	reg("Controller", 5, controller.NewControllerAPI) // Adds PrecheckMigration.
+	reg("Controller", 6, controller.NewControllerAPI) // Adds CloneModel.
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
 	reg("Firewaller", 3, firewaller.NewFirewallerAPI)
@@ -147,7 +160,8 @@ func AllFacades() *facade.Registry {
 	reg("LifeFlag", 1, lifeflag.NewExternalFacade)
 	reg("Logger", 1, loggerapi.NewLoggerAPI)
 	reg("LogForwarding", 1, logfwd.NewFacade)
-	reg("MachineActions", 1, machineactions.NewExternalFacade)
+	reg("MachineActions", 1, machineactions.NewExternalFacadeV1)
+	reg("MachineActions", 2, machineactions.NewExternalFacade) // Adds AddActionsOutput.
 
 	reg("MachineManager", 2, machinemanager.NewMachineManagerAPI)
 	reg("MachineManager", 3, machinemanager.NewMachineManagerAPI) // Version 3 adds DestroyMachine and ForceDestroyMachine.
@@ -164,6 +178,7 @@ func AllFacades() *facade.Registry {
 	reg("MigrationMaster", 1, migrationmaster.NewFacade)
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
 	reg("MigrationTarget", 1, migrationtarget.NewFacade)
+	reg("MigrationTarget", 2, migrationtarget.NewFacade) // Adds PrecheckFailures.
 
 	reg("ModelConfig", 1, modelconfig.NewFacade)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
@@ -190,6 +205,7 @@ func AllFacades() *facade.Registry {
 
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
+	reg("Secrets", 1, secrets.NewSecretsAPI)
 	reg("Singular", 1, singular.NewExternalFacade)
 
 	reg("SSHClient", 1, sshclient.NewFacade)
@@ -206,7 +222,8 @@ func AllFacades() *facade.Registry {
 	reg("UnitAssigner", 1, unitassigner.New)
 
 	reg("Uniter", 4, uniter.NewUniterAPIV4)
-	reg("Uniter", 5, uniter.NewUniterAPI)
+	reg("Uniter", 5, uniter.NewUniterAPIV5)
+	reg("Uniter", 6, uniter.NewUniterAPI)
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
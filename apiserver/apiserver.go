@@ -546,6 +546,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	logStreamHandler := srv.trackRequests(newLogStreamEndpointHandler(httpCtxt))
 	debugLogHandler := srv.trackRequests(newDebugLogDBHandler(httpCtxt))
 	pubsubHandler := srv.trackRequests(newPubSubHandler(httpCtxt, srv.centralHub))
+	actionOutputHandler := srv.trackRequests(&actionOutputHandler{ctxt: httpCtxt})
 
 	// This handler is model specific even though it only ever makes sense
 	// for a controller because the API caller that is handed to the worker
@@ -554,6 +555,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	add("/model/:modeluuid/pubsub", pubsubHandler)
 	add("/model/:modeluuid/logstream", logStreamHandler)
 	add("/model/:modeluuid/log", debugLogHandler)
+	add("/model/:modeluuid/action-output", actionOutputHandler)
 
 	logSinkHandler := newLogSinkHandler(httpCtxt, srv.logSinkWriter, newAgentLoggingStrategy)
 	add("/model/:modeluuid/logsink", srv.trackRequests(logSinkHandler))
@@ -227,6 +227,7 @@ func deployApplication(
 		AttachStorage:    attachStorage,
 		EndpointBindings: args.EndpointBindings,
 		Resources:        args.Resources,
+		Trust:            args.Trust,
 	})
 	return errors.Trace(err)
 }
@@ -596,6 +597,22 @@ func (api *API) Unexpose(args params.ApplicationUnexpose) error {
 	return app.ClearExposed()
 }
 
+// SetTrust grants or revokes an application's access to the model's
+// cloud credential, which its units may read with credential-get.
+func (api *API) SetTrust(args params.ApplicationTrust) error {
+	if err := api.checkCanWrite(); err != nil {
+		return err
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(args.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.SetTrust(args.Trust)
+}
+
 // AddUnits adds a given number of units to an application.
 func (api *API) AddUnits(args params.AddApplicationUnits) (params.AddApplicationUnitsResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -852,6 +869,54 @@ func (api *API) GetConstraints(args params.GetApplicationConstraints) (params.Ge
 	return params.GetConstraintsResults{cons}, errors.Trace(err)
 }
 
+// UnitsHookRuns returns the most recent hook runs of each given unit,
+// newest first, so that slow or failing hooks can be found.
+func (api *API) UnitsHookRuns(args params.HookRunsArgs) (params.HookRunsResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.HookRunsResults{}, errors.Trace(err)
+	}
+	if args.Count < 0 {
+		return params.HookRunsResults{}, errors.NotValidf("negative count")
+	}
+	results := params.HookRunsResults{
+		Results: make([]params.HookRunsResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		runs, err := api.unitHookRuns(entity.Tag, args.Count)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Runs = runs
+	}
+	return results, nil
+}
+
+func (api *API) unitHookRuns(tag string, count int) ([]params.HookRun, error) {
+	unitTag, err := names.ParseUnitTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(unitTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	runs, err := unit.HookRuns(count)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.HookRun, len(runs))
+	for i, run := range runs {
+		result[i] = params.HookRun{
+			Hook:     run.Hook,
+			Started:  run.Started,
+			Duration: run.Duration,
+			Result:   run.Result,
+		}
+	}
+	return result, nil
+}
+
 // SetConstraints sets the constraints for a given application.
 func (api *API) SetConstraints(args params.SetConstraints) error {
 	if err := api.checkCanWrite(); err != nil {
@@ -1078,6 +1078,27 @@ func (s *applicationSuite) TestApplicationDeployConfig(c *gc.C) {
 	c.Assert(settings, gc.DeepEquals, charm.Settings{"username": "fred"})
 }
 
+func (s *applicationSuite) TestApplicationDeployTrust(c *gc.C) {
+	curl, _ := s.UploadCharm(c, "precise/dummy-0", "dummy")
+	err := application.AddCharmWithAuthorization(s.State, params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			CharmURL:        curl.String(),
+			ApplicationName: "application-name",
+			Trust:           true,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+
+	application, err := s.State.Application("application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.IsTrusted(), jc.IsTrue)
+}
+
 func (s *applicationSuite) TestApplicationDeployConfigError(c *gc.C) {
 	// TODO(fwereade): test Config/ConfigYAML handling directly on srvClient.
 	// Can't be done cleanly until it's extracted similarly to Machiner.
@@ -1927,6 +1948,70 @@ func (s *applicationSuite) TestBlockChangesApplicationExpose(c *gc.C) {
 	s.assertApplicationExposeBlocked(c, "TestBlockChangesApplicationExpose")
 }
 
+func (s *applicationSuite) TestApplicationSetTrust(c *gc.C) {
+	app := s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+
+	err := s.applicationAPI.SetTrust(params.ApplicationTrust{ApplicationName: "dummy", Trust: true})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsTrusted(), jc.IsTrue)
+
+	err = s.applicationAPI.SetTrust(params.ApplicationTrust{ApplicationName: "dummy", Trust: false})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsTrusted(), jc.IsFalse)
+
+	err = s.applicationAPI.SetTrust(params.ApplicationTrust{ApplicationName: "unknown", Trust: true})
+	c.Assert(err, gc.ErrorMatches, `application "unknown" not found`)
+}
+
+func (s *applicationSuite) TestBlockChangesApplicationSetTrust(c *gc.C) {
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.BlockAllChanges(c, "TestBlockChangesApplicationSetTrust")
+	err := s.applicationAPI.SetTrust(params.ApplicationTrust{ApplicationName: "dummy", Trust: true})
+	s.AssertBlocked(c, err, "TestBlockChangesApplicationSetTrust")
+}
+
+func (s *applicationSuite) TestUnitsHookRuns(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.application})
+	started := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, hook := range []string{"install", "config-changed"} {
+		err := unit.RecordHookRun(state.HookRun{
+			Hook:     hook,
+			Started:  started.Add(time.Duration(i) * time.Minute),
+			Duration: 10 * time.Second,
+			Result:   "success",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	results, err := s.applicationAPI.UnitsHookRuns(params.HookRunsArgs{
+		Entities: []params.Entity{
+			{Tag: unit.Tag().String()},
+			{Tag: "unit-unknown-0"},
+			{Tag: "application-unknown"},
+		},
+		Count: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.HookRunsResults{
+		Results: []params.HookRunsResult{{
+			Runs: []params.HookRun{{
+				Hook:     "config-changed",
+				Started:  started.Add(time.Minute),
+				Duration: 10 * time.Second,
+				Result:   "success",
+			}},
+		}, {
+			Error: &params.Error{Message: `unit "unknown/0" not found`, Code: params.CodeNotFound},
+		}, {
+			Error: &params.Error{Message: `"application-unknown" is not a valid unit tag`},
+		}},
+	})
+}
+
 var applicationUnexposeTests = []struct {
 	about       string
 	application string
@@ -68,6 +68,7 @@ type Application interface {
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	SetTrust(bool) error
 	UpdateConfigSettings(charm.Settings) error
 }
 
@@ -104,6 +105,7 @@ type Unit interface {
 	Destroy() error
 	IsPrincipal() bool
 	Life() state.Life
+	HookRuns(int) ([]state.HookRun, error)
 
 	AssignWithPolicy(state.AssignmentPolicy) error
 	AssignWithPlacement(*instance.Placement) error
@@ -38,6 +38,9 @@ type DeployApplicationParams struct {
 	EndpointBindings map[string]string
 	// Resources is a map of resource name to IDs of pending resources.
 	Resources map[string]string
+	// Trust reports whether the application may access the model's
+	// cloud credential.
+	Trust bool
 }
 
 type ApplicationDeployer interface {
@@ -82,6 +85,7 @@ func DeployApplication(st ApplicationDeployer, args DeployApplicationParams) (Ap
 		Placement:        args.Placement,
 		Resources:        args.Resources,
 		EndpointBindings: effectiveBindings,
+		Trust:            args.Trust,
 	}
 
 	if !args.Charm.Meta().Subordinate {
@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog defines an API end point for querying the audit
+// entries recorded by the controller.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the audit log
+// facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	AuditEntries(audit.Query) ([]audit.AuditEntry, error)
+}
+
+// API implements the audit log facade.
+type API struct {
+	backend Backend
+}
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	return NewAPI(st, auth)
+}
+
+// NewAPI returns a new audit log API facade. Only controller
+// superusers may query the audit log.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// Query returns the audit log entries matching the query, oldest
+// first.
+func (api *API) Query(args params.AuditLogQuery) (params.AuditLogEntries, error) {
+	query := audit.Query{
+		ModelUUID: args.ModelUUID,
+		Facade:    args.Facade,
+		Method:    args.Method,
+		After:     args.After,
+		Before:    args.Before,
+		Limit:     args.Limit,
+	}
+	if args.UserTag != "" {
+		tag, err := names.ParseUserTag(args.UserTag)
+		if err != nil {
+			return params.AuditLogEntries{}, errors.Trace(err)
+		}
+		query.OriginName = tag.String()
+	}
+	if query.Method != "" && query.Facade == "" {
+		return params.AuditLogEntries{}, errors.NotValidf("method without facade")
+	}
+	entries, err := api.backend.AuditEntries(query)
+	if err != nil {
+		return params.AuditLogEntries{}, errors.Trace(err)
+	}
+	result := params.AuditLogEntries{
+		Entries: make([]params.AuditLogEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			JujuServerVersion: entry.JujuServerVersion,
+			ModelUUID:         entry.ModelUUID,
+			ConversationID:    entry.ConversationID,
+			Timestamp:         entry.Timestamp,
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Data:              entry.Data,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/auditlog"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	gitjujutesting.IsolationSuite
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser-bob"),
+	}
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresSuperuser(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin-bob")
+	_, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	t0 := time.Date(2017, time.September, 26, 10, 0, 0, 0, time.UTC)
+	s.backend.entries = []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         t0,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        "user-mary",
+		Operation:         "Application:v4 - Destroy",
+		Data:              map[string]interface{}{"foo": "bar"},
+	}}
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.Query(params.AuditLogQuery{
+		UserTag:   "user-mary",
+		ModelUUID: coretesting.ModelTag.Id(),
+		Facade:    "Application",
+		Method:    "Destroy",
+		After:     t0,
+		Before:    t0.Add(time.Hour),
+		Limit:     5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AuditLogEntries{
+		Entries: []params.AuditLogEntry{{
+			JujuServerVersion: version.MustParse("2.3.0"),
+			ModelUUID:         coretesting.ModelTag.Id(),
+			Timestamp:         t0,
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "API request",
+			OriginName:        "user-mary",
+			Operation:         "Application:v4 - Destroy",
+			Data:              map[string]interface{}{"foo": "bar"},
+		}},
+	})
+	s.backend.CheckCall(c, 0, "AuditEntries", audit.Query{
+		OriginName: "user-mary",
+		ModelUUID:  coretesting.ModelTag.Id(),
+		Facade:     "Application",
+		Method:     "Destroy",
+		After:      t0,
+		Before:     t0.Add(time.Hour),
+		Limit:      5,
+	})
+}
+
+func (s *auditLogSuite) TestQueryInvalidUser(c *gc.C) {
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Query(params.AuditLogQuery{UserTag: "machine-0"})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *auditLogSuite) TestQueryMethodWithoutFacade(c *gc.C) {
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Query(params.AuditLogQuery{Method: "Destroy"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	api, err := auditlog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Query(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	gitjujutesting.Stub
+	entries []audit.AuditEntry
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) AuditEntries(query audit.Query) ([]audit.AuditEntry, error) {
+	b.MethodCall(b, "AuditEntries", query)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.entries, nil
+}
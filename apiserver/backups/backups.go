@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/backups/target"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.backups")
@@ -105,6 +106,32 @@ func extractResourceValue(resources facade.Resources, key string) (string, error
 	return strRes.String(), nil
 }
 
+// target returns the controller's backup target, or an error
+// satisfying errors.IsNotFound if it has none.
+func (a *API) target() (target.Target, error) {
+	cfg, err := a.backend.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newTarget(cfg)
+}
+
+var newTarget = target.New
+
+// encryptionKey returns the key that backup archives are encrypted and
+// decrypted with: the given key if it is not empty, or else the
+// controller's default backup encryption key.
+func (a *API) encryptionKey(key string) (string, error) {
+	if key != "" {
+		return key, nil
+	}
+	cfg, err := a.backend.ControllerConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cfg.BackupEncryptionKey(), nil
+}
+
 var newBackups = func(backend Backend) (backups.Backups, io.Closer) {
 	stor := backups.NewStorage(backend)
 	return backups.NewBackups(stor), stor
@@ -129,6 +156,8 @@ func ResultFromMetadata(meta *backups.Metadata) params.BackupsMetadataResult {
 		result.Finished = *meta.Finished
 	}
 	result.Notes = meta.Notes
+	result.Scheduled = meta.Scheduled
+	result.Encrypted = meta.Encrypted
 
 	result.Model = meta.Origin.Model
 	result.Machine = meta.Origin.Machine
@@ -163,6 +192,8 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Version = result.Version
 	meta.Origin.Series = result.Series
 	meta.Notes = result.Notes
+	meta.Scheduled = result.Scheduled
+	meta.Encrypted = result.Encrypted
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
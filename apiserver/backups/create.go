@@ -8,8 +8,10 @@ import (
 	"github.com/juju/replicaset"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/backups/target"
 )
 
 var waitUntilReady = replicaset.WaitUntilReady
@@ -17,6 +19,14 @@ var waitUntilReady = replicaset.WaitUntilReady
 // Create is the API method that requests juju to create a new backup
 // of its state.  It returns the metadata for that backup.
 func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataResult, err error) {
+	key, err := a.encryptionKey(args.Key)
+	if err != nil {
+		return p, errors.Trace(err)
+	}
+	if args.Encrypt && key == "" {
+		return p, errors.Errorf("cannot encrypt backup: no key given and %q not set", controller.BackupEncryptionKey)
+	}
+
 	backupsMethods, closer := newBackups(a.backend)
 	defer closer.Close()
 
@@ -53,10 +63,21 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 	}
 	meta.Notes = args.Notes
 
-	err = backupsMethods.Create(meta, a.paths, dbInfo)
+	err = backupsMethods.Create(meta, a.paths, dbInfo, key)
 	if err != nil {
 		return p, errors.Trace(err)
 	}
 
+	// Copy the backup off the controller, if a backup target is
+	// configured.
+	t, err := a.target()
+	if errors.IsNotFound(err) {
+		return ResultFromMetadata(meta), nil
+	} else if err != nil {
+		return p, errors.Annotatef(err, "backup %q created but not copied", meta.ID())
+	}
+	if err := target.Export(t, backupsMethods, meta.ID()); err != nil {
+		return p, errors.Annotatef(err, "backup %q created but not copied", meta.ID())
+	}
 	return ResultFromMetadata(meta), nil
 }
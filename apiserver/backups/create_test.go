@@ -4,12 +4,18 @@
 package backups_test
 
 import (
+	"bytes"
+	"io/ioutil"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups/target"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -53,3 +59,60 @@ func (s *backupsSuite) TestCreateError(c *gc.C) {
 	c.Logf("%v", err)
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	impl := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		Encrypt: true,
+		Key:     "sekrit",
+	}
+	_, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(impl.KeyArg, gc.Equals, "sekrit")
+}
+
+func (s *backupsSuite) TestCreateEncryptNoKey(c *gc.C) {
+	impl := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{Encrypt: true}
+	_, err := s.api.Create(args)
+	c.Check(err, gc.ErrorMatches, `cannot encrypt backup: no key given and "backup-encryption-key" not set`)
+	c.Check(impl.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestCreateDefaultKey(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	impl := s.setBackups(c, s.meta, "")
+	api, err := backups.NewAPI(&encryptingStateShim{&stateShim{s.State}, "sekrit"}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = api.Create(params.BackupsCreateArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(impl.KeyArg, gc.Equals, "sekrit")
+}
+
+func (s *backupsSuite) TestCreateCopiesToTarget(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	meta := backupstesting.NewMetadata()
+	impl := s.setBackups(c, meta, "")
+	impl.Archive = ioutil.NopCloser(bytes.NewBufferString("0123456789"))
+	t := target.NewDirectory(c.MkDir())
+	s.PatchValue(backups.NewTarget, func(controller.Config) (target.Target, error) {
+		return t, nil
+	})
+
+	_, err := s.api.Create(params.BackupsCreateArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(impl.Calls, jc.DeepEquals, []string{"Create", "Get"})
+
+	copied, err := t.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(copied, gc.HasLen, 1)
+	c.Assert(copied[0].ID(), gc.Equals, meta.ID())
+}
@@ -6,4 +6,5 @@ package backups
 var (
 	NewBackups     = &newBackups
 	WaitUntilReady = &waitUntilReady
+	NewTarget      = &newTarget
 )
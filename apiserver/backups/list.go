@@ -7,18 +7,32 @@ import (
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
 )
 
 // List provides the implementation of the API method.
 func (a *API) List(args params.BackupsListArgs) (params.BackupsListResult, error) {
 	var result params.BackupsListResult
 
-	backups, closer := newBackups(a.backend)
-	defer closer.Close()
-
-	metaList, err := backups.List()
-	if err != nil {
-		return result, errors.Trace(err)
+	var metaList []*backups.Metadata
+	if args.Target {
+		t, err := a.target()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		metaList, err = t.List()
+		if err != nil {
+			return result, errors.Annotatef(err, "listing backups in %s", t)
+		}
+	} else {
+		backupsMethods, closer := newBackups(a.backend)
+		defer closer.Close()
+
+		var err error
+		metaList, err = backupsMethods.List()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
 	}
 
 	result.List = make([]params.BackupsMetadataResult, len(metaList))
@@ -12,6 +12,9 @@ import (
 
 	"github.com/juju/juju/apiserver/backups"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups/target"
+	backupstesting "github.com/juju/juju/state/backups/testing"
 )
 
 func (s *backupsSuite) TestListOkay(c *gc.C) {
@@ -36,3 +39,25 @@ func (s *backupsSuite) TestListError(c *gc.C) {
 
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) TestListTarget(c *gc.C) {
+	impl := s.setBackups(c, s.meta, "")
+	t := target.NewDirectory(c.MkDir())
+	meta := backupstesting.NewMetadata()
+	err := t.Put(meta, bytes.NewBufferString("0123456789"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(backups.NewTarget, func(controller.Config) (target.Target, error) {
+		return t, nil
+	})
+
+	result, err := s.api.List(params.BackupsListArgs{Target: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.List, gc.HasLen, 1)
+	c.Check(result.List[0].ID, gc.Equals, meta.ID())
+	c.Check(impl.Calls, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestListTargetNotConfigured(c *gc.C) {
+	_, err := s.api.List(params.BackupsListArgs{Target: true})
+	c.Check(err, gc.ErrorMatches, "backup target not found")
+}
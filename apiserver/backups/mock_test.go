@@ -3,7 +3,10 @@
 
 package backups_test
 
-import "github.com/juju/juju/state"
+import (
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
 
 type stateShim struct {
 	*state.State
@@ -12,3 +15,19 @@ type stateShim struct {
 func (s *stateShim) MachineSeries(id string) (string, error) {
 	return "xenial", nil
 }
+
+// encryptingStateShim is a stateShim whose controller config sets a
+// default backup encryption key.
+type encryptingStateShim struct {
+	*stateShim
+	key string
+}
+
+func (s *encryptingStateShim) ControllerConfig() (controller.Config, error) {
+	cfg, err := s.State.ControllerConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg[controller.BackupEncryptionKey] = s.key
+	return cfg, nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/backups/target"
 )
 
 var bootstrapNode = names.NewMachineTag("0")
@@ -28,6 +29,10 @@ func (a *API) Restore(p params.RestoreArgs) error {
 	backup, closer := newBackups(a.backend)
 	defer closer.Close()
 
+	if err := a.importFromTarget(backup, p.BackupId); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Obtain the address of current machine, where we will be performing restore.
 	machine, err := a.backend.Machine(a.machineID)
 	if err != nil {
@@ -61,6 +66,11 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		return errors.Annotate(err, "cannot obtain instance id for machine to be restored")
 	}
 
+	key, err := a.encryptionKey(p.Key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	logger.Infof("beginning server side restore of backup %q", p.BackupId)
 	// Restore
 	restoreArgs := backups.RestoreArgs{
@@ -69,6 +79,7 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		NewInstId:      instanceId,
 		NewInstTag:     machine.Tag(),
 		NewInstSeries:  machine.Series(),
+		Key:            key,
 	}
 
 	session := a.backend.MongoSession().Copy()
@@ -139,6 +150,26 @@ func (a *API) Restore(p params.RestoreArgs) error {
 	return nil
 }
 
+// importFromTarget copies the backup with the given ID from the
+// controller's backup target, if it is not stored on the controller.
+func (a *API) importFromTarget(backup backups.Backups, id string) error {
+	_, archive, err := backup.Get(id)
+	if err == nil {
+		archive.Close()
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	t, err := a.target()
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("backup %q", id)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("backup %q not stored on the controller; copying it from %s", id, t)
+	return errors.Trace(target.Import(t, backup, id))
+}
+
 // PrepareRestore implements the server side of Backups.PrepareRestore.
 func (a *API) PrepareRestore() error {
 	info := a.backend.RestoreInfo()
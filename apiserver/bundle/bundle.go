@@ -10,38 +10,75 @@ import (
 	"github.com/juju/bundlechanges"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
 
 // NewFacade provides the required signature for facade registration.
-func NewFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
-	return NewBundle(auth)
+func NewFacade(st *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
+	return NewBundle(NewStateBackend(st), auth)
+}
+
+// NewFacadeV1 provides the required signature for registering version 1
+// of the facade, which doesn't have ExportBundle.
+func NewFacadeV1(st *state.State, resources facade.Resources, auth facade.Authorizer) (BundleV1, error) {
+	return NewFacade(st, resources, auth)
 }
 
 // NewBundle creates and returns a new Bundle API facade.
-func NewBundle(auth facade.Authorizer) (Bundle, error) {
+func NewBundle(backend Backend, auth facade.Authorizer) (Bundle, error) {
 	if !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
-	return &bundleAPI{}, nil
+	return &bundleAPI{
+		backend:    backend,
+		authorizer: auth,
+	}, nil
 }
 
-// Bundle defines the API endpoint used to retrieve bundle changes.
-type Bundle interface {
+// BundleV1 defines the version 1 API endpoint used to retrieve bundle
+// changes.
+type BundleV1 interface {
 	// GetChanges returns the list of changes required to deploy the given
 	// bundle data.
 	GetChanges(params.BundleChangesParams) (params.BundleChangesResults, error)
 }
 
+// Bundle defines the API endpoint used to retrieve bundle changes and
+// export the current model as a bundle.
+type Bundle interface {
+	BundleV1
+
+	// ExportBundle returns the YAML representation of a bundle that
+	// would recreate the applications, machines and relations of the
+	// current model.
+	ExportBundle() (params.StringResult, error)
+}
+
 // bundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
-type bundleAPI struct{}
+type bundleAPI struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+func (b *bundleAPI) checkCanRead() error {
+	allowed, err := b.authorizer.HasPermission(permission.ReadAccess, b.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
 
 // GetChanges returns the list of changes required to deploy the given bundle
 // data. The changes are sorted by requirements, so that they can be applied in
@@ -83,3 +120,35 @@ func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	}
 	return results, nil
 }
+
+// ExportBundle returns the YAML representation of a bundle that would
+// recreate the applications, machines and relations of the current model.
+func (b *bundleAPI) ExportBundle() (params.StringResult, error) {
+	var result params.StringResult
+	if err := b.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	model, err := b.backend.ExportPartial(state.ExportConfig{
+		SkipActions:            true,
+		SkipCloudImageMetadata: true,
+		SkipCredentials:        true,
+		SkipIPAddresses:        true,
+		SkipSSHHostKeys:        true,
+		SkipStatusHistory:      true,
+		SkipLinkLayerDevices:   true,
+	})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	data, err := bundleDataFromModel(model)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	bytes, err := yaml.Marshal(data)
+	if err != nil {
+		return result, errors.Annotate(err, "cannot marshal bundle")
+	}
+	result.Result = string(bytes)
+	return result, nil
+}
@@ -4,31 +4,69 @@
 package bundle_test
 
 import (
+	"reflect"
+
+	"github.com/juju/description"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/bundle"
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
 
 type bundleSuite struct {
 	coretesting.BaseSuite
-	facade bundle.Bundle
+	auth    apiservertesting.FakeAuthorizer
+	backend *stubBackend
+	facade  bundle.Bundle
 }
 
 var _ = gc.Suite(&bundleSuite{})
 
 func (s *bundleSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
-	auth := apiservertesting.FakeAuthorizer{
-		Tag: names.NewUserTag("who"),
+	s.auth = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+	s.backend = &stubBackend{
+		model: description.NewModel(description.ModelArgs{
+			Config: map[string]interface{}{
+				"uuid":           coretesting.ModelTag.Id(),
+				"default-series": "xenial",
+			},
+			Owner: names.NewUserTag("admin"),
+		}),
 	}
-	facade, err := bundle.NewBundle(auth)
+	s.facade = s.makeFacade(c)
+}
+
+func (s *bundleSuite) makeFacade(c *gc.C) bundle.Bundle {
+	facade, err := bundle.NewBundle(s.backend, s.auth)
 	c.Assert(err, jc.ErrorIsNil)
-	s.facade = facade
+	return facade
+}
+
+type stubBackend struct {
+	testing.Stub
+	model description.Model
+}
+
+func (b *stubBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *stubBackend) ExportPartial(cfg state.ExportConfig) (description.Model, error) {
+	b.MethodCall(b, "ExportPartial", cfg)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.model, nil
 }
 
 func (s *bundleSuite) TestGetChangesBundleContentError(c *gc.C) {
@@ -198,3 +236,146 @@ func (s *bundleSuite) TestGetChangesBundleEndpointBindingsSuccess(c *gc.C) {
 		}
 	}
 }
+
+func (s *bundleSuite) TestV1HasNoExportBundle(c *gc.C) {
+	v1 := reflect.TypeOf((*bundle.BundleV1)(nil)).Elem()
+	_, ok := v1.MethodByName("ExportBundle")
+	c.Assert(ok, jc.IsFalse)
+	_, ok = v1.MethodByName("GetChanges")
+	c.Assert(ok, jc.IsTrue)
+}
+
+func (s *bundleSuite) TestExportBundlePermissionDenied(c *gc.C) {
+	s.auth.Tag = names.NewUserTag("nobody")
+	facade := s.makeFacade(c)
+	_, err := facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *bundleSuite) TestExportBundleNoApplications(c *gc.C) {
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "applications in model not found")
+	c.Assert(result.Error.Code, gc.Equals, params.CodeNotFound)
+}
+
+func (s *bundleSuite) TestExportBundle(c *gc.C) {
+	model := s.backend.model
+	model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("0"),
+		Series: "xenial",
+	}).SetConstraints(description.ConstraintsArgs{
+		Memory: 4096,
+	})
+	model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("1"),
+		Series: "trusty",
+	})
+
+	mysql := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("mysql"),
+		Series:   "xenial",
+		CharmURL: "cs:xenial/mysql-57",
+		Settings: map[string]interface{}{
+			"dataset-size": "80%",
+		},
+		EndpointBindings: map[string]string{
+			"":   "",
+			"db": "internal",
+		},
+		StorageConstraints: map[string]description.StorageConstraintArgs{
+			"data": {Pool: "ebs", Size: 10240, Count: 1},
+		},
+	})
+	mysql.SetConstraints(description.ConstraintsArgs{CpuCores: 2})
+	mysql.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("mysql/1"),
+		Machine: names.NewMachineTag("0/lxd/1"),
+	})
+	mysql.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("mysql/0"),
+		Machine: names.NewMachineTag("0"),
+	})
+
+	wordpress := model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("wordpress"),
+		Series:   "trusty",
+		CharmURL: "cs:trusty/wordpress-5",
+		Exposed:  true,
+	})
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/0"),
+		Machine: names.NewMachineTag("1"),
+	})
+
+	model.AddApplication(description.ApplicationArgs{
+		Tag:         names.NewApplicationTag("nrpe"),
+		Series:      "xenial",
+		CharmURL:    "cs:xenial/nrpe-2",
+		Subordinate: true,
+	})
+
+	rel := model.AddRelation(description.RelationArgs{
+		Id:  1,
+		Key: "wordpress:db mysql:db",
+	})
+	rel.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "wordpress",
+		Name:            "db",
+		Role:            "requirer",
+	})
+	rel.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "mysql",
+		Name:            "db",
+		Role:            "provider",
+	})
+	peer := model.AddRelation(description.RelationArgs{
+		Id:  2,
+		Key: "mysql:cluster",
+	})
+	peer.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "mysql",
+		Name:            "cluster",
+		Role:            "peer",
+	})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.Equals, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 2
+    to:
+    - "0"
+    - lxd:0
+    options:
+      dataset-size: 80%
+    constraints: cores=2
+    storage:
+      data: ebs,1,10240M
+    bindings:
+      db: internal
+  nrpe:
+    charm: cs:xenial/nrpe-2
+  wordpress:
+    charm: cs:trusty/wordpress-5
+    series: trusty
+    num_units: 1
+    to:
+    - "1"
+    expose: true
+machines:
+  "0":
+    constraints: mem=4096M
+  "1":
+    series: trusty
+series: xenial
+relations:
+- - wordpress:db
+  - mysql:db
+`[1:])
+	s.backend.CheckCallNames(c, "ExportPartial")
+}
@@ -0,0 +1,207 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+)
+
+// bundleDataFromModel builds the bundle data that would recreate the
+// applications, machines and relations of the given model description.
+func bundleDataFromModel(model description.Model) (*charm.BundleData, error) {
+	if len(model.Applications()) == 0 {
+		return nil, errors.NotFoundf("applications in model")
+	}
+	data := &charm.BundleData{
+		Applications: make(map[string]*charm.ApplicationSpec),
+		Machines:     make(map[string]*charm.MachineSpec),
+	}
+	defaultSeries, _ := model.Config()["default-series"].(string)
+	data.Series = defaultSeries
+
+	for _, machine := range model.Machines() {
+		spec := &charm.MachineSpec{
+			Constraints: constraintsString(machine.Constraints()),
+			Annotations: machine.Annotations(),
+		}
+		if machine.Series() != defaultSeries {
+			spec.Series = machine.Series()
+		}
+		data.Machines[machine.Id()] = spec
+	}
+
+	for _, application := range model.Applications() {
+		spec := &charm.ApplicationSpec{
+			Charm:            application.CharmURL(),
+			Expose:           application.Exposed(),
+			Options:          application.Settings(),
+			Annotations:      application.Annotations(),
+			Constraints:      constraintsString(application.Constraints()),
+			Storage:          storageDirectives(application.StorageConstraints()),
+			EndpointBindings: endpointBindings(application.EndpointBindings()),
+		}
+		if application.Series() != defaultSeries {
+			spec.Series = application.Series()
+		}
+		if !application.Subordinate() {
+			units := application.Units()
+			spec.NumUnits = len(units)
+			spec.To = unitPlacement(units)
+		}
+		data.Applications[application.Name()] = spec
+	}
+
+	for _, relation := range model.Relations() {
+		endpoints := relation.Endpoints()
+		// Peer relations are established automatically and so are
+		// not represented in a bundle.
+		if len(endpoints) != 2 {
+			continue
+		}
+		relationData := make([]string, len(endpoints))
+		for i, ep := range endpoints {
+			relationData[i] = fmt.Sprintf("%s:%s", ep.ApplicationName(), ep.Name())
+		}
+		data.Relations = append(data.Relations, relationData)
+	}
+	sort.Sort(relationsByName(data.Relations))
+
+	if len(data.Machines) == 0 {
+		data.Machines = nil
+	}
+	return data, nil
+}
+
+// unitPlacement returns the bundle placement directives that put the
+// given units on the machines they are currently deployed to. Units
+// in containers are placed in new containers of the same type on the
+// corresponding top level machine.
+func unitPlacement(units []description.Unit) []string {
+	if len(units) == 0 {
+		return nil
+	}
+	// Sort the units by number so the placement is stable.
+	sorted := make([]description.Unit, len(units))
+	copy(sorted, units)
+	sort.Sort(unitsByNumber(sorted))
+
+	placement := make([]string, len(sorted))
+	for i, unit := range sorted {
+		machineId := unit.Machine().Id()
+		if names.IsContainerMachine(machineId) {
+			parts := strings.Split(machineId, "/")
+			placement[i] = fmt.Sprintf("%s:%s", parts[len(parts)-2], parts[0])
+			continue
+		}
+		placement[i] = machineId
+	}
+	return placement
+}
+
+// constraintsString returns the string representation of the given
+// constraints, or an empty string if there are none.
+func constraintsString(cons description.Constraints) string {
+	if cons == nil {
+		return ""
+	}
+	var value constraints.Value
+	if arch := cons.Architecture(); arch != "" {
+		value.Arch = &arch
+	}
+	if container := instance.ContainerType(cons.Container()); container != "" {
+		value.Container = &container
+	}
+	if cores := cons.CpuCores(); cores != 0 {
+		value.CpuCores = &cores
+	}
+	if power := cons.CpuPower(); power != 0 {
+		value.CpuPower = &power
+	}
+	if instanceType := cons.InstanceType(); instanceType != "" {
+		value.InstanceType = &instanceType
+	}
+	if mem := cons.Memory(); mem != 0 {
+		value.Mem = &mem
+	}
+	if disk := cons.RootDisk(); disk != 0 {
+		value.RootDisk = &disk
+	}
+	if spaces := cons.Spaces(); len(spaces) > 0 {
+		value.Spaces = &spaces
+	}
+	if tags := cons.Tags(); len(tags) > 0 {
+		value.Tags = &tags
+	}
+	if virtType := cons.VirtType(); virtType != "" {
+		value.VirtType = &virtType
+	}
+	return value.String()
+}
+
+// storageDirectives converts the application storage constraints into
+// the "pool,count,size" directives understood by bundles.
+func storageDirectives(cons map[string]description.StorageConstraint) map[string]string {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]string)
+	for name, sc := range cons {
+		var parts []string
+		if sc.Pool() != "" {
+			parts = append(parts, sc.Pool())
+		}
+		parts = append(parts, fmt.Sprint(sc.Count()))
+		parts = append(parts, fmt.Sprintf("%dM", sc.Size()))
+		result[name] = strings.Join(parts, ",")
+	}
+	return result
+}
+
+// endpointBindings returns the explicit endpoint bindings of an
+// application, skipping those bound to the default space.
+func endpointBindings(bindings map[string]string) map[string]string {
+	result := make(map[string]string)
+	for endpoint, space := range bindings {
+		if endpoint == "" || space == "" {
+			continue
+		}
+		result[endpoint] = space
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+type unitsByNumber []description.Unit
+
+func (u unitsByNumber) Len() int      { return len(u) }
+func (u unitsByNumber) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByNumber) Less(i, j int) bool {
+	return unitNumber(u[i].Name()) < unitNumber(u[j].Name())
+}
+
+func unitNumber(unitName string) int {
+	n, _ := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	return n
+}
+
+type relationsByName [][]string
+
+func (r relationsByName) Len() int      { return len(r) }
+func (r relationsByName) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByName) Less(i, j int) bool {
+	return strings.Join(r[i], " ") < strings.Join(r[j], " ")
+}
@@ -131,6 +131,56 @@ func FinishActions(args params.ActionExecutionResults, actionFn func(string) (st
 	return results
 }
 
+// LogActionsMessages records progress messages logged by running
+// actions.
+// It's a helper function currently used by the uniter.
+// It needs an actionFn that can fetch an action from state using it's id that's usually created by AuthAndActionFromTagFn
+func LogActionsMessages(args params.ActionLogMessages, actionFn func(string) (state.Action, error)) params.ErrorResults {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Messages))}
+
+	for i, arg := range args.Messages {
+		action, err := actionFn(arg.ActionTag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+		if err := action.Log(arg.Message); err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+	}
+
+	return results
+}
+
+// AddActionsOutput records output written by running actions, so that
+// it can be streamed to clients.
+// It's a helper function currently used by the uniter and by machineactions.
+// It needs an actionFn that can fetch an action from state using it's id that's usually created by AuthAndActionFromTagFn
+func AddActionsOutput(args params.ActionOutputs, actionFn func(string) (state.Action, error)) params.ErrorResults {
+	results := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Outputs))}
+
+	for i, arg := range args.Outputs {
+		action, err := actionFn(arg.ActionTag)
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+		err = action.AddOutput(state.ActionOutput{
+			Seq:       arg.Seq,
+			Stream:    arg.Stream,
+			Data:      arg.Data,
+			Timestamp: arg.Timestamp,
+		})
+		if err != nil {
+			results.Results[i].Error = ServerError(err)
+			continue
+		}
+	}
+
+	return results
+}
+
 // Actions returns the Actions by Tags passed in and ensures that the receiver asking for
 // them is the same one that has the action.
 // It's a helper function currently used by the uniter and by machineactions.
@@ -233,6 +283,13 @@ func ConvertActions(ar state.ActionReceiver, fn GetActionsFn) ([]params.ActionRe
 // to params.ActionResult.
 func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.ActionResult {
 	output, message := action.Results()
+	var log []params.ActionMessage
+	for _, m := range action.Messages() {
+		log = append(log, params.ActionMessage{
+			Timestamp: m.Timestamp,
+			Message:   m.Message,
+		})
+	}
 	return params.ActionResult{
 		Action: &params.Action{
 			Receiver:   actionReceiverTag.String(),
@@ -243,6 +300,7 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action) params.A
 		Status:    string(action.Status()),
 		Message:   message,
 		Output:    output,
+		Log:       log,
 		Enqueued:  action.Enqueued(),
 		Started:   action.Started(),
 		Completed: action.Completed(),
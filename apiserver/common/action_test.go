@@ -185,6 +185,52 @@ func (s *actionsSuite) TestFinishActions(c *gc.C) {
 	})
 }
 
+func (s *actionsSuite) TestAddActionsOutput(c *gc.C) {
+	args := params.ActionOutputs{
+		[]params.ActionOutput{
+			{ActionTag: "success", Stream: "stdout", Data: "hello\n"},
+			{ActionTag: "notfound"},
+			{ActionTag: "addFail", Stream: "stderr"},
+		},
+	}
+	expectErr := errors.New("kaboom")
+	actionFn := makeGetActionByTagString(map[string]state.Action{
+		"success": fakeAction{},
+		"addFail": fakeAction{addOutputErr: expectErr},
+	})
+	results := common.AddActionsOutput(args, actionFn)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		[]params.ErrorResult{
+			{},
+			{common.ServerError(actionNotFoundErr)},
+			{common.ServerError(expectErr)},
+		},
+	})
+}
+
+func (s *actionsSuite) TestLogActionsMessages(c *gc.C) {
+	args := params.ActionLogMessages{
+		[]params.ActionLogMessage{
+			{ActionTag: "success", Message: "copying files"},
+			{ActionTag: "notfound"},
+			{ActionTag: "logFail", Message: "copying files"},
+		},
+	}
+	expectErr := errors.New("kaboom")
+	actionFn := makeGetActionByTagString(map[string]state.Action{
+		"success": fakeAction{},
+		"logFail": fakeAction{logErr: expectErr},
+	})
+	results := common.LogActionsMessages(args, actionFn)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		[]params.ErrorResult{
+			{},
+			{common.ServerError(actionNotFoundErr)},
+			{common.ServerError(expectErr)},
+		},
+	})
+}
+
 func (s *actionsSuite) TestWatchActionNotifications(c *gc.C) {
 	args := entities("invalid-actionreceiver", "machine-1", "machine-2", "machine-3")
 	canAccess := makeCanAccess(map[names.Tag]bool{
@@ -330,11 +376,13 @@ type fakeEntity struct {
 
 type fakeAction struct {
 	state.Action
-	receiver  string
-	name      string
-	beginErr  error
-	finishErr error
-	status    state.ActionStatus
+	receiver     string
+	name         string
+	beginErr     error
+	finishErr    error
+	addOutputErr error
+	logErr       error
+	status       state.ActionStatus
 }
 
 func (mock fakeAction) Status() state.ActionStatus {
@@ -361,6 +409,14 @@ func (mock fakeAction) Finish(state.ActionResults) (state.Action, error) {
 	return nil, mock.finishErr
 }
 
+func (mock fakeAction) AddOutput(...state.ActionOutput) error {
+	return mock.addOutputErr
+}
+
+func (mock fakeAction) Log(string) error {
+	return mock.logErr
+}
+
 // entities is a convenience constructor for params.Entities.
 func entities(tags ...string) params.Entities {
 	entities := params.Entities{
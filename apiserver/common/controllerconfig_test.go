@@ -33,10 +33,12 @@ func (f *fakeControllerAccessor) ControllerConfig() (controller.Config, error) {
 		return nil, f.controllerConfigError
 	}
 	return map[string]interface{}{
-		controller.ControllerUUIDKey: testing.ControllerTag.Id(),
-		controller.CACertKey:         testing.CACert,
-		controller.APIPort:           4321,
-		controller.StatePort:         1234,
+		controller.ControllerUUIDKey:   testing.ControllerTag.Id(),
+		controller.CACertKey:           testing.CACert,
+		controller.APIPort:             4321,
+		controller.StatePort:           1234,
+		controller.BackupS3SecretKey:   "secret",
+		controller.BackupEncryptionKey: "sekrit",
 	}, nil
 }
 
@@ -0,0 +1,30 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// SecretDetails returns the details of the secret, without its value.
+func SecretDetails(secret *state.Secret) params.Secret {
+	result := params.Secret{
+		Id:             secret.Id(),
+		Owner:          secret.Owner().String(),
+		Label:          secret.Label(),
+		Description:    secret.Description(),
+		Revision:       secret.Revision(),
+		RotateInterval: secret.RotateInterval(),
+		Created:        secret.Created(),
+		Updated:        secret.Updated(),
+	}
+	if next := secret.NextRotateTime(); !next.IsZero() {
+		result.NextRotateTime = &next
+	}
+	for _, tag := range secret.Grants() {
+		result.Grants = append(result.Grants, tag.String())
+	}
+	return result
+}
@@ -7,12 +7,15 @@ package controller
 
 import (
 	"encoding/json"
+	"io"
 	"sort"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/txn"
+	"github.com/juju/utils"
 	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
@@ -25,8 +28,11 @@ import (
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/permission"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/state/storage"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.controller")
@@ -44,7 +50,10 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(params.Entities) (params.ModelStatusResults, error)
 	InitiateMigration(params.InitiateMigrationArgs) (params.InitiateMigrationResults, error)
+	PrecheckMigration(params.InitiateMigrationArgs) (params.MigrationPrecheckResults, error)
+	CloneModel(params.CloneModelArgs) (params.CloneModelResults, error)
 	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
+	BackupStatus() (params.BackupStatus, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -233,6 +242,50 @@ func (s *ControllerAPI) ModelConfig() (params.ModelConfigResults, error) {
 	return result, nil
 }
 
+// BackupStatus returns the controller's backup schedule, and the most
+// recent backup stored on the controller.
+func (s *ControllerAPI) BackupStatus() (params.BackupStatus, error) {
+	result := params.BackupStatus{}
+	if err := s.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	controllerConfig, err := s.state.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Schedule = controllerConfig.BackupSchedule()
+
+	// Backups are stored against the controller model.
+	st := s.state
+	if !st.IsController() {
+		controllerModel, err := s.state.ControllerModel()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		var release func()
+		st, release, err = s.statePool.Get(controllerModel.UUID())
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		defer release()
+	}
+	stor := backups.NewStorage(st)
+	defer stor.Close()
+	all, err := backups.NewBackups(stor).List()
+	if err != nil {
+		return result, errors.Annotate(err, "listing backups")
+	}
+	for _, meta := range all {
+		if meta.Stored() == nil || !meta.Started.After(result.LastBackupStarted) {
+			continue
+		}
+		result.LastBackupID = meta.ID()
+		result.LastBackupStarted = meta.Started
+	}
+	return result, nil
+}
+
 // HostedModelConfigs returns all the information that the client needs in
 // order to connect directly with the host model's provider and destroy it
 // directly.
@@ -387,36 +440,143 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, targetInfo, err := c.migrationSpecState(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Close()
 
-	// Ensure the model exists.
-	if _, err := c.state.GetModel(modelTag); err != nil {
-		return "", errors.Annotate(err, "unable to read model")
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState, &targetInfo); err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.state.ForModel(modelTag)
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// PrecheckMigration runs the migration prechecks for one or more
+// models against their target controllers, reporting every
+// precondition that is not met. No migration is started.
+func (c *ControllerAPI) PrecheckMigration(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationPrecheckResults, error,
+) {
+	out := params.MigrationPrecheckResults{
+		Results: make([]params.MigrationPrecheckResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		failures, err := c.precheckOneMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Failures = failures
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) precheckOneMigration(spec params.MigrationSpec) ([]string, error) {
+	hostedState, targetInfo, err := c.migrationSpecState(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer hostedState.Close()
+
+	failures, err := listMigrationPrecheckFailures(hostedState, &targetInfo)
+	return failures, errors.Trace(err)
+}
+
+// CloneModel copies one or more models under new names, to either
+// their own controller or another. Each copy has the config,
+// constraints, applications and relations of its model, but no
+// machines or units.
+func (c *ControllerAPI) CloneModel(args params.CloneModelArgs) (params.CloneModelResults, error) {
+	out := params.CloneModelResults{
+		Results: make([]params.CloneModelResult, len(args.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range args.Specs {
+		modelTag, err := c.cloneOneModel(spec)
+		if err != nil {
+			out.Results[i].Error = common.ServerError(err)
+		} else {
+			out.Results[i].ModelTag = modelTag.String()
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) cloneOneModel(spec params.CloneModelSpec) (names.ModelTag, error) {
+	if !names.IsValidModelName(spec.Name) {
+		return names.ModelTag{}, errors.NotValidf("model name %q", spec.Name)
+	}
+	hostedState, targetInfo, err := c.migrationSpecState(params.MigrationSpec{
+		ModelTag:   spec.ModelTag,
+		TargetInfo: spec.TargetInfo,
+	})
+	if err != nil {
+		return names.ModelTag{}, errors.Trace(err)
+	}
 	defer hostedState.Close()
 
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return names.ModelTag{}, errors.Trace(err)
+	}
+	serialized, err := migration.ExportModelClone(hostedState, spec.Name, uuid.String())
+	if err != nil {
+		return names.ModelTag{}, errors.Annotate(err, "exporting model")
+	}
+	if err := importModelClone(hostedState, &targetInfo, uuid.String(), serialized); err != nil {
+		return names.ModelTag{}, errors.Trace(err)
+	}
+	return names.NewModelTag(uuid.String()), nil
+}
+
+// migrationSpecState returns the state of the model to be migrated
+// and the details of the target controller given in the spec. The
+// caller is responsible for closing the returned state.
+func (c *ControllerAPI) migrationSpecState(spec params.MigrationSpec) (*state.State, coremigration.TargetInfo, error) {
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, coremigration.TargetInfo{}, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return nil, coremigration.TargetInfo{}, errors.Annotate(err, "unable to read model")
+	}
+
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return nil, coremigration.TargetInfo{}, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return nil, coremigration.TargetInfo{}, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return nil, coremigration.TargetInfo{}, errors.Annotate(err, "invalid macaroons")
 		}
 	}
 	targetInfo := coremigration.TargetInfo{
@@ -428,20 +588,11 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Macaroons:     macs,
 	}
 
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState, &targetInfo); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
+	hostedState, err := c.state.ForModel(modelTag)
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, coremigration.TargetInfo{}, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	return hostedState, targetInfo, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -521,6 +672,145 @@ var runMigrationPrechecks = func(st *state.State, targetInfo *coremigration.Targ
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// listMigrationPrecheckFailures runs the prechecks on the migration
+// without stopping at the first failure, and returns a description of
+// every precondition that is not met on either controller.
+var listMigrationPrecheckFailures = func(st *state.State, targetInfo *coremigration.TargetInfo) ([]string, error) {
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backend")
+	}
+	sourceFailures, err := migration.SourcePrecheckFailures(backend)
+	if err != nil {
+		return nil, errors.Annotate(err, "source prechecks")
+	}
+	var failures []string
+	for _, failure := range sourceFailures {
+		failures = append(failures, "source: "+failure.Error())
+	}
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return nil, errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	modelInfo, err := makeModelInfo(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := migrationtarget.NewClient(conn)
+	targetFailures, err := client.PrecheckFailures(modelInfo)
+	if errors.IsNotSupported(err) {
+		// An older target controller can only report the first
+		// precondition that is not met.
+		if err := client.Prechecks(modelInfo); err != nil {
+			targetFailures = []string{err.Error()}
+		}
+	} else if err != nil {
+		return nil, errors.Annotate(err, "target prechecks")
+	}
+	for _, failure := range targetFailures {
+		failures = append(failures, "target: "+failure)
+	}
+	return failures, nil
+}
+
+// importModelClone imports the serialized copy of the model with the
+// given UUID into the target controller, along with the charms and
+// resources it uses. If any step fails, the partially imported model is
+// removed.
+var importModelClone = func(
+	st *state.State, targetInfo *coremigration.TargetInfo, uuid string, serialized coremigration.SerializedModel,
+) (err error) {
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	client := migrationtarget.NewClient(conn)
+
+	if err := client.Import(serialized.Bytes); err != nil {
+		return errors.Annotate(err, "importing model")
+	}
+	defer func() {
+		if err != nil {
+			if abortErr := client.Abort(uuid); abortErr != nil {
+				logger.Errorf("cannot remove partially cloned model %s: %v", uuid, abortErr)
+			}
+		}
+	}()
+
+	uploader := modelUploader{client: client, modelUUID: uuid}
+	if err := migration.UploadCharms(serialized.Charms, stateCharmDownloader{st}, uploader); err != nil {
+		return errors.Annotate(err, "uploading charms")
+	}
+	if err := migration.UploadResources(serialized.Resources, stateResourceDownloader{st}, uploader); err != nil {
+		return errors.Annotate(err, "uploading resources")
+	}
+	return errors.Annotate(client.Activate(uuid), "activating model")
+}
+
+// stateCharmDownloader reads the charm archives stored for a model.
+type stateCharmDownloader struct {
+	st *state.State
+}
+
+// OpenCharm is part of the migration.CharmDownloader interface.
+func (d stateCharmDownloader) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	ch, err := d.st.Charm(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	store := storage.NewStorage(d.st.ModelUUID(), d.st.MongoSession())
+	reader, _, err := store.Get(ch.StoragePath())
+	return reader, errors.Trace(err)
+}
+
+// stateResourceDownloader reads the resources stored for a model.
+type stateResourceDownloader struct {
+	st *state.State
+}
+
+// OpenResource is part of the migration.ResourceDownloader interface.
+func (d stateResourceDownloader) OpenResource(application, name string) (io.ReadCloser, error) {
+	resources, err := d.st.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	_, reader, err := resources.OpenResource(application, name)
+	return reader, errors.Trace(err)
+}
+
+// modelUploader uploads charms and resources into a model being
+// imported on the target controller.
+type modelUploader struct {
+	client    *migrationtarget.Client
+	modelUUID string
+}
+
+// UploadCharm is part of the migration.CharmUploader interface.
+func (u modelUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, content)
+}
+
+// UploadResource is part of the migration.ResourceUploader interface.
+func (u modelUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	return u.client.UploadResource(u.modelUUID, res, content)
+}
+
+// SetPlaceholderResource is part of the migration.ResourceUploader
+// interface.
+func (u modelUploader) SetPlaceholderResource(res resource.Resource) error {
+	return u.client.SetPlaceholderResource(u.modelUUID, res)
+}
+
+// SetUnitResource is part of the migration.ResourceUploader interface.
+func (u modelUploader) SetUnitResource(unit string, res resource.Resource) error {
+	return u.client.SetUnitResource(u.modelUUID, unit, res)
+}
+
 func makeModelInfo(st *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
@@ -547,12 +837,17 @@ func makeModelInfo(st *state.State) (coremigration.ModelInfo, error) {
 	}
 	controllerVersion, _ := controllerConfig.AgentVersion()
 
+	credential, _ := model.CloudCredential()
+
 	return coremigration.ModelInfo{
 		UUID:                   model.UUID(),
 		Name:                   model.Name(),
 		Owner:                  model.Owner(),
 		AgentVersion:           agentVersion,
 		ControllerAgentVersion: controllerVersion,
+		Cloud:                  model.Cloud(),
+		CloudRegion:            model.CloudRegion(),
+		CloudCredential:        credential,
 	}, nil
 }
 
@@ -6,8 +6,10 @@ package controller_test
 import (
 	"encoding/json"
 	"regexp"
+	"strings"
 	"time"
 
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
@@ -23,10 +25,12 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
@@ -263,6 +267,27 @@ func (s *controllerSuite) TestModelConfigFromNonController(c *gc.C) {
 	c.Assert(cfg.Config["name"], jc.DeepEquals, params.ConfigValue{Value: "controller"})
 }
 
+func (s *controllerSuite) TestBackupStatus(c *gc.C) {
+	status, err := s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, params.BackupStatus{})
+
+	stor := backups.NewStorage(s.State)
+	defer stor.Close()
+	r := strings.NewReader("<compressed archive data>")
+	meta, err := backups.NewMetadataState(s.State, "0", "xenial")
+	c.Assert(err, jc.ErrorIsNil)
+	meta.Raw.Size = int64(r.Len())
+	id, err := backups.NewBackups(stor).Add(r, meta)
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err = s.controller.BackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Schedule, gc.Equals, "")
+	c.Assert(status.LastBackupID, gc.Equals, id)
+	c.Assert(status.LastBackupStarted.Unix(), gc.Equals, meta.Started.Unix())
+}
+
 func (s *controllerSuite) TestControllerConfig(c *gc.C) {
 	cfg, err := s.controller.ControllerConfig()
 	c.Assert(err, jc.ErrorIsNil)
@@ -541,6 +566,164 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestPrecheckMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetPrecheckFailures(s, []string{"source: cleanup needed", "target: upgrade in progress"}, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}, {
+			ModelTag: randomModelTag(), // Doesn't exist.
+		}},
+	}
+	out, err := s.controller.PrecheckMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0].ModelTag, gc.Equals, st.ModelTag().String())
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].Failures, jc.DeepEquals, []string{
+		"source: cleanup needed",
+		"target: upgrade in progress",
+	})
+
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "unable to read model: .+")
+
+	// No migration is started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestPrecheckMigrationError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetPrecheckFailures(s, nil, errors.New("boom"))
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}},
+	}
+	out, err := s.controller.PrecheckMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+	c.Check(out.Results[0].Failures, gc.HasLen, 0)
+}
+
+func (s *controllerSuite) TestCloneModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st)
+	app := f.MakeApplication(c, nil)
+	f.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	var (
+		importedUUID string
+		imported     coremigration.SerializedModel
+	)
+	controller.SetCloneImport(s, func(uuid string, serialized coremigration.SerializedModel) error {
+		importedUUID = uuid
+		imported = serialized
+		return nil
+	})
+
+	args := params.CloneModelArgs{
+		Specs: []params.CloneModelSpec{{
+			ModelTag: st.ModelTag().String(),
+			Name:     "staging",
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}},
+	}
+	out, err := s.controller.CloneModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].ModelTag, gc.Equals, names.NewModelTag(importedUUID).String())
+	c.Check(importedUUID, gc.Not(gc.Equals), st.ModelUUID())
+
+	curl, _ := app.CharmURL()
+	c.Check(imported.Charms, jc.DeepEquals, []string{curl.String()})
+	model, err := description.Deserialize(imported.Bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Tag().Id(), gc.Equals, importedUUID)
+	c.Check(model.Config()["name"], gc.Equals, "staging")
+	c.Check(model.Machines(), gc.HasLen, 0)
+}
+
+func (s *controllerSuite) TestCloneModelInvalidName(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetCloneImport(s, func(string, coremigration.SerializedModel) error {
+		c.Fatalf("unexpected import")
+		return nil
+	})
+
+	args := params.CloneModelArgs{
+		Specs: []params.CloneModelSpec{{
+			ModelTag: st.ModelTag().String(),
+			Name:     "Not Valid",
+		}},
+	}
+	out, err := s.controller.CloneModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, `model name "Not Valid" not valid`)
+	c.Check(out.Results[0].ModelTag, gc.Equals, "")
+}
+
+func (s *controllerSuite) TestCloneModelImportError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetCloneImport(s, func(string, coremigration.SerializedModel) error {
+		return errors.New("boom")
+	})
+
+	args := params.CloneModelArgs{
+		Specs: []params.CloneModelSpec{{
+			ModelTag: st.ModelTag().String(),
+			Name:     "staging",
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}},
+	}
+	out, err := s.controller.CloneModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "boom")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -17,3 +17,15 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetPrecheckFailures(p patcher, failures []string, err error) {
+	p.PatchValue(&listMigrationPrecheckFailures, func(*state.State, *migration.TargetInfo) ([]string, error) {
+		return failures, err
+	})
+}
+
+func SetCloneImport(p patcher, f func(uuid string, serialized migration.SerializedModel) error) {
+	p.PatchValue(&importModelClone, func(_ *state.State, _ *migration.TargetInfo, uuid string, serialized migration.SerializedModel) error {
+		return f(uuid, serialized)
+	})
+}
@@ -139,6 +139,13 @@ func (api *CrossModelRelationsAPI) publishRelationChange(change params.RemoteRel
 		}
 	}
 
+	if change.ApplicationSettings != nil {
+		logger.Debugf("%s updated application settings (%v)", applicationTag.Id(), change.ApplicationSettings)
+		if err := rel.SetRemoteApplicationSettings(applicationTag.Id(), change.ApplicationSettings); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	for _, change := range change.ChangedUnits {
 		unitTag := names.NewUnitTag(fmt.Sprintf("%s/%v", applicationTag.Id(), change.UnitId))
 		logger.Debugf("changed unit tag for remote id %v is %v", change.UnitId, unitTag)
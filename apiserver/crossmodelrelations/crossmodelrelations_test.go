@@ -92,6 +92,34 @@ func (s *remoteRelationsSuite) TestPublishLocalRelationsChange(c *gc.C) {
 	})
 }
 
+func (s *remoteRelationsSuite) TestPublishLocalRelationsChangeApplicationSettings(c *gc.C) {
+	s.st.remoteApplications["db2"] = &mockRemoteApplication{}
+	s.st.remoteEntities[names.NewApplicationTag("db2")] = "token-db2"
+	rel := newMockRelation(1)
+	s.st.relations["db2:db django:db"] = rel
+	s.st.remoteEntities[names.NewRelationTag("db2:db django:db")] = "token-db2:db django:db"
+	results, err := s.api.PublishLocalRelationChange(params.RemoteRelationsChanges{
+		Changes: []params.RemoteRelationChangeEvent{
+			{
+				Life: params.Alive,
+				ApplicationId: params.RemoteEntityId{
+					ModelUUID: "uuid",
+					Token:     "token-db2"},
+				RelationId: params.RemoteEntityId{
+					ModelUUID: "uuid",
+					Token:     "token-db2:db django:db"},
+				ApplicationSettings: map[string]interface{}{"endpoint": "10.0.0.1"},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = results.Combine()
+	c.Assert(err, jc.ErrorIsNil)
+	rel.CheckCalls(c, []testing.StubCall{
+		{"SetRemoteApplicationSettings", []interface{}{"db2", map[string]interface{}{"endpoint": "10.0.0.1"}}},
+	})
+}
+
 func (s *remoteRelationsSuite) assertRegisterRemoteRelations(c *gc.C) {
 	app := &mockApplication{}
 	app.eps = []state.Endpoint{{
@@ -202,6 +202,11 @@ func (r *mockRelation) RemoteUnit(unitId string) (crossmodelrelations.RelationUn
 	return u, nil
 }
 
+func (r *mockRelation) SetRemoteApplicationSettings(appName string, settings map[string]interface{}) error {
+	r.MethodCall(r, "SetRemoteApplicationSettings", appName, settings)
+	return r.NextErr()
+}
+
 type mockRemoteApplication struct {
 	testing.Stub
 	consumerproxy bool
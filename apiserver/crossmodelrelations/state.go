@@ -68,6 +68,10 @@ type Relation interface {
 	// RemoteUnit returns a RelationUnit for the remote application unit
 	// with the supplied ID.
 	RemoteUnit(unitId string) (RelationUnit, error)
+
+	// SetRemoteApplicationSettings replaces the relation settings of
+	// the remote application with the supplied name.
+	SetRemoteApplicationSettings(appName string, settings map[string]interface{}) error
 }
 
 // RelationUnit provides access to the settings of a single unit in a relation,
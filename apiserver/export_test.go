@@ -30,6 +30,8 @@ var (
 	JSMimeType            = jsMimeType
 	GUIURLPathPrefix      = guiURLPathPrefix
 	SpritePath            = spritePath
+
+	ActionOutputPollInterval = &actionOutputPollInterval
 )
 
 func ServerMacaroon(srv *Server) (*macaroon.Macaroon, error) {
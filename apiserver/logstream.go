@@ -239,6 +239,7 @@ func (h *logStreamRequestHandler) apiFromRecords(records []*state.LogRecord) par
 			Location:  rec.Location,
 			Level:     rec.Level.String(),
 			Message:   rec.Message,
+			Kind:      string(rec.Kind),
 		}
 		result.Records[i] = apiRec
 	}
@@ -65,6 +65,13 @@ func (f *Facade) FinishActions(args params.ActionExecutionResults) params.ErrorR
 	return common.FinishActions(args, actionFn)
 }
 
+// AddActionsOutput records output written by running actions, so
+// that it can be streamed to clients.
+func (f *Facade) AddActionsOutput(args params.ActionOutputs) params.ErrorResults {
+	actionFn := common.AuthAndActionFromTagFn(f.accessMachine, f.backend.ActionByTag)
+	return common.AddActionsOutput(args, actionFn)
+}
+
 // WatchActionNotifications returns a StringsWatcher for observing
 // incoming action calls to a machine.
 func (f *Facade) WatchActionNotifications(args params.Entities) params.StringsWatchResults {
@@ -108,3 +115,12 @@ func (f *Facade) RunningActions(args params.Entities) params.ActionsByReceivers
 
 	return response
 }
+
+// FacadeV1 implements the V1 machineactions API, which cannot record
+// the output of running actions.
+type FacadeV1 struct {
+	*Facade
+}
+
+// AddActionsOutput isn't on the V1 API.
+func (f *FacadeV1) AddActionsOutput(_, _ struct{}) {}
@@ -18,6 +18,15 @@ func NewExternalFacade(st *state.State, res facade.Resources, auth facade.Author
 	return NewFacade(backendShim{st}, res, auth)
 }
 
+// NewExternalFacadeV1 is used for API registration.
+func NewExternalFacadeV1(st *state.State, res facade.Resources, auth facade.Authorizer) (*FacadeV1, error) {
+	facade, err := NewExternalFacade(st, res, auth)
+	if err != nil {
+		return nil, err
+	}
+	return &FacadeV1{facade}, nil
+}
+
 type backendShim struct {
 	st *state.State
 }
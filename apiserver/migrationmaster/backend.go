@@ -19,6 +19,8 @@ type Backend interface {
 	ModelUUID() string
 	ModelName() (string, error)
 	ModelOwner() (names.UserTag, error)
+	ModelCloud() (cloud, region string, err error)
+	ModelCloudCredential() (names.CloudCredentialTag, bool, error)
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
 
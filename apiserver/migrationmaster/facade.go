@@ -123,12 +123,28 @@ func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
 		return empty, errors.Annotate(err, "retrieving agent version")
 	}
 
-	return params.MigrationModelInfo{
+	cloud, region, err := api.backend.ModelCloud()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving model cloud")
+	}
+
+	credential, hasCredential, err := api.backend.ModelCloudCredential()
+	if err != nil {
+		return empty, errors.Annotate(err, "retrieving model cloud credential")
+	}
+
+	info := params.MigrationModelInfo{
 		UUID:         api.backend.ModelUUID(),
 		Name:         name,
 		OwnerTag:     owner.String(),
 		AgentVersion: vers,
-	}, nil
+		CloudTag:     names.NewCloudTag(cloud).String(),
+		CloudRegion:  region,
+	}
+	if hasCredential {
+		info.CloudCredentialTag = credential.String()
+	}
+	return info, nil
 }
 
 // SetPhase sets the phase of the active model migration. The provided
@@ -122,6 +122,9 @@ func (s *Suite) TestModelInfo(c *gc.C) {
 	c.Assert(model.Name, gc.Equals, "model-name")
 	c.Assert(model.OwnerTag, gc.Equals, names.NewUserTag("owner").String())
 	c.Assert(model.AgentVersion, gc.Equals, version.MustParse("1.2.3"))
+	c.Assert(model.CloudTag, gc.Equals, names.NewCloudTag("dummy").String())
+	c.Assert(model.CloudRegion, gc.Equals, "dummy-region")
+	c.Assert(model.CloudCredentialTag, gc.Equals, names.NewCloudCredentialTag("dummy/owner/cred").String())
 }
 
 func (s *Suite) TestSetPhase(c *gc.C) {
@@ -433,6 +436,14 @@ func (b *stubBackend) ModelOwner() (names.UserTag, error) {
 	return names.NewUserTag("owner"), nil
 }
 
+func (b *stubBackend) ModelCloud() (string, string, error) {
+	return "dummy", "dummy-region", nil
+}
+
+func (b *stubBackend) ModelCloudCredential() (names.CloudCredentialTag, bool, error) {
+	return names.NewCloudCredentialTag("dummy/owner/cred"), true, nil
+}
+
 func (b *stubBackend) AgentVersion() (version.Number, error) {
 	return version.MustParse("1.2.3"), nil
 }
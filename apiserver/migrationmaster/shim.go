@@ -51,6 +51,25 @@ func (s *backendShim) ModelOwner() (names.UserTag, error) {
 	return model.Owner(), nil
 }
 
+// ModelCloud implements Backend.
+func (s *backendShim) ModelCloud() (string, string, error) {
+	model, err := s.Model()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return model.Cloud(), model.CloudRegion(), nil
+}
+
+// ModelCloudCredential implements Backend.
+func (s *backendShim) ModelCloudCredential() (names.CloudCredentialTag, bool, error) {
+	model, err := s.Model()
+	if err != nil {
+		return names.CloudCredentialTag{}, false, errors.Trace(err)
+	}
+	tag, ok := model.CloudCredential()
+	return tag, ok, nil
+}
+
 // AgentVersion implements Backend.
 func (s *backendShim) AgentVersion() (version.Number, error) {
 	cfg, err := s.ModelConfig()
@@ -79,24 +79,67 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	backend, modelInfo, err := api.precheckArgs(model)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return migration.TargetPrecheck(backend, modelInfo)
+}
+
+// PrecheckFailures runs the same checks as Prechecks, but reports
+// every precondition for the migration that is not met rather than
+// only the first.
+func (api *API) PrecheckFailures(model params.MigrationModelInfo) (params.StringsResult, error) {
+	var result params.StringsResult
+	backend, modelInfo, err := api.precheckArgs(model)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	failures, err := migration.TargetPrecheckFailures(backend, modelInfo)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result, nil
+	}
+	for _, failure := range failures {
+		result.Result = append(result.Result, failure.Error())
+	}
+	return result, nil
+}
+
+func (api *API) precheckArgs(model params.MigrationModelInfo) (migration.PrecheckBackend, coremigration.ModelInfo, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return nil, coremigration.ModelInfo{}, errors.Trace(err)
+	}
+	var cloud string
+	if model.CloudTag != "" {
+		cloudTag, err := names.ParseCloudTag(model.CloudTag)
+		if err != nil {
+			return nil, coremigration.ModelInfo{}, errors.Trace(err)
+		}
+		cloud = cloudTag.Id()
+	}
+	var credentialTag names.CloudCredentialTag
+	if model.CloudCredentialTag != "" {
+		credentialTag, err = names.ParseCloudCredentialTag(model.CloudCredentialTag)
+		if err != nil {
+			return nil, coremigration.ModelInfo{}, errors.Trace(err)
+		}
+	}
 	backend, err := migration.PrecheckShim(api.state)
 	if err != nil {
-		return errors.Annotate(err, "creating backend")
-	}
-	return migration.TargetPrecheck(
-		backend,
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
-	)
+		return nil, coremigration.ModelInfo{}, errors.Annotate(err, "creating backend")
+	}
+	return backend, coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+		Cloud:                  cloud,
+		CloudRegion:            model.CloudRegion,
+		CloudCredential:        credentialTag,
+	}, nil
 }
 
 // Import takes a serialized Juju model, deserializes it, and
@@ -56,7 +56,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 }
 
 func (s *Suite) TestFacadeRegistered(c *gc.C) {
-	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 1)
+	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 2)
 	c.Assert(err, jc.ErrorIsNil)
 
 	api, err := factory(&facadetest.Context{
@@ -121,6 +121,45 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestPrecheckFailures(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+
+	// Set both the model and source controller versions ahead of
+	// the target controller.
+	sourceVersion := controllerVersion
+	sourceVersion.Minor++
+
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           sourceVersion,
+		ControllerAgentVersion: sourceVersion,
+	}
+	result, err := api.PrecheckFailures(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.HasLen, 2)
+	c.Check(result.Result[0], gc.Matches, "model has higher version than target controller .+")
+	c.Check(result.Result[1], gc.Matches, "source controller has higher version than target controller .+")
+}
+
+func (s *Suite) TestPrecheckFailuresNone(c *gc.C) {
+	api := s.mustNewAPI(c)
+	args := params.MigrationModelInfo{
+		UUID:                   "uuid",
+		Name:                   "some-model",
+		OwnerTag:               names.NewUserTag("someone").String(),
+		AgentVersion:           s.controllerVersion(c),
+		ControllerAgentVersion: s.controllerVersion(c),
+	}
+	result, err := api.PrecheckFailures(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Result, gc.HasLen, 0)
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
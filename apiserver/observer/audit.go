@@ -3,11 +3,15 @@
 package observer
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
@@ -23,8 +27,18 @@ type AuditContext struct {
 	JujuServerVersion version.Number
 
 	// ModelUUID is the UUID of the model the audit observer is
-	// currently running on.
+	// currently running on. It is used for requests made before the
+	// connection has logged in to a model.
 	ModelUUID string
+
+	// ExcludeMethods holds the API methods, in the form
+	// "Facade.Method", whose calls are not audited.
+	ExcludeMethods set.Strings
+
+	// CaptureArgs determines whether the arguments of each call,
+	// and any error it returns, are recorded. Secret values are
+	// redacted.
+	CaptureArgs bool
 }
 
 type ErrorHandler func(error)
@@ -34,6 +48,8 @@ func NewAudit(ctx *AuditContext, handleAuditEntry audit.AuditEntrySinkFn, errorH
 	return &Audit{
 		jujuServerVersion: ctx.JujuServerVersion,
 		modelUUID:         ctx.ModelUUID,
+		excludeMethods:    ctx.ExcludeMethods,
+		captureArgs:       ctx.CaptureArgs,
 		errorHandler:      errorHandler,
 		handleAuditEntry:  handleAuditEntry,
 	}
@@ -44,6 +60,8 @@ func NewAudit(ctx *AuditContext, handleAuditEntry audit.AuditEntrySinkFn, errorH
 type Audit struct {
 	jujuServerVersion version.Number
 	modelUUID         string
+	excludeMethods    set.Strings
+	captureArgs       bool
 	errorHandler      ErrorHandler
 	handleAuditEntry  audit.AuditEntrySinkFn
 
@@ -55,34 +73,59 @@ type Audit struct {
 	state struct {
 		remoteAddress    string
 		authenticatedTag string
+		modelUUID        string
+		conversationID   string
 	}
 }
 
 // Login implements Observer.
-func (a *Audit) Login(entity names.Tag, _ names.ModelTag, _ bool, _ string) {
+func (a *Audit) Login(entity names.Tag, model names.ModelTag, _ bool, _ string) {
 	a.state.authenticatedTag = entity.String()
+	a.state.modelUUID = model.Id()
 }
 
 // Join implements Observer.
-func (a *Audit) Join(req *http.Request, _ uint64) {
+func (a *Audit) Join(req *http.Request, connectionID uint64) {
 	a.state.remoteAddress = req.RemoteAddr
+	a.state.conversationID = newConversationID(connectionID)
 }
 
 // Leave implements Observer.
 func (a *Audit) Leave() {
 	a.state.remoteAddress = ""
 	a.state.authenticatedTag = ""
+	a.state.modelUUID = ""
+	a.state.conversationID = ""
+}
+
+// newConversationID returns a random identifier for the calls made
+// over one API connection. The connection ID, which is only unique
+// within the lifetime of the API server, is used if no random
+// identifier can be generated.
+func newConversationID(connectionID uint64) string {
+	id, err := utils.RandomBytes(8)
+	if err != nil {
+		return fmt.Sprintf("%016x", connectionID)
+	}
+	return fmt.Sprintf("%x", id)
 }
 
 // RPCObserver implements Observer.
 func (a *Audit) RPCObserver() rpc.Observer {
+	modelUUID := a.modelUUID
+	if a.state.modelUUID != "" {
+		modelUUID = a.state.modelUUID
+	}
 	return &AuditRPCObserver{
 		jujuServerVersion: a.jujuServerVersion,
-		modelUUID:         a.modelUUID,
+		modelUUID:         modelUUID,
+		excludeMethods:    a.excludeMethods,
+		captureArgs:       a.captureArgs,
 		errorHandler:      a.errorHandler,
 		handleAuditEntry:  a.handleAuditEntry,
 		authenticatedTag:  a.state.authenticatedTag,
 		remoteAddress:     a.state.remoteAddress,
+		conversationID:    a.state.conversationID,
 	}
 }
 
@@ -91,33 +134,71 @@ func (a *Audit) RPCObserver() rpc.Observer {
 type AuditRPCObserver struct {
 	jujuServerVersion version.Number
 	modelUUID         string
+	excludeMethods    set.Strings
+	captureArgs       bool
 	errorHandler      ErrorHandler
 	handleAuditEntry  audit.AuditEntrySinkFn
 	authenticatedTag  string
 	remoteAddress     string
+	conversationID    string
 }
 
 // ServerRequest implements Observer.
 func (a *AuditRPCObserver) ServerRequest(hdr *rpc.Header, body interface{}) {
+	if a.excluded(hdr.Request) {
+		return
+	}
 	auditEntry := a.boilerplateAuditEntry()
 	auditEntry.OriginName = a.authenticatedTag
 
 	auditEntry.OriginType = "API request"
 	auditEntry.Operation = rpcRequestToOperation(hdr.Request)
-	auditEntry.Data = map[string]interface{}{"request-body": body}
-	err := a.handleAuditEntry(auditEntry)
-	if err != nil {
-		a.errorHandler(errors.Trace(err))
+	auditEntry.Data = map[string]interface{}{"request-id": hdr.RequestId}
+	if a.captureArgs {
+		requestBody, err := redact(body)
+		if err != nil {
+			a.errorHandler(errors.Annotate(err, "cannot capture request arguments"))
+		} else {
+			auditEntry.Data["request-body"] = requestBody
+		}
+	}
+	a.writeEntry(auditEntry)
+}
+
+// ServerReply implements Observer. Error results are only recorded
+// when arguments are being captured.
+func (a *AuditRPCObserver) ServerReply(req rpc.Request, hdr *rpc.Header, _ interface{}) {
+	if !a.captureArgs || hdr.Error == "" || a.excluded(req) {
+		return
 	}
+	auditEntry := a.boilerplateAuditEntry()
+	auditEntry.OriginType = "API error"
+	auditEntry.Operation = rpcRequestToOperation(req)
+	auditEntry.Data = map[string]interface{}{
+		"request-id": hdr.RequestId,
+		"error":      hdr.Error,
+	}
+	if hdr.ErrorCode != "" {
+		auditEntry.Data["error-code"] = hdr.ErrorCode
+	}
+	a.writeEntry(auditEntry)
+}
+
+func (a *AuditRPCObserver) excluded(req rpc.Request) bool {
+	return a.excludeMethods.Contains(req.Type + "." + req.Action)
 }
 
-// ServerReply implements Observer.
-func (a *AuditRPCObserver) ServerReply(rpc.Request, *rpc.Header, interface{}) {}
+func (a *AuditRPCObserver) writeEntry(auditEntry audit.AuditEntry) {
+	if err := a.handleAuditEntry(auditEntry); err != nil {
+		a.errorHandler(errors.Trace(err))
+	}
+}
 
 func (a *AuditRPCObserver) boilerplateAuditEntry() audit.AuditEntry {
 	return audit.AuditEntry{
 		JujuServerVersion: a.jujuServerVersion,
 		ModelUUID:         a.modelUUID,
+		ConversationID:    a.conversationID,
 		Timestamp:         time.Now().UTC(),
 		RemoteAddress:     a.remoteAddress,
 		OriginName:        a.authenticatedTag,
@@ -125,5 +206,65 @@ func (a *AuditRPCObserver) boilerplateAuditEntry() audit.AuditEntry {
 }
 
 func rpcRequestToOperation(req rpc.Request) string {
-	return fmt.Sprintf("%s:v%d - %s", req.Type, req.Version, req.Action)
+	return audit.Operation(req.Type, req.Version, req.Action)
+}
+
+// redactedValue replaces the values of secret fields in captured
+// request arguments.
+const redactedValue = "<redacted>"
+
+// secretFields holds the names of the fields, compared without regard
+// to case, that hold secrets. Names are matched exactly, so that fields
+// such as "private-address" are still recorded.
+var secretFields = set.NewStrings(
+	"password",
+	"secret",
+	"secrets",
+	"secret-key",
+	"shared-secret",
+	"macaroon",
+	"macaroons",
+	"credential",
+	"credentials",
+	"cloud-credential",
+	"metrics-credentials",
+	"token",
+	"private-key",
+	"ca-private-key",
+)
+
+// redact returns the JSON form of the given request body, decoded into
+// generic values, with the values of all secret fields replaced.
+func redact(body interface{}) (interface{}, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return redactValue(value), nil
+}
+
+func redactValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, v := range value {
+			if isSecretField(key) {
+				value[key] = redactedValue
+				continue
+			}
+			value[key] = redactValue(v)
+		}
+	case []interface{}:
+		for i, v := range value {
+			value[i] = redactValue(v)
+		}
+	}
+	return value
+}
+
+func isSecretField(name string) bool {
+	return secretFields.Contains(strings.ToLower(name))
 }
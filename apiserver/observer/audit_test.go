@@ -0,0 +1,184 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package observer_test
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/set"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditSuite struct {
+	testing.IsolationSuite
+
+	entries []audit.AuditEntry
+	errors  []error
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.entries = nil
+	s.errors = nil
+}
+
+func (s *auditSuite) newAudit(ctx observer.AuditContext) *observer.Audit {
+	ctx.JujuServerVersion = version.MustParse("2.3.0")
+	ctx.ModelUUID = coretesting.ControllerTag.Id()
+	a := observer.NewAudit(&ctx, func(entry audit.AuditEntry) error {
+		s.entries = append(s.entries, entry)
+		return nil
+	}, func(err error) {
+		s.errors = append(s.errors, err)
+	})
+	a.Join(&http.Request{RemoteAddr: "10.0.0.1:1234"}, 42)
+	a.Login(names.NewUserTag("bob"), coretesting.ModelTag, false, "")
+	return a
+}
+
+func request(facade, method string) *rpc.Header {
+	return &rpc.Header{
+		RequestId: 7,
+		Request:   rpc.Request{Type: facade, Version: 1, Action: method},
+	}
+}
+
+func (s *auditSuite) TestServerRequest(c *gc.C) {
+	a := s.newAudit(observer.AuditContext{})
+	a.RPCObserver().ServerRequest(request("Client", "FullStatus"), struct{ Password string }{"sekrit"})
+
+	c.Assert(s.errors, gc.HasLen, 0)
+	c.Assert(s.entries, gc.HasLen, 1)
+	entry := s.entries[0]
+	c.Check(entry.ModelUUID, gc.Equals, coretesting.ModelTag.Id())
+	c.Check(entry.ConversationID, gc.Not(gc.Equals), "")
+	c.Check(entry.RemoteAddress, gc.Equals, "10.0.0.1:1234")
+	c.Check(entry.OriginType, gc.Equals, "API request")
+	c.Check(entry.OriginName, gc.Equals, "user-bob")
+	c.Check(entry.Operation, gc.Equals, "Client:v1 - FullStatus")
+	c.Check(entry.Data, jc.DeepEquals, map[string]interface{}{"request-id": uint64(7)})
+}
+
+func (s *auditSuite) TestConversationID(c *gc.C) {
+	a := s.newAudit(observer.AuditContext{})
+	a.RPCObserver().ServerRequest(request("Client", "FullStatus"), nil)
+	a.RPCObserver().ServerRequest(request("Client", "AddMachines"), nil)
+
+	other := s.newAudit(observer.AuditContext{})
+	other.RPCObserver().ServerRequest(request("Client", "FullStatus"), nil)
+
+	c.Assert(s.entries, gc.HasLen, 3)
+	c.Check(s.entries[0].ConversationID, gc.Equals, s.entries[1].ConversationID)
+	c.Check(s.entries[2].ConversationID, gc.Not(gc.Equals), s.entries[0].ConversationID)
+}
+
+func (s *auditSuite) TestExcludeMethods(c *gc.C) {
+	a := s.newAudit(observer.AuditContext{
+		ExcludeMethods: set.NewStrings("Client.FullStatus", "Pinger.Ping"),
+	})
+	a.RPCObserver().ServerRequest(request("Client", "FullStatus"), nil)
+	a.RPCObserver().ServerRequest(request("Pinger", "Ping"), nil)
+	a.RPCObserver().ServerRequest(request("Client", "AddMachines"), nil)
+
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].Operation, gc.Equals, "Client:v1 - AddMachines")
+}
+
+func (s *auditSuite) TestCaptureArgsRedactsSecrets(c *gc.C) {
+	a := s.newAudit(observer.AuditContext{CaptureArgs: true})
+	type change struct {
+		Tag        string `json:"tag"`
+		Password   string `json:"password"`
+		Credential string `json:"cloud-credential"`
+	}
+	body := struct {
+		Changes  []change          `json:"changes"`
+		Settings map[string]string `json:"settings"`
+	}{
+		Changes: []change{{Tag: "user-bob", Password: "sekrit", Credential: "cred"}},
+		Settings: map[string]string{
+			"private-key":     "key",
+			"private-address": "10.0.0.2",
+			"tokens-issued":   "3",
+			"name":            "value",
+		},
+	}
+	a.RPCObserver().ServerRequest(request("UserManager", "SetPassword"), body)
+
+	c.Assert(s.errors, gc.HasLen, 0)
+	c.Assert(s.entries, gc.HasLen, 1)
+	c.Check(s.entries[0].Data["request-body"], jc.DeepEquals, map[string]interface{}{
+		"changes": []interface{}{
+			map[string]interface{}{
+				"tag":              "user-bob",
+				"password":         "<redacted>",
+				"cloud-credential": "<redacted>",
+			},
+		},
+		"settings": map[string]interface{}{
+			"private-key":     "<redacted>",
+			"private-address": "10.0.0.2",
+			"tokens-issued":   "3",
+			"name":            "value",
+		},
+	})
+}
+
+func (s *auditSuite) TestServerReplyErrorNotCapturedByDefault(c *gc.C) {
+	a := s.newAudit(observer.AuditContext{})
+	hdr := request("Client", "AddMachines")
+	hdr.Error = "boom"
+	a.RPCObserver().ServerReply(hdr.Request, hdr, struct{}{})
+	c.Assert(s.entries, gc.HasLen, 0)
+}
+
+func (s *auditSuite) TestServerReplyErrorCaptured(c *gc.C) {
+	a := s.newAudit(observer.AuditContext{CaptureArgs: true})
+	rpcObserver := a.RPCObserver()
+
+	hdr := request("Client", "AddMachines")
+	rpcObserver.ServerReply(hdr.Request, hdr, struct{}{})
+	c.Assert(s.entries, gc.HasLen, 0)
+
+	hdr.Error = "permission denied"
+	hdr.ErrorCode = "unauthorized access"
+	rpcObserver.ServerReply(hdr.Request, hdr, struct{}{})
+	c.Assert(s.entries, gc.HasLen, 1)
+	entry := s.entries[0]
+	c.Check(entry.OriginType, gc.Equals, "API error")
+	c.Check(entry.Operation, gc.Equals, "Client:v1 - AddMachines")
+	c.Check(entry.Data, jc.DeepEquals, map[string]interface{}{
+		"request-id": uint64(7),
+		"error":      "permission denied",
+		"error-code": "unauthorized access",
+	})
+}
+
+func (s *auditSuite) TestSinkErrorHandled(c *gc.C) {
+	ctx := &observer.AuditContext{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+	}
+	a := observer.NewAudit(ctx, func(audit.AuditEntry) error {
+		return errors.New("sink failed")
+	}, func(err error) {
+		s.errors = append(s.errors, err)
+	})
+	a.Join(&http.Request{RemoteAddr: "10.0.0.1:1234"}, 42)
+	a.RPCObserver().ServerRequest(request("Client", "FullStatus"), nil)
+	c.Assert(s.errors, gc.HasLen, 1)
+	c.Check(s.errors[0], gc.ErrorMatches, "sink failed")
+}
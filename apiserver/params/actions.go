@@ -54,9 +54,16 @@ type ActionResult struct {
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
+// ActionMessage holds a progress message logged by an action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
 // ActionsByReceivers wrap a slice of Actions for API calls.
 type ActionsByReceivers struct {
 	Actions []ActionsByReceiver `json:"actions,omitempty"`
@@ -116,6 +123,44 @@ type ActionExecutionResult struct {
 	Message   string                 `json:"message,omitempty"`
 }
 
+// ActionLogMessages holds progress messages logged by running actions.
+type ActionLogMessages struct {
+	Messages []ActionLogMessage `json:"messages"`
+}
+
+// ActionLogMessage holds a progress message logged by a running action.
+type ActionLogMessage struct {
+	ActionTag string `json:"action-tag"`
+	Message   string `json:"message"`
+}
+
+// ActionOutputs holds output written by running actions, so that it
+// can be streamed to clients.
+type ActionOutputs struct {
+	Outputs []ActionOutput `json:"outputs"`
+}
+
+// ActionOutput holds a chunk of the output written by a running action.
+type ActionOutput struct {
+	ActionTag string    `json:"action-tag"`
+	Seq       int       `json:"seq"`
+	Stream    string    `json:"stream"`
+	Data      string    `json:"data"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ActionOutputMessage is sent over the action output stream for each
+// chunk of output written by a streamed action, and once more when the
+// action finishes, with Status set to the action's final status.
+type ActionOutputMessage struct {
+	ActionTag string    `json:"action-tag"`
+	Receiver  string    `json:"receiver"`
+	Stream    string    `json:"stream,omitempty"`
+	Data      string    `json:"data,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+	Status    string    `json:"status,omitempty"`
+}
+
 // ApplicationsCharmActionsResults holds a slice of ApplicationCharmActionsResult for
 // a bulk result of charm Actions for Applications.
 type ApplicationsCharmActionsResults struct {
@@ -138,3 +183,52 @@ type ActionSpec struct {
 	Description string                 `json:"description"`
 	Params      map[string]interface{} `json:"params"`
 }
+
+// ActionScheduleArgs holds the arguments for scheduling actions.
+type ActionScheduleArgs struct {
+	Schedules []ActionScheduleArg `json:"schedules"`
+}
+
+// ActionScheduleArg holds the arguments for scheduling an action to
+// be enqueued on its receivers once, at the given time, or repeatedly
+// on the given schedule.
+type ActionScheduleArg struct {
+	Receivers  []string               `json:"receivers"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Schedule   string                 `json:"schedule,omitempty"`
+	At         *time.Time             `json:"at,omitempty"`
+}
+
+// ActionSchedule describes a scheduled action.
+type ActionSchedule struct {
+	Id         string                 `json:"id"`
+	Receivers  []string               `json:"receivers"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Schedule   string                 `json:"schedule,omitempty"`
+	NextRun    time.Time              `json:"next-run"`
+	LastRun    *time.Time             `json:"last-run,omitempty"`
+	Owner      string                 `json:"owner,omitempty"`
+}
+
+// ActionScheduleResults holds the results of scheduling actions.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results"`
+}
+
+// ActionScheduleResult holds a scheduled action, or an error.
+type ActionScheduleResult struct {
+	Result *ActionSchedule `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// ActionSchedules holds the scheduled actions of a model.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionScheduleIds holds the ids of scheduled actions.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+
+	"github.com/juju/version"
+)
+
+// AuditLogQuery holds the parameters for querying the controller's
+// audit log. Empty fields match all entries.
+type AuditLogQuery struct {
+	// UserTag restricts the entries to calls made by this user.
+	UserTag string `json:"user-tag,omitempty"`
+
+	// ModelUUID restricts the entries to calls made on this model.
+	ModelUUID string `json:"model-uuid,omitempty"`
+
+	// Facade restricts the entries to calls made on this facade.
+	Facade string `json:"facade,omitempty"`
+
+	// Method restricts the entries to calls made on this facade
+	// method.
+	Method string `json:"method,omitempty"`
+
+	// After restricts the entries to those at or after this time.
+	After time.Time `json:"after,omitempty"`
+
+	// Before restricts the entries to those before this time.
+	Before time.Time `json:"before,omitempty"`
+
+	// Limit is the maximum number of the most recent entries to
+	// return. Zero means no limit.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single audit log entry.
+type AuditLogEntry struct {
+	JujuServerVersion version.Number         `json:"juju-server-version"`
+	ModelUUID         string                 `json:"model-uuid"`
+	ConversationID    string                 `json:"conversation-id,omitempty"`
+	Timestamp         time.Time              `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+// AuditLogEntries holds the audit log entries matching a query,
+// oldest first.
+type AuditLogEntries struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
@@ -12,6 +12,13 @@ import (
 // BackupsCreateArgs holds the args for the API Create method.
 type BackupsCreateArgs struct {
 	Notes string `json:"notes"`
+
+	// Encrypt requests that the backup archive be encrypted. If Key
+	// is empty, the controller's backup-encryption-key is used.
+	Encrypt bool `json:"encrypt,omitempty"`
+
+	// Key, if not empty, is used to encrypt the backup archive.
+	Key string `json:"key,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -21,6 +28,9 @@ type BackupsInfoArgs struct {
 
 // BackupsListArgs holds the args for the API List method.
 type BackupsListArgs struct {
+	// Target, if true, lists the backups stored on the controller's
+	// backup target rather than those stored on the controller.
+	Target bool `json:"target,omitempty"`
 }
 
 // BackupsDownloadArgs holds the args for the API Download method.
@@ -68,6 +78,13 @@ type BackupsMetadataResult struct {
 	Version  version.Number `json:"version"`
 	Series   string         `json:"series"`
 
+	// Scheduled is true if the backup was created by the controller's
+	// backup schedule.
+	Scheduled bool `json:"scheduled,omitempty"`
+
+	// Encrypted is true if the backup archive is encrypted.
+	Encrypted bool `json:"encrypted,omitempty"`
+
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
 }
@@ -76,4 +93,24 @@ type BackupsMetadataResult struct {
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
+
+	// Key is used to decrypt an encrypted backup archive. If empty,
+	// the controller's backup-encryption-key is used.
+	Key string `json:"key,omitempty"`
+}
+
+// BackupStatus holds the backup schedule of a controller and the
+// most recent backup stored on it.
+type BackupStatus struct {
+	// Schedule is the controller's backup schedule, or empty if
+	// backups are only created on request.
+	Schedule string `json:"schedule,omitempty"`
+
+	// LastBackupID is the ID of the most recent stored backup, or
+	// empty if there are none.
+	LastBackupID string `json:"last-backup-id,omitempty"`
+
+	// LastBackupStarted is when the most recent stored backup was
+	// started.
+	LastBackupStarted time.Time `json:"last-backup-started"`
 }
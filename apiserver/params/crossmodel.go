@@ -321,6 +321,10 @@ type RemoteRelationChangeEvent struct {
 	// the relation since the last change.
 	DepartedUnits []int `json:"departed-units,omitempty"`
 
+	// ApplicationSettings holds the application-level settings of the
+	// application in the relation, if they are known.
+	ApplicationSettings map[string]interface{} `json:"application-settings,omitempty"`
+
 	// Macaroon is used for authentication.
 	Macaroon *macaroon.Macaroon `json:"macaroon,omitempty"`
 }
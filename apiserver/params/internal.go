@@ -294,6 +294,132 @@ type RelationUnitsSettings struct {
 	RelationUnits []RelationUnitSettings `json:"relation-units"`
 }
 
+// RelationApplication holds a relation tag and the tag of an
+// application taking part in the relation.
+type RelationApplication struct {
+	Relation    string `json:"relation"`
+	Application string `json:"application"`
+}
+
+// RelationApplications holds the arguments for reading the
+// application-level settings of applications in relations.
+type RelationApplications struct {
+	RelationApplications []RelationApplication `json:"relation-applications"`
+}
+
+// RelationApplicationSettings holds a relation tag, the tag of an
+// application taking part in the relation, and the application-level
+// settings to write. Keys with empty values are deleted.
+type RelationApplicationSettings struct {
+	Relation    string   `json:"relation"`
+	Application string   `json:"application"`
+	Settings    Settings `json:"settings"`
+}
+
+// RelationApplicationsSettings holds the arguments for writing the
+// application-level settings of applications in relations.
+type RelationApplicationsSettings struct {
+	RelationApplications []RelationApplicationSettings `json:"relation-applications"`
+}
+
+// GoalStateStatus holds the status of a unit or relation, as reported
+// by the goal-state hook tool.
+type GoalStateStatus struct {
+	Status string     `json:"status"`
+	Since  *time.Time `json:"since,omitempty"`
+}
+
+// UnitsGoalState holds the goal state statuses of units and
+// applications, keyed by name.
+type UnitsGoalState map[string]GoalStateStatus
+
+// GoalState holds the units expected for an application and for each
+// of its related applications, keyed by the application's endpoint
+// name.
+type GoalState struct {
+	Units     UnitsGoalState            `json:"units"`
+	Relations map[string]UnitsGoalState `json:"relations"`
+}
+
+// GoalStateResult holds the goal state of a unit's application, or an
+// error.
+type GoalStateResult struct {
+	Result *GoalState `json:"result,omitempty"`
+	Error  *Error     `json:"error,omitempty"`
+}
+
+// GoalStateResults holds the results of a GoalStates call.
+type GoalStateResults struct {
+	Results []GoalStateResult `json:"results"`
+}
+
+// CharmStateResults holds the charm state of a number of units.
+type CharmStateResults struct {
+	Results []CharmStateResult `json:"results"`
+}
+
+// CharmStateResult holds the key/value state that a charm has stored
+// for a unit, or an error.
+type CharmStateResult struct {
+	Result map[string]string `json:"result,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// SetCharmStateArgs holds the arguments for setting the charm state
+// of a number of units.
+type SetCharmStateArgs struct {
+	Args []SetCharmStateArg `json:"args"`
+}
+
+// SetCharmStateArg holds the key/value state to store for a unit,
+// replacing any existing state.
+type SetCharmStateArg struct {
+	Tag        string            `json:"tag"`
+	CharmState map[string]string `json:"charm-state"`
+}
+
+// HookRun holds the details of a single run of a unit's hook.
+type HookRun struct {
+	Hook     string        `json:"hook"`
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	Result   string        `json:"result"`
+}
+
+// RecordHookRunsArgs holds the arguments for recording the hook runs
+// of a number of units.
+type RecordHookRunsArgs struct {
+	Args []RecordHookRunsArg `json:"args"`
+}
+
+// RecordHookRunsArg holds hook runs to record for a unit.
+type RecordHookRunsArg struct {
+	Tag  string    `json:"tag"`
+	Runs []HookRun `json:"runs"`
+}
+
+// HookRunsArgs holds the arguments for reading the most recent hook
+// runs of a number of units.
+type HookRunsArgs struct {
+	Entities []Entity `json:"entities"`
+
+	// Count is the maximum number of runs to return for each unit.
+	// If zero, all of the recorded runs are returned.
+	Count int `json:"count,omitempty"`
+}
+
+// HookRunsResults holds the hook runs of a number of units.
+type HookRunsResults struct {
+	Results []HookRunsResult `json:"results"`
+}
+
+// HookRunsResult holds the most recent hook runs of a unit, newest
+// first, or an error.
+type HookRunsResult struct {
+	Runs  []HookRun `json:"runs,omitempty"`
+	Error *Error    `json:"error,omitempty"`
+}
+
 // RelationResult returns information about a single relation,
 // or an error.
 type RelationResult struct {
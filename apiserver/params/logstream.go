@@ -24,6 +24,11 @@ type LogStreamRecord struct {
 	Location  string    `json:"lo"`
 	Level     string    `json:"lv"`
 	Message   string    `json:"msg"`
+
+	// Kind is set for records written by the controller itself,
+	// rather than by an agent. It is "audit" for audited API calls
+	// and "status-history" for status changes.
+	Kind string `json:"kind,omitempty"`
 }
 
 // LogStreamConfig holds all the information necessary to open a
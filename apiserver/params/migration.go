@@ -52,6 +52,49 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationPrecheckResults is used to return the results of running
+// the migration prechecks for one or more models.
+type MigrationPrecheckResults struct {
+	Results []MigrationPrecheckResult `json:"results"`
+}
+
+// MigrationPrecheckResult is used to return every precondition for the
+// migration of a single model that is not met. Error is set if the
+// prechecks could not be run.
+type MigrationPrecheckResult struct {
+	ModelTag string   `json:"model-tag"`
+	Error    *Error   `json:"error,omitempty"`
+	Failures []string `json:"failures,omitempty"`
+}
+
+// CloneModelArgs holds the details required to clone one or more
+// models.
+type CloneModelArgs struct {
+	Specs []CloneModelSpec `json:"specs"`
+}
+
+// CloneModelSpec holds the details required to copy a single model
+// under a new name to the controller described by TargetInfo, which
+// may be the model's own controller.
+type CloneModelSpec struct {
+	ModelTag   string              `json:"model-tag"`
+	Name       string              `json:"name"`
+	TargetInfo MigrationTargetInfo `json:"target-info"`
+}
+
+// CloneModelResults is used to return the results of one or more
+// attempts to clone models.
+type CloneModelResults struct {
+	Results []CloneModelResult `json:"results"`
+}
+
+// CloneModelResult is used to return the tag of the model created by
+// cloning a single model.
+type CloneModelResult struct {
+	ModelTag string `json:"model-tag,omitempty"`
+	Error    *Error `json:"error,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -132,6 +175,9 @@ type MigrationModelInfo struct {
 	OwnerTag               string         `json:"owner-tag"`
 	AgentVersion           version.Number `json:"agent-version"`
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+	CloudTag               string         `json:"cloud-tag,omitempty"`
+	CloudRegion            string         `json:"cloud-region,omitempty"`
+	CloudCredentialTag     string         `json:"cloud-credential-tag,omitempty"`
 }
 
 // MigrationStatus reports the current status of a model migration.
@@ -226,6 +226,7 @@ type ApplicationDeploy struct {
 	AttachStorage    []string                       `json:"attach-storage,omitempty"`
 	EndpointBindings map[string]string              `json:"endpoint-bindings,omitempty"`
 	Resources        map[string]string              `json:"resources,omitempty"`
+	Trust            bool                           `json:"trust,omitempty"`
 }
 
 // ApplicationUpdate holds the parameters for making the application Update call.
@@ -329,6 +330,12 @@ type ApplicationUnexpose struct {
 	ApplicationName string `json:"application"`
 }
 
+// ApplicationTrust holds parameters for the application SetTrust call.
+type ApplicationTrust struct {
+	ApplicationName string `json:"application"`
+	Trust           bool   `json:"trust"`
+}
+
 // ApplicationMetricCredential holds parameters for the SetApplicationCredentials call.
 type ApplicationMetricCredential struct {
 	ApplicationName   string `json:"application"`
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// CreateSecretArgs holds the arguments for creating secrets.
+type CreateSecretArgs struct {
+	Args []CreateSecretArg `json:"args"`
+}
+
+// CreateSecretArg holds the arguments for creating a secret owned by
+// the calling unit's application.
+type CreateSecretArg struct {
+	Label          string            `json:"label,omitempty"`
+	Description    string            `json:"description,omitempty"`
+	Data           map[string]string `json:"data"`
+	RotateInterval time.Duration     `json:"rotate-interval,omitempty"`
+}
+
+// UpdateSecretArgs holds the arguments for updating secrets.
+type UpdateSecretArgs struct {
+	Args []UpdateSecretArg `json:"args"`
+}
+
+// UpdateSecretArg holds the new value of a secret.
+type UpdateSecretArg struct {
+	Id   string            `json:"id"`
+	Data map[string]string `json:"data"`
+}
+
+// GetSecretArgs holds the ids of the secrets whose values are read.
+type GetSecretArgs struct {
+	Args []GetSecretArg `json:"args"`
+}
+
+// GetSecretArg identifies a secret whose value is read.
+type GetSecretArg struct {
+	Id string `json:"id"`
+}
+
+// SecretValueResults holds the values of secrets.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// SecretValueResult holds the value of a secret, or an error.
+type SecretValueResult struct {
+	Data  map[string]string `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// GrantSecretArgs holds the arguments for granting access to secrets.
+type GrantSecretArgs struct {
+	Args []GrantSecretArg `json:"args"`
+}
+
+// GrantSecretArg grants the applications in a relation access to a
+// secret.
+type GrantSecretArg struct {
+	Id          string `json:"id"`
+	RelationTag string `json:"relation-tag"`
+}
+
+// Secret holds the details of a secret, without its value.
+type Secret struct {
+	Id             string        `json:"id"`
+	Owner          string        `json:"owner"`
+	Label          string        `json:"label,omitempty"`
+	Description    string        `json:"description,omitempty"`
+	Revision       int           `json:"revision"`
+	RotateInterval time.Duration `json:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time    `json:"next-rotate-time,omitempty"`
+	Grants         []string      `json:"grants,omitempty"`
+	Created        time.Time     `json:"created"`
+	Updated        time.Time     `json:"updated"`
+
+	// Value holds the secret's key/value pairs. It is only set
+	// when explicitly requested by a model administrator.
+	Value map[string]string `json:"value,omitempty"`
+}
+
+// SecretsResults holds the secrets visible to each of a number of
+// entities.
+type SecretsResults struct {
+	Results []SecretsResult `json:"results"`
+}
+
+// SecretsResult holds a list of secrets, or an error.
+type SecretsResult struct {
+	Secrets []Secret `json:"secrets,omitempty"`
+	Error   *Error   `json:"error,omitempty"`
+}
+
+// ListSecretsArgs holds the arguments for listing the secrets in a
+// model.
+type ListSecretsArgs struct {
+	// Owner, if set, restricts the result to the secrets owned by the
+	// application with the given tag.
+	Owner string `json:"owner,omitempty"`
+
+	// ShowSecrets reports whether the values of the secrets are
+	// included in the result. This requires admin access to the
+	// model.
+	ShowSecrets bool `json:"show-secrets,omitempty"`
+}
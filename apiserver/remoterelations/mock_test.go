@@ -193,6 +193,7 @@ type mockRelation struct {
 	key                   string
 	life                  state.Life
 	units                 map[string]remoterelations.RelationUnit
+	appSettings           map[string]map[string]interface{}
 	endpoints             []state.Endpoint
 	endpointUnitsWatchers map[string]*mockRelationUnitsWatcher
 }
@@ -233,6 +234,18 @@ func (r *mockRelation) Unit(unitId string) (remoterelations.RelationUnit, error)
 	return u, nil
 }
 
+func (r *mockRelation) ApplicationSettings(appName string) (map[string]interface{}, error) {
+	r.MethodCall(r, "ApplicationSettings", appName)
+	if err := r.NextErr(); err != nil {
+		return nil, err
+	}
+	settings, ok := r.appSettings[appName]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", appName)
+	}
+	return settings, nil
+}
+
 func (r *mockRelation) Endpoints() []state.Endpoint {
 	r.MethodCall(r, "Endpoints")
 	return r.endpoints
@@ -168,17 +168,7 @@ func (api *RemoteRelationsAPI) RelationUnitSettings(relationUnits params.Relatio
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-		paramsSettings := make(params.Settings)
-		for k, v := range settings {
-			vString, ok := v.(string)
-			if !ok {
-				return nil, errors.Errorf(
-					"invalid relation setting %q: expected string, got %T", k, v,
-				)
-			}
-			paramsSettings[k] = vString
-		}
-		return paramsSettings, nil
+		return convertSettings(settings)
 	}
 	for i, ru := range relationUnits.RelationUnits {
 		settings, err := one(ru)
@@ -191,6 +181,56 @@ func (api *RemoteRelationsAPI) RelationUnitSettings(relationUnits params.Relatio
 	return results, nil
 }
 
+// RelationApplicationSettings returns the application settings for the given
+// applications and relations in the local model.
+func (api *RemoteRelationsAPI) RelationApplicationSettings(args params.RelationApplications) (params.SettingsResults, error) {
+	results := params.SettingsResults{
+		Results: make([]params.SettingsResult, len(args.RelationApplications)),
+	}
+	one := func(ra params.RelationApplication) (params.Settings, error) {
+		relationTag, err := names.ParseRelationTag(ra.Relation)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rel, err := api.st.KeyRelation(relationTag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		applicationTag, err := names.ParseApplicationTag(ra.Application)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		settings, err := rel.ApplicationSettings(applicationTag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return convertSettings(settings)
+	}
+	for i, ra := range args.RelationApplications {
+		settings, err := one(ra)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Settings = settings
+	}
+	return results, nil
+}
+
+func convertSettings(settings map[string]interface{}) (params.Settings, error) {
+	paramsSettings := make(params.Settings)
+	for k, v := range settings {
+		vString, ok := v.(string)
+		if !ok {
+			return nil, errors.Errorf(
+				"invalid relation setting %q: expected string, got %T", k, v,
+			)
+		}
+		paramsSettings[k] = vString
+	}
+	return paramsSettings, nil
+}
+
 func (api *RemoteRelationsAPI) remoteRelation(entity params.Entity) (*params.RemoteRelation, error) {
 	tag, err := names.ParseRelationTag(entity.Tag)
 	if err != nil {
@@ -265,6 +265,28 @@ func (s *remoteRelationsSuite) TestRelationUnitSettings(c *gc.C) {
 	})
 }
 
+func (s *remoteRelationsSuite) TestRelationApplicationSettings(c *gc.C) {
+	db2Relation := newMockRelation(123)
+	db2Relation.appSettings = map[string]map[string]interface{}{
+		"django": {"key": "value"},
+	}
+	s.st.relations["db2:db django:db"] = db2Relation
+	result, err := s.api.RelationApplicationSettings(params.RelationApplications{
+		RelationApplications: []params.RelationApplication{
+			{Relation: "relation-db2.db#django.db", Application: "application-django"},
+			{Relation: "relation-db2.db#django.db", Application: "application-db2"},
+		}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.SettingsResult{
+		{Settings: params.Settings{"key": "value"}},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: `application "db2" not found`}},
+	})
+	db2Relation.CheckCalls(c, []testing.StubCall{
+		{"ApplicationSettings", []interface{}{"django"}},
+		{"ApplicationSettings", []interface{}{"db2"}},
+	})
+}
+
 func (s *remoteRelationsSuite) TestRemoteApplications(c *gc.C) {
 	s.st.remoteApplications["django"] = newMockRemoteApplication("django", "me/model.riak")
 	result, err := s.api.RemoteApplications(params.Entities{Entities: []params.Entity{{Tag: "application-django"}}})
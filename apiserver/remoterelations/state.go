@@ -80,6 +80,10 @@ type Relation interface {
 	// Unit returns a RelationUnit for the unit with the supplied ID.
 	Unit(unitId string) (RelationUnit, error)
 
+	// ApplicationSettings returns the settings of the application
+	// with the supplied name within the relation.
+	ApplicationSettings(appName string) (map[string]interface{}, error)
+
 	// WatchUnits returns a watcher that notifies of changes to the units of the
 	// specified application in the relation.
 	WatchUnits(applicationName string) (state.RelationUnitsWatcher, error)
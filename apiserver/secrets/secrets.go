@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets implements the API endpoint used by Juju clients to
+// inspect the secrets owned by applications in a model.
+package secrets
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// SecretsAPI implements the client API for inspecting secrets.
+type SecretsAPI struct {
+	state      *state.State
+	authorizer facade.Authorizer
+}
+
+// NewSecretsAPI returns a new client API facade for secrets.
+func NewSecretsAPI(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*SecretsAPI, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &SecretsAPI{
+		state:      st,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (s *SecretsAPI) checkPermission(access permission.Access) error {
+	ok, err := s.authorizer.HasPermission(access, s.state.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ListSecrets returns the details of the secrets in the model,
+// optionally restricted to those owned by one application. The values
+// of the secrets are only included if requested, which requires admin
+// access to the model.
+func (s *SecretsAPI) ListSecrets(args params.ListSecretsArgs) (params.SecretsResult, error) {
+	access := permission.ReadAccess
+	if args.ShowSecrets {
+		access = permission.AdminAccess
+	}
+	if err := s.checkPermission(access); err != nil {
+		return params.SecretsResult{}, errors.Trace(err)
+	}
+	var owner names.ApplicationTag
+	if args.Owner != "" {
+		var err error
+		if owner, err = names.ParseApplicationTag(args.Owner); err != nil {
+			return params.SecretsResult{}, errors.Trace(err)
+		}
+	}
+
+	secrets, err := s.state.AllSecrets()
+	if err != nil {
+		return params.SecretsResult{}, errors.Trace(err)
+	}
+	var result params.SecretsResult
+	for _, secret := range secrets {
+		if args.Owner != "" && secret.Owner() != owner {
+			continue
+		}
+		details := common.SecretDetails(secret)
+		if args.ShowSecrets {
+			if details.Value, err = secret.Value(); err != nil {
+				return params.SecretsResult{}, errors.Trace(err)
+			}
+		}
+		result.Secrets = append(result.Secrets, details)
+	}
+	return result, nil
+}
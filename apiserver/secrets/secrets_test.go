@@ -0,0 +1,98 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/secrets"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type secretsSuite struct {
+	jujutesting.JujuConnSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	api        *secrets.SecretsAPI
+	secret     *state.Secret
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: s.AdminUserTag(c),
+	}
+	var err error
+	s.api, err = secrets.NewSecretsAPI(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "wordpress",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	s.secret, err = s.State.AddSecret(state.SecretArgs{
+		Owner: mysql.ApplicationTag(),
+		Label: "root",
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSecret(state.SecretArgs{
+		Owner: names.NewApplicationTag("wordpress"),
+		Data:  map[string]string{"key": "k3y"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *secretsSuite) TestNewSecretsAPINonClient(c *gc.C) {
+	_, err := secrets.NewSecretsAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUnitTag("mysql/0"),
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	result, err := s.api.ListSecrets(params.ListSecretsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Secrets, gc.HasLen, 2)
+	c.Check(result.Secrets[0].Id, gc.Equals, s.secret.Id())
+	c.Check(result.Secrets[0].Owner, gc.Equals, "application-mysql")
+	c.Check(result.Secrets[0].Label, gc.Equals, "root")
+	c.Check(result.Secrets[0].Value, gc.IsNil)
+	c.Check(result.Secrets[1].Owner, gc.Equals, "application-wordpress")
+}
+
+func (s *secretsSuite) TestListSecretsOwner(c *gc.C) {
+	result, err := s.api.ListSecrets(params.ListSecretsArgs{
+		Owner:       "application-mysql",
+		ShowSecrets: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Secrets, gc.HasLen, 1)
+	c.Check(result.Secrets[0].Value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+}
+
+func (s *secretsSuite) TestListSecretsReadOnly(c *gc.C) {
+	api, err := secrets.NewSecretsAPI(s.State, nil, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ListSecrets(params.ListSecretsArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Secrets, gc.HasLen, 2)
+
+	_, err = api.ListSecrets(params.ListSecretsArgs{ShowSecrets: true})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ReadApplicationSettings returns the application-level settings of each
+// given application in a relation. The calling unit's application must
+// take part in the relation.
+func (u *UniterAPI) ReadApplicationSettings(args params.RelationApplications) (params.SettingsResults, error) {
+	result := params.SettingsResults{
+		Results: make([]params.SettingsResult, len(args.RelationApplications)),
+	}
+	for i, arg := range args.RelationApplications {
+		rel, appName, err := u.getRelationApplication(arg.Relation, arg.Application)
+		if err == nil {
+			var settings map[string]interface{}
+			settings, err = rel.ApplicationSettings(appName)
+			if err == nil {
+				result.Results[i].Settings, err = convertRelationSettings(settings)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// UpdateApplicationSettings writes the application-level settings of the
+// calling unit's application in each given relation. Keys with empty
+// values are deleted. Only the leader may write the settings.
+func (u *UniterAPI) UpdateApplicationSettings(args params.RelationApplicationsSettings) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.RelationApplications)),
+	}
+	for i, arg := range args.RelationApplications {
+		rel, appName, err := u.getRelationApplication(arg.Relation, arg.Application)
+		if err == nil && appName != u.unit.ApplicationName() {
+			err = common.ErrPerm
+		}
+		if err == nil {
+			token := u.st.LeadershipChecker().LeadershipCheck(appName, u.unit.Name())
+			err = rel.UpdateApplicationSettings(appName, token, arg.Settings)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// getRelationApplication returns the relation with the given tag, and
+// the name of the application with the given tag, if both the calling
+// unit's application and the given application take part in the
+// relation.
+func (u *UniterAPI) getRelationApplication(relTag, appTag string) (*state.Relation, string, error) {
+	rtag, err := names.ParseRelationTag(relTag)
+	if err != nil {
+		return nil, "", common.ErrPerm
+	}
+	atag, err := names.ParseApplicationTag(appTag)
+	if err != nil {
+		return nil, "", common.ErrPerm
+	}
+	rel, err := u.st.KeyRelation(rtag.Id())
+	if errors.IsNotFound(err) {
+		return nil, "", common.ErrPerm
+	} else if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if _, err := rel.Endpoint(u.unit.ApplicationName()); err != nil {
+		return nil, "", common.ErrPerm
+	}
+	if _, err := rel.Endpoint(atag.Id()); err != nil {
+		return nil, "", common.ErrPerm
+	}
+	return rel, atag.Id(), nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+func (s *uniterSuite) TestUpdateApplicationSettings(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", s.wordpressUnit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c, "wordpress", "mysql")
+
+	result, err := s.uniter.UpdateApplicationSettings(params.RelationApplicationsSettings{
+		RelationApplications: []params.RelationApplicationSettings{
+			{Relation: rel.Tag().String(), Application: "application-wordpress", Settings: params.Settings{"url": "http://wp"}},
+			{Relation: rel.Tag().String(), Application: "application-mysql", Settings: params.Settings{"url": "http://wp"}},
+			{Relation: "relation-foo.bar#baz.qux", Application: "application-wordpress"},
+			{Relation: rel.Tag().String(), Application: "unit-wordpress-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	settings, err := rel.ApplicationSettings("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{"url": "http://wp"})
+}
+
+func (s *uniterSuite) TestUpdateApplicationSettingsNotLeader(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	result, err := s.uniter.UpdateApplicationSettings(params.RelationApplicationsSettings{
+		RelationApplications: []params.RelationApplicationSettings{
+			{Relation: rel.Tag().String(), Application: "application-wordpress", Settings: params.Settings{"url": "http://wp"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `cannot write settings for application "wordpress" in relation .*: prerequisites failed: .*`)
+}
+
+func (s *uniterSuite) TestReadApplicationSettings(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	err := rel.UpdateApplicationSettings("mysql", fakeToken{}, map[string]string{"host": "db"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.ReadApplicationSettings(params.RelationApplications{
+		RelationApplications: []params.RelationApplication{
+			{Relation: rel.Tag().String(), Application: "application-mysql"},
+			{Relation: rel.Tag().String(), Application: "application-wordpress"},
+			{Relation: rel.Tag().String(), Application: "application-logging"},
+			{Relation: "relation-foo.bar#baz.qux", Application: "application-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SettingsResults{
+		Results: []params.SettingsResult{
+			{Settings: params.Settings{"host": "db"}},
+			{Settings: params.Settings{}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+// fakeToken implements leadership.Token.
+type fakeToken struct{}
+
+// Check is part of the leadership.Token interface.
+func (fakeToken) Check(interface{}) error {
+	return nil
+}
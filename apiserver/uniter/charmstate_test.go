@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+func (s *uniterSuite) TestSetCharmState(c *gc.C) {
+	result, err := s.uniter.SetCharmState(params.SetCharmStateArgs{
+		Args: []params.SetCharmStateArg{
+			{Tag: "unit-wordpress-0", CharmState: map[string]string{"cluster": "formed"}},
+			{Tag: "unit-mysql-0", CharmState: map[string]string{"cluster": "formed"}},
+			{Tag: "application-wordpress", CharmState: map[string]string{"cluster": "formed"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	charmState, err := s.wordpressUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"cluster": "formed"})
+}
+
+func (s *uniterSuite) TestGetCharmState(c *gc.C) {
+	err := s.wordpressUnit.SetCharmState(map[string]string{"cluster": "formed"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.GetCharmState(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CharmStateResults{
+		Results: []params.CharmStateResult{
+			{Result: map[string]string{"cluster": "formed"}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
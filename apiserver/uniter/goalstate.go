@@ -0,0 +1,149 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// relationJoined is the goal state status of a live relation.
+const relationJoined = "joined"
+
+// GoalStates returns the goal state of the application of each given
+// unit: the units expected for the application, and the applications
+// and units expected at the far end of each of its relations.
+func (u *UniterAPI) GoalStates(args params.Entities) (params.GoalStateResults, error) {
+	result := params.GoalStateResults{
+		Results: make([]params.GoalStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.GoalStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result, err = u.goalState(unit)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) goalState(unit *state.Unit) (*params.GoalState, error) {
+	application, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := goalStateUnits(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relations, err := u.goalStateRelations(application)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.GoalState{
+		Units:     units,
+		Relations: relations,
+	}, nil
+}
+
+// goalStateUnits returns the statuses of the units of the application
+// that are expected to be running. Dying units are reported as such,
+// and dead units are omitted.
+func goalStateUnits(application *state.Application) (params.UnitsGoalState, error) {
+	units, err := application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(params.UnitsGoalState)
+	for _, unit := range units {
+		switch unit.Life() {
+		case state.Dead:
+			continue
+		case state.Dying:
+			result[unit.Name()] = params.GoalStateStatus{Status: state.Dying.String()}
+			continue
+		}
+		info, err := unit.Status()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[unit.Name()] = params.GoalStateStatus{
+			Status: info.Status.String(),
+			Since:  info.Since,
+		}
+	}
+	return result, nil
+}
+
+// goalStateRelations returns, keyed by endpoint name, the statuses of
+// the related applications and their units for each relation of the
+// application.
+func (u *UniterAPI) goalStateRelations(application *state.Application) (map[string]params.UnitsGoalState, error) {
+	relations, err := application.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]params.UnitsGoalState)
+	for _, rel := range relations {
+		ep, err := rel.Endpoint(application.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		related, err := rel.RelatedEndpoints(application.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		goal, ok := result[ep.Name]
+		if !ok {
+			goal = make(params.UnitsGoalState)
+			result[ep.Name] = goal
+		}
+		for _, relatedEp := range related {
+			goal[relatedEp.ApplicationName] = goalStateRelationStatus(rel)
+			relatedApp, err := u.st.Application(relatedEp.ApplicationName)
+			if errors.IsNotFound(err) {
+				// Units of remote applications are not known
+				// in this model.
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			units, err := goalStateUnits(relatedApp)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for name, unitStatus := range units {
+				goal[name] = unitStatus
+			}
+		}
+	}
+	return result, nil
+}
+
+// goalStateRelationStatus returns the goal state status of a relation.
+func goalStateRelationStatus(rel *state.Relation) params.GoalStateStatus {
+	if rel.Life() != state.Alive {
+		return params.GoalStateStatus{Status: rel.Life().String()}
+	}
+	return params.GoalStateStatus{Status: relationJoined}
+}
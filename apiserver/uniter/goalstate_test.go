@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/status"
+)
+
+func (s *uniterSuite) setGoalStateStatus(c *gc.C, setter status.StatusSetter, value status.Status) {
+	now := time.Now()
+	err := setter.SetStatus(status.StatusInfo{Status: value, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *uniterSuite) TestGoalStates(c *gc.C) {
+	s.setGoalStateStatus(c, s.wordpressUnit, status.Active)
+	s.setGoalStateStatus(c, s.mysqlUnit, status.Maintenance)
+	s.addRelation(c, "wordpress", "mysql")
+
+	result, err := s.uniter.GoalStates(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "application-wordpress"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[2].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	goal := result.Results[1].Result
+	c.Assert(goal, gc.NotNil)
+	c.Assert(goal.Units, gc.HasLen, 1)
+	c.Check(goal.Units["wordpress/0"].Status, gc.Equals, "active")
+	c.Check(goal.Units["wordpress/0"].Since, gc.NotNil)
+	c.Assert(goal.Relations, gc.HasLen, 1)
+	db := goal.Relations["db"]
+	c.Assert(db, gc.HasLen, 2)
+	c.Check(db["mysql"], jc.DeepEquals, params.GoalStateStatus{Status: "joined"})
+	c.Check(db["mysql/0"].Status, gc.Equals, "maintenance")
+}
@@ -0,0 +1,56 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// RecordHookRuns records the hook runs of each given unit, so that
+// recent hook durations and failures can be shown to users.
+func (u *UniterAPI) RecordHookRuns(args params.RecordHookRunsArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			err = u.recordHookRuns(tag, arg.Runs)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) recordHookRuns(tag names.UnitTag, runs []params.HookRun) error {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		err := unit.RecordHookRun(state.HookRun{
+			Hook:     run.Hook,
+			Started:  run.Started,
+			Duration: run.Duration,
+			Result:   run.Result,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
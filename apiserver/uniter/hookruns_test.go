@@ -0,0 +1,49 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+func (s *uniterSuite) TestRecordHookRuns(c *gc.C) {
+	started := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	runs := []params.HookRun{{
+		Hook:     "config-changed",
+		Started:  started,
+		Duration: 90 * time.Second,
+		Result:   "failure",
+	}}
+	result, err := s.uniter.RecordHookRuns(params.RecordHookRunsArgs{
+		Args: []params.RecordHookRunsArg{
+			{Tag: "unit-wordpress-0", Runs: runs},
+			{Tag: "unit-mysql-0", Runs: runs},
+			{Tag: "application-wordpress", Runs: runs},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	hookRuns, err := s.wordpressUnit.HookRuns(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hookRuns, jc.DeepEquals, []state.HookRun{{
+		Hook:     "config-changed",
+		Started:  started,
+		Duration: 90 * time.Second,
+		Result:   "failure",
+	}})
+}
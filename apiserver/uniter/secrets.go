@@ -0,0 +1,216 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// CreateSecrets creates secrets owned by the calling unit's
+// application, and returns their ids. Only the leader may create
+// secrets.
+func (u *UniterAPI) CreateSecrets(args params.CreateSecretArgs) (params.StringResults, error) {
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Args)),
+	}
+	if err := u.checkLeader(); err != nil {
+		for i := range args.Args {
+			result.Results[i].Error = common.ServerError(err)
+		}
+		return result, nil
+	}
+	owner := names.NewApplicationTag(u.unit.ApplicationName())
+	for i, arg := range args.Args {
+		secret, err := u.st.AddSecret(state.SecretArgs{
+			Owner:          owner,
+			Label:          arg.Label,
+			Description:    arg.Description,
+			Data:           arg.Data,
+			RotateInterval: arg.RotateInterval,
+		})
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = secret.Id()
+	}
+	return result, nil
+}
+
+// UpdateSecrets stores new values for secrets owned by the calling
+// unit's application. Only the leader may update secrets.
+func (u *UniterAPI) UpdateSecrets(args params.UpdateSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		secret, err := u.ownedSecret(arg.Id)
+		if err == nil {
+			err = secret.Update(arg.Data)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// GrantSecrets grants the applications in relations access to secrets
+// owned by the calling unit's application. Only the leader may grant
+// access to secrets.
+func (u *UniterAPI) GrantSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		result.Results[i].Error = common.ServerError(u.grantSecret(arg))
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) grantSecret(arg params.GrantSecretArg) error {
+	secret, err := u.ownedSecret(arg.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tag, err := names.ParseRelationTag(arg.RelationTag)
+	if err != nil {
+		return common.ErrPerm
+	}
+	relation, err := u.st.KeyRelation(tag.Id())
+	if errors.IsNotFound(err) {
+		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return secret.Grant(relation)
+}
+
+// GetSecretValues returns the values of the secrets with the given
+// ids, which the calling unit must be permitted to read.
+func (u *UniterAPI) GetSecretValues(args params.GetSecretArgs) (params.SecretValueResults, error) {
+	result := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		data, err := u.secretValue(arg.Id)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Data = data
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) secretValue(id string) (map[string]string, error) {
+	secret, err := u.st.Secret(id)
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	readable, err := secret.CanRead(u.unit.ApplicationName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !readable {
+		return nil, common.ErrPerm
+	}
+	return secret.Value()
+}
+
+// UnitSecrets returns the details of the secrets whose values may be
+// read by each of the given units.
+func (u *UniterAPI) UnitSecrets(args params.Entities) (params.SecretsResults, error) {
+	result := params.SecretsResults{
+		Results: make([]params.SecretsResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.SecretsResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := names.UnitApplication(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		secrets, err := u.st.ReadableSecrets(application)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		details := make([]params.Secret, len(secrets))
+		for j, secret := range secrets {
+			details[j] = common.SecretDetails(secret)
+		}
+		result.Results[i].Secrets = details
+	}
+	return result, nil
+}
+
+// WatchUnitSecrets returns a NotifyWatcher for observing changes to
+// the secrets that may be read by each of the given units.
+func (u *UniterAPI) WatchUnitSecrets(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil || !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		watch := u.st.WatchApplicationSecrets(u.unit.ApplicationName())
+		// Consume the initial event.
+		if _, ok := <-watch.Changes(); ok {
+			result.Results[i].NotifyWatcherId = u.resources.Register(watch)
+		} else {
+			result.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return result, nil
+}
+
+// ownedSecret returns the secret with the given id, if it is owned by
+// the calling unit's application and the unit is the leader.
+func (u *UniterAPI) ownedSecret(id string) (*state.Secret, error) {
+	secret, err := u.st.Secret(id)
+	if errors.IsNotFound(err) {
+		return nil, common.ErrPerm
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if secret.Owner().Id() != u.unit.ApplicationName() {
+		return nil, common.ErrPerm
+	}
+	if err := u.checkLeader(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secret, nil
+}
+
+// checkLeader returns an error if the calling unit is not the leader
+// of its application.
+func (u *UniterAPI) checkLeader() error {
+	token := u.st.LeadershipChecker().LeadershipCheck(u.unit.ApplicationName(), u.unit.Name())
+	if err := token.Check(nil); err != nil {
+		return errors.Annotatef(err, "only the leader of %q can manage its secrets", u.unit.ApplicationName())
+	}
+	return nil
+}
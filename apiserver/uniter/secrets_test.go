@@ -0,0 +1,191 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+func (s *uniterSuite) claimMysqlLeadership(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("mysql", s.mysqlUnit.Name(), time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *uniterSuite) TestCreateSecrets(c *gc.C) {
+	s.claimMysqlLeadership(c)
+	mysqlUniter := s.makeMysqlUniter(c)
+	result, err := mysqlUniter.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{
+			Label:          "root",
+			Data:           map[string]string{"password": "s3cret"},
+			RotateInterval: time.Hour,
+		}, {
+			Label: "empty",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, "cannot add secret: no secret data given")
+
+	secret, err := s.State.Secret(result.Results[0].Result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Owner(), gc.Equals, s.mysql.ApplicationTag())
+	c.Check(secret.Label(), gc.Equals, "root")
+	c.Check(secret.RotateInterval(), gc.Equals, time.Hour)
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+}
+
+func (s *uniterSuite) TestCreateSecretsNotLeader(c *gc.C) {
+	mysqlUniter := s.makeMysqlUniter(c)
+	result, err := mysqlUniter.CreateSecrets(params.CreateSecretArgs{
+		Args: []params.CreateSecretArg{{Data: map[string]string{"password": "s3cret"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, `only the leader of "mysql" can manage its secrets: .*`)
+}
+
+func (s *uniterSuite) addMysqlSecret(c *gc.C) *state.Secret {
+	secret, err := s.State.AddSecret(state.SecretArgs{
+		Owner: s.mysql.ApplicationTag(),
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return secret
+}
+
+func (s *uniterSuite) TestUpdateSecrets(c *gc.C) {
+	s.claimMysqlLeadership(c)
+	secret := s.addMysqlSecret(c)
+	mysqlUniter := s.makeMysqlUniter(c)
+	result, err := mysqlUniter.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{
+			{Id: secret.Id(), Data: map[string]string{"password": "n3w"}},
+			{Id: "42", Data: map[string]string{"password": "n3w"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+	secret, err = s.State.Secret(secret.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Revision(), gc.Equals, 2)
+}
+
+func (s *uniterSuite) TestUpdateSecretsNotOwner(c *gc.C) {
+	secret := s.addMysqlSecret(c)
+	result, err := s.uniter.UpdateSecrets(params.UpdateSecretArgs{
+		Args: []params.UpdateSecretArg{{Id: secret.Id(), Data: map[string]string{"password": "n3w"}}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
+
+func (s *uniterSuite) TestGrantAndGetSecretValues(c *gc.C) {
+	s.claimMysqlLeadership(c)
+	secret := s.addMysqlSecret(c)
+	rel := s.addRelation(c, "wordpress", "mysql")
+
+	// wordpress cannot read the secret until it is granted.
+	args := params.GetSecretArgs{Args: []params.GetSecretArg{{Id: secret.Id()}}}
+	values, err := s.uniter.GetSecretValues(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values.Results, gc.HasLen, 1)
+	c.Check(values.Results[0].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	mysqlUniter := s.makeMysqlUniter(c)
+	result, err := mysqlUniter.GrantSecrets(params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{
+			{Id: secret.Id(), RelationTag: rel.Tag().String()},
+			{Id: secret.Id(), RelationTag: "relation-foo.bar#baz.qux"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	values, err = s.uniter.GetSecretValues(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, params.SecretValueResults{
+		Results: []params.SecretValueResult{{
+			Data: map[string]string{"password": "s3cret"},
+		}},
+	})
+}
+
+func (s *uniterSuite) TestUnitSecrets(c *gc.C) {
+	secret := s.addMysqlSecret(c)
+	rel := s.addRelation(c, "wordpress", "mysql")
+	err := secret.Grant(rel)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.UnitSecrets(params.Entities{Entities: []params.Entity{
+		{Tag: s.wordpressUnit.Tag().String()},
+		{Tag: s.mysqlUnit.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Secrets, gc.HasLen, 1)
+	c.Check(result.Results[0].Secrets[0].Id, gc.Equals, secret.Id())
+	c.Check(result.Results[0].Secrets[0].Owner, gc.Equals, "application-mysql")
+	c.Check(result.Results[0].Secrets[0].Revision, gc.Equals, 1)
+	c.Check(result.Results[0].Secrets[0].Grants, jc.DeepEquals, []string{rel.Tag().String()})
+	c.Check(result.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
+
+func (s *uniterSuite) TestWatchUnitSecrets(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.uniter.WatchUnitSecrets(params.Entities{Entities: []params.Entity{
+		{Tag: s.wordpressUnit.Tag().String()},
+		{Tag: s.mysqlUnit.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	// Secrets that the unit's application cannot read are not
+	// reported.
+	s.addMysqlSecret(c)
+	wc.AssertNoChange()
+	_, err = s.State.AddSecret(state.SecretArgs{
+		Owner: s.wordpress.ApplicationTag(),
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -14,6 +14,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/cloudspec"
 	"github.com/juju/juju/apiserver/common/networkingcommon"
 	"github.com/juju/juju/apiserver/facade"
 	leadershipapiserver "github.com/juju/juju/apiserver/leadership"
@@ -23,13 +24,14 @@ import (
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/state/watcher"
 	"github.com/juju/utils/set"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v5) of the Uniter API.
+// UniterAPI implements the latest version (v6) of the Uniter API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -48,14 +50,21 @@ type UniterAPI struct {
 	accessApplication common.GetAuthFunc
 	unit              *state.Unit
 	accessMachine     common.GetAuthFunc
+	cloudSpec         cloudspec.CloudSpecAPI
 	StorageAPI
 }
 
+// UniterAPIV5 doesn't have the secrets, CloudSpec or application
+// relation settings methods.
+type UniterAPIV5 struct {
+	UniterAPI
+}
+
 // UniterAPIV4 has old WatchApplicationRelations and NetworkConfig
 // methods, and doesn't have the new SLALevel, NetworkInfo or
 // WatchUnitRelations methods.
 type UniterAPIV4 struct {
-	UniterAPI
+	UniterAPIV5
 }
 
 // newUniterAPI creates a new instance of the core Uniter API.
@@ -121,6 +130,7 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 		return nil, errors.Annotate(err, "could not create meter status API handler")
 	}
 	accessUnitOrApplication := common.AuthAny(accessUnit, accessApplication)
+	environConfigGetter := stateenvirons.EnvironConfigGetter{st}
 	return &UniterAPI{
 		LifeGetter:                 common.NewLifeGetter(st, accessUnitOrApplication),
 		DeadEnsurer:                common.NewDeadEnsurer(st, accessUnit),
@@ -141,18 +151,30 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 		accessApplication: accessApplication,
 		accessMachine:     accessMachine,
 		unit:              unit,
+		cloudSpec:         cloudspec.NewCloudSpec(environConfigGetter.CloudSpec, common.AuthFuncForTag(st.ModelTag())),
 		StorageAPI:        *storageAPI,
 	}, nil
 }
 
+// NewUniterAPIV5 creates an instance of the V5 uniter API.
+func NewUniterAPIV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV5, error) {
+	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV5{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV4 creates an instance of the V4 uniter API.
 func NewUniterAPIV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV4, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	uniterAPI, err := NewUniterAPIV5(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV4{
-		UniterAPI: *uniterAPI,
+		UniterAPIV5: *uniterAPI,
 	}, nil
 }
 
@@ -942,6 +964,30 @@ func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.Er
 	return common.FinishActions(args, actionFn), nil
 }
 
+// AddActionsOutput records output written by running actions, so
+// that it can be streamed to clients.
+func (u *UniterAPI) AddActionsOutput(args params.ActionOutputs) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+	return common.AddActionsOutput(args, actionFn), nil
+}
+
+// LogActionsMessages records progress messages logged by running
+// actions.
+func (u *UniterAPI) LogActionsMessages(args params.ActionLogMessages) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+	return common.LogActionsMessages(args, actionFn), nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -1556,6 +1602,20 @@ func (u *UniterAPI) SLALevel() (params.StringResult, error) {
 	return result, err
 }
 
+// CloudSpec returns the cloud spec, including the credential, of the
+// model the unit is in. Only units of trusted applications may read
+// the cloud spec.
+func (u *UniterAPI) CloudSpec() (params.CloudSpecResult, error) {
+	application, err := u.st.Application(u.unit.ApplicationName())
+	if err != nil {
+		return params.CloudSpecResult{}, errors.Trace(err)
+	}
+	if !application.IsTrusted() {
+		return params.CloudSpecResult{Error: common.ServerError(common.ErrPerm)}, nil
+	}
+	return u.cloudSpec.GetCloudSpec(u.st.ModelTag()), nil
+}
+
 // NetworkInfo returns network interfaces/addresses for specified bindings.
 func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkInfoResults, error) {
 	canAccess, err := u.accessUnit()
@@ -1860,3 +1920,50 @@ func (u *UniterAPIV4) NetworkInfo(_, _ struct{}) {}
 
 // WatchUnitRelations isn't on the V4 API.
 func (u *UniterAPIV4) WatchUnitRelations(_, _ struct{}) {}
+
+// Mask the methods added in V6 from the V5 API.
+
+// GetCharmState isn't on the V5 API.
+func (u *UniterAPIV5) GetCharmState(_, _ struct{}) {}
+
+// SetCharmState isn't on the V5 API.
+func (u *UniterAPIV5) SetCharmState(_, _ struct{}) {}
+
+// GoalStates isn't on the V5 API.
+func (u *UniterAPIV5) GoalStates(_, _ struct{}) {}
+
+// CloudSpec isn't on the V5 API.
+func (u *UniterAPIV5) CloudSpec(_, _ struct{}) {}
+
+// ReadApplicationSettings isn't on the V5 API.
+func (u *UniterAPIV5) ReadApplicationSettings(_, _ struct{}) {}
+
+// UpdateApplicationSettings isn't on the V5 API.
+func (u *UniterAPIV5) UpdateApplicationSettings(_, _ struct{}) {}
+
+// CreateSecrets isn't on the V5 API.
+func (u *UniterAPIV5) CreateSecrets(_, _ struct{}) {}
+
+// UpdateSecrets isn't on the V5 API.
+func (u *UniterAPIV5) UpdateSecrets(_, _ struct{}) {}
+
+// GrantSecrets isn't on the V5 API.
+func (u *UniterAPIV5) GrantSecrets(_, _ struct{}) {}
+
+// GetSecretValues isn't on the V5 API.
+func (u *UniterAPIV5) GetSecretValues(_, _ struct{}) {}
+
+// UnitSecrets isn't on the V5 API.
+func (u *UniterAPIV5) UnitSecrets(_, _ struct{}) {}
+
+// WatchUnitSecrets isn't on the V5 API.
+func (u *UniterAPIV5) WatchUnitSecrets(_, _ struct{}) {}
+
+// AddActionsOutput isn't on the V5 API.
+func (u *UniterAPIV5) AddActionsOutput(_, _ struct{}) {}
+
+// LogActionsMessages isn't on the V5 API.
+func (u *UniterAPIV5) LogActionsMessages(_, _ struct{}) {}
+
+// RecordHookRuns isn't on the V5 API.
+func (u *UniterAPIV5) RecordHookRuns(_, _ struct{}) {}
@@ -1555,6 +1555,71 @@ func (s *uniterSuite) TestFinishActionsAuthAccess(c *gc.C) {
 	}
 }
 
+func (s *uniterSuite) TestAddActionsOutput(c *gc.C) {
+	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	bad, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	args := params.ActionOutputs{Outputs: []params.ActionOutput{{
+		ActionTag: good.ActionTag().String(),
+		Seq:       0,
+		Stream:    "stdout",
+		Data:      "migrating\n",
+		Timestamp: now,
+	}, {
+		ActionTag: bad.ActionTag().String(),
+		Stream:    "stdout",
+		Data:      "nope\n",
+		Timestamp: now,
+	}}}
+	res, err := s.uniter.AddActionsOutput(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{Error: nil},
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+
+	output, err := good.Output(-1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(output, jc.DeepEquals, []state.ActionOutput{{
+		Seq:       0,
+		Stream:    state.ActionStdout,
+		Data:      "migrating\n",
+		Timestamp: now,
+	}})
+}
+
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	good, err = good.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	bad, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionLogMessages{Messages: []params.ActionLogMessage{{
+		ActionTag: good.ActionTag().String(),
+		Message:   "copying files",
+	}, {
+		ActionTag: bad.ActionTag().String(),
+		Message:   "nope",
+	}}}
+	res, err := s.uniter.LogActionsMessages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, gc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{Error: nil},
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+
+	good, err = s.State.ActionByTag(good.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := good.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, "copying files")
+}
+
 func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	ten_seconds_ago := time.Now().Add(-10 * time.Second)
 	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
@@ -2459,6 +2524,25 @@ func (s *uniterSuite) TestSLALevel(c *gc.C) {
 	c.Assert(result, jc.DeepEquals, params.StringResult{Result: "essential"})
 }
 
+func (s *uniterSuite) TestCloudSpecNotTrusted(c *gc.C) {
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CloudSpecResult{
+		Error: apiservertesting.ErrUnauthorized,
+	})
+}
+
+func (s *uniterSuite) TestCloudSpecTrusted(c *gc.C) {
+	err := s.wordpress.SetTrust(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.CloudSpec()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Result, gc.NotNil)
+	c.Check(result.Result.Type, gc.Equals, "dummy")
+}
+
 func (s *uniterSuite) TestPrivateAddressWithRemoteRelation(c *gc.C) {
 	s.makeRemoteWordpress(c)
 	thisUniter := s.makeMysqlUniter(c)
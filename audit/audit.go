@@ -5,6 +5,8 @@
 package audit
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -24,6 +26,10 @@ type AuditEntry struct {
 	// ModelUUID is the ID of the model the audit entry was written
 	// on.
 	ModelUUID string
+	// ConversationID identifies the API connection the audited call
+	// was made over. All the calls made over one connection share
+	// the same conversation ID.
+	ConversationID string
 	// Timestamp is when the audit entry was generated. It must be
 	// stored with the UTC locale.
 	Timestamp time.Time
@@ -78,3 +84,75 @@ func (e AuditEntry) Validate() error {
 
 	return nil
 }
+
+// Query describes the audit entries to be retrieved from an audit
+// store. Empty fields match all entries.
+type Query struct {
+	// OriginName restricts the entries to those triggered by the
+	// named origin, e.g. "user-bob".
+	OriginName string
+	// ModelUUID restricts the entries to those written on the model
+	// with this UUID.
+	ModelUUID string
+	// Facade restricts the entries to calls made on this API facade.
+	Facade string
+	// Method restricts the entries to calls made on this facade
+	// method. It is only used if Facade is also set.
+	Method string
+	// After restricts the entries to those generated at or after this
+	// time.
+	After time.Time
+	// Before restricts the entries to those generated before this
+	// time.
+	Before time.Time
+	// Limit is the maximum number of entries to return, the most
+	// recent being returned. Zero means no limit.
+	Limit int
+}
+
+// Matches returns whether the entry satisfies the query.
+func (q Query) Matches(e AuditEntry) bool {
+	if q.OriginName != "" && q.OriginName != e.OriginName {
+		return false
+	}
+	if q.ModelUUID != "" && q.ModelUUID != e.ModelUUID {
+		return false
+	}
+	if !q.After.IsZero() && e.Timestamp.Before(q.After) {
+		return false
+	}
+	if !q.Before.IsZero() && !e.Timestamp.Before(q.Before) {
+		return false
+	}
+	if q.Facade != "" {
+		facade, method := ParseOperation(e.Operation)
+		if facade != q.Facade {
+			return false
+		}
+		if q.Method != "" && method != q.Method {
+			return false
+		}
+	}
+	return true
+}
+
+// Operation returns the audit operation recorded for a call to the
+// given API facade method.
+func Operation(facade string, version int, method string) string {
+	return fmt.Sprintf("%s:v%d - %s", facade, version, method)
+}
+
+// ParseOperation returns the facade and method names of an operation
+// created by Operation. If the operation is not in that form, it is
+// returned as the facade name.
+func ParseOperation(operation string) (facade, method string) {
+	parts := strings.SplitN(operation, " - ", 2)
+	if len(parts) != 2 {
+		return operation, ""
+	}
+	facade = parts[0]
+	if i := strings.LastIndex(facade, ":v"); i >= 0 {
+		facade = facade[:i]
+	}
+	return facade, parts[1]
+}
@@ -114,3 +114,47 @@ func validEntry() audit.AuditEntry {
 		Operation:         ".",
 	}
 }
+
+func (s *auditSuite) TestOperation(c *gc.C) {
+	operation := audit.Operation("Application", 4, "Destroy")
+	c.Assert(operation, gc.Equals, "Application:v4 - Destroy")
+	facade, method := audit.ParseOperation(operation)
+	c.Assert(facade, gc.Equals, "Application")
+	c.Assert(method, gc.Equals, "Destroy")
+}
+
+func (s *auditSuite) TestParseOperationUnknownForm(c *gc.C) {
+	facade, method := audit.ParseOperation("deploy")
+	c.Assert(facade, gc.Equals, "deploy")
+	c.Assert(method, gc.Equals, "")
+}
+
+func (s *auditSuite) TestQueryMatches(c *gc.C) {
+	t0 := time.Date(2017, time.September, 26, 10, 0, 0, 0, time.UTC)
+	entry := validEntry()
+	entry.Timestamp = t0
+	entry.OriginName = "user-bob"
+	entry.Operation = "Application:v4 - Destroy"
+
+	for i, test := range []struct {
+		query   audit.Query
+		matches bool
+	}{
+		{audit.Query{}, true},
+		{audit.Query{OriginName: "user-bob"}, true},
+		{audit.Query{OriginName: "user-mary"}, false},
+		{audit.Query{ModelUUID: entry.ModelUUID}, true},
+		{audit.Query{ModelUUID: utils.MustNewUUID().String()}, false},
+		{audit.Query{Facade: "Application"}, true},
+		{audit.Query{Facade: "Application", Method: "Destroy"}, true},
+		{audit.Query{Facade: "Application", Method: "Deploy"}, false},
+		{audit.Query{Facade: "Client"}, false},
+		{audit.Query{After: t0}, true},
+		{audit.Query{After: t0.Add(time.Second)}, false},
+		{audit.Query{Before: t0}, false},
+		{audit.Query{Before: t0.Add(time.Second)}, true},
+	} {
+		c.Logf("test %d: %+v", i, test.query)
+		c.Check(test.query.Matches(entry), gc.Equals, test.matches)
+	}
+}
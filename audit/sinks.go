@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+// LogModule is the module name given to the log records written by a
+// log record sink.
+const LogModule = "juju.audit"
+
+// LogRecordFn writes a single log record, as done by state.DbLogger.
+type LogRecordFn func(t time.Time, entity, module, location string, level loggo.Level, msg string) error
+
+// NewMultiSink returns an audit entry sink which sends each entry to
+// all of the given sinks. Every sink is called, even if an earlier
+// one fails; the returned error describes all the failures.
+func NewMultiSink(sinks ...AuditEntrySinkFn) AuditEntrySinkFn {
+	return func(entry AuditEntry) error {
+		var messages []string
+		for _, sink := range sinks {
+			if err := sink(entry); err != nil {
+				messages = append(messages, err.Error())
+			}
+		}
+		if len(messages) > 0 {
+			return errors.New(strings.Join(messages, "; "))
+		}
+		return nil
+	}
+}
+
+// NewLogRecordSink returns an audit entry sink which writes each entry
+// as a log record using the supplied function. The records are written
+// with the LogModule module, and the entry's origin as the entity, so
+// that they can be forwarded along with other log records.
+func NewLogRecordSink(logFn LogRecordFn) AuditEntrySinkFn {
+	return func(entry AuditEntry) error {
+		msg, err := json.Marshal(logRecordEntry{
+			ModelUUID:      entry.ModelUUID,
+			ConversationID: entry.ConversationID,
+			RemoteAddress:  entry.RemoteAddress,
+			OriginType:     entry.OriginType,
+			OriginName:     entry.OriginName,
+			Operation:      entry.Operation,
+			Data:           entry.Data,
+		})
+		if err != nil {
+			return errors.Annotate(err, "cannot marshal audit entry")
+		}
+		err = logFn(entry.Timestamp, entry.OriginName, LogModule, "", loggo.INFO, string(msg))
+		return errors.Trace(err)
+	}
+}
+
+// logRecordEntry is the form of an audit entry written as the message
+// of a log record.
+type logRecordEntry struct {
+	ModelUUID      string                 `json:"model-uuid"`
+	ConversationID string                 `json:"conversation-id,omitempty"`
+	RemoteAddress  string                 `json:"remote-address"`
+	OriginType     string                 `json:"origin-type"`
+	OriginName     string                 `json:"origin-name"`
+	Operation      string                 `json:"operation"`
+	Data           map[string]interface{} `json:"data,omitempty"`
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package audit_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type sinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&sinksSuite{})
+
+func (s *sinksSuite) TestMultiSinkCallsAllSinks(c *gc.C) {
+	var called []string
+	sink := func(name string, err error) audit.AuditEntrySinkFn {
+		return func(audit.AuditEntry) error {
+			called = append(called, name)
+			return err
+		}
+	}
+	multi := audit.NewMultiSink(
+		sink("a", errors.New("a failed")),
+		sink("b", nil),
+		sink("c", errors.New("c failed")),
+	)
+	err := multi(validEntry())
+	c.Assert(err, gc.ErrorMatches, "a failed; c failed")
+	c.Assert(called, jc.DeepEquals, []string{"a", "b", "c"})
+}
+
+func (s *sinksSuite) TestMultiSinkSuccess(c *gc.C) {
+	multi := audit.NewMultiSink(func(audit.AuditEntry) error { return nil })
+	err := multi(validEntry())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *sinksSuite) TestLogRecordSink(c *gc.C) {
+	t0 := time.Date(2017, time.September, 26, 10, 0, 0, 0, time.UTC)
+	modelUUID := coretesting.ModelTag.Id()
+	var records []string
+	logFn := func(t time.Time, entity, module, location string, level loggo.Level, msg string) error {
+		c.Check(t, gc.Equals, t0)
+		c.Check(entity, gc.Equals, "user-bob")
+		c.Check(module, gc.Equals, "juju.audit")
+		c.Check(location, gc.Equals, "")
+		c.Check(level, gc.Equals, loggo.INFO)
+		records = append(records, msg)
+		return nil
+	}
+	sink := audit.NewLogRecordSink(logFn)
+	err := sink(audit.AuditEntry{
+		Timestamp:     t0,
+		ModelUUID:     modelUUID,
+		RemoteAddress: "10.0.0.1",
+		OriginType:    "API request",
+		OriginName:    "user-bob",
+		Operation:     "Application:v4 - Destroy",
+		Data:          map[string]interface{}{"foo": "bar"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, jc.DeepEquals, []string{
+		`{"model-uuid":"` + modelUUID + `","remote-address":"10.0.0.1",` +
+			`"origin-type":"API request","origin-name":"user-bob",` +
+			`"operation":"Application:v4 - Destroy","data":{"foo":"bar"}}`,
+	})
+}
+
+func (s *sinksSuite) TestLogRecordSinkError(c *gc.C) {
+	sink := audit.NewLogRecordSink(func(time.Time, string, string, string, loggo.Level, string) error {
+		return errors.New("boom")
+	})
+	err := sink(validEntry())
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
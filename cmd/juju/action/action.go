@@ -56,6 +56,18 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// ScheduleActions schedules actions to be enqueued on their
+	// receivers once at a given time, or repeatedly on a schedule.
+	ScheduleActions(params.ActionScheduleArgs) (params.ActionScheduleResults, error)
+
+	// ListSchedules returns the scheduled actions of the model.
+	ListSchedules() ([]params.ActionSchedule, error)
+
+	// RemoveSchedules removes the scheduled actions with the given ids.
+	RemoveSchedules(ids []string) (params.ErrorResults, error)
+
+	OutputWatcher
 }
 
 // ActionCommandBase is the base type for action sub-commands.
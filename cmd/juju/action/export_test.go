@@ -13,8 +13,10 @@ import (
 )
 
 var (
-	NewActionAPIClient = &newAPIClient
-	AddValueToMap      = addValueToMap
+	NewActionAPIClient  = &newAPIClient
+	NewStatusAPIClient  = &newStatusAPIClient
+	RolloutPollInterval = &rolloutPollInterval
+	AddValueToMap       = addValueToMap
 )
 
 type ShowOutputCommand struct {
@@ -37,6 +39,14 @@ func (c *RunCommand) UnitTag() names.UnitTag {
 	return c.unitTag
 }
 
+func (c *RunCommand) ApplicationName() string {
+	return c.applicationName
+}
+
+func (c *RunCommand) Leader() bool {
+	return c.leader
+}
+
 func (c *RunCommand) ActionName() string {
 	return c.actionName
 }
@@ -98,3 +108,21 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
+
+func NewScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &scheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel)
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel)
+}
@@ -14,6 +14,7 @@ import (
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
@@ -127,6 +128,13 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	scheduleArgs       params.ActionScheduleArgs
+	scheduleResults    []params.ActionScheduleResult
+	schedules          []params.ActionSchedule
+	removedSchedules   []string
+	errorResults       []params.ErrorResult
+	outputMessages     []params.ActionOutputMessage
+	pendingLog         []params.ActionMessage
 	apiErr             error
 }
 
@@ -196,6 +204,7 @@ func (c *fakeAPIClient) Actions(args params.Entities) (params.ActionResults, err
 		return params.ActionResults{Results: []params.ActionResult{{
 			Status:   params.ActionPending,
 			Output:   map[string]interface{}{},
+			Log:      c.pendingLog,
 			Started:  time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
 			Enqueued: time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
 		}}}, nil
@@ -209,3 +218,26 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) ScheduleActions(args params.ActionScheduleArgs) (params.ActionScheduleResults, error) {
+	c.scheduleArgs = args
+	return params.ActionScheduleResults{Results: c.scheduleResults}, c.apiErr
+}
+
+func (c *fakeAPIClient) ListSchedules() ([]params.ActionSchedule, error) {
+	return c.schedules, c.apiErr
+}
+
+func (c *fakeAPIClient) WatchActionOutput(tags []names.ActionTag) (<-chan params.ActionOutputMessage, error) {
+	messages := make(chan params.ActionOutputMessage, len(c.outputMessages))
+	for _, msg := range c.outputMessages {
+		messages <- msg
+	}
+	close(messages)
+	return messages, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveSchedules(ids []string) (params.ErrorResults, error) {
+	c.removedSchedules = ids
+	return params.ErrorResults{Results: c.errorResults}, c.apiErr
+}
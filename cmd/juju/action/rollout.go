@@ -0,0 +1,302 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// leaderSuffix is appended to an application name to target the
+// application's leader unit.
+const leaderSuffix = "/leader"
+
+// rolloutPollInterval is how often the results of actions running on
+// the units of an application are checked.
+var rolloutPollInterval = 2 * time.Second
+
+// StatusAPI is used to find the units of an application.
+type StatusAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	Close() error
+}
+
+var newStatusAPIClient = func(c *ActionCommandBase) (StatusAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return root.Client(), nil
+}
+
+// validateRolloutFlags checks the flags controlling how an action is
+// rolled out to the units of an application.
+func (c *runCommand) validateRolloutFlags() error {
+	switch {
+	case c.maxParallel < 0:
+		return errors.New("--max-parallel must be positive")
+	case c.batchSize < 0:
+		return errors.New("--batch-size must be positive")
+	case c.maxParallel > 0 && c.batchSize > 0:
+		return errors.New("only one of --max-parallel or --batch-size can be specified")
+	}
+	waves := c.maxParallel > 0 || c.batchSize > 0
+	if waves && (c.applicationName == "" || c.leader) {
+		return errors.New("--max-parallel and --batch-size can only be used with an application")
+	}
+	if c.failFast && !waves {
+		return errors.New("--fail-fast requires --max-parallel or --batch-size")
+	}
+	return nil
+}
+
+// applicationUnits returns the units of the application the action
+// targets, ordered by name, or just its leader unit if the leader is
+// targeted.
+func (c *runCommand) applicationUnits() ([]names.UnitTag, error) {
+	api, err := newStatusAPIClient(&c.ActionCommandBase)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer api.Close()
+
+	status, err := api.Status([]string{c.applicationName})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	application, ok := status.Applications[c.applicationName]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", c.applicationName)
+	}
+	var unitNames []string
+	for name, unit := range application.Units {
+		if c.leader && !unit.Leader {
+			continue
+		}
+		unitNames = append(unitNames, name)
+	}
+	if len(unitNames) == 0 {
+		if c.leader {
+			return nil, errors.Errorf("application %q has no leader", c.applicationName)
+		}
+		return nil, errors.Errorf("application %q has no units", c.applicationName)
+	}
+	utils.SortStringsNaturally(unitNames)
+	units := make([]names.UnitTag, len(unitNames))
+	for i, name := range unitNames {
+		units[i] = names.NewUnitTag(name)
+	}
+	return units, nil
+}
+
+// unitAction records the action queued on a unit.
+type unitAction struct {
+	unit   names.UnitTag
+	tag    names.ActionTag
+	result params.ActionResult
+}
+
+func (a *unitAction) failed() bool {
+	return a.result.Status != params.ActionCompleted
+}
+
+// runOnUnits queues the action on the given units. Unless the action is
+// rolled out in waves or --wait is given, the action IDs are written
+// without waiting for the results.
+func (c *runCommand) runOnUnits(ctx *cmd.Context, api APIClient, units []names.UnitTag, actionParams map[string]interface{}) error {
+	waves := c.maxParallel > 0 || c.batchSize > 0
+	if !waves && !c.wait.forever && c.wait.d <= 0 {
+		queued, err := c.enqueue(api, units, actionParams)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		output := make(map[string]interface{})
+		for _, action := range queued {
+			output[action.unit.Id()] = map[string]string{"id": action.tag.Id()}
+		}
+		return c.out.Write(ctx, output)
+	}
+
+	maxParallel := c.maxParallel
+	if !waves {
+		maxParallel = len(units)
+	}
+	var timeout <-chan time.Time
+	if c.wait.d > 0 {
+		timeout = time.After(c.wait.d)
+	}
+
+	var (
+		pending  = units
+		running  []*unitAction
+		finished []*unitAction
+		failed   []string
+		timedOut bool
+	)
+	for (len(pending) > 0 || len(running) > 0) && !timedOut {
+		// Start the action on as many units as the flags allow.
+		start := 0
+		switch {
+		case len(failed) > 0 && c.failFast:
+		case c.batchSize > 0:
+			if len(running) == 0 {
+				start = c.batchSize
+			}
+		default:
+			start = maxParallel - len(running)
+		}
+		if start > len(pending) {
+			start = len(pending)
+		}
+		if start > 0 {
+			queued, err := c.enqueue(api, pending[:start], actionParams)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			for _, action := range queued {
+				ctx.Infof("%s: queued action %s", action.unit.Id(), action.tag.Id())
+			}
+			pending = pending[start:]
+			running = append(running, queued...)
+		}
+		if len(running) == 0 {
+			// Nothing is running and nothing more may start.
+			break
+		}
+
+		// Check the results of the running actions, and wait for a
+		// while if none of them has finished.
+		stillRunning, err := pollUnitActions(api, running)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, action := range running {
+			switch action.result.Status {
+			case params.ActionPending, params.ActionRunning:
+				continue
+			}
+			finished = append(finished, action)
+			ctx.Infof("%s: action %s %s", action.unit.Id(), action.tag.Id(), action.result.Status)
+			if action.failed() {
+				failed = append(failed, action.unit.Id())
+			}
+		}
+		if len(stillRunning) == len(running) {
+			select {
+			case <-time.After(rolloutPollInterval):
+			case <-timeout:
+				timedOut = true
+			}
+		}
+		running = stillRunning
+	}
+
+	output := make(map[string]interface{})
+	for _, action := range append(finished, running...) {
+		result := FormatActionResult(action.result)
+		result["id"] = action.tag.Id()
+		output[action.unit.Id()] = result
+	}
+	if err := c.out.Write(ctx, output); err != nil {
+		return errors.Trace(err)
+	}
+
+	var problems []string
+	if len(failed) > 0 {
+		utils.SortStringsNaturally(failed)
+		problems = append(problems, fmt.Sprintf("failed on %s", strings.Join(failed, ", ")))
+	}
+	if len(running) > 0 {
+		problems = append(problems, fmt.Sprintf("timed out waiting for %s", unitIds(running)))
+	}
+	if len(pending) > 0 {
+		var notRun []string
+		for _, unit := range pending {
+			notRun = append(notRun, unit.Id())
+		}
+		problems = append(problems, fmt.Sprintf("not run on %s", strings.Join(notRun, ", ")))
+	}
+	if len(problems) > 0 {
+		return errors.Errorf("action %q %s", c.actionName, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// enqueue queues the action on the given units.
+func (c *runCommand) enqueue(api APIClient, units []names.UnitTag, actionParams map[string]interface{}) ([]*unitAction, error) {
+	args := params.Actions{Actions: make([]params.Action, len(units))}
+	for i, unit := range units {
+		args.Actions[i] = params.Action{
+			Receiver:   unit.String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+		}
+	}
+	results, err := api.Enqueue(args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(units) {
+		return nil, errors.New("illegal number of results returned")
+	}
+	queued := make([]*unitAction, len(units))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "cannot queue action on %s", units[i].Id())
+		}
+		if result.Action == nil {
+			return nil, errors.Errorf("action failed to enqueue on %s", units[i].Id())
+		}
+		tag, err := names.ParseActionTag(result.Action.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		queued[i] = &unitAction{unit: units[i], tag: tag, result: result}
+	}
+	return queued, nil
+}
+
+// pollUnitActions updates the results of the given actions, and returns
+// the actions that have not yet finished.
+func pollUnitActions(api APIClient, actions []*unitAction) ([]*unitAction, error) {
+	entities := params.Entities{Entities: make([]params.Entity, len(actions))}
+	for i, action := range actions {
+		entities.Entities[i].Tag = action.tag.String()
+	}
+	results, err := api.Actions(entities)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(actions) {
+		return nil, errors.Errorf("expected %d results, got %d", len(actions), len(results.Results))
+	}
+	var running []*unitAction
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Annotatef(result.Error, "cannot get result of action %s", actions[i].tag.Id())
+		}
+		actions[i].result = result
+		switch result.Status {
+		case params.ActionPending, params.ActionRunning:
+			running = append(running, actions[i])
+		}
+	}
+	return running, nil
+}
+
+func unitIds(actions []*unitAction) string {
+	ids := make([]string, len(actions))
+	for i, action := range actions {
+		ids[i] = action.unit.Id()
+	}
+	return strings.Join(ids, ", ")
+}
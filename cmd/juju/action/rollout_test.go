@@ -0,0 +1,294 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type RolloutSuite struct {
+	BaseActionSuite
+	client *rolloutAPIClient
+	status *fakeStatusAPI
+}
+
+var _ = gc.Suite(&RolloutSuite{})
+
+func (s *RolloutSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.client = &rolloutAPIClient{
+		fakeAPIClient: &fakeAPIClient{},
+		polls:         make(map[string]int),
+		runFor:        1,
+	}
+	s.status = &fakeStatusAPI{
+		status: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Units: map[string]params.UnitStatus{
+						"mysql/0":  {},
+						"mysql/1":  {Leader: true},
+						"mysql/2":  {},
+						"mysql/10": {},
+					},
+				},
+			},
+		},
+	}
+	s.PatchValue(action.NewActionAPIClient,
+		func(c *action.ActionCommandBase) (action.APIClient, error) {
+			return s.client, nil
+		},
+	)
+	s.PatchValue(action.NewStatusAPIClient,
+		func(c *action.ActionCommandBase) (action.StatusAPI, error) {
+			return s.status, nil
+		},
+	)
+	s.PatchValue(action.RolloutPollInterval, time.Millisecond)
+}
+
+func (s *RolloutSuite) runAction(c *gc.C, args ...string) (*cmd.Context, error) {
+	command, _ := action.NewRunCommandForTest(s.store)
+	return cmdtesting.RunCommand(c, command, append([]string{"-m", "admin"}, args...)...)
+}
+
+func (s *RolloutSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		application string
+		leader      bool
+		err         string
+	}{{
+		args:        []string{"mysql", "backup"},
+		application: "mysql",
+	}, {
+		args:        []string{"mysql/leader", "backup"},
+		application: "mysql",
+		leader:      true,
+	}, {
+		args:        []string{"mysql", "backup", "--max-parallel", "2", "--fail-fast"},
+		application: "mysql",
+	}, {
+		args: []string{"mysql", "backup", "--max-parallel", "2", "--batch-size", "2"},
+		err:  "only one of --max-parallel or --batch-size can be specified",
+	}, {
+		args: []string{"mysql", "backup", "--max-parallel", "-1"},
+		err:  "--max-parallel must be positive",
+	}, {
+		args: []string{"mysql/0", "backup", "--batch-size", "2"},
+		err:  "--max-parallel and --batch-size can only be used with an application",
+	}, {
+		args: []string{"mysql/leader", "backup", "--max-parallel", "2"},
+		err:  "--max-parallel and --batch-size can only be used with an application",
+	}, {
+		args: []string{"mysql", "backup", "--fail-fast"},
+		err:  "--fail-fast requires --max-parallel or --batch-size",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		wrapped, command := action.NewRunCommandForTest(s.store)
+		err := cmdtesting.InitCommand(wrapped, append([]string{"-m", "admin"}, test.args...))
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(command.ApplicationName(), gc.Equals, test.application)
+		c.Check(command.Leader(), gc.Equals, test.leader)
+	}
+}
+
+func (s *RolloutSuite) TestRunOnApplication(c *gc.C) {
+	ctx, err := s.runAction(c, "mysql", "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.client.waves, jc.DeepEquals, [][]string{{"mysql/0", "mysql/1", "mysql/2", "mysql/10"}})
+
+	var out map[string]map[string]string
+	err = yaml.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, jc.DeepEquals, map[string]map[string]string{
+		"mysql/0":  {"id": s.client.ids["mysql/0"]},
+		"mysql/1":  {"id": s.client.ids["mysql/1"]},
+		"mysql/2":  {"id": s.client.ids["mysql/2"]},
+		"mysql/10": {"id": s.client.ids["mysql/10"]},
+	})
+	c.Check(s.client.polls, gc.HasLen, 0)
+}
+
+func (s *RolloutSuite) TestRunOnLeader(c *gc.C) {
+	ctx, err := s.runAction(c, "mysql/leader", "backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.client.waves, jc.DeepEquals, [][]string{{"mysql/1"}})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals,
+		fmt.Sprintf("Action queued with id: %s\n", s.client.ids["mysql/1"]))
+}
+
+func (s *RolloutSuite) TestRunOnApplicationNoLeader(c *gc.C) {
+	unit := s.status.status.Applications["mysql"].Units["mysql/1"]
+	unit.Leader = false
+	s.status.status.Applications["mysql"].Units["mysql/1"] = unit
+	_, err := s.runAction(c, "mysql/leader", "backup")
+	c.Assert(err, gc.ErrorMatches, `application "mysql" has no leader`)
+}
+
+func (s *RolloutSuite) TestRunOnMissingApplication(c *gc.C) {
+	_, err := s.runAction(c, "wordpress", "backup")
+	c.Assert(err, gc.ErrorMatches, `application "wordpress" not found`)
+}
+
+func (s *RolloutSuite) TestRunOnApplicationWait(c *gc.C) {
+	ctx, err := s.runAction(c, "mysql", "backup", "--wait")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.client.waves, jc.DeepEquals, [][]string{{"mysql/0", "mysql/1", "mysql/2", "mysql/10"}})
+
+	var out map[string]map[string]interface{}
+	err = yaml.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.HasLen, 4)
+	c.Check(out["mysql/2"]["status"], gc.Equals, "completed")
+	c.Check(out["mysql/2"]["id"], gc.Equals, s.client.ids["mysql/2"])
+}
+
+func (s *RolloutSuite) TestMaxParallel(c *gc.C) {
+	s.client.runFor = 2
+	_, err := s.runAction(c, "mysql", "backup", "--max-parallel", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.client.waves, jc.DeepEquals, [][]string{
+		{"mysql/0", "mysql/1"},
+		{"mysql/2", "mysql/10"},
+	})
+}
+
+func (s *RolloutSuite) TestMaxParallelRefillsAsUnitsFinish(c *gc.C) {
+	s.client.runForUnit = map[string]int{"mysql/0": 1, "mysql/1": 3}
+	_, err := s.runAction(c, "mysql", "backup", "--max-parallel", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.client.waves, jc.DeepEquals, [][]string{
+		{"mysql/0", "mysql/1"},
+		{"mysql/2"},
+		{"mysql/10"},
+	})
+}
+
+func (s *RolloutSuite) TestBatchSize(c *gc.C) {
+	s.client.runForUnit = map[string]int{"mysql/0": 1, "mysql/1": 1, "mysql/2": 3}
+	_, err := s.runAction(c, "mysql", "backup", "--batch-size", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.client.waves, jc.DeepEquals, [][]string{
+		{"mysql/0", "mysql/1", "mysql/2"},
+		{"mysql/10"},
+	})
+}
+
+func (s *RolloutSuite) TestFailureWithoutFailFast(c *gc.C) {
+	s.client.fail = map[string]bool{"mysql/0": true}
+	ctx, err := s.runAction(c, "mysql", "backup", "--batch-size", "1")
+	c.Assert(err, gc.ErrorMatches, `action "backup" failed on mysql/0`)
+	c.Check(s.client.waves, gc.HasLen, 4)
+
+	var out map[string]map[string]interface{}
+	err = yaml.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out["mysql/0"]["status"], gc.Equals, "failed")
+	c.Check(out["mysql/1"]["status"], gc.Equals, "completed")
+}
+
+func (s *RolloutSuite) TestFailFast(c *gc.C) {
+	s.client.fail = map[string]bool{"mysql/1": true}
+	ctx, err := s.runAction(c, "mysql", "backup", "--max-parallel", "2", "--fail-fast")
+	c.Assert(err, gc.ErrorMatches, `action "backup" failed on mysql/1; not run on mysql/2, mysql/10`)
+	c.Check(s.client.waves, jc.DeepEquals, [][]string{{"mysql/0", "mysql/1"}})
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "mysql/1: action "+s.client.ids["mysql/1"]+" failed\n")
+}
+
+// rolloutAPIClient is a fake APIClient that records the units actions
+// are queued on, and completes each action after it has been polled a
+// number of times.
+type rolloutAPIClient struct {
+	*fakeAPIClient
+	waves      [][]string
+	ids        map[string]string
+	polls      map[string]int
+	runFor     int
+	runForUnit map[string]int
+	fail       map[string]bool
+	units      map[string]string
+}
+
+func (c *rolloutAPIClient) Enqueue(args params.Actions) (params.ActionResults, error) {
+	if c.ids == nil {
+		c.ids = make(map[string]string)
+		c.units = make(map[string]string)
+	}
+	var wave []string
+	results := make([]params.ActionResult, len(args.Actions))
+	for i, a := range args.Actions {
+		unit, err := names.ParseUnitTag(a.Receiver)
+		if err != nil {
+			return params.ActionResults{}, err
+		}
+		id := fmt.Sprintf("f47ac10b-58cc-4372-a567-0e02b2c3d4%02d", len(c.ids))
+		c.ids[unit.Id()] = id
+		c.units[id] = unit.Id()
+		wave = append(wave, unit.Id())
+		results[i] = params.ActionResult{
+			Action: &params.Action{Tag: names.NewActionTag(id).String(), Receiver: a.Receiver, Name: a.Name},
+			Status: params.ActionPending,
+		}
+	}
+	c.waves = append(c.waves, wave)
+	return params.ActionResults{Results: results}, nil
+}
+
+func (c *rolloutAPIClient) Actions(args params.Entities) (params.ActionResults, error) {
+	results := make([]params.ActionResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		id := strings.TrimPrefix(entity.Tag, "action-")
+		unit := c.units[id]
+		c.polls[unit]++
+		runFor := c.runFor
+		if n, ok := c.runForUnit[unit]; ok {
+			runFor = n
+		}
+		status := params.ActionRunning
+		if c.polls[unit] >= runFor {
+			status = params.ActionCompleted
+			if c.fail[unit] {
+				status = params.ActionFailed
+			}
+		}
+		results[i] = params.ActionResult{
+			Action: &params.Action{Tag: entity.Tag},
+			Status: status,
+		}
+	}
+	return params.ActionResults{Results: results}, nil
+}
+
+type fakeStatusAPI struct {
+	jujutesting.Stub
+	status *params.FullStatus
+}
+
+func (f *fakeStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.MethodCall(f, "Status", patterns)
+	return f.status, f.NextErr()
+}
+
+func (f *fakeStatusAPI) Close() error {
+	return nil
+}
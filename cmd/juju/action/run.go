@@ -30,19 +30,34 @@ func NewRunCommand() cmd.Command {
 // params
 type runCommand struct {
 	ActionCommandBase
-	unitTag      names.UnitTag
-	actionName   string
-	paramsYAML   cmd.FileVar
-	parseStrings bool
-	wait         waitFlag
-	out          cmd.Output
-	args         [][]string
+	unitTag         names.UnitTag
+	applicationName string
+	leader          bool
+	actionName      string
+	paramsYAML      cmd.FileVar
+	parseStrings    bool
+	wait            waitFlag
+	stream          bool
+	maxParallel     int
+	batchSize       int
+	failFast        bool
+	out             cmd.Output
+	args            [][]string
 }
 
 const runDoc = `
 Queue an Action for execution on a given unit, with a given set of params.
 The Action ID is returned for use with 'juju show-action-output <ID>' or
 'juju show-action-status <ID>'.
+
+Instead of a unit, an application may be given to queue the Action on all of
+its units, or "<application>/leader" to queue it on the application's leader
+unit. By default the Action is queued on all the units at once. To roll it out
+in waves instead, use --max-parallel to limit how many units run the Action at
+any one time, or --batch-size to run it on a batch of units at a time, waiting
+for each batch to finish before starting the next. Rolling out in waves always
+waits for the results. With --fail-fast, no more units are started once the
+Action has failed on a unit.
  
 Params are validated according to the charm for the unit's application.  The 
 valid params can be seen using "juju actions <application> --schema".
@@ -116,6 +131,23 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju run-action mysql/leader backup --wait
+...
+
+$ juju run-action memcached flush --wait
+...
+The results are shown for every unit of memcached.
+
+$ juju run-action postgresql/0 migrate --wait --stream
+postgresql/0: applying migration 1 of 12
+...
+The output of the Action is shown as it is written, followed by its results.
+
+$ juju run-action postgresql restart --max-parallel 2 --fail-fast
+...
+The restart action runs on at most 2 units at a time, and no more units are
+restarted once it has failed on one.
 `
 
 // ActionNameRule describes the format an action name must match to be valid.
@@ -128,58 +160,113 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.BoolVar(&c.stream, "stream", false, "Show the output of the action as it is written while waiting")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "Maximum number of units to run the action on at once")
+	f.IntVar(&c.batchSize, "batch-size", 0, "Number of units to run the action on in each batch")
+	f.BoolVar(&c.failFast, "fail-fast", false, "Stop starting the action on units once it has failed on one")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> <action name> [key.key.key...=value]",
+		Args:    "<unit> | <application> | <application>/leader <action name> [key.key.key...=value]",
 		Purpose: "Queue an action for execution.",
 		Doc:     runDoc,
 	}
 }
 
-// Init gets the unit tag, and checks for other correct args.
+// Init gets the unit tag or application name, and checks for other
+// correct args.
 func (c *runCommand) Init(args []string) error {
 	switch len(args) {
 	case 0:
-		return errors.New("no unit specified")
+		return errors.New("no unit or application specified")
 	case 1:
 		return errors.New("no action specified")
 	default:
-		// Grab and verify the unit and action names.
-		unitName := args[0]
-		if !names.IsValidUnit(unitName) {
-			return errors.Errorf("invalid unit name %q", unitName)
+		// Grab and verify the target and action names.
+		target := args[0]
+		switch {
+		case names.IsValidUnit(target):
+			c.unitTag = names.NewUnitTag(target)
+		case strings.HasSuffix(target, leaderSuffix) &&
+			names.IsValidApplication(strings.TrimSuffix(target, leaderSuffix)):
+			c.applicationName = strings.TrimSuffix(target, leaderSuffix)
+			c.leader = true
+		case names.IsValidApplication(target):
+			c.applicationName = target
+		default:
+			return errors.Errorf("invalid unit or application name %q", target)
+		}
+		if err := c.validateRolloutFlags(); err != nil {
+			return errors.Trace(err)
+		}
+		if c.stream {
+			if !c.wait.forever && c.wait.d <= 0 {
+				return errors.New("--stream requires --wait")
+			}
+			if c.applicationName != "" && !c.leader {
+				return errors.New("--stream can only be used with a unit")
+			}
 		}
 		ActionName := args[1]
 		if valid := ActionNameRule.MatchString(ActionName); !valid {
 			return errors.Errorf("invalid action name %q", ActionName)
 		}
-		c.unitTag = names.NewUnitTag(unitName)
 		c.actionName = ActionName
 		if len(args) == 2 {
 			return nil
 		}
 		// Parse CLI key-value args if they exist.
-		c.args = make([][]string, 0)
-		for _, arg := range args[2:] {
-			thisArg := strings.SplitN(arg, "=", 2)
-			if len(thisArg) != 2 {
-				return errors.Errorf("argument %q must be of the form key...=value", arg)
+		var err error
+		c.args, err = parseKeyValueArgs(args[2:])
+		return err
+	}
+}
+
+// parseKeyValueArgs parses action arguments of the form
+// key.key.key...=value into slices of the form [key, key, key, value].
+func parseKeyValueArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0)
+	for _, arg := range args {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return nil, errors.Errorf("argument %q must be of the form key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		// check each key for validity
+		for _, key := range keySlice {
+			if valid := keyRule.MatchString(key); !valid {
+				return nil, errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
 			}
-			keySlice := strings.Split(thisArg[0], ".")
-			// check each key for validity
-			for _, key := range keySlice {
-				if valid := keyRule.MatchString(key); !valid {
-					return errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
-				}
+		}
+		// result={..., [key, key, key, key, value]}
+		result = append(result, append(keySlice, thisArg[1]))
+	}
+	return result, nil
+}
+
+// addKeyValueArgs inserts the values of arguments parsed by
+// parseKeyValueArgs into the given action parameters. Values are
+// parsed as YAML unless parseStrings is true.
+func addKeyValueArgs(args [][]string, parseStrings bool, actionParams map[string]interface{}) error {
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return err
 			}
-			// c.args={..., [key, key, key, key, value]}
-			c.args = append(c.args, append(keySlice, thisArg[1]))
 		}
-		return nil
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, actionParams)
 	}
+	return nil
 }
 
 func (c *runCommand) Run(ctx *cmd.Context) error {
@@ -215,21 +302,8 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		actionParams = betterParams
 	}
 
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return err
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
+	if err := addKeyValueArgs(c.args, c.parseStrings, actionParams); err != nil {
+		return err
 	}
 
 	conformantParams, err := common.ConformYAML(actionParams)
@@ -242,6 +316,17 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.applicationName != "" {
+		units, err := c.applicationUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !c.leader {
+			return c.runOnUnits(ctx, api, units, actionParams)
+		}
+		c.unitTag = units[0]
+	}
+
 	actionParam := params.Actions{
 		Actions: []params.Action{{
 			Receiver:   c.unitTag.String(),
@@ -290,6 +375,16 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(c.wait.d)
 	}
 
+	if c.stream {
+		_, timedOut, err := NewOutputWriter(ctx).Stream(api, []names.ActionTag{tag}, wait.C)
+		if err != nil {
+			// Wait for the results without showing the output.
+			logger.Warningf("%v", err)
+		} else if timedOut {
+			// Fetch the result as it is now.
+			wait = time.NewTimer(0)
+		}
+	}
 	result, err = GetActionResult(api, tag.Id(), wait)
 	if err != nil {
 		return errors.Trace(err)
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd/cmdtesting"
@@ -19,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
+	coretesting "github.com/juju/juju/testing"
 )
 
 var (
@@ -69,7 +71,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}{{
 		should:      "fail with missing args",
 		args:        []string{},
-		expectError: "no unit specified",
+		expectError: "no unit or application specified",
 	}, {
 		should:      "fail with no action specified",
 		args:        []string{validUnitId},
@@ -77,7 +79,7 @@ func (s *RunSuite) TestInit(c *gc.C) {
 	}, {
 		should:      "fail with invalid unit tag",
 		args:        []string{invalidUnitId, "valid-action-name"},
-		expectError: "invalid unit name \"something-strange-\"",
+		expectError: "invalid unit or application name \"something-strange-\"",
 	}, {
 		should:      "fail with invalid action name",
 		args:        []string{validUnitId, "BadName"},
@@ -142,6 +144,14 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			{"foo", "baz", "bo", "3"},
 			{"bar", "foo", "hello"},
 		},
+	}, {
+		should:      "fail with --stream and no --wait",
+		args:        []string{validUnitId, "valid-action-name", "--stream"},
+		expectError: "--stream requires --wait",
+	}, {
+		should:      "fail with --stream and an application",
+		args:        []string{"mysql", "valid-action-name", "--wait", "--stream"},
+		expectError: "--stream can only be used with a unit",
 	}, {
 		should: "handle key-value args with no --params",
 		args: []string{
@@ -399,3 +409,48 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestRunStream(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		delay:   time.NewTimer(0),
+		timeout: time.NewTimer(coretesting.LongWait),
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+			Status: params.ActionCompleted,
+			Output: map[string]interface{}{},
+		}},
+		outputMessages: []params.ActionOutputMessage{{
+			ActionTag: validActionTagString,
+			Receiver:  names.NewUnitTag(validUnitId).String(),
+			Stream:    "stdout",
+			Data:      "migrating\n",
+		}, {
+			ActionTag: validActionTagString,
+			Receiver:  names.NewUnitTag(validUnitId).String(),
+			Stream:    "stderr",
+			Data:      "slow ",
+		}, {
+			ActionTag: validActionTagString,
+			Receiver:  names.NewUnitTag(validUnitId).String(),
+			Stream:    "stderr",
+			Data:      "table\n",
+		}, {
+			ActionTag: validActionTagString,
+			Receiver:  names.NewUnitTag(validUnitId).String(),
+			Status:    params.ActionCompleted,
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand, "-m", "admin", validUnitId, "migrate", "--wait", "--stream")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "mysql/0: slow table\n")
+	stdout := cmdtesting.Stdout(ctx)
+	c.Check(strings.HasPrefix(stdout, "mysql/0: migrating\n"), jc.IsTrue, gc.Commentf("%s", stdout))
+	c.Check(stdout, jc.Contains, "status: completed")
+}
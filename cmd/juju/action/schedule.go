@@ -0,0 +1,380 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/actions"
+)
+
+func NewScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&scheduleCommand{})
+}
+
+// scheduleCommand schedules an action to be enqueued on the given
+// applications and units at a given time, or repeatedly.
+type scheduleCommand struct {
+	ActionCommandBase
+	receivers    []names.Tag
+	actionName   string
+	schedule     string
+	at           string
+	atTime       time.Time
+	parseStrings bool
+	out          cmd.Output
+	args         [][]string
+}
+
+const scheduleDoc = `
+Schedule an action to be queued on the given applications and units, either
+once at a given time or repeatedly on a schedule. When an action scheduled on
+an application runs, it is queued on every unit of the application at that
+time. The queued actions can be followed with 'juju show-action-status'.
+
+Exactly one of --schedule and --at must be given. A schedule is either a cron
+expression with five fields (minute, hour, day of month, month and day of
+week), "@every <duration>" with a duration of at least a minute, or one of
+"@hourly", "@daily", "@weekly", "@monthly" and "@yearly". All times are UTC.
+A time given with --at must be in RFC3339 format.
+
+Params are given and validated as for 'juju run-action'.
+
+Examples:
+
+    juju schedule-action mysql backup --schedule "0 2 * * *"
+    juju schedule-action mysql/0,mysql/1 backup out=nightly.tar.bz2 --schedule @daily
+    juju schedule-action postgresql snapshot --schedule "@every 6h"
+    juju schedule-action mysql/0 backup --at 2017-12-24T23:00:00Z
+
+See also:
+    list-schedules
+    remove-schedule
+    run-action
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *scheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.StringVar(&c.schedule, "schedule", "", "Schedule on which to queue the action repeatedly")
+	f.StringVar(&c.at, "at", "", "Time at which to queue the action once, in RFC3339 format")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+// Info is part of the cmd.Command interface.
+func (c *scheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "schedule-action",
+		Args:    "<application or unit>[,...] <action name> [key.key.key...=value]",
+		Purpose: "Schedule an action to be queued at a given time or repeatedly.",
+		Doc:     scheduleDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *scheduleCommand) Init(args []string) error {
+	switch {
+	case c.schedule == "" && c.at == "":
+		return errors.New("one of --schedule or --at must be specified")
+	case c.schedule != "" && c.at != "":
+		return errors.New("only one of --schedule or --at can be specified")
+	case c.schedule != "":
+		if _, err := actions.ParseSchedule(c.schedule); err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		at, err := time.Parse(time.RFC3339, c.at)
+		if err != nil {
+			return errors.Errorf("invalid time %q, expected RFC3339 format", c.at)
+		}
+		c.atTime = at
+	}
+	switch len(args) {
+	case 0:
+		return errors.New("no application or unit specified")
+	case 1:
+		return errors.New("no action specified")
+	}
+	for _, receiver := range strings.Split(args[0], ",") {
+		switch {
+		case names.IsValidUnit(receiver):
+			c.receivers = append(c.receivers, names.NewUnitTag(receiver))
+		case names.IsValidApplication(receiver):
+			c.receivers = append(c.receivers, names.NewApplicationTag(receiver))
+		default:
+			return errors.Errorf("invalid application or unit name %q", receiver)
+		}
+	}
+	c.actionName = args[1]
+	if !ActionNameRule.MatchString(c.actionName) {
+		return errors.Errorf("invalid action name %q", c.actionName)
+	}
+	var err error
+	c.args, err = parseKeyValueArgs(args[2:])
+	return err
+}
+
+// Run is part of the cmd.Command interface.
+func (c *scheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams := map[string]interface{}{}
+	if err := addKeyValueArgs(c.args, c.parseStrings, actionParams); err != nil {
+		return err
+	}
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return err
+	}
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("params must be a map, got %T", conformantParams)
+	}
+
+	arg := params.ActionScheduleArg{
+		Name:       c.actionName,
+		Parameters: typedConformantParams,
+		Schedule:   c.schedule,
+	}
+	for _, receiver := range c.receivers {
+		arg.Receivers = append(arg.Receivers, receiver.String())
+	}
+	if !c.atTime.IsZero() {
+		arg.At = &c.atTime
+	}
+	results, err := api.ScheduleActions(params.ActionScheduleArgs{
+		Schedules: []params.ActionScheduleArg{arg},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.Result == nil {
+		return errors.New("action failed to schedule")
+	}
+	return c.out.Write(ctx, map[string]string{
+		"id":       result.Result.Id,
+		"next-run": formatScheduleTime(result.Result.NextRun),
+	})
+}
+
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+// listSchedulesCommand lists the scheduled actions of a model.
+type listSchedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const listSchedulesDoc = `
+List the actions scheduled in the model with 'juju schedule-action', in the
+order they are next due to be queued. All times are UTC.
+
+See also:
+    schedule-action
+    remove-schedule
+`
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": printSchedulesTabular,
+	})
+}
+
+// Info is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "list-schedules",
+		Purpose: "List scheduled actions.",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"schedules"},
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// scheduleOutput is the serialisation format of a scheduled action.
+type scheduleOutput struct {
+	Id         string                 `yaml:"id" json:"id"`
+	Action     string                 `yaml:"action" json:"action"`
+	Receivers  []string               `yaml:"receivers" json:"receivers"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Schedule   string                 `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+	NextRun    string                 `yaml:"next-run" json:"next-run"`
+	LastRun    string                 `yaml:"last-run,omitempty" json:"last-run,omitempty"`
+	Owner      string                 `yaml:"owner,omitempty" json:"owner,omitempty"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	schedules, err := api.ListSchedules()
+	if err != nil {
+		return err
+	}
+	if len(schedules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No scheduled actions.")
+		return nil
+	}
+	result := make([]scheduleOutput, len(schedules))
+	for i, schedule := range schedules {
+		out := scheduleOutput{
+			Id:         schedule.Id,
+			Action:     schedule.Name,
+			Parameters: schedule.Parameters,
+			Schedule:   schedule.Schedule,
+			NextRun:    formatScheduleTime(schedule.NextRun),
+		}
+		for _, receiver := range schedule.Receivers {
+			tag, err := names.ParseTag(receiver)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			out.Receivers = append(out.Receivers, tag.Id())
+		}
+		if schedule.LastRun != nil {
+			out.LastRun = formatScheduleTime(*schedule.LastRun)
+		}
+		if owner, err := names.ParseUserTag(schedule.Owner); err == nil {
+			out.Owner = owner.Id()
+		}
+		result[i] = out
+	}
+	return c.out.Write(ctx, result)
+}
+
+// printSchedulesTabular prints the scheduled actions in tabular format.
+func printSchedulesTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.([]scheduleOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "ID\tAction\tReceivers\tSchedule\tNext run\tLast run\tOwner")
+	for _, s := range schedules {
+		schedule := s.Schedule
+		if schedule == "" {
+			schedule = "once"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Id, s.Action, strings.Join(s.Receivers, ","), schedule, s.NextRun, s.LastRun, s.Owner,
+		)
+	}
+	return tw.Flush()
+}
+
+func formatScheduleTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+// removeScheduleCommand removes scheduled actions.
+type removeScheduleCommand struct {
+	ActionCommandBase
+	ids []string
+}
+
+const removeScheduleDoc = `
+Remove scheduled actions, given their IDs as shown by 'juju list-schedules'.
+Actions already queued by the schedules are not affected; use
+'juju cancel-action' to cancel them.
+
+Examples:
+
+    juju remove-schedule 3
+    juju remove-schedule 3 5
+
+See also:
+    schedule-action
+    list-schedules
+`
+
+// Info is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-schedule",
+		Args:    "<schedule ID> [<schedule ID>...]",
+		Purpose: "Remove scheduled actions.",
+		Doc:     removeScheduleDoc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule IDs specified")
+	}
+	c.ids = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.RemoveSchedules(c.ids)
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != len(c.ids) {
+		return errors.Errorf("expected %d results, got %d", len(c.ids), len(results.Results))
+	}
+	failed := false
+	for i, result := range results.Results {
+		if result.Error != nil {
+			ctx.Infof("cannot remove schedule %s: %v", c.ids[i], result.Error)
+			failed = true
+			continue
+		}
+		ctx.Verbosef("removed schedule %s", c.ids[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
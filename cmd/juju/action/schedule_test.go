@@ -0,0 +1,228 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) runSchedule(c *gc.C, client *fakeAPIClient, args ...string) (*cmd.Context, error) {
+	restore := s.patchAPIClient(client)
+	defer restore()
+	command := action.NewScheduleCommandForTest(s.store)
+	return cmdtesting.RunCommand(c, command, append([]string{"-m", "admin"}, args...)...)
+}
+
+func (s *ScheduleSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"mysql", "backup"},
+		err:  "one of --schedule or --at must be specified",
+	}, {
+		args: []string{"mysql", "backup", "--schedule", "@daily", "--at", "2017-12-24T23:00:00Z"},
+		err:  "only one of --schedule or --at can be specified",
+	}, {
+		args: []string{"mysql", "backup", "--schedule", "61 * * * *"},
+		err:  `schedule "61 \* \* \* \*" not valid: minute: value 61 out of range \[0-59\]`,
+	}, {
+		args: []string{"mysql", "backup", "--at", "tomorrow"},
+		err:  `invalid time "tomorrow", expected RFC3339 format`,
+	}, {
+		args: []string{"--schedule", "@daily"},
+		err:  "no application or unit specified",
+	}, {
+		args: []string{"mysql", "--schedule", "@daily"},
+		err:  "no action specified",
+	}, {
+		args: []string{"mysql,-foo", "backup", "--schedule", "@daily"},
+		err:  `invalid application or unit name "-foo"`,
+	}, {
+		args: []string{"mysql", "Backup", "--schedule", "@daily"},
+		err:  `invalid action name "Backup"`,
+	}, {
+		args: []string{"mysql", "backup", "out", "--schedule", "@daily"},
+		err:  `argument "out" must be of the form key...=value`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runSchedule(c, &fakeAPIClient{}, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ScheduleSuite) TestSchedule(c *gc.C) {
+	nextRun := time.Date(2017, 6, 2, 2, 0, 0, 0, time.UTC)
+	client := &fakeAPIClient{
+		scheduleResults: []params.ActionScheduleResult{{
+			Result: &params.ActionSchedule{Id: "3", NextRun: nextRun},
+		}},
+	}
+	ctx, err := s.runSchedule(c, client,
+		"mysql,wordpress/1", "backup", "out.kind=xz", "--schedule", "0 2 * * *",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	var out map[string]string
+	err = yaml.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, jc.DeepEquals, map[string]string{"id": "3", "next-run": "2017-06-02T02:00:00Z"})
+	c.Check(client.scheduleArgs, jc.DeepEquals, params.ActionScheduleArgs{
+		Schedules: []params.ActionScheduleArg{{
+			Receivers: []string{"application-mysql", "unit-wordpress-1"},
+			Name:      "backup",
+			Parameters: map[string]interface{}{
+				"out": map[string]interface{}{"kind": "xz"},
+			},
+			Schedule: "0 2 * * *",
+		}},
+	})
+}
+
+func (s *ScheduleSuite) TestScheduleAt(c *gc.C) {
+	at := time.Date(2017, 12, 24, 23, 0, 0, 0, time.UTC)
+	client := &fakeAPIClient{
+		scheduleResults: []params.ActionScheduleResult{{
+			Result: &params.ActionSchedule{Id: "0", NextRun: at},
+		}},
+	}
+	_, err := s.runSchedule(c, client, "mysql/0", "backup", "--at", "2017-12-24T23:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.scheduleArgs.Schedules, gc.HasLen, 1)
+	arg := client.scheduleArgs.Schedules[0]
+	c.Check(arg.Schedule, gc.Equals, "")
+	c.Assert(arg.At, gc.NotNil)
+	c.Check(arg.At.Equal(at), jc.IsTrue)
+}
+
+func (s *ScheduleSuite) TestScheduleError(c *gc.C) {
+	client := &fakeAPIClient{
+		scheduleResults: []params.ActionScheduleResult{{
+			Error: &params.Error{Message: `action "backup" not defined on application mysql`},
+		}},
+	}
+	_, err := s.runSchedule(c, client, "mysql", "backup", "--schedule", "@daily")
+	c.Assert(err, gc.ErrorMatches, `action "backup" not defined on application mysql`)
+}
+
+func (s *ScheduleSuite) runListSchedules(c *gc.C, client *fakeAPIClient, args ...string) (*cmd.Context, error) {
+	restore := s.patchAPIClient(client)
+	defer restore()
+	command := action.NewListSchedulesCommandForTest(s.store)
+	return cmdtesting.RunCommand(c, command, append([]string{"-m", "admin"}, args...)...)
+}
+
+func (s *ScheduleSuite) listClient() *fakeAPIClient {
+	lastRun := time.Date(2017, 6, 1, 2, 0, 0, 0, time.UTC)
+	return &fakeAPIClient{
+		schedules: []params.ActionSchedule{{
+			Id:        "0",
+			Name:      "backup",
+			Receivers: []string{"application-mysql"},
+			Schedule:  "0 2 * * *",
+			NextRun:   time.Date(2017, 6, 2, 2, 0, 0, 0, time.UTC),
+			LastRun:   &lastRun,
+			Owner:     "user-admin",
+		}, {
+			Id:         "1",
+			Name:       "snapshot",
+			Receivers:  []string{"unit-postgresql-0", "unit-postgresql-1"},
+			Parameters: map[string]interface{}{"outfile": "xmas.bz2"},
+			NextRun:    time.Date(2017, 12, 24, 23, 0, 0, 0, time.UTC),
+			Owner:      "user-bob",
+		}},
+	}
+}
+
+func (s *ScheduleSuite) TestListSchedulesTabular(c *gc.C) {
+	ctx, err := s.runListSchedules(c, s.listClient())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"ID  Action    Receivers                  Schedule   Next run              Last run              Owner\n"+
+		"0   backup    mysql                      0 2 * * *  2017-06-02T02:00:00Z  2017-06-01T02:00:00Z  admin\n"+
+		"1   snapshot  postgresql/0,postgresql/1  once       2017-12-24T23:00:00Z                        bob\n",
+	)
+}
+
+func (s *ScheduleSuite) TestListSchedulesYAML(c *gc.C) {
+	ctx, err := s.runListSchedules(c, s.listClient(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	var out []map[string]interface{}
+	err = yaml.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, jc.DeepEquals, []map[string]interface{}{{
+		"id":        "0",
+		"action":    "backup",
+		"receivers": []interface{}{"mysql"},
+		"schedule":  "0 2 * * *",
+		"next-run":  "2017-06-02T02:00:00Z",
+		"last-run":  "2017-06-01T02:00:00Z",
+		"owner":     "admin",
+	}, {
+		"id":         "1",
+		"action":     "snapshot",
+		"receivers":  []interface{}{"postgresql/0", "postgresql/1"},
+		"parameters": map[interface{}]interface{}{"outfile": "xmas.bz2"},
+		"next-run":   "2017-12-24T23:00:00Z",
+		"owner":      "bob",
+	}})
+}
+
+func (s *ScheduleSuite) TestListSchedulesEmpty(c *gc.C) {
+	ctx, err := s.runListSchedules(c, &fakeAPIClient{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No scheduled actions.\n")
+}
+
+func (s *ScheduleSuite) runRemoveSchedule(c *gc.C, client *fakeAPIClient, args ...string) (*cmd.Context, error) {
+	restore := s.patchAPIClient(client)
+	defer restore()
+	command := action.NewRemoveScheduleCommandForTest(s.store)
+	return cmdtesting.RunCommand(c, command, append([]string{"-m", "admin"}, args...)...)
+}
+
+func (s *ScheduleSuite) TestRemoveScheduleNoIds(c *gc.C) {
+	_, err := s.runRemoveSchedule(c, &fakeAPIClient{})
+	c.Assert(err, gc.ErrorMatches, "no schedule IDs specified")
+}
+
+func (s *ScheduleSuite) TestRemoveSchedule(c *gc.C) {
+	client := &fakeAPIClient{
+		errorResults: []params.ErrorResult{{}, {}},
+	}
+	_, err := s.runRemoveSchedule(c, client, "0", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.removedSchedules, jc.DeepEquals, []string{"0", "3"})
+}
+
+func (s *ScheduleSuite) TestRemoveScheduleFailure(c *gc.C) {
+	client := &fakeAPIClient{
+		errorResults: []params.ErrorResult{{}, {Error: &params.Error{Message: `action schedule "3" not found`}}},
+	}
+	ctx, err := s.runRemoveSchedule(c, client, "0", "3")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "cannot remove schedule 3: action schedule \"3\" not found\n")
+}
+
+func (s *ScheduleSuite) TestRemoveScheduleAPIError(c *gc.C) {
+	client := &fakeAPIClient{apiErr: errors.New("boom")}
+	_, err := s.runRemoveSchedule(c, client, "0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -4,6 +4,7 @@
 package action
 
 import (
+	"fmt"
 	"regexp"
 	"time"
 
@@ -27,6 +28,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
 const showOutputDoc = `
@@ -38,6 +40,11 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+To follow the progress messages logged by a running action with action-log,
+use the --watch flag.  Each message is shown as it is logged, and the results
+are shown once the action has finished.  --watch waits indefinitely unless
+--wait is also given.
 `
 
 // Set up the output.
@@ -45,6 +52,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.watch, "watch", false, "Show progress messages as they are logged")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -90,6 +98,9 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
+	if c.watch && waitDur < 0 {
+		waitDur = 0
+	}
 	wait := time.NewTimer(0 * time.Second)
 
 	switch {
@@ -104,12 +115,43 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
-	result, err := GetActionResult(api, c.requestedId, wait)
+	var update func(params.ActionResult)
+	if c.watch {
+		// Show each progress message once, as it is logged. Only
+		// the most recent messages are kept, so the new messages
+		// are those after the last one shown.
+		var last *params.ActionMessage
+		update = func(result params.ActionResult) {
+			start := 0
+			if last != nil {
+				for i := len(result.Log) - 1; i >= 0; i-- {
+					msg := result.Log[i]
+					if msg.Timestamp.Equal(last.Timestamp) && msg.Message == last.Message {
+						start = i + 1
+						break
+					}
+				}
+			}
+			for _, msg := range result.Log[start:] {
+				fmt.Fprintln(ctx.Stdout, formatActionMessage(msg))
+			}
+			if n := len(result.Log); n > 0 {
+				last = &result.Log[n-1]
+			}
+		}
+	}
+	tick := time.NewTimer(2 * time.Second)
+	result, err := timerLoop(api, c.requestedId, wait, tick, update)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	return c.out.Write(ctx, FormatActionResult(result))
+	output := FormatActionResult(result)
+	if c.watch {
+		// The messages have already been shown.
+		delete(output, "log")
+	}
+	return c.out.Write(ctx, output)
 }
 
 // GetActionResult tries to repeatedly fetch an action until it is
@@ -121,13 +163,13 @@ func GetActionResult(api APIClient, requestedId string, wait *time.Timer) (param
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	tick := time.NewTimer(2 * time.Second)
 
-	return timerLoop(api, requestedId, wait, tick)
+	return timerLoop(api, requestedId, wait, tick, nil)
 }
 
 // timerLoop loops indefinitely to query the given API, until "wait" times
-// out, using the "tick" timer to delay the API queries.  It writes the
-// result to the given output.
-func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (params.ActionResult, error) {
+// out, using the "tick" timer to delay the API queries.  If update is not
+// nil, it is called with each result fetched.
+func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer, update func(params.ActionResult)) (params.ActionResult, error) {
 	var (
 		result params.ActionResult
 		err    error
@@ -140,6 +182,9 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 		if err != nil {
 			return result, err
 		}
+		if update != nil {
+			update(result)
+		}
 
 		// Whether or not we're waiting for a result, if a completed
 		// result arrives, we're done.
@@ -204,6 +249,9 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		response["log"] = formatActionLog(result.Log)
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
@@ -223,3 +271,19 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 
 	return response
 }
+
+// formatActionMessage formats a progress message logged by an action
+// for display.
+func formatActionMessage(m params.ActionMessage) string {
+	return fmt.Sprintf("%s %s", m.Timestamp.UTC().Format("2006-01-02 15:04:05"), m.Message)
+}
+
+// formatActionLog formats the progress messages logged by an action for
+// display.
+func formatActionLog(messages []params.ActionMessage) []string {
+	log := make([]string, len(messages))
+	for i, m := range messages {
+		log[i] = formatActionMessage(m)
+	}
+	return log
+}
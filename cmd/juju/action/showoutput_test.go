@@ -167,6 +167,30 @@ timing:
   completed: 2015-02-14 08:15:30 +0000 UTC
   enqueued: 2015-02-14 08:13:00 +0000 UTC
   started: 2015-02-14 08:15:00 +0000 UTC
+`[1:],
+	}, {
+		should:            "pretty-print action output with progress messages",
+		withClientQueryID: validActionId,
+		withAPITimeout:    10 * time.Second,
+		withTags:          tagsForIdPrefix(validActionId, validActionTagString),
+		withAPIResponse: []params.ActionResult{{
+			Status: "running",
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+				Message:   "copying files",
+			}, {
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+				Message:   "compressing",
+			}},
+			Started: time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+		}},
+		expectedOutput: `
+log:
+- 2015-02-14 08:15:10 copying files
+- 2015-02-14 08:15:20 compressing
+status: running
+timing:
+  started: 2015-02-14 08:15:00 +0000 UTC
 `[1:],
 	}, {
 		should:            "pretty-print action output with no completed time",
@@ -290,6 +314,87 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestWatch(c *gc.C) {
+	copying := params.ActionMessage{
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+		Message:   "copying files",
+	}
+	compressing := params.ActionMessage{
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+		Message:   "compressing",
+	}
+	client := makeFakeClient(
+		1*time.Second,
+		10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status:    "completed",
+			Log:       []params.ActionMessage{copying, compressing},
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	client.pendingLog = []params.ActionMessage{copying}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch")
+	c.Assert(err, gc.IsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+2015-02-14 08:15:10 copying files
+2015-02-14 08:15:20 compressing
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+`[1:])
+}
+
+func (s *ShowOutputSuite) TestWatchDiscardedMessages(c *gc.C) {
+	copying := params.ActionMessage{
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+		Message:   "copying files",
+	}
+	compressing := params.ActionMessage{
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+		Message:   "compressing",
+	}
+	uploading := params.ActionMessage{
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 25, 0, time.UTC),
+		Message:   "uploading",
+	}
+	// By the time the action completes, the first message has been
+	// discarded.
+	client := makeFakeClient(
+		1*time.Second,
+		10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status:    "completed",
+			Log:       []params.ActionMessage{compressing, uploading},
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	client.pendingLog = []params.ActionMessage{copying, compressing}
+	restore := s.patchAPIClient(client)
+	defer restore()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch")
+	c.Assert(err, gc.IsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+2015-02-14 08:15:10 copying files
+2015-02-14 08:15:20 compressing
+2015-02-14 08:15:25 uploading
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+`[1:])
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
@@ -141,6 +141,9 @@ func resultToMap(result params.ActionResult) map[string]interface{} {
 
 	}
 	item["status"] = result.Status
+	if len(result.Log) != 0 {
+		item["log"] = formatActionLog(result.Log)
+	}
 
 	// result.Completed uses the zero-value to indicate not completed
 	if result.Completed.Equal(time.Time{}) {
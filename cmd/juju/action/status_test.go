@@ -92,6 +92,41 @@ func (s *StatusSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *StatusSuite) TestRunWithLog(c *gc.C) {
+	faketag := "action-deadbeef-0000-4000-8000-feedfacebeef"
+	fakeClient := makeFakeClient(
+		0*time.Second, // No API delay
+		5*time.Second, // 5 second test timeout
+		tagsForIdPrefix("deadbeef", faketag),
+		[]params.ActionResult{{
+			Status: "running",
+			Action: &params.Action{Tag: faketag, Name: "backup", Receiver: "unit-mysql-0"},
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+				Message:   "copying files",
+			}},
+		}},
+		params.ActionsByNames{},
+		"", // No API error
+	)
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	s.subcommand, _ = action.NewStatusCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, "-m", "admin", "deadbeef")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+actions:
+- action: backup
+  completed at: n/a
+  id: deadbeef-0000-4000-8000-feedfacebeef
+  log:
+  - 2015-02-14 08:15:10 copying files
+  status: running
+  unit: mysql/0
+`[1:])
+}
+
 func (s *StatusSuite) runTestCase(c *gc.C, tc statusTestCase) {
 	for _, modelFlag := range s.modelFlags {
 		fakeClient := makeFakeClient(
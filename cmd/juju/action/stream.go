@@ -0,0 +1,106 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// OutputWatcher streams the output of running actions.
+type OutputWatcher interface {
+	// WatchActionOutput streams the output of the actions with the
+	// given tags as it is written.
+	WatchActionOutput(tags []names.ActionTag) (<-chan params.ActionOutputMessage, error)
+}
+
+// OutputWriter writes the output of actions to a command's context,
+// prefixing each line with the name of the unit or machine running the
+// action. Standard error is written to the context's standard error.
+type OutputWriter struct {
+	ctx *cmd.Context
+
+	// midLine records the streams, keyed by receiver and stream
+	// name, whose output last ended part way through a line.
+	midLine map[string]bool
+}
+
+// NewOutputWriter returns an OutputWriter that writes to the given
+// context.
+func NewOutputWriter(ctx *cmd.Context) *OutputWriter {
+	return &OutputWriter{
+		ctx:     ctx,
+		midLine: make(map[string]bool),
+	}
+}
+
+// Write writes output written to the named stream, either "stdout" or
+// "stderr", by the action receiver with the given tag.
+func (w *OutputWriter) Write(receiver names.Tag, stream, data string) {
+	var out io.Writer = w.ctx.Stdout
+	if stream == "stderr" {
+		out = w.ctx.Stderr
+	}
+	key := receiver.String() + " " + stream
+	for data != "" {
+		line := data
+		if i := strings.Index(data, "\n"); i >= 0 {
+			line = data[:i+1]
+		}
+		data = data[len(line):]
+		if !w.midLine[key] {
+			fmt.Fprintf(out, "%s: ", ReceiverName(receiver))
+		}
+		fmt.Fprint(out, line)
+		w.midLine[key] = !strings.HasSuffix(line, "\n")
+	}
+}
+
+// Stream writes the output of the actions with the given tags as it is
+// written, until all of the actions have finished or the timeout fires.
+// It reports whether it timed out, and the receivers whose output was
+// written.
+func (w *OutputWriter) Stream(api OutputWatcher, tags []names.ActionTag, timeout <-chan time.Time) (map[names.Tag]bool, bool, error) {
+	messages, err := api.WatchActionOutput(tags)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	streamed := make(map[names.Tag]bool)
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				return streamed, false, nil
+			}
+			if msg.Data == "" {
+				continue
+			}
+			receiver, err := names.ActionReceiverFromTag(msg.Receiver)
+			if err != nil {
+				return nil, false, errors.Trace(err)
+			}
+			streamed[receiver] = true
+			w.Write(receiver, msg.Stream, msg.Data)
+		case <-timeout:
+			return streamed, true, nil
+		}
+	}
+}
+
+// ReceiverName returns the name used to identify an action receiver
+// in streamed output.
+func ReceiverName(receiver names.Tag) string {
+	if _, ok := receiver.(names.UnitTag); ok {
+		return receiver.Id()
+	}
+	return names.ReadableString(receiver)
+}
@@ -60,29 +60,8 @@ func deployBundle(
 	log deploymentLogger,
 	bundleStorage map[string]map[string]storage.Constraints,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
-	verifyConstraints := func(s string) error {
-		_, err := constraints.Parse(s)
-		return err
-	}
-	verifyStorage := func(s string) error {
-		_, err := storage.ParseConstraints(s)
-		return err
-	}
-	var verifyError error
-	if bundleFilePath == "" {
-		verifyError = data.Verify(verifyConstraints, verifyStorage)
-	} else {
-		verifyError = data.VerifyLocal(bundleFilePath, verifyConstraints, verifyStorage)
-	}
-	if verifyError != nil {
-		if verr, ok := verifyError.(*charm.VerificationError); ok {
-			errs := make([]string, len(verr.Errors))
-			for i, err := range verr.Errors {
-				errs[i] = err.Error()
-			}
-			return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
-		}
-		return nil, errors.Annotate(verifyError, "cannot deploy bundle")
+	if err := verifyBundle(data, bundleFilePath); err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	// Retrieve bundle changes.
@@ -167,6 +146,145 @@ func deployBundle(
 	return csMacs, nil
 }
 
+// verifyBundle checks that the given bundle data is valid. If bundleDir
+// is not empty, local charm paths are verified relative to it.
+func verifyBundle(data *charm.BundleData, bundleDir string) error {
+	verifyConstraints := func(s string) error {
+		_, err := constraints.Parse(s)
+		return err
+	}
+	verifyStorage := func(s string) error {
+		_, err := storage.ParseConstraints(s)
+		return err
+	}
+	var verifyError error
+	if bundleDir == "" {
+		verifyError = data.Verify(verifyConstraints, verifyStorage)
+	} else {
+		verifyError = data.VerifyLocal(bundleDir, verifyConstraints, verifyStorage)
+	}
+	if verifyError != nil {
+		if verr, ok := verifyError.(*charm.VerificationError); ok {
+			errs := make([]string, len(verr.Errors))
+			for i, err := range verr.Errors {
+				errs[i] = err.Error()
+			}
+			return errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+		}
+		return errors.Annotate(verifyError, "cannot deploy bundle")
+	}
+	return nil
+}
+
+// bundleChangesDescription returns a human readable description of each
+// of the changes required to deploy the given bundle data, in the order
+// in which they would be applied. The bundle data must already have been
+// verified.
+func bundleChangesDescription(data *charm.BundleData) ([]string, error) {
+	d := &changeDescriber{
+		names:    make(map[string]string),
+		machines: make(map[string]string),
+		units:    make(map[string]int),
+	}
+	changes := bundlechanges.FromData(data)
+	result := make([]string, len(changes))
+	for i, change := range changes {
+		description, err := d.describe(change)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = description
+	}
+	return result, nil
+}
+
+// changeDescriber keeps track of the entities that would be created by
+// bundle changes, so that placeholders in subsequent changes can be
+// described in terms of those entities.
+type changeDescriber struct {
+	// names maps change ids to the name of the charm, application or
+	// unit they create.
+	names map[string]string
+	// machines maps change ids to a description of the machine created
+	// by the change, or of the machine hosting the unit it creates.
+	machines map[string]string
+	// units holds the number of units added for each application.
+	units map[string]int
+	// numMachines holds the number of top level machines added.
+	numMachines int
+}
+
+func (d *changeDescriber) describe(change bundlechanges.Change) (string, error) {
+	id := change.Id()
+	switch change := change.(type) {
+	case *bundlechanges.AddCharmChange:
+		p := change.Params
+		d.names[id] = p.Charm
+		if p.Series == "" {
+			return fmt.Sprintf("upload charm %s", p.Charm), nil
+		}
+		return fmt.Sprintf("upload charm %s for series %s", p.Charm, p.Series), nil
+	case *bundlechanges.AddApplicationChange:
+		p := change.Params
+		d.names[id] = p.Application
+		description := fmt.Sprintf("deploy application %s", p.Application)
+		if p.Series != "" {
+			description += " on " + p.Series
+		}
+		return description + " using " + resolve(p.Charm, d.names), nil
+	case *bundlechanges.AddMachineChange:
+		p := change.Params
+		if p.ContainerType == "" {
+			d.machines[id] = fmt.Sprintf("new machine %d", d.numMachines)
+			d.numMachines++
+		} else {
+			parent := "new machine"
+			if p.ParentId != "" {
+				parent = d.machine(p.ParentId)
+			}
+			d.machines[id] = fmt.Sprintf("new %s container on %s", p.ContainerType, parent)
+		}
+		return "add " + d.machines[id], nil
+	case *bundlechanges.AddUnitChange:
+		p := change.Params
+		application := resolve(p.Application, d.names)
+		unit := fmt.Sprintf("%s/%d", application, d.units[application])
+		d.units[application]++
+		d.names[id] = unit
+		if p.To == "" {
+			d.machines[id] = "new machine hosting " + unit
+			return fmt.Sprintf("add unit %s to new machine", unit), nil
+		}
+		d.machines[id] = d.machine(p.To)
+		return fmt.Sprintf("add unit %s to %s", unit, d.machines[id]), nil
+	case *bundlechanges.AddRelationChange:
+		p := change.Params
+		return fmt.Sprintf(
+			"add relation %s - %s",
+			resolveRelation(p.Endpoint1, d.names),
+			resolveRelation(p.Endpoint2, d.names),
+		), nil
+	case *bundlechanges.ExposeChange:
+		return fmt.Sprintf("expose %s", resolve(change.Params.Application, d.names)), nil
+	case *bundlechanges.SetAnnotationsChange:
+		p := change.Params
+		if p.EntityType == bundlechanges.MachineType {
+			return fmt.Sprintf("set annotations for %s", d.machine(p.Id)), nil
+		}
+		return fmt.Sprintf("set annotations for %s %s", p.EntityType, resolve(p.Id, d.names)), nil
+	}
+	return "", errors.Errorf("unknown change type: %T", change)
+}
+
+// machine returns a description of the machine referred to by the given
+// placeholder, which may also be the id of an existing machine.
+func (d *changeDescriber) machine(placeholder string) string {
+	if !strings.HasPrefix(placeholder, "$") {
+		return "existing machine " + placeholder
+	}
+	return d.machines[placeholder[1:]]
+}
+
 // bundleHandler provides helpers and the state required to deploy a bundle.
 type bundleHandler struct {
 	// bundleDir is the path where the bundle file is located for local bundles.
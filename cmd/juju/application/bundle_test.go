@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -65,6 +66,32 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleSuccess(c *gc.C) {
 	})
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleDryRun(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	testcharms.UploadBundle(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple")
+	ctx, err := cmdtesting.RunCommand(c, NewDeployCommand(), "bundle/wordpress-simple", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Changes to deploy bundle:
+- upload charm mysql
+- deploy application mysql using mysql
+- upload charm wordpress
+- deploy application wordpress using wordpress
+- add relation wordpress:db - mysql:server
+- add unit mysql/0 to new machine
+- add unit wordpress/0 to new machine
+`[1:])
+	s.assertCharmsUploaded(c)
+	s.assertApplicationsDeployed(c, map[string]serviceInfo{})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployDryRunCharm(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	_, err := runDeploy(c, "xenial/mysql-42", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "Flags provided but not supported when deploying a charm: --dry-run.")
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleWithTermsSuccess(c *gc.C) {
 	testcharms.UploadCharm(c, s.client, "xenial/terms1-17", "terms1")
 	testcharms.UploadCharm(c, s.client, "xenial/terms2-42", "terms2")
@@ -1253,3 +1280,48 @@ func (w mockAllWatcher) Next() ([]multiwatcher.Delta, error) {
 func (mockAllWatcher) Stop() error {
 	return nil
 }
+
+type bundleChangesDescriptionSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&bundleChangesDescriptionSuite{})
+
+func (s *bundleChangesDescriptionSuite) TestDescription(c *gc.C) {
+	data, err := charm.ReadBundleData(strings.NewReader(`
+        series: xenial
+        applications:
+            mysql:
+                charm: cs:xenial/mysql-42
+                num_units: 1
+                to: ["lxd:0"]
+            wordpress:
+                charm: cs:xenial/wordpress-47
+                num_units: 1
+                to: ["0"]
+                expose: true
+                annotations:
+                    gui-x: "10"
+        machines:
+            "0":
+                series: xenial
+        relations:
+            - ["wordpress:db", "mysql:server"]
+    `))
+	c.Assert(err, jc.ErrorIsNil)
+	description, err := bundleChangesDescription(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(description, jc.SameContents, []string{
+		"upload charm cs:xenial/mysql-42 for series xenial",
+		"deploy application mysql on xenial using cs:xenial/mysql-42",
+		"upload charm cs:xenial/wordpress-47 for series xenial",
+		"deploy application wordpress on xenial using cs:xenial/wordpress-47",
+		"expose wordpress",
+		"set annotations for application wordpress",
+		"add new machine 0",
+		"add relation wordpress:db - mysql:server",
+		"add new lxd container on new machine 0",
+		"add unit mysql/0 to new lxd container on new machine 0",
+		"add unit wordpress/0 to new machine 0",
+	})
+}
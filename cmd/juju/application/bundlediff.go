@@ -0,0 +1,350 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/juju/charm.v6-unstable"
+)
+
+// The following constants describe where an entity is missing from
+// when comparing a bundle to a model.
+const (
+	missingFromBundle = "bundle"
+	missingFromModel  = "model"
+)
+
+// bundleDiff describes the differences between a bundle and the
+// model it is compared to. A nil field means there is no difference.
+type bundleDiff struct {
+	Applications map[string]*applicationDiff `yaml:"applications,omitempty"`
+	Machines     map[string]*machineDiff     `yaml:"machines,omitempty"`
+	Series       *stringDiff                 `yaml:"series,omitempty"`
+	Relations    *relationsDiff              `yaml:"relations,omitempty"`
+}
+
+// applicationDiff describes the differences between an application
+// in a bundle and the same application in the model.
+type applicationDiff struct {
+	Missing          string                `yaml:"missing,omitempty"`
+	Charm            *stringDiff           `yaml:"charm,omitempty"`
+	Series           *stringDiff           `yaml:"series,omitempty"`
+	NumUnits         *intDiff              `yaml:"num_units,omitempty"`
+	Expose           *boolDiff             `yaml:"expose,omitempty"`
+	Options          map[string]optionDiff `yaml:"options,omitempty"`
+	Annotations      map[string]stringDiff `yaml:"annotations,omitempty"`
+	Constraints      *stringDiff           `yaml:"constraints,omitempty"`
+	Storage          map[string]stringDiff `yaml:"storage,omitempty"`
+	EndpointBindings map[string]stringDiff `yaml:"bindings,omitempty"`
+}
+
+func (d *applicationDiff) empty() bool {
+	return d.Missing == "" &&
+		d.Charm == nil &&
+		d.Series == nil &&
+		d.NumUnits == nil &&
+		d.Expose == nil &&
+		len(d.Options) == 0 &&
+		len(d.Annotations) == 0 &&
+		d.Constraints == nil &&
+		len(d.Storage) == 0 &&
+		len(d.EndpointBindings) == 0
+}
+
+// machineDiff describes the differences between a machine in a
+// bundle and the machine with the same id in the model.
+type machineDiff struct {
+	Missing     string                `yaml:"missing,omitempty"`
+	Series      *stringDiff           `yaml:"series,omitempty"`
+	Annotations map[string]stringDiff `yaml:"annotations,omitempty"`
+}
+
+func (d *machineDiff) empty() bool {
+	return d.Missing == "" && d.Series == nil && len(d.Annotations) == 0
+}
+
+// relationsDiff holds the relations that are only present in one of
+// the bundle and the model.
+type relationsDiff struct {
+	BundleAdditions [][]string `yaml:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `yaml:"model-additions,omitempty"`
+}
+
+type stringDiff struct {
+	Bundle string `yaml:"bundle"`
+	Model  string `yaml:"model"`
+}
+
+type intDiff struct {
+	Bundle int `yaml:"bundle"`
+	Model  int `yaml:"model"`
+}
+
+type boolDiff struct {
+	Bundle bool `yaml:"bundle"`
+	Model  bool `yaml:"model"`
+}
+
+type optionDiff struct {
+	Bundle interface{} `yaml:"bundle"`
+	Model  interface{} `yaml:"model"`
+}
+
+// diffBundle compares the given bundle data with the bundle data
+// exported from a model, and returns the differences between them.
+// Annotations are only compared if includeAnnotations is true.
+func diffBundle(bundle, model *charm.BundleData, includeAnnotations bool) *bundleDiff {
+	differ := bundleDiffer{
+		bundle:             bundle,
+		model:              model,
+		includeAnnotations: includeAnnotations,
+	}
+	return differ.diff()
+}
+
+type bundleDiffer struct {
+	bundle             *charm.BundleData
+	model              *charm.BundleData
+	includeAnnotations bool
+}
+
+func (d *bundleDiffer) diff() *bundleDiff {
+	result := &bundleDiff{
+		Applications: d.diffApplications(),
+		Machines:     d.diffMachines(),
+		Relations:    d.diffRelations(),
+	}
+	if d.bundle.Series != "" && d.bundle.Series != d.model.Series {
+		result.Series = &stringDiff{Bundle: d.bundle.Series, Model: d.model.Series}
+	}
+	return result
+}
+
+func (d *bundleDiffer) diffApplications() map[string]*applicationDiff {
+	result := make(map[string]*applicationDiff)
+	for name, bundleApp := range d.bundle.Applications {
+		modelApp, found := d.model.Applications[name]
+		if !found {
+			result[name] = &applicationDiff{Missing: missingFromModel}
+			continue
+		}
+		if diff := d.diffApplication(bundleApp, modelApp); !diff.empty() {
+			result[name] = diff
+		}
+	}
+	for name := range d.model.Applications {
+		if _, found := d.bundle.Applications[name]; !found {
+			result[name] = &applicationDiff{Missing: missingFromBundle}
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func (d *bundleDiffer) diffApplication(bundle, model *charm.ApplicationSpec) *applicationDiff {
+	bundleSeries := effectiveSeries(bundle.Series, d.bundle.Series)
+	modelSeries := effectiveSeries(model.Series, d.model.Series)
+	result := &applicationDiff{
+		Options:          diffOptions(bundle.Options, model.Options),
+		Storage:          diffStrings(bundle.Storage, model.Storage),
+		EndpointBindings: diffStrings(bundle.EndpointBindings, model.EndpointBindings),
+	}
+	if !charmMatches(bundle.Charm, model.Charm, modelSeries) {
+		result.Charm = &stringDiff{Bundle: bundle.Charm, Model: model.Charm}
+	}
+	if bundleSeries != "" && bundleSeries != modelSeries {
+		result.Series = &stringDiff{Bundle: bundleSeries, Model: modelSeries}
+	}
+	if bundle.NumUnits != model.NumUnits {
+		result.NumUnits = &intDiff{Bundle: bundle.NumUnits, Model: model.NumUnits}
+	}
+	if bundle.Expose != model.Expose {
+		result.Expose = &boolDiff{Bundle: bundle.Expose, Model: model.Expose}
+	}
+	if bundle.Constraints != model.Constraints {
+		result.Constraints = &stringDiff{Bundle: bundle.Constraints, Model: model.Constraints}
+	}
+	if d.includeAnnotations {
+		result.Annotations = diffStrings(bundle.Annotations, model.Annotations)
+	}
+	return result
+}
+
+func (d *bundleDiffer) diffMachines() map[string]*machineDiff {
+	result := make(map[string]*machineDiff)
+	for id, bundleMachine := range d.bundle.Machines {
+		modelMachine, found := d.model.Machines[id]
+		if !found {
+			result[id] = &machineDiff{Missing: missingFromModel}
+			continue
+		}
+		if bundleMachine == nil {
+			bundleMachine = &charm.MachineSpec{}
+		}
+		if modelMachine == nil {
+			modelMachine = &charm.MachineSpec{}
+		}
+		diff := &machineDiff{}
+		bundleSeries := effectiveSeries(bundleMachine.Series, d.bundle.Series)
+		modelSeries := effectiveSeries(modelMachine.Series, d.model.Series)
+		if bundleSeries != "" && bundleSeries != modelSeries {
+			diff.Series = &stringDiff{Bundle: bundleSeries, Model: modelSeries}
+		}
+		if d.includeAnnotations {
+			diff.Annotations = diffStrings(bundleMachine.Annotations, modelMachine.Annotations)
+		}
+		if !diff.empty() {
+			result[id] = diff
+		}
+	}
+	for id := range d.model.Machines {
+		if _, found := d.bundle.Machines[id]; !found {
+			result[id] = &machineDiff{Missing: missingFromBundle}
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func (d *bundleDiffer) diffRelations() *relationsDiff {
+	result := &relationsDiff{
+		BundleAdditions: unmatchedRelations(d.bundle.Relations, d.model.Relations),
+		ModelAdditions:  unmatchedRelations(d.model.Relations, d.bundle.Relations),
+	}
+	if len(result.BundleAdditions) == 0 && len(result.ModelAdditions) == 0 {
+		return nil
+	}
+	return result
+}
+
+// unmatchedRelations returns the relations in source that have no
+// matching relation in target.
+func unmatchedRelations(source, target [][]string) [][]string {
+	var result [][]string
+	for _, relation := range source {
+		found := false
+		for _, other := range target {
+			if relationMatches(relation, other) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, relation)
+		}
+	}
+	sort.Sort(relationsByEndpoints(result))
+	return result
+}
+
+// relationMatches returns whether the two relations connect the same
+// endpoints, regardless of their order. An endpoint without a relation
+// name matches any endpoint of the same application.
+func relationMatches(r1, r2 []string) bool {
+	if len(r1) != 2 || len(r2) != 2 {
+		return false
+	}
+	return endpointMatches(r1[0], r2[0]) && endpointMatches(r1[1], r2[1]) ||
+		endpointMatches(r1[0], r2[1]) && endpointMatches(r1[1], r2[0])
+}
+
+func endpointMatches(ep1, ep2 string) bool {
+	app1, name1 := splitEndpoint(ep1)
+	app2, name2 := splitEndpoint(ep2)
+	if app1 != app2 {
+		return false
+	}
+	return name1 == "" || name2 == "" || name1 == name2
+}
+
+func splitEndpoint(endpoint string) (string, string) {
+	parts := strings.SplitN(endpoint, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// charmMatches returns whether the charm referred to in a bundle is
+// the one used by the model. Series and revision are only compared if
+// specified in the bundle.
+func charmMatches(bundleCharm, modelCharm, modelSeries string) bool {
+	if bundleCharm == modelCharm {
+		return true
+	}
+	bundleURL, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		return false
+	}
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return false
+	}
+	if bundleURL.Series == "" {
+		bundleURL.Series = modelSeries
+	}
+	if bundleURL.Revision == -1 {
+		bundleURL.Revision = modelURL.Revision
+	}
+	return bundleURL.String() == modelURL.String()
+}
+
+func effectiveSeries(series, defaultSeries string) string {
+	if series != "" {
+		return series
+	}
+	return defaultSeries
+}
+
+func diffOptions(bundle, model map[string]interface{}) map[string]optionDiff {
+	result := make(map[string]optionDiff)
+	for key, bundleValue := range bundle {
+		modelValue, found := model[key]
+		if !found || !reflect.DeepEqual(bundleValue, modelValue) {
+			result[key] = optionDiff{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	for key, modelValue := range model {
+		if _, found := bundle[key]; !found {
+			result[key] = optionDiff{Model: modelValue}
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func diffStrings(bundle, model map[string]string) map[string]stringDiff {
+	result := make(map[string]stringDiff)
+	for key, bundleValue := range bundle {
+		if modelValue := model[key]; bundleValue != modelValue {
+			result[key] = stringDiff{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	for key, modelValue := range model {
+		if _, found := bundle[key]; !found {
+			result[key] = stringDiff{Model: modelValue}
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+type relationsByEndpoints [][]string
+
+func (r relationsByEndpoints) Len() int      { return len(r) }
+func (r relationsByEndpoints) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByEndpoints) Less(i, j int) bool {
+	return strings.Join(r[i], " ") < strings.Join(r[j], " ")
+}
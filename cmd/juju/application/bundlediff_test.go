@@ -0,0 +1,160 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type bundleDiffSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&bundleDiffSuite{})
+
+func readBundleData(c *gc.C, content string) *charm.BundleData {
+	data, err := charm.ReadBundleData(strings.NewReader(content))
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+const diffModelBundle = `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 1
+    to: ["0"]
+    options:
+      dataset-size: 80%
+    annotations:
+      gui-x: "10"
+  wordpress:
+    charm: cs:trusty/wordpress-5
+    series: trusty
+    num_units: 2
+    expose: true
+machines:
+  "0": {}
+relations:
+- - wordpress:db
+  - mysql:db
+`
+
+func (s *bundleDiffSuite) TestNoDifferences(c *gc.C) {
+	bundle := readBundleData(c, `
+series: xenial
+applications:
+  mysql:
+    charm: mysql
+    num_units: 1
+    to: ["0"]
+    options:
+      dataset-size: 80%
+  wordpress:
+    charm: cs:trusty/wordpress
+    series: trusty
+    num_units: 2
+    expose: true
+machines:
+  "0":
+relations:
+- [mysql, wordpress]
+`)
+	model := readBundleData(c, diffModelBundle)
+	diff := diffBundle(bundle, model, false)
+	c.Assert(diff, jc.DeepEquals, &bundleDiff{})
+}
+
+func (s *bundleDiffSuite) TestDifferences(c *gc.C) {
+	bundle := readBundleData(c, `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-58
+    num_units: 2
+    options:
+      dataset-size: 50%
+      max-connections: 100
+    constraints: mem=4G
+    annotations:
+      gui-x: "20"
+  haproxy:
+    charm: cs:xenial/haproxy
+    num_units: 1
+machines:
+  "1": {}
+relations:
+- - haproxy:reverseproxy
+  - wordpress:website
+`)
+	model := readBundleData(c, diffModelBundle)
+	diff := diffBundle(bundle, model, true)
+	c.Assert(diff, jc.DeepEquals, &bundleDiff{
+		Applications: map[string]*applicationDiff{
+			"haproxy": {Missing: missingFromModel},
+			"mysql": {
+				Charm:    &stringDiff{Bundle: "cs:xenial/mysql-58", Model: "cs:xenial/mysql-57"},
+				NumUnits: &intDiff{Bundle: 2, Model: 1},
+				Options: map[string]optionDiff{
+					"dataset-size":    {Bundle: "50%", Model: "80%"},
+					"max-connections": {Bundle: 100},
+				},
+				Annotations: map[string]stringDiff{
+					"gui-x": {Bundle: "20", Model: "10"},
+				},
+				Constraints: &stringDiff{Bundle: "mem=4G"},
+			},
+			"wordpress": {Missing: missingFromBundle},
+		},
+		Machines: map[string]*machineDiff{
+			"0": {Missing: missingFromBundle},
+			"1": {Missing: missingFromModel},
+		},
+		Relations: &relationsDiff{
+			BundleAdditions: [][]string{{"haproxy:reverseproxy", "wordpress:website"}},
+			ModelAdditions:  [][]string{{"wordpress:db", "mysql:db"}},
+		},
+	})
+}
+
+func (s *bundleDiffSuite) TestSeriesDifferences(c *gc.C) {
+	bundle := readBundleData(c, `
+series: bionic
+applications:
+  mysql:
+    charm: cs:mysql-57
+    num_units: 1
+    to: ["0"]
+    options:
+      dataset-size: 80%
+  wordpress:
+    charm: cs:trusty/wordpress-5
+    series: trusty
+    num_units: 2
+    expose: true
+machines:
+  "0":
+    series: xenial
+relations:
+- - wordpress:db
+  - mysql:db
+`)
+	model := readBundleData(c, diffModelBundle)
+	diff := diffBundle(bundle, model, false)
+	c.Assert(diff, jc.DeepEquals, &bundleDiff{
+		Applications: map[string]*applicationDiff{
+			"mysql": {
+				Series: &stringDiff{Bundle: "bionic", Model: "xenial"},
+			},
+		},
+		Series: &stringDiff{Bundle: "bionic", Model: "xenial"},
+	})
+}
@@ -0,0 +1,288 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// includeFilePrefix identifies bundle option values that are
+	// replaced by the content of the referenced file.
+	includeFilePrefix = "include-file://"
+
+	// includeBase64Prefix identifies bundle option values that are
+	// replaced by the base64 encoded content of the referenced file.
+	includeBase64Prefix = "include-base64://"
+)
+
+// variablePattern matches the environment variable references in
+// bundle option values, either $VAR or ${VAR}, and the "$$" escape for
+// a literal "$". A "$" that is not followed by a variable name, such as
+// in "$10", is left alone.
+var variablePattern = regexp.MustCompile(`\$(?:\$|\{([A-Za-z_][A-Za-z0-9_]*)\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// bundleComposer applies overlays to a bundle and resolves the file
+// includes and environment variable references in application options.
+type bundleComposer struct {
+	// getenv returns the value of an environment variable, and
+	// whether it is set.
+	getenv func(string) (string, bool)
+}
+
+// composeBundle resolves the option values of the given bundle data,
+// then merges each of the overlay files onto it, in order. Relative
+// include paths are resolved relative to bundleDir for the base
+// bundle, and relative to the overlay's directory for overlays. An
+// empty bundleDir denotes a bundle from the charm store, whose option
+// values are left untouched: resolving them would let remote content
+// read the user's files and environment.
+func composeBundle(data *charm.BundleData, bundleDir string, overlays []string) error {
+	composer := bundleComposer{getenv: os.LookupEnv}
+	return composer.compose(data, bundleDir, overlays)
+}
+
+func (b *bundleComposer) compose(data *charm.BundleData, bundleDir string, overlays []string) error {
+	if bundleDir != "" {
+		if err := b.resolveOptions(data, bundleDir); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, filename := range overlays {
+		if err := b.applyOverlayFile(data, filename); err != nil {
+			return errors.Annotatef(err, "cannot apply overlay %q", filename)
+		}
+	}
+	return nil
+}
+
+func (b *bundleComposer) applyOverlayFile(data *charm.BundleData, filename string) error {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	overlay, err := charm.ReadBundleData(strings.NewReader(string(content)))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The raw form of the overlay is used to determine which fields
+	// of each application are actually specified.
+	var raw struct {
+		Applications map[string]map[string]interface{} `yaml:"applications"`
+		Services     map[string]map[string]interface{} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return errors.Trace(err)
+	}
+	fields := raw.Applications
+	if len(raw.Services) > 0 {
+		fields = raw.Services
+	}
+	if err := b.resolveOptions(overlay, filepath.Dir(filename)); err != nil {
+		return errors.Trace(err)
+	}
+	applyOverlay(data, overlay, fields)
+	return nil
+}
+
+// applyOverlay merges the overlay bundle data onto the base bundle
+// data. An application with an empty stanza in the overlay is removed
+// from the base bundle, along with its relations. The fields map
+// holds, for each overlay application, the fields that were specified.
+func applyOverlay(base, overlay *charm.BundleData, fields map[string]map[string]interface{}) {
+	if overlay.Series != "" {
+		base.Series = overlay.Series
+	}
+	if base.Applications == nil {
+		base.Applications = make(map[string]*charm.ApplicationSpec)
+	}
+	for name, spec := range overlay.Applications {
+		if spec == nil {
+			delete(base.Applications, name)
+			base.Relations = removeApplicationRelations(base.Relations, name)
+			continue
+		}
+		existing, found := base.Applications[name]
+		if !found || existing == nil {
+			base.Applications[name] = spec
+			continue
+		}
+		mergeApplication(existing, spec, fields[name])
+	}
+	if len(overlay.Machines) > 0 && base.Machines == nil {
+		base.Machines = make(map[string]*charm.MachineSpec)
+	}
+	for id, spec := range overlay.Machines {
+		base.Machines[id] = spec
+	}
+	for _, relation := range overlay.Relations {
+		if !containsRelation(base.Relations, relation) {
+			base.Relations = append(base.Relations, relation)
+		}
+	}
+}
+
+// mergeApplication merges the fields specified in the overlay
+// application onto the base application. Map fields are merged key by
+// key, other fields are replaced.
+func mergeApplication(base, overlay *charm.ApplicationSpec, fields map[string]interface{}) {
+	isSet := func(field string) bool {
+		_, ok := fields[field]
+		return ok
+	}
+	if isSet("charm") {
+		base.Charm = overlay.Charm
+	}
+	if isSet("series") {
+		base.Series = overlay.Series
+	}
+	if isSet("num_units") {
+		base.NumUnits = overlay.NumUnits
+	}
+	if isSet("to") {
+		base.To = overlay.To
+	}
+	if isSet("expose") {
+		base.Expose = overlay.Expose
+	}
+	if isSet("constraints") {
+		base.Constraints = overlay.Constraints
+	}
+	if len(overlay.Options) > 0 && base.Options == nil {
+		base.Options = make(map[string]interface{})
+	}
+	for key, value := range overlay.Options {
+		base.Options[key] = value
+	}
+	base.Annotations = mergeStrings(base.Annotations, overlay.Annotations)
+	base.Storage = mergeStrings(base.Storage, overlay.Storage)
+	base.EndpointBindings = mergeStrings(base.EndpointBindings, overlay.EndpointBindings)
+	if base.Resources == nil {
+		base.Resources = overlay.Resources
+	} else {
+		for key, value := range overlay.Resources {
+			base.Resources[key] = value
+		}
+	}
+}
+
+func mergeStrings(base, overlay map[string]string) map[string]string {
+	if len(overlay) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(map[string]string)
+	}
+	for key, value := range overlay {
+		base[key] = value
+	}
+	return base
+}
+
+// removeApplicationRelations returns the relations that do not
+// involve the named application.
+func removeApplicationRelations(relations [][]string, application string) [][]string {
+	var result [][]string
+	for _, relation := range relations {
+		involved := false
+		for _, endpoint := range relation {
+			if app, _ := splitEndpoint(endpoint); app == application {
+				involved = true
+				break
+			}
+		}
+		if !involved {
+			result = append(result, relation)
+		}
+	}
+	return result
+}
+
+func containsRelation(relations [][]string, relation []string) bool {
+	for _, existing := range relations {
+		if relationMatches(existing, relation) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveOptions replaces the file includes and environment variable
+// references in the string option values of every application.
+func (b *bundleComposer) resolveOptions(data *charm.BundleData, dir string) error {
+	for name, spec := range data.Applications {
+		if spec == nil {
+			continue
+		}
+		for key, value := range spec.Options {
+			s, ok := value.(string)
+			if !ok {
+				continue
+			}
+			resolved, err := b.resolveValue(s, dir)
+			if err != nil {
+				return errors.Annotatef(err, "application %q option %q", name, key)
+			}
+			spec.Options[key] = resolved
+		}
+	}
+	return nil
+}
+
+// resolveValue returns the content of the file referred to by value if
+// it is an include, or the value with any $VAR or ${VAR} environment
+// variable references expanded otherwise.
+func (b *bundleComposer) resolveValue(value, dir string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, includeFilePrefix):
+		content, err := readInclude(value[len(includeFilePrefix):], dir)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return string(content), nil
+	case strings.HasPrefix(value, includeBase64Prefix):
+		content, err := readInclude(value[len(includeBase64Prefix):], dir)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return base64.StdEncoding.EncodeToString(content), nil
+	}
+	var missing []string
+	result := variablePattern.ReplaceAllStringFunc(value, func(ref string) string {
+		if ref == "$$" {
+			return "$"
+		}
+		match := variablePattern.FindStringSubmatch(ref)
+		name := match[1] + match[2]
+		v, ok := b.getenv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", errors.Errorf("environment variable %q not set", missing[0])
+	}
+	return result, nil
+}
+
+func readInclude(path, dir string) ([]byte, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read include file")
+	}
+	return content, nil
+}
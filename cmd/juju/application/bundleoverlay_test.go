@@ -0,0 +1,278 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type bundleOverlaySuite struct {
+	coretesting.BaseSuite
+	dir      string
+	env      map[string]string
+	composer bundleComposer
+}
+
+var _ = gc.Suite(&bundleOverlaySuite{})
+
+func (s *bundleOverlaySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.env = make(map[string]string)
+	s.composer = bundleComposer{
+		getenv: func(name string) (string, bool) {
+			value, ok := s.env[name]
+			return value, ok
+		},
+	}
+}
+
+func (s *bundleOverlaySuite) writeFile(c *gc.C, name, content string) string {
+	path := filepath.Join(s.dir, name)
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+const overlayBaseBundle = `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 1
+    options:
+      dataset-size: 80%
+      max-connections: 100
+  wordpress:
+    charm: cs:xenial/wordpress-47
+    num_units: 1
+    expose: true
+  haproxy:
+    charm: cs:xenial/haproxy-41
+    num_units: 1
+relations:
+- [wordpress, mysql]
+- [haproxy, wordpress]
+`
+
+func (s *bundleOverlaySuite) TestMergeOverlay(c *gc.C) {
+	data := readBundleData(c, overlayBaseBundle)
+	overlay := s.writeFile(c, "overlay.yaml", `
+applications:
+  mysql:
+    num_units: 3
+    options:
+      max-connections: 500
+    annotations:
+      gui-x: "10"
+  memcached:
+    charm: cs:xenial/memcached-7
+    num_units: 1
+  haproxy:
+relations:
+- [wordpress, memcached]
+- [mysql, wordpress]
+`)
+	err := s.composer.compose(data, s.dir, []string{overlay})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, &charm.BundleData{
+		Series: "xenial",
+		Applications: map[string]*charm.ApplicationSpec{
+			"mysql": {
+				Charm:    "cs:xenial/mysql-57",
+				NumUnits: 3,
+				Options: map[string]interface{}{
+					"dataset-size":    "80%",
+					"max-connections": 500,
+				},
+				Annotations: map[string]string{"gui-x": "10"},
+			},
+			"wordpress": {
+				Charm:    "cs:xenial/wordpress-47",
+				NumUnits: 1,
+				Expose:   true,
+			},
+			"memcached": {
+				Charm:    "cs:xenial/memcached-7",
+				NumUnits: 1,
+			},
+		},
+		Relations: [][]string{
+			{"wordpress", "mysql"},
+			{"wordpress", "memcached"},
+		},
+	})
+}
+
+func (s *bundleOverlaySuite) TestOverlaysAppliedInOrder(c *gc.C) {
+	data := readBundleData(c, overlayBaseBundle)
+	first := s.writeFile(c, "first.yaml", `
+applications:
+  mysql:
+    num_units: 2
+`)
+	second := s.writeFile(c, "second.yaml", `
+series: bionic
+applications:
+  mysql:
+    num_units: 4
+`)
+	err := s.composer.compose(data, s.dir, []string{first, second})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Series, gc.Equals, "bionic")
+	c.Assert(data.Applications["mysql"].NumUnits, gc.Equals, 4)
+}
+
+func (s *bundleOverlaySuite) TestOverlayNotFound(c *gc.C) {
+	data := readBundleData(c, overlayBaseBundle)
+	missing := filepath.Join(s.dir, "missing.yaml")
+	err := s.composer.compose(data, s.dir, []string{missing})
+	c.Assert(err, gc.ErrorMatches, `cannot apply overlay ".*missing.yaml": .* no such file or directory`)
+}
+
+func (s *bundleOverlaySuite) TestIncludes(c *gc.C) {
+	s.writeFile(c, "config.txt", "some config")
+	s.writeFile(c, "cert.pem", "certificate")
+	data := readBundleData(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    options:
+      config: include-file://config.txt
+      cert: include-base64://cert.pem
+`)
+	err := s.composer.compose(data, s.dir, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications["mysql"].Options, jc.DeepEquals, map[string]interface{}{
+		"config": "some config",
+		"cert":   "Y2VydGlmaWNhdGU=",
+	})
+
+	// Includes in overlays are relative to the overlay's directory.
+	sub := filepath.Join(s.dir, "sub")
+	err = mkdirAndWrite(sub, "config.txt", "overlay config")
+	c.Assert(err, jc.ErrorIsNil)
+	overlay := filepath.Join(sub, "overlay.yaml")
+	err = ioutil.WriteFile(overlay, []byte(`
+applications:
+  mysql:
+    options:
+      config: include-file://config.txt
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.composer.compose(data, s.dir, []string{overlay})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications["mysql"].Options["config"], gc.Equals, "overlay config")
+}
+
+func (s *bundleOverlaySuite) TestIncludeNotFound(c *gc.C) {
+	data := readBundleData(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    options:
+      config: include-file://missing.txt
+`)
+	err := s.composer.compose(data, s.dir, nil)
+	c.Assert(err, gc.ErrorMatches, `application "mysql" option "config": cannot read include file: .*`)
+}
+
+func (s *bundleOverlaySuite) TestEnvironmentVariables(c *gc.C) {
+	s.env["DB_USER"] = "admin"
+	s.env["DB_HOST"] = "db.example.com"
+	data := readBundleData(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    options:
+      user: ${DB_USER}
+      url: mysql://${DB_USER}@${DB_HOST}/
+      host: $DB_HOST
+      price: $10
+      password: pa$$word$DB_USER
+      count: 3
+`)
+	err := s.composer.compose(data, s.dir, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications["mysql"].Options, jc.DeepEquals, map[string]interface{}{
+		"user":     "admin",
+		"url":      "mysql://admin@db.example.com/",
+		"host":     "db.example.com",
+		"price":    "$10",
+		"password": "pa$wordadmin",
+		"count":    3,
+	})
+}
+
+func (s *bundleOverlaySuite) TestEnvironmentVariableNotSet(c *gc.C) {
+	data := readBundleData(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    options:
+      user: $DB_USER
+`)
+	err := s.composer.compose(data, s.dir, nil)
+	c.Assert(err, gc.ErrorMatches, `application "mysql" option "user": environment variable "DB_USER" not set`)
+}
+
+func (s *bundleOverlaySuite) TestStoreBundleReferencesUntouched(c *gc.C) {
+	s.env["AWS_SECRET_ACCESS_KEY"] = "sekrit"
+	s.writeFile(c, "id_rsa", "private key")
+	for i, value := range []string{
+		"include-file://" + filepath.Join(s.dir, "id_rsa"),
+		"include-base64://id_rsa",
+		"key=${AWS_SECRET_ACCESS_KEY}",
+		"key=$AWS_SECRET_ACCESS_KEY",
+	} {
+		c.Logf("test %d: %s", i, value)
+		data := readBundleData(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+`)
+		data.Applications["mysql"].Options = map[string]interface{}{"config": value}
+		err := s.composer.compose(data, "", nil)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(data.Applications["mysql"].Options["config"], gc.Equals, value)
+	}
+}
+
+func (s *bundleOverlaySuite) TestStoreBundleWithLocalOverlay(c *gc.C) {
+	s.env["DB_USER"] = "admin"
+	data := readBundleData(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    options:
+      price: $10
+`)
+	overlay := s.writeFile(c, "overlay.yaml", `
+applications:
+  mysql:
+    options:
+      user: ${DB_USER}
+`)
+	err := s.composer.compose(data, "", []string{overlay})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data.Applications["mysql"].Options, jc.DeepEquals, map[string]interface{}{
+		"price": "$10",
+		"user":  "admin",
+	})
+}
+
+func mkdirAndWrite(dir, name, content string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+}
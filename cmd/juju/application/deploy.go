@@ -5,6 +5,7 @@ package application
 
 import (
 	"archive/zip"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -267,6 +268,10 @@ type DeployCommand struct {
 	// running an unsupported series.
 	Force bool
 
+	// Trust is used to grant the application access to the model's
+	// cloud credential.
+	Trust bool
+
 	ApplicationName string
 	Config          cmd.FileVar
 	ConstraintsStr  string
@@ -290,6 +295,14 @@ type DeployCommand struct {
 	Bindings map[string]string
 	Steps    []DeployStep
 
+	// DryRun is used to specify that the bundle shouldn't actually be
+	// deployed but just output the changes.
+	DryRun bool
+
+	// BundleOverlayFile holds the paths of the bundles to overlay on
+	// the primary bundle, in the order they are applied.
+	BundleOverlayFile []string
+
 	// NewAPIRoot stores a function which returns a new API root.
 	NewAPIRoot func() (DeployAPI, error)
 
@@ -335,6 +348,27 @@ For example:
 
   juju deploy /path/to/bundle/openstack/bundle.yaml
 
+The changes that deploying a bundle would make can be displayed, without
+applying them, by specifying the '--dry-run' option:
+
+  juju deploy /path/to/bundle/openstack/bundle.yaml --dry-run
+
+Bundles can be customised for a particular environment by specifying one
+or more overlays with the '--overlay' option. Overlays use the same format
+as bundles and are merged onto the primary bundle in the order given.
+An application with an empty stanza in an overlay is removed from the
+bundle, along with its relations.
+
+  juju deploy ./bundle.yaml --overlay ./production.yaml --overlay ./site.yaml
+
+Application option values in bundles and overlays may refer to the
+content of a file with 'include-file://<path>', or to its base64 encoded
+content with 'include-base64://<path>'. Relative paths are resolved from
+the directory of the bundle or overlay. Other option values may refer to
+environment variables as $VAR or ${VAR}; use $$ for a literal $. Includes
+and variables are only resolved in local bundles and overlays; option
+values in bundles from the charm store are used as they are.
+
 If an 'application name' is not provided, the application name used is the
 'charm or bundle' name.
 
@@ -395,12 +429,16 @@ Examples:
     (deploy 2 units to machines that are in the 'dmz' space but not of
     the 'cmd' or the 'database' spaces)
 
+    juju deploy aws-integrator --trust
+    (deploy a charm that may read the model's cloud credential)
+
 See also:
     spaces
     config
     add-unit
     set-constraints
     get-constraints
+    trust
 `
 
 // DeployStep is an action that needs to be taken during charm deployment.
@@ -442,7 +480,9 @@ var (
 		"bind", "config", "constraints", "force", "n", "num-units",
 		"series", "to", "resource", "attach-storage",
 	}
-	bundleOnlyFlags = []string{}
+	bundleOnlyFlags = []string{
+		"dry-run", "overlay",
+	}
 )
 
 func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -459,6 +499,9 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.StringVar(&c.BindToSpaces, "bind", "", "Configure application endpoint bindings to spaces")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the bundle deploy would do")
+	f.BoolVar(&c.Trust, "trust", false, "Allows the charm to access the model's cloud credential")
+	f.Var(cmd.NewAppendStringsValue(&c.BundleOverlayFile), "overlay", "Bundles to overlay on the primary bundle, applied in order")
 
 	for _, step := range c.Steps {
 		step.SetFlags(f)
@@ -513,6 +556,12 @@ func (c *DeployCommand) deployBundle(
 	apiRoot DeployAPI,
 	bundleStorage map[string]map[string]storage.Constraints,
 ) error {
+	if err := c.composeBundle(ctx, filePath, data); err != nil {
+		return errors.Trace(err)
+	}
+	if c.DryRun {
+		return errors.Trace(c.showBundleChanges(ctx, filePath, data))
+	}
 	// TODO(ericsnow) Do something with the CS macaroons that were returned?
 	if _, err := deployBundle(
 		filePath,
@@ -528,6 +577,39 @@ func (c *DeployCommand) deployBundle(
 	return nil
 }
 
+// composeBundle applies the overlays specified on the command line to
+// the bundle data, and resolves includes and environment variable
+// references in application options. The filePath is empty for
+// bundles from the charm store.
+func (c *DeployCommand) composeBundle(ctx *cmd.Context, filePath string, data *charm.BundleData) error {
+	overlays := make([]string, len(c.BundleOverlayFile))
+	for i, overlay := range c.BundleOverlayFile {
+		overlays[i] = ctx.AbsPath(overlay)
+	}
+	return errors.Trace(composeBundle(data, filePath, overlays))
+}
+
+// showBundleChanges writes the changes that deploying the given bundle
+// would make, without applying any of them.
+func (c *DeployCommand) showBundleChanges(ctx *cmd.Context, filePath string, data *charm.BundleData) error {
+	if err := verifyBundle(data, filePath); err != nil {
+		return errors.Trace(err)
+	}
+	changes, err := bundleChangesDescription(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(changes) == 0 {
+		fmt.Fprintln(ctx.Stdout, "No changes to apply.")
+		return nil
+	}
+	fmt.Fprintln(ctx.Stdout, "Changes to deploy bundle:")
+	for _, change := range changes {
+		fmt.Fprintf(ctx.Stdout, "- %s\n", change)
+	}
+	return nil
+}
+
 func (c *DeployCommand) deployCharm(
 	id charmstore.CharmID,
 	csMac *macaroon.Macaroon,
@@ -625,6 +707,7 @@ func (c *DeployCommand) deployCharm(
 		AttachStorage:    c.AttachStorage,
 		Resources:        ids,
 		EndpointBindings: c.Bindings,
+		Trust:            c.Trust,
 	}))
 }
 
@@ -395,6 +395,15 @@ func (s *DeploySuite) TestNumUnits(c *gc.C) {
 	s.AssertService(c, "multi-series", curl, 13, 0)
 }
 
+func (s *DeploySuite) TestDeployTrust(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
+	_, err := runDeploy(c, ch, "--trust", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+	app, err := s.State.Application("multi-series")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsTrusted(), jc.IsTrue)
+}
+
 func (s *DeploySuite) TestNumUnitsSubordinate(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "logging")
 	_, err := runDeploy(c, "--num-units", "3", ch, "--series", "quantal")
@@ -0,0 +1,210 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/charmrepo.v2-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const diffBundleDoc = `
+Bundle can be a local bundle file or the name of a bundle in
+the charm store.
+
+The differences are displayed as YAML. For each application, machine
+and relation that differs, the value in the bundle and the value in the
+model are shown, or, if it is only present in one of them, where it is
+missing from. Annotations are only compared if --annotations is given.
+
+Overlays specified with --overlay are applied to the bundle, in order,
+before it is compared with the model, as they are by deploy.
+
+Examples:
+    juju diff-bundle localbundle.yaml
+    juju diff-bundle canonical-kubernetes
+    juju diff-bundle -m othermodel hadoop-spark
+    juju diff-bundle mongodb-cluster --channel beta
+    juju diff-bundle localbundle.yaml --annotations
+    juju diff-bundle localbundle.yaml --overlay production.yaml
+
+See also:
+    deploy
+    export-bundle
+`
+
+// NewDiffBundleCommand returns a command to compare a bundle against
+// the selected model.
+func NewDiffBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&diffBundleCommand{})
+}
+
+// diffBundleCommand compares a bundle to a model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	bundle             string
+	channel            csparams.Channel
+	overlays           []string
+	includeAnnotations bool
+	out                cmd.Output
+
+	newAPIFunc    func() (DiffBundleAPI, error)
+	newCharmStore func() (BundleResolver, error)
+}
+
+// DiffBundleAPI provides the API methods the diff-bundle command needs.
+type DiffBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+// BundleResolver resolves and fetches bundles from the charm store.
+type BundleResolver interface {
+	ResolveWithChannel(*charm.URL) (*charm.URL, csparams.Channel, []string, error)
+	GetBundle(*charm.URL) (charm.Bundle, error)
+}
+
+// Info is part of cmd.Command.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or name>",
+		Purpose: "Compares a bundle with a model and reports any differences.",
+		Doc:     diffBundleDoc,
+	}
+}
+
+// SetFlags is part of cmd.Command.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.StringVar((*string)(&c.channel), "channel", "", "Channel to use when getting the bundle from the charm store")
+	f.BoolVar(&c.includeAnnotations, "annotations", false, "Include differences in annotations")
+	f.Var(cmd.NewAppendStringsValue(&c.overlays), "overlay", "Bundles to overlay on the primary bundle, applied in order")
+}
+
+// Init is part of cmd.Command.
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no bundle specified")
+	}
+	c.bundle = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of cmd.Command.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	bundleData, bundleDir, err := c.readBundle(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	overlays := make([]string, len(c.overlays))
+	for i, overlay := range c.overlays {
+		overlays[i] = ctx.AbsPath(overlay)
+	}
+	if err := composeBundle(bundleData, bundleDir, overlays); err != nil {
+		return errors.Trace(err)
+	}
+	if err := verifyBundle(bundleData, bundleDir); err != nil {
+		return errors.Trace(err)
+	}
+
+	api, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	modelYAML, err := api.ExportBundle()
+	if err != nil && !params.IsCodeNotFound(err) {
+		return errors.Annotate(err, "exporting model")
+	}
+	modelData := &charm.BundleData{}
+	if err == nil {
+		modelData, err = charm.ReadBundleData(strings.NewReader(modelYAML))
+		if err != nil {
+			return errors.Annotate(err, "reading model bundle")
+		}
+	}
+
+	diff := diffBundle(bundleData, modelData, c.includeAnnotations)
+	return c.out.Write(ctx, diff)
+}
+
+func (c *diffBundleCommand) newAPI() (DiffBundleAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle.NewClient(root), nil
+}
+
+func (c *diffBundleCommand) charmStore() (BundleResolver, error) {
+	if c.newCharmStore != nil {
+		return c.newCharmStore()
+	}
+	bakeryClient, err := c.BakeryClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cstoreClient := newCharmStoreClient(bakeryClient).WithChannel(c.channel)
+	return charmrepo.NewCharmStoreFromClient(cstoreClient), nil
+}
+
+// readBundle reads the bundle data either from a local file or
+// directory, or from the charm store. For local bundles, the directory
+// used to resolve relative charm paths is also returned.
+func (c *diffBundleCommand) readBundle(ctx *cmd.Context) (*charm.BundleData, string, error) {
+	path := ctx.AbsPath(c.bundle)
+	if info, err := os.Stat(path); err == nil {
+		if !info.IsDir() {
+			bundleData, err := charmrepo.ReadBundleFile(path)
+			if err != nil {
+				return nil, "", errors.Annotatef(err, "cannot read bundle %q", c.bundle)
+			}
+			return bundleData, filepath.Dir(path), nil
+		}
+		b, err := charm.ReadBundleDir(path)
+		if err != nil {
+			return nil, "", errors.Annotatef(err, "cannot read bundle %q", c.bundle)
+		}
+		return b.Data(), path, nil
+	}
+
+	bundleURL, err := charm.ParseURL(c.bundle)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	store, err := c.charmStore()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	resolvedURL, _, _, err := store.ResolveWithChannel(bundleURL)
+	if err != nil {
+		return nil, "", errors.Annotatef(err, "cannot resolve URL %q", c.bundle)
+	}
+	if resolvedURL.Series != "bundle" {
+		return nil, "", errors.Errorf("%q is not a bundle", c.bundle)
+	}
+	b, err := store.GetBundle(resolvedURL)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return b.Data(), "", nil
+}
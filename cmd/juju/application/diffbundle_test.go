@@ -0,0 +1,255 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type diffBundleSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api   *fakeDiffBundleAPI
+	store *fakeBundleResolver
+	dir   string
+}
+
+var _ = gc.Suite(&diffBundleSuite{})
+
+func (s *diffBundleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeDiffBundleAPI{
+		bundle: `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 1
+series: xenial
+`,
+	}
+	s.store = &fakeBundleResolver{}
+	s.dir = c.MkDir()
+}
+
+func (s *diffBundleSuite) runDiffBundle(c *gc.C, args ...string) (string, error) {
+	command := &diffBundleCommand{
+		newAPIFunc: func() (DiffBundleAPI, error) {
+			return s.api, nil
+		},
+		newCharmStore: func() (BundleResolver, error) {
+			return s.store, nil
+		},
+	}
+	command.SetClientStore(NewMockStore())
+	ctx, err := cmdtesting.RunCommandInDir(c, modelcmd.Wrap(command), args, s.dir)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *diffBundleSuite) writeBundle(c *gc.C, content string) string {
+	path := filepath.Join(s.dir, "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *diffBundleSuite) TestNoArgs(c *gc.C) {
+	_, err := s.runDiffBundle(c)
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
+
+func (s *diffBundleSuite) TestTooManyArgs(c *gc.C) {
+	_, err := s.runDiffBundle(c, "bundle.yaml", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *diffBundleSuite) TestLocalBundle(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-58
+    num_units: 1
+  wordpress:
+    charm: cs:xenial/wordpress
+    num_units: 1
+relations:
+- [wordpress, mysql]
+`)
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+applications:
+  mysql:
+    charm:
+      bundle: cs:xenial/mysql-58
+      model: cs:xenial/mysql-57
+  wordpress:
+    missing: model
+relations:
+  bundle-additions:
+  - - wordpress
+    - mysql
+`[1:])
+	s.api.CheckCallNames(c, "ExportBundle", "Close")
+	s.store.CheckNoCalls(c)
+}
+
+func (s *diffBundleSuite) TestLocalBundleWithOverlay(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 1
+  wordpress:
+    charm: cs:xenial/wordpress
+    num_units: 1
+relations:
+- [wordpress, mysql]
+`)
+	overlay := filepath.Join(s.dir, "overlay.yaml")
+	err := ioutil.WriteFile(overlay, []byte(`
+applications:
+  mysql:
+    num_units: 2
+  wordpress:
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	out, err := s.runDiffBundle(c, path, "--overlay", "overlay.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+applications:
+  mysql:
+    num_units:
+      bundle: 2
+      model: 1
+`[1:])
+}
+
+func (s *diffBundleSuite) TestEmptyModel(c *gc.C) {
+	s.api.SetErrors(&params.Error{Code: params.CodeNotFound, Message: "applications in model not found"})
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-58
+    num_units: 1
+`)
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+applications:
+  mysql:
+    missing: model
+`[1:])
+}
+
+func (s *diffBundleSuite) TestExportError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	path := s.writeBundle(c, "applications: {}\n")
+	_, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.ErrorMatches, "exporting model: boom")
+}
+
+func (s *diffBundleSuite) TestInvalidBundle(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-58
+    num_units: -1
+`)
+	_, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.ErrorMatches, `(?s)the provided bundle has the following errors:.*negative number of units.*`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *diffBundleSuite) TestCharmStoreBundle(c *gc.C) {
+	s.store.bundle = readBundleData(c, `
+applications:
+  mysql:
+    charm: cs:xenial/mysql-57
+    num_units: 1
+`)
+	out, err := s.runDiffBundle(c, "mysql-bundle")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{}\n")
+	s.store.CheckCallNames(c, "ResolveWithChannel", "GetBundle")
+	s.store.CheckCall(c, 1, "GetBundle", charm.MustParseURL("cs:bundle/mysql-bundle-1"))
+}
+
+func (s *diffBundleSuite) TestCharmStoreNotBundle(c *gc.C) {
+	s.store.charm = true
+	_, err := s.runDiffBundle(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `"mysql" is not a bundle`)
+}
+
+type fakeDiffBundleAPI struct {
+	gitjujutesting.Stub
+	bundle string
+}
+
+func (f *fakeDiffBundleAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeDiffBundleAPI) ExportBundle() (string, error) {
+	f.MethodCall(f, "ExportBundle")
+	if err := f.NextErr(); err != nil {
+		return "", err
+	}
+	return f.bundle, nil
+}
+
+type fakeBundleResolver struct {
+	gitjujutesting.Stub
+	bundle *charm.BundleData
+	charm  bool
+}
+
+func (f *fakeBundleResolver) ResolveWithChannel(url *charm.URL) (*charm.URL, csparams.Channel, []string, error) {
+	f.MethodCall(f, "ResolveWithChannel", url)
+	if err := f.NextErr(); err != nil {
+		return nil, "", nil, err
+	}
+	resolved := *url
+	resolved.Series = "bundle"
+	if f.charm {
+		resolved.Series = "xenial"
+	}
+	resolved.Revision = 1
+	return &resolved, csparams.StableChannel, nil, nil
+}
+
+func (f *fakeBundleResolver) GetBundle(url *charm.URL) (charm.Bundle, error) {
+	f.MethodCall(f, "GetBundle", url)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return fakeBundle{f.bundle}, nil
+}
+
+type fakeBundle struct {
+	data *charm.BundleData
+}
+
+func (b fakeBundle) Data() *charm.BundleData {
+	return b.data
+}
+
+func (b fakeBundle) ReadMe() string {
+	return ""
+}
@@ -60,6 +60,23 @@ func NewRemoveRelationCommandForTest(api ApplicationDestroyRelationAPI) modelcmd
 	return modelcmd.Wrap(cmd)
 }
 
+// NewTrustCommandForTest returns a TrustCommand with the api provided as specified.
+func NewTrustCommandForTest(api ApplicationTrustAPI) modelcmd.ModelCommand {
+	cmd := &trustCommand{newAPIFunc: func() (ApplicationTrustAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewHookHistoryCommandForTest returns a HookHistoryCommand with the
+// api provided as specified.
+func NewHookHistoryCommandForTest(api HookHistoryAPI) modelcmd.ModelCommand {
+	cmd := &hookHistoryCommand{newAPIFunc: func() (HookHistoryAPI, error) {
+		return api, nil
+	}}
+	return modelcmd.Wrap(cmd)
+}
+
 // NewConsumeCommandForTest returns a ConsumeCommand with the specified api.
 func NewConsumeCommandForTest(
 	store jujuclient.ClientStore,
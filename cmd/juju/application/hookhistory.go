@@ -0,0 +1,186 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var usageHookHistorySummary = `
+Shows the most recent hooks run by units, with their durations.`[1:]
+
+var usageHookHistoryDetails = `
+Shows the most recent hooks run by each of the given units, newest first,
+with the time each hook started, how long it took to run, and whether it
+succeeded, failed, or was killed for exceeding the model's hook timeout.
+Hooks that are not implemented by the charm are not shown.
+
+Use -n to change the number of hooks shown for each unit, or -n 0 to
+show all of the hooks recorded for the units. The controller keeps the
+last 100 hooks run by each unit.
+
+Examples:
+    juju show-hook-history mysql/0
+    juju show-hook-history -n 20 mysql/0 mysql/1
+    juju show-hook-history --format yaml mysql/0
+
+See also:
+    show-status-log`[1:]
+
+// defaultHookHistoryCount is the number of hook runs shown for each
+// unit by default.
+const defaultHookHistoryCount = 10
+
+// NewHookHistoryCommand returns a command that shows the most recent
+// hook runs of units.
+func NewHookHistoryCommand() modelcmd.ModelCommand {
+	cmd := &hookHistoryCommand{}
+	cmd.newAPIFunc = func() (HookHistoryAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// hookHistoryCommand shows the most recent hook runs of units.
+type hookHistoryCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	unitNames  []string
+	count      int
+	newAPIFunc func() (HookHistoryAPI, error)
+}
+
+// HookHistoryAPI defines the API methods that the show-hook-history
+// command uses.
+type HookHistoryAPI interface {
+	Close() error
+	UnitsHookRuns(unitNames []string, count int) ([]params.HookRunsResult, error)
+}
+
+// Info is part of the cmd.Command interface.
+func (c *hookHistoryCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-hook-history",
+		Args:    "<unit name> [<unit name>...]",
+		Purpose: usageHookHistorySummary,
+		Doc:     usageHookHistoryDetails,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *hookHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.count, "n", defaultHookHistoryCount, "The number of hooks to show for each unit, or 0 for all")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": printHookHistoryTabular,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *hookHistoryCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit name specified")
+	}
+	for _, name := range args {
+		if !names.IsValidUnit(name) {
+			return errors.NotValidf("unit name %q", name)
+		}
+	}
+	if c.count < 0 {
+		return errors.New("-n must not be negative")
+	}
+	c.unitNames = args
+	return nil
+}
+
+type hookRunOutput struct {
+	Hook     string `yaml:"hook" json:"hook"`
+	Started  string `yaml:"started" json:"started"`
+	Duration string `yaml:"duration" json:"duration"`
+	Result   string `yaml:"result" json:"result"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *hookHistoryCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.UnitsHookRuns(c.unitNames, c.count)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	history := make(map[string][]hookRunOutput)
+	var anyFailed bool
+	for i, result := range results {
+		name := c.unitNames[i]
+		if result.Error != nil {
+			anyFailed = true
+			ctx.Infof("cannot show hook history for unit %s: %s", name, result.Error)
+			continue
+		}
+		runs := make([]hookRunOutput, len(result.Runs))
+		for j, run := range result.Runs {
+			runs[j] = hookRunOutput{
+				Hook:     run.Hook,
+				Started:  run.Started.UTC().Format(time.RFC3339),
+				Duration: run.Duration.String(),
+				Result:   run.Result,
+			}
+		}
+		history[name] = runs
+	}
+	if err := c.out.Write(ctx, history); err != nil {
+		return errors.Trace(err)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// printHookHistoryTabular prints the hook runs of each unit in tabular
+// format.
+func printHookHistoryTabular(writer io.Writer, value interface{}) error {
+	history, ok := value.(map[string][]hookRunOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", history, value)
+	}
+	unitNames := make([]string, 0, len(history))
+	for name := range history {
+		unitNames = append(unitNames, name)
+	}
+	utils.SortStringsNaturally(unitNames)
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Unit\tHook\tStarted\tDuration\tResult")
+	for _, name := range unitNames {
+		for _, run := range history[name] {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", name, run.Hook, run.Started, run.Duration, run.Result)
+		}
+	}
+	return tw.Flush()
+}
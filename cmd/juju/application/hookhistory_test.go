@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+)
+
+type HookHistorySuite struct {
+	testing.IsolationSuite
+	mockAPI *mockHookHistoryAPI
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	started := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.mockAPI = &mockHookHistoryAPI{
+		Stub: &testing.Stub{},
+		results: []params.HookRunsResult{{
+			Runs: []params.HookRun{{
+				Hook:     "config-changed",
+				Started:  started.Add(time.Minute),
+				Duration: 95 * time.Second,
+				Result:   "timeout",
+			}, {
+				Hook:     "install",
+				Started:  started,
+				Duration: 12 * time.Second,
+				Result:   "success",
+			}},
+		}},
+	}
+}
+
+func (s *HookHistorySuite) runHookHistory(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewHookHistoryCommandForTest(s.mockAPI), args...)
+}
+
+func (s *HookHistorySuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no unit name specified",
+	}, {
+		args: []string{"mysql"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"-n", "-1", "mysql/0"},
+		err:  "-n must not be negative",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runHookHistory(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *HookHistorySuite) TestTabular(c *gc.C) {
+	ctx, err := s.runHookHistory(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Unit     Hook            Started               Duration  Result\n"+
+		"mysql/0  config-changed  2017-06-01T12:01:00Z  1m35s     timeout\n"+
+		"mysql/0  install         2017-06-01T12:00:00Z  12s       success\n",
+	)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"UnitsHookRuns", []interface{}{[]string{"mysql/0"}, 10}},
+		{"Close", nil},
+	})
+}
+
+func (s *HookHistorySuite) TestYAML(c *gc.C) {
+	ctx, err := s.runHookHistory(c, "-n", "2", "--format", "yaml", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.YAMLEquals, map[string]interface{}{
+		"mysql/0": []interface{}{
+			map[string]interface{}{
+				"hook":     "config-changed",
+				"started":  "2017-06-01T12:01:00Z",
+				"duration": "1m35s",
+				"result":   "timeout",
+			},
+			map[string]interface{}{
+				"hook":     "install",
+				"started":  "2017-06-01T12:00:00Z",
+				"duration": "12s",
+				"result":   "success",
+			},
+		},
+	})
+	s.mockAPI.CheckCall(c, 0, "UnitsHookRuns", []string{"mysql/0"}, 2)
+}
+
+func (s *HookHistorySuite) TestUnitError(c *gc.C) {
+	s.mockAPI.results = append(s.mockAPI.results, params.HookRunsResult{
+		Error: &params.Error{Message: `unit "mysql/1" not found`, Code: params.CodeNotFound},
+	})
+	ctx, err := s.runHookHistory(c, "mysql/0", "mysql/1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `cannot show hook history for unit mysql/1: unit "mysql/1" not found`+"\n")
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "mysql/0  install")
+}
+
+func (s *HookHistorySuite) TestAPIError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runHookHistory(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockHookHistoryAPI struct {
+	*testing.Stub
+	results []params.HookRunsResult
+}
+
+func (s *mockHookHistoryAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return nil
+}
+
+func (s *mockHookHistoryAPI) UnitsHookRuns(unitNames []string, count int) ([]params.HookRunsResult, error) {
+	s.MethodCall(s, "UnitsHookRuns", unitNames, count)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.results, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var usageTrustSummary = `
+Grants an application access to the model's cloud credential.`[1:]
+
+var usageTrustDetails = `
+Trusted applications may read the specification of the cloud the model
+is running in, including the credential used to access it, by running
+the credential-get hook tool. This lets charms that integrate with the
+underlying cloud call its API without the credential having to be
+passed in through charm config.
+
+Use --remove to revoke the trust of an application.
+
+Examples:
+    juju trust aws-integrator
+    juju trust --remove aws-integrator
+
+See also:
+    deploy`[1:]
+
+// NewTrustCommand returns a command to grant or revoke the trust of
+// an application.
+func NewTrustCommand() modelcmd.ModelCommand {
+	cmd := &trustCommand{}
+	cmd.newAPIFunc = func() (ApplicationTrustAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// trustCommand grants or revokes the trust of an application.
+type trustCommand struct {
+	modelcmd.ModelCommandBase
+	ApplicationName string
+	Remove          bool
+	newAPIFunc      func() (ApplicationTrustAPI, error)
+}
+
+// ApplicationTrustAPI defines the API methods that the trust command
+// uses.
+type ApplicationTrustAPI interface {
+	Close() error
+	SetTrust(application string, trust bool) error
+}
+
+// Info is part of the cmd.Command interface.
+func (c *trustCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "trust",
+		Args:    "<application name>",
+		Purpose: usageTrustSummary,
+		Doc:     usageTrustDetails,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *trustCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.Remove, "remove", false, "Revoke the trust of the application")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *trustCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	c.ApplicationName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *trustCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.ProcessBlockedError(client.SetTrust(c.ApplicationName, !c.Remove), block.BlockChange)
+}
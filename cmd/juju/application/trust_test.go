@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type TrustSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockTrustAPI
+}
+
+func (s *TrustSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockTrustAPI{Stub: &testing.Stub{}}
+}
+
+var _ = gc.Suite(&TrustSuite{})
+
+func (s *TrustSuite) runTrust(c *gc.C, args ...string) error {
+	_, err := cmdtesting.RunCommand(c, NewTrustCommandForTest(s.mockAPI), args...)
+	return err
+}
+
+func (s *TrustSuite) TestTrustNoApplication(c *gc.C) {
+	err := s.runTrust(c)
+	c.Assert(err, gc.ErrorMatches, "no application name specified")
+}
+
+func (s *TrustSuite) TestTrustTooManyArgs(c *gc.C) {
+	err := s.runTrust(c, "gitlab", "mysql")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mysql"\]`)
+}
+
+func (s *TrustSuite) TestTrust(c *gc.C) {
+	err := s.runTrust(c, "gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetTrust", []interface{}{"gitlab", true}},
+		{"Close", nil},
+	})
+}
+
+func (s *TrustSuite) TestTrustRemove(c *gc.C) {
+	err := s.runTrust(c, "--remove", "gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetTrust", []interface{}{"gitlab", false}},
+		{"Close", nil},
+	})
+}
+
+func (s *TrustSuite) TestTrustFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	err := s.runTrust(c, "gitlab")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *TrustSuite) TestTrustBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestTrustBlocked"))
+	err := s.runTrust(c, "gitlab")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestTrustBlocked.*")
+}
+
+type mockTrustAPI struct {
+	*testing.Stub
+}
+
+func (s mockTrustAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return nil
+}
+
+func (s mockTrustAPI) SetTrust(application string, trust bool) error {
+	s.MethodCall(s, "SetTrust", application, trust)
+	return s.NextErr()
+}
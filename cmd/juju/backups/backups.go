@@ -6,7 +6,9 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -25,10 +27,15 @@ type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
 	Create(notes string) (*params.BackupsMetadataResult, error)
+	// CreateEncrypted sends an RPC request to create a new backup
+	// encrypted with the given key, or the controller's default key.
+	CreateEncrypted(notes, key string) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
 	List() (*params.BackupsListResult, error)
+	// ListTarget gets the metadata of the backups on the backup target.
+	ListTarget() (*params.BackupsListResult, error)
 	// Download pulls the backup archive file.
 	Download(id string) (io.ReadCloser, error)
 	// Upload pushes a backup archive to storage.
@@ -80,6 +87,8 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
 	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
 	fmt.Fprintf(ctx.Stdout, "notes:           %q\n", result.Notes)
+	fmt.Fprintf(ctx.Stdout, "scheduled:       %v\n", result.Scheduled)
+	fmt.Fprintf(ctx.Stdout, "encrypted:       %v\n", result.Encrypted)
 
 	fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", result.Model)
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
@@ -105,6 +114,20 @@ func getArchive(filename string) (rc ArchiveReader, metaResult *params.BackupsMe
 		return nil, nil, errors.Trace(err)
 	}
 
+	// An encrypted archive must be decrypted before its metadata
+	// can be read.
+	_, encrypted, err := statebackups.IsEncryptedArchive(archive)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if encrypted {
+		return nil, nil, errors.Errorf("backup archive %q is encrypted", filename)
+	}
+	_, err = archive.Seek(0, os.SEEK_SET)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
 	// Extract the metadata.
 	ad, err := statebackups.NewArchiveDataReader(archive)
 	if err != nil {
@@ -156,3 +179,57 @@ func getArchive(filename string) (rc ArchiveReader, metaResult *params.BackupsMe
 
 	return archive, metaResult, nil
 }
+
+// readKeyFile returns the backup encryption key held in the named file.
+func readKeyFile(filename string) (string, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	key := strings.TrimRight(string(data), "\r\n")
+	if key == "" {
+		return "", errors.Errorf("key file %q is empty", filename)
+	}
+	return key, nil
+}
+
+// decryptArchiveFile writes the decrypted contents of the named backup
+// archive to a temporary file, and returns the name of that file along
+// with a function that removes it. An archive that is not encrypted is
+// used as is.
+func decryptArchiveFile(filename, key string) (_ string, cleanup func(), err error) {
+	archive, err := os.Open(filename)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	defer archive.Close()
+
+	r, encrypted, err := statebackups.IsEncryptedArchive(archive)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	if !encrypted {
+		return filename, func() {}, nil
+	}
+	decrypted, err := statebackups.DecryptArchive(r, key)
+	if err != nil {
+		return "", nil, errors.Annotatef(err, "decrypting %q", filename)
+	}
+
+	// The decrypted archive holds secrets, and so is only readable by
+	// the user; TempFile creates files with mode 0600.
+	f, err := ioutil.TempFile("", statebackups.FilenamePrefix)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	cleanup = func() { os.Remove(f.Name()) }
+	_, err = io.Copy(f, decrypted)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, errors.Annotatef(err, "decrypting %q", filename)
+	}
+	return f.Name(), cleanup, nil
+}
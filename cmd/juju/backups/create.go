@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/state/backups"
 )
@@ -44,6 +45,14 @@ Therefore, you should use the --download or --filename options, or use:
 to get a local copy of the backup archive.
 This local copy can then be used to restore an model even if that
 model was already destroyed or is otherwise unavailable.
+
+The --encrypt option encrypts the backup archive before it is stored,
+using the controller's backup-encryption-key. A different key may be
+given with the --key-file option, which implies --encrypt. When the
+controller's backup-encryption-key is set, all backups are encrypted.
+Encrypted archives can only be restored with the key they were
+encrypted with. The controller's backup-encryption-key is never shown
+by "juju controller-config", so keep a copy of it somewhere safe.
 `
 
 // NewCreateCommand returns a command used to create backups.
@@ -60,6 +69,10 @@ type createCommand struct {
 	Filename string
 	// Notes is the custom message to associated with the new backup.
 	Notes string
+	// Encrypt means the backup archive should be encrypted.
+	Encrypt bool
+	// KeyFile is the file holding the key to encrypt the archive with.
+	KeyFile string
 }
 
 // Info implements Command.Info.
@@ -77,6 +90,8 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.BoolVar(&c.Encrypt, "encrypt", false, "Encrypt the archive")
+	f.StringVar(&c.KeyFile, "key-file", "", "Encrypt the archive with the key in this file")
 }
 
 // Init implements Command.Init.
@@ -93,6 +108,9 @@ func (c *createCommand) Init(args []string) error {
 	if c.Filename == "" {
 		return errors.Errorf("missing filename")
 	}
+	if c.KeyFile != "" {
+		c.Encrypt = true
+	}
 
 	return nil
 }
@@ -110,7 +128,19 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	result, err := client.Create(c.Notes)
+	var result *params.BackupsMetadataResult
+	if c.Encrypt {
+		var key string
+		if c.KeyFile != "" {
+			key, err = readKeyFile(ctx.AbsPath(c.KeyFile))
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		result, err = client.CreateEncrypted(c.Notes, key)
+	} else {
+		result, err = client.Create(c.Notes)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -5,6 +5,8 @@ package backups_test
 
 import (
 	"bytes"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -133,6 +135,39 @@ func (s *createSuite) TestFilenameAndNoDownload(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "cannot mix --no-download and --filename")
 }
 
+func (s *createSuite) TestEncrypt(c *gc.C) {
+	client := s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "spam", "--encrypt", "--no-download")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "spam", "CreateEncrypted")
+	c.Check(client.key, gc.Equals, "")
+}
+
+func (s *createSuite) TestKeyFile(c *gc.C) {
+	keyFile := filepath.Join(c.MkDir(), "backup.key")
+	err := ioutil.WriteFile(keyFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.setSuccess()
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "--key-file", keyFile, "--no-download")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "CreateEncrypted")
+	c.Check(client.key, gc.Equals, "sekrit")
+}
+
+func (s *createSuite) TestKeyFileEmpty(c *gc.C) {
+	keyFile := filepath.Join(c.MkDir(), "backup.key")
+	err := ioutil.WriteFile(keyFile, []byte("\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.setSuccess()
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "--key-file", keyFile, "--no-download")
+	c.Assert(err, gc.ErrorMatches, `key file ".*backup.key" is empty`)
+	c.Check(client.calls, gc.HasLen, 0)
+}
+
 func (s *createSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand)
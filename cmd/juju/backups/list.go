@@ -8,12 +8,20 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
 const listDoc = `
 backups provides the metadata associated with all backups.
+
+By default the backups stored on the controller are listed. With
+--target, the backups that have been copied to the controller's
+backup target are listed instead; these can be restored by ID with
+"juju restore-backup --id" even if the controller no longer stores
+them.
 `
 
 // NewListCommand returns a command used to list metadata for backups.
@@ -24,6 +32,8 @@ func NewListCommand() cmd.Command {
 // listCommand is the sub-command for listing all available backups.
 type listCommand struct {
 	CommandBase
+	// Target indicates that the backups on the backup target are listed.
+	Target bool
 }
 
 // Info implements Command.Info.
@@ -37,6 +47,12 @@ func (c *listCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *listCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.Target, "target", false, "List the backups on the controller's backup target")
+}
+
 // Init implements Command.Init.
 func (c *listCommand) Init(args []string) error {
 	if err := cmd.CheckEmpty(args); err != nil {
@@ -58,7 +74,12 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	result, err := client.List()
+	var result *params.BackupsListResult
+	if c.Target {
+		result, err = client.ListTarget()
+	} else {
+		result, err = client.List()
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
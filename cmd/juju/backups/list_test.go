@@ -42,6 +42,15 @@ func (s *listSuite) TestBrief(c *gc.C) {
 	s.checkStd(c, ctx, out, "")
 }
 
+func (s *listSuite) TestTarget(c *gc.C) {
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, "--target")
+	c.Assert(err, jc.ErrorIsNil)
+	out := s.metaresult.ID + "\n"
+	s.checkStd(c, ctx, out, "")
+	client.Check(c, "", "", "ListTarget")
+}
+
 func (s *listSuite) TestError(c *gc.C) {
 	s.setFailure("failed!")
 	_, err := cmdtesting.RunCommand(c, s.subcommand)
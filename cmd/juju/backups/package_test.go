@@ -32,6 +32,8 @@ stored:          0001-01-01 00:00:00 +0000 UTC
 started:         0001-01-01 00:00:00 +0000 UTC
 finished:        0001-01-01 00:00:00 +0000 UTC
 notes:           ""
+scheduled:       false
+encrypted:       false
 model ID:        ""
 machine ID:      ""
 created on host: ""
@@ -123,6 +125,7 @@ type fakeAPIClient struct {
 	args  []string
 	idArg string
 	notes string
+	key   string
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -141,6 +144,17 @@ func (c *fakeAPIClient) Create(notes string) (*params.BackupsMetadataResult, err
 	return c.metaresult, nil
 }
 
+func (c *fakeAPIClient) CreateEncrypted(notes, key string) (*params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "CreateEncrypted")
+	c.args = append(c.args, "notes", "key")
+	c.notes = notes
+	c.key = key
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.metaresult, nil
+}
+
 func (c *fakeAPIClient) Info(id string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Info")
 	c.args = append(c.args, "id")
@@ -161,6 +175,16 @@ func (c *fakeAPIClient) List() (*params.BackupsListResult, error) {
 	return &result, nil
 }
 
+func (c *fakeAPIClient) ListTarget() (*params.BackupsListResult, error) {
+	c.calls = append(c.calls, "ListTarget")
+	if c.err != nil {
+		return nil, c.err
+	}
+	var result params.BackupsListResult
+	result.List = []params.BackupsMetadataResult{*c.metaresult}
+	return &result, nil
+}
+
 func (c *fakeAPIClient) Download(id string) (io.ReadCloser, error) {
 	c.calls = append(c.calls, "Download")
 	c.args = append(c.args, "id")
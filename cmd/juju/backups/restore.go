@@ -54,6 +54,7 @@ type restoreCommand struct {
 	constraintsStr string
 	filename       string
 	backupId       string
+	keyFile        string
 	bootstrap      bool
 	buildAgent     bool
 
@@ -72,6 +73,9 @@ type RestoreAPI interface {
 	// Restore is taken from backups.Client.
 	Restore(backupId string, newClient backups.ClientConnection) error
 
+	// RestoreEncrypted is taken from backups.Client.
+	RestoreEncrypted(backupId, key string, newClient backups.ClientConnection) error
+
 	// RestoreReader is taken from backups.Client.
 	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient backups.ClientConnection) error
 }
@@ -87,6 +91,14 @@ configured to treat the new controller as their master.
 
 The given constraints will be used to choose the new instance.
 
+A backup given with --id that is no longer stored on the controller is
+fetched from the controller's backup target, if one is configured; see
+"juju backups --target".
+
+An encrypted backup is decrypted with the key in the file given with
+--key-file. A backup given with --id may instead be decrypted with the
+controller's backup-encryption-key, if it was encrypted with that key.
+
 If the provided state cannot be restored, this command will fail with
 an appropriate message.  For instance, if the existing bootstrap
 instance is already running then the command will fail with a message
@@ -112,6 +124,7 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.bootstrap, "b", false, "Bootstrap a new state machine")
 	f.StringVar(&c.filename, "file", "", "Provide a file to be used as the backup.")
 	f.StringVar(&c.backupId, "id", "", "Provide the name of the backup to be restored")
+	f.StringVar(&c.keyFile, "key-file", "", "Decrypt the backup with the key in this file")
 	f.BoolVar(&c.buildAgent, "build-agent", false, "Build binary agent if bootstraping a new machine")
 }
 
@@ -397,6 +410,14 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	var key string
+	if c.keyFile != "" {
+		key, err = readKeyFile(ctx.AbsPath(c.keyFile))
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	var archive ArchiveReader
 	var meta *params.BackupsMetadataResult
 	target := c.backupId
@@ -405,8 +426,16 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		// we'll need the info later regardless if
 		// we need it now to rebootstrap.
 		target = c.filename
-		var err error
-		archive, meta, err = c.getArchiveFunc(c.filename)
+		filename := c.filename
+		if key != "" {
+			var cleanup func()
+			filename, cleanup, err = decryptArchiveFile(c.filename, key)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			defer cleanup()
+		}
+		archive, meta, err = c.getArchiveFunc(filename)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -429,6 +458,8 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	// to restore the backup.
 	if c.filename != "" {
 		err = client.RestoreReader(archive, meta, c.newClient)
+	} else if key != "" {
+		err = client.RestoreEncrypted(c.backupId, key, c.newClient)
 	} else {
 		err = client.Restore(c.backupId, c.newClient)
 	}
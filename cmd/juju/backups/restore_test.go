@@ -4,8 +4,12 @@
 package backups_test
 
 import (
+	"bytes"
 	"io"
+	"io/ioutil"
+	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
@@ -24,6 +28,7 @@ import (
 	"github.com/juju/juju/network"
 	_ "github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/lxd"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
 )
@@ -105,9 +110,51 @@ func (s *restoreSuite) TestRestoreArgs(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "it is not possible to rebootstrap and restore from an id.")
 }
 
+func (s *restoreSuite) writeKeyFile(c *gc.C) string {
+	keyFile := filepath.Join(c.MkDir(), "backup.key")
+	err := ioutil.WriteFile(keyFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return keyFile
+}
+
+func (s *restoreSuite) TestRestoreIDWithKeyFile(c *gc.C) {
+	api := &mockRestoreAPI{}
+	s.command = backups.NewRestoreCommandForTest(s.store, api, nil, nil, nil)
+	_, err := cmdtesting.RunCommand(c, s.command, "restore", "--id", "anid", "--key-file", s.writeKeyFile(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(api.backupId, gc.Equals, "anid")
+	c.Check(api.key, gc.Equals, "sekrit")
+}
+
+func (s *restoreSuite) TestRestoreFileWithKeyFile(c *gc.C) {
+	var encrypted bytes.Buffer
+	err := statebackups.EncryptArchive(&encrypted, strings.NewReader("<compressed tarball>"), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	archiveFile := filepath.Join(c.MkDir(), "juju-backup.tar.gz")
+	err = ioutil.WriteFile(archiveFile, encrypted.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var archiveData string
+	s.command = backups.NewRestoreCommandForTest(
+		s.store, &mockRestoreAPI{},
+		func(filename string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			data, err := ioutil.ReadFile(filename)
+			c.Assert(err, jc.ErrorIsNil)
+			archiveData = string(data)
+			return &mockArchiveReader{}, &params.BackupsMetadataResult{}, nil
+		},
+		nil, nil,
+	)
+	_, err = cmdtesting.RunCommand(c, s.command, "restore", "--file", archiveFile, "--key-file", s.writeKeyFile(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archiveData, gc.Equals, "<compressed tarball>")
+}
+
 // TODO(wallyworld) - add more api related unit tests
 type mockRestoreAPI struct {
 	backups.RestoreAPI
+	backupId string
+	key      string
 }
 
 func (*mockRestoreAPI) Close() error {
@@ -118,6 +165,12 @@ func (*mockRestoreAPI) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResul
 	return nil
 }
 
+func (a *mockRestoreAPI) RestoreEncrypted(backupId, key string, newClient apibackups.ClientConnection) error {
+	a.backupId = backupId
+	a.key = key
+	return nil
+}
+
 type mockArchiveReader struct {
 	backups.ArchiveReader
 }
@@ -0,0 +1,133 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func newCloneModelCommand() modelcmd.ModelCommand {
+	var cmd cloneModelCommand
+	cmd.newAPIRoot = cmd.CommandBase.NewAPIRoot
+	return modelcmd.Wrap(&cmd, modelcmd.WrapSkipModelFlags)
+}
+
+// cloneModelCommand copies a model under a new name. It shares the
+// way the target controller is found and authenticated with migrate.
+type cloneModelCommand struct {
+	migrateCommand
+	cloneAPI     cloneModelAPI
+	newModelName string
+}
+
+type cloneModelAPI interface {
+	CloneModel(spec controller.MigrationSpec, name string) (string, error)
+}
+
+const cloneModelDoc = `
+clone-model creates a new model with the given name from a copy of an
+existing model's config, constraints, applications and relations. The
+new model has no machines or units; it is useful for stamping out a
+staging copy of a production topology and then adding units to it.
+
+The copy is created on the model's own controller, unless a target
+controller is given. As with the "migrate" command, the target
+controller must be in the juju client's local configuration cache.
+
+Application resources that were uploaded to the original model are not
+copied; resources from the charm store are fetched again as needed.
+
+Examples:
+    juju clone-model production staging
+    juju clone-model production staging other-controller
+
+See also:
+    migrate
+    add-unit
+`
+
+// Info implements cmd.Command.
+func (c *cloneModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "clone-model",
+		Args:    "<model-name> <new-model-name> [<target-controller-name>]",
+		Purpose: "Copy a model's applications and relations to a new model.",
+		Doc:     cloneModelDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *cloneModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+}
+
+// Init implements cmd.Command.
+func (c *cloneModelCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("model not specified")
+	}
+	if len(args) < 2 {
+		return errors.New("new model name not specified")
+	}
+	if len(args) > 3 {
+		return errors.New("too many arguments specified")
+	}
+
+	c.SetModelName(args[0], false)
+	c.newModelName = args[1]
+	if len(args) > 2 {
+		c.targetController = args[2]
+	}
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *cloneModelCommand) Run(ctx *cmd.Context) error {
+	if c.targetController == "" {
+		controllerName, err := c.ControllerName()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.targetController = controllerName
+	}
+	spec, err := c.getMigrationSpec()
+	if err != nil {
+		return err
+	}
+	modelName, err := c.ModelName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uuids, err := c.ModelUUIDs([]string{modelName})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	spec.ModelUUID = uuids[0]
+	api, err := c.getCloneAPI()
+	if err != nil {
+		return err
+	}
+	uuid, err := api.CloneModel(*spec, c.newModelName)
+	if err != nil {
+		return err
+	}
+	ctx.Infof("Cloned model %q to %q on controller %q (UUID %s)", modelName, c.newModelName, c.targetController, uuid)
+	return nil
+}
+
+func (c *cloneModelCommand) getCloneAPI() (cloneModelAPI, error) {
+	if c.cloneAPI != nil {
+		return c.cloneAPI, nil
+	}
+	apiRoot, err := c.NewControllerAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return controller.NewClient(apiRoot), nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type CloneModelSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      *fakeCloneModelAPI
+	modelAPI *fakeModelAPI
+	store    *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&CloneModelSuite{})
+
+const sourceControllerUUID = "eeeeeeee-0bad-400d-8000-4b1d0d06f00d"
+
+func (s *CloneModelSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	for name, details := range map[string]jujuclient.ControllerDetails{
+		"source": {
+			ControllerUUID: sourceControllerUUID,
+			APIEndpoints:   []string{"5.4.3.2:1"},
+			CACert:         "somecert",
+		},
+		"target": {
+			ControllerUUID: targetControllerUUID,
+			APIEndpoints:   []string{"1.2.3.4:5"},
+			CACert:         "cert",
+		},
+	} {
+		err := s.store.AddController(name, details)
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.store.UpdateAccount(name, jujuclient.AccountDetails{
+			User:     name + "user",
+			Password: "secret",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.store.SetCurrentController("source")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeCloneModelAPI{}
+	s.modelAPI = &fakeModelAPI{
+		models: []base.UserModel{{
+			Name:  "model",
+			UUID:  modelUUID,
+			Owner: "sourceuser",
+		}},
+	}
+}
+
+func (s *CloneModelSuite) TestMissingModel(c *gc.C) {
+	_, err := s.makeAndRun(c)
+	c.Assert(err, gc.ErrorMatches, "model not specified")
+}
+
+func (s *CloneModelSuite) TestMissingNewName(c *gc.C) {
+	_, err := s.makeAndRun(c, "model")
+	c.Assert(err, gc.ErrorMatches, "new model name not specified")
+}
+
+func (s *CloneModelSuite) TestTooManyArgs(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "staging", "target", "wat")
+	c.Assert(err, gc.ErrorMatches, "too many arguments specified")
+}
+
+func (s *CloneModelSuite) TestSameController(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "staging")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals,
+		`Cloned model "model" to "staging" on controller "source" (UUID new-uuid)`+"\n")
+	c.Check(s.api.nameSeen, gc.Equals, "staging")
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: sourceControllerUUID,
+		TargetAddrs:          []string{"5.4.3.2:1"},
+		TargetCACert:         "somecert",
+		TargetUser:           "sourceuser",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *CloneModelSuite) TestOtherController(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "staging", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals,
+		`Cloned model "model" to "staging" on controller "target" (UUID new-uuid)`+"\n")
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *CloneModelSuite) TestModelDoesntExist(c *gc.C) {
+	_, err := s.makeAndRun(c, "wat", "staging")
+	c.Check(err, gc.ErrorMatches, "model .+ not found")
+	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
+}
+
+func (s *CloneModelSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
+	cmd := newCloneModelCommand()
+	cmd.SetClientStore(s.store)
+	cmd.SetModelAPI(s.modelAPI)
+	inner := modelcmd.InnerCommand(cmd).(*cloneModelCommand)
+	inner.cloneAPI = s.api
+	inner.newAPIRoot = func(jujuclient.ClientStore, string, string) (api.Connection, error) {
+		c.Fatalf("unexpected connection to target controller")
+		return nil, nil
+	}
+	return cmdtesting.RunCommand(c, cmd, args...)
+}
+
+type fakeCloneModelAPI struct {
+	specSeen *controller.MigrationSpec
+	nameSeen string
+}
+
+func (a *fakeCloneModelAPI) CloneModel(spec controller.MigrationSpec, name string) (string, error) {
+	a.specSeen = &spec
+	a.nameSeen = name
+	return "new-uuid", nil
+}
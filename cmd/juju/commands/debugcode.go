@@ -0,0 +1,82 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network/ssh"
+)
+
+// defaultDebugAt is the breakpoint specification used when none is
+// given: the charm should stop at every breakpoint.
+const defaultDebugAt = "all"
+
+func newDebugCodeCommand(hostChecker ssh.ReachableChecker) cmd.Command {
+	c := new(debugCodeCommand)
+	c.setHostChecker(hostChecker)
+	return modelcmd.Wrap(c)
+}
+
+// debugCodeCommand is responsible for launching a ssh shell on a given
+// unit, in which hooks run with breakpoints enabled.
+type debugCodeCommand struct {
+	debugHooksCommand
+}
+
+const debugCodeDoc = `
+Interactively debug hooks or actions remotely on an application unit.
+
+Unlike debug-hooks, which replaces the hook with an interactive shell,
+debug-code runs the hook or action in a tmux session on the unit with the
+JUJU_DEBUG_AT environment variable set to the value of --at. A charm that
+supports breakpoints pauses at the points named by JUJU_DEBUG_AT, so that
+a debugger may be attached in the tmux session.
+
+The value of --at is a comma-separated list of breakpoint names that the
+charm understands, such as "hook" to stop at the start of the hook's code.
+The special value "all" stops at every breakpoint.
+
+If hook or action names are given, only those are debugged; all others
+run as normal.
+
+See the "juju help ssh" for information about SSH related options
+accepted by the debug-code command.
+
+Examples:
+
+    juju debug-code mysql/0
+    juju debug-code --at=hook mysql/0 config-changed backup
+
+See also:
+    debug-hooks
+    ssh
+`
+
+// Info implements Command.
+func (c *debugCodeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "debug-code",
+		Args:    "<unit name> [hook or action names]",
+		Purpose: "Launch a tmux session to debug hooks and actions with breakpoints.",
+		Doc:     debugCodeDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *debugCodeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.debugHooksCommand.SetFlags(f)
+	f.StringVar(&c.debugAt, "at", defaultDebugAt, "Comma-separated list of breakpoints to stop at")
+}
+
+// Init implements Command.
+func (c *debugCodeCommand) Init(args []string) error {
+	if c.debugAt == "" {
+		return errors.New("--at must not be empty")
+	}
+	return c.debugHooksCommand.Init(args)
+}
@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"encoding/base64"
+	"regexp"
+	"runtime"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+var _ = gc.Suite(&DebugCodeSuite{})
+
+type DebugCodeSuite struct {
+	SSHCommonSuite
+}
+
+var debugCodeTests = []struct {
+	info string
+	args []string
+	// debugArgs holds the base64-encoded debug arguments
+	// expected to be written out by the client script.
+	debugArgs string
+	error     string
+}{{
+	info:      `all hooks and actions, stopping at all breakpoints`,
+	args:      []string{"mysql/0"},
+	debugArgs: "ZGVidWctYXQ6IGFsbAo=", // debug-at: all
+}, {
+	info:      `named action and breakpoint`,
+	args:      []string{"--at=hook", "mysql/0", "fakeaction"},
+	debugArgs: "aG9va3M6Ci0gZmFrZWFjdGlvbgpkZWJ1Zy1hdDogaG9vawo=", // hooks: [fakeaction], debug-at: hook
+}, {
+	info:  `empty breakpoints`,
+	args:  []string{"--at=", "mysql/0"},
+	error: `--at must not be empty`,
+}, {
+	info:  `invalid hook`,
+	args:  []string{"mysql/0", "invalid-hook"},
+	error: `unit "mysql/0" does not contain hook or action "invalid-hook"`,
+}, {
+	info:  `no unit`,
+	args:  []string{"--at=hook"},
+	error: `no unit name specified`,
+}}
+
+var debugCodeScriptRE = regexp.MustCompile(`echo (\S+) \| base64 -d > \$F`)
+
+func (s *DebugCodeSuite) TestDebugCodeCommand(c *gc.C) {
+	//TODO(bogdanteleaga): Fix once debughooks are supported on windows
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Skipping on windows for now")
+	}
+
+	s.setupModel(c)
+
+	for i, t := range debugCodeTests {
+		c.Logf("test %d: %s\n\t%s\n", i, t.info, t.args)
+
+		s.setHostChecker(validAddresses("0.public"))
+
+		ctx, err := cmdtesting.RunCommand(c, newDebugCodeCommand(s.hostChecker), t.args...)
+		if t.error != "" {
+			c.Check(err, gc.ErrorMatches, t.error)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		match := debugCodeScriptRE.FindStringSubmatch(cmdtesting.Stdout(ctx))
+		c.Assert(match, gc.HasLen, 2)
+		script, err := base64.StdEncoding.DecodeString(match[1])
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(string(script), jc.Contains, `echo "`+t.debugArgs+`" | base64 -d`)
+	}
+}
@@ -10,10 +10,12 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/charms"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/network/ssh"
 	unitdebug "github.com/juju/juju/worker/uniter/runner/debug"
@@ -29,6 +31,10 @@ func newDebugHooksCommand(hostChecker ssh.ReachableChecker) cmd.Command {
 type debugHooksCommand struct {
 	sshCommand
 	hooks []string
+
+	// debugAt holds the breakpoints to stop at when debugging
+	// code with debug-code. It is empty for debug-hooks.
+	debugAt string
 }
 
 const debugHooksDoc = `
@@ -41,7 +47,7 @@ accepted by the debug-hooks command.
 func (c *debugHooksCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "debug-hooks",
-		Args:    "<unit name> [hook names]",
+		Args:    "<unit name> [hook or action names]",
 		Purpose: "Launch a tmux session to debug a hook.",
 		Doc:     debugHooksDoc,
 	}
@@ -69,14 +75,19 @@ func (c *debugHooksCommand) Init(args []string) error {
 
 type charmRelationsAPI interface {
 	CharmRelations(serviceName string) ([]string, error)
+	GetCharmURL(serviceName string) (*charm.URL, error)
+}
+
+type charmInfoAPI interface {
+	CharmInfo(charmURL string) (*charms.CharmInfo, error)
 }
 
-func (c *debugHooksCommand) getServiceAPI() (charmRelationsAPI, error) {
+func (c *debugHooksCommand) getServiceAPI() (charmRelationsAPI, charmInfoAPI, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
-	return application.NewClient(root), nil
+	return application.NewClient(root), charms.NewClient(root), nil
 }
 
 func (c *debugHooksCommand) validateHooks() error {
@@ -87,7 +98,7 @@ func (c *debugHooksCommand) validateHooks() error {
 	if err != nil {
 		return err
 	}
-	serviceAPI, err := c.getServiceAPI()
+	serviceAPI, charmAPI, err := c.getServiceAPI()
 	if err != nil {
 		return err
 	}
@@ -95,6 +106,14 @@ func (c *debugHooksCommand) validateHooks() error {
 	if err != nil {
 		return err
 	}
+	charmURL, err := serviceAPI.GetCharmURL(service)
+	if err != nil {
+		return err
+	}
+	charmInfo, err := charmAPI.CharmInfo(charmURL.String())
+	if err != nil {
+		return err
+	}
 
 	validHooks := make(map[string]bool)
 	for _, hook := range hooks.UnitHooks() {
@@ -106,6 +125,13 @@ func (c *debugHooksCommand) validateHooks() error {
 			validHooks[hook] = true
 		}
 	}
+	// Actions are run in the same way as hooks, and so may be
+	// debugged in the same way.
+	if charmInfo.Actions != nil {
+		for action := range charmInfo.Actions.ActionSpecs {
+			validHooks[action] = true
+		}
+	}
 	for _, hook := range c.hooks {
 		if !validHooks[hook] {
 			names := make([]string, 0, len(validHooks))
@@ -114,7 +140,7 @@ func (c *debugHooksCommand) validateHooks() error {
 			}
 			sort.Strings(names)
 			logger.Infof("unknown hook %s, valid hook names: %v", hook, names)
-			return errors.Errorf("unit %q does not contain hook %q", c.Target, hook)
+			return errors.Errorf("unit %q does not contain hook or action %q", c.Target, hook)
 		}
 	}
 	return nil
@@ -134,7 +160,7 @@ func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 	debugctx := unitdebug.NewHooksContext(c.Target)
-	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.ClientScript(debugctx, c.hooks)))
+	script := base64.StdEncoding.EncodeToString([]byte(unitdebug.ClientScript(debugctx, c.hooks, c.debugAt)))
 	innercmd := fmt.Sprintf(`F=$(mktemp); echo %s | base64 -d > $F; . $F`, script)
 	args := []string{fmt.Sprintf("sudo /bin/bash -c '%s'", innercmd)}
 	c.Args = args
@@ -103,6 +103,11 @@ var debugHooksTests = []struct {
 	args:        []string{"mysql/0", "juju-info-relation-joined"},
 	hostChecker: validAddresses("0.public"),
 	expected:    nil,
+}, {
+	info:        `actions may be specified`,
+	args:        []string{"mysql/0", "fakeaction"},
+	hostChecker: validAddresses("0.public"),
+	expected:    nil,
 }, {
 	info:  `invalid unit syntax`,
 	args:  []string{"mysql"},
@@ -114,7 +119,7 @@ var debugHooksTests = []struct {
 }, {
 	info:  `invalid hook`,
 	args:  []string{"mysql/0", "invalid-hook"},
-	error: `unit "mysql/0" does not contain hook "invalid-hook"`,
+	error: `unit "mysql/0" does not contain hook or action "invalid-hook"`,
 }, {
 	info:  `no args at all`,
 	args:  nil,
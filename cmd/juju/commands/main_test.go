@@ -403,6 +403,7 @@ var commandNames = []string{
 	"agreements",
 	"attach",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
@@ -411,6 +412,7 @@ var commandNames = []string{
 	"cancel-action",
 	"change-user-password",
 	"charm",
+	"clone-model",
 	"clouds",
 	"collect-metrics",
 	"config",
@@ -420,12 +422,14 @@ var commandNames = []string{
 	"create-storage-pool",
 	"create-wallet",
 	"credentials",
+	"debug-code",
 	"debug-hooks",
 	"debug-log",
 	"deploy",
 	"destroy-controller",
 	"destroy-model",
 	"detach-storage",
+	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -435,6 +439,7 @@ var commandNames = []string{
 	"enable-ha",
 	"enable-user",
 	"expose",
+	"export-bundle",
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
@@ -457,6 +462,8 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-schedules",
+	"list-secrets",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -485,6 +492,7 @@ var commandNames = []string{
 	"remove-credential",
 	"remove-machine",
 	"remove-relation",
+	"remove-schedule",
 	"remove-ssh-key",
 	"remove-storage",
 	"remove-unit",
@@ -496,7 +504,10 @@ var commandNames = []string{
 	"revoke",
 	"run",
 	"run-action",
+	"schedule-action",
+	"schedules",
 	"scp",
+	"secrets",
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
@@ -509,6 +520,7 @@ var commandNames = []string{
 	"show-backup",
 	"show-cloud",
 	"show-controller",
+	"show-hook-history",
 	"show-machine",
 	"show-model",
 	"show-status",
@@ -526,6 +538,7 @@ var commandNames = []string{
 	"subnets",
 	"switch",
 	"sync-tools",
+	"trust",
 	"unexpose",
 	"unregister",
 	"update-clouds",
@@ -536,6 +549,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait-for",
 	"wallets",
 	"whoami",
 }
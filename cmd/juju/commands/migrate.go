@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
@@ -27,10 +30,12 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	targetController string
+	dryRun           bool
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	PrecheckMigration(spec controller.MigrationSpec) ([]string, error)
 }
 
 const migrateDoc = `
@@ -56,6 +61,15 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, the checks made before a migration starts are run
+against both controllers, and every condition that would prevent the
+migration is reported. No migration is started. The command fails if
+any of the checks fail.
+
+Examples:
+    juju migrate mymodel other-controller
+    juju migrate --dry-run mymodel other-controller
+
 See also:
     login
     controllers
@@ -72,6 +86,12 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the model can be migrated without starting a migration")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -140,6 +160,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.precheckMigration(ctx, api, *spec)
+	}
 	id, err := api.InitiateMigration(*spec)
 	if err != nil {
 		return err
@@ -148,6 +171,24 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// precheckMigration reports every condition on either controller that
+// would prevent the model from being migrated.
+func (c *migrateCommand) precheckMigration(ctx *cmd.Context, api migrateAPI, spec controller.MigrationSpec) error {
+	failures, err := api.PrecheckMigration(spec)
+	if err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		ctx.Infof("Migration prechecks passed; no migration was started")
+		return nil
+	}
+	fmt.Fprintln(ctx.Stderr, "Migration prechecks failed:")
+	for _, failure := range failures {
+		fmt.Fprintf(ctx.Stderr, "  %s\n", failure)
+	}
+	return cmd.ErrSilent
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
@@ -210,6 +210,38 @@ func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration prechecks passed; no migration was started\n")
+	c.Check(s.api.initiated, jc.IsFalse)
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunFailures(c *gc.C) {
+	s.api.precheckFailures = []string{
+		"source: machine 0 is dying",
+		"target: upgrade in progress",
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Migration prechecks failed:
+  source: machine 0 is dying
+  target: upgrade in progress
+`[1:])
+	c.Check(s.api.initiated, jc.IsFalse)
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, s.makeCommand(), args...)
 }
@@ -227,14 +259,22 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	specSeen         *controller.MigrationSpec
+	initiated        bool
+	precheckFailures []string
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
 	a.specSeen = &spec
+	a.initiated = true
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) PrecheckMigration(spec controller.MigrationSpec) ([]string, error) {
+	a.specSeen = &spec
+	return a.precheckFailures, nil
+}
+
 type fakeModelAPI struct {
 	models []base.UserModel
 }
@@ -43,6 +43,7 @@ type runCommand struct {
 	services  []string
 	units     []string
 	commands  string
+	stream    bool
 	timeAfter func(time.Duration) <-chan time.Time
 }
 
@@ -76,11 +77,19 @@ targets.
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 
+With --stream, the output of the commands is shown as it is written, with
+each line prefixed by the unit or machine that wrote it, followed by a summary
+of the exit code of the commands on each target. Output that cannot be
+streamed, such as that of commands run on Windows machines or by older
+agents, is shown once the commands have finished.
+
 If you need to pass flags to the command being run, you must precede the
 command and its arguments with "--", to tell "juju run" to stop processing
 those arguments. For example:
 
     juju run --all -- hostname -f
+
+    juju run --application postgresql --stream -- /srv/migrate.sh
 `
 
 func (c *runCommand) Info() *cmd.Info {
@@ -105,6 +114,7 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
+	f.BoolVar(&c.stream, "stream", false, "Show the output of the commands as it is written")
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -138,6 +148,10 @@ func (c *runCommand) Init(args []string) error {
 		}
 	}
 
+	if c.stream && c.out.Name() != "default" {
+		return errors.Errorf("--stream cannot be used with --format")
+	}
+
 	var nameErrors []string
 	for _, machineId := range c.machines {
 		if !names.IsValidMachine(machineId) {
@@ -274,7 +288,25 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 
 	timeout := c.timeAfter(c.timeout)
+	var timedOut bool
+	var output *action.OutputWriter
+	streamed := make(map[names.Tag]bool)
+	if c.stream {
+		output = action.NewOutputWriter(ctx)
+		tags := make([]names.ActionTag, len(actionsToQuery))
+		for i, actionToQuery := range actionsToQuery {
+			tags[i] = actionToQuery.actionTag
+		}
+		streamed, timedOut, err = output.Stream(client, tags, timeout)
+		if err != nil {
+			// Wait for the results without showing the output.
+			logger.Warningf("%v", err)
+			streamed = make(map[names.Tag]bool)
+		}
+	}
+
 	values := []interface{}{}
+	var receivers []names.Tag
 	for len(actionsToQuery) > 0 {
 		actionResults, err := client.Actions(entities(actionsToQuery))
 		if err != nil {
@@ -292,11 +324,11 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 			}
 
 			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+			receivers = append(receivers, actionsToQuery[i].receiver.tag)
 		}
 		actionsToQuery = newActionsToQuery
 
-		if len(actionsToQuery) > 0 {
-			var timedOut bool
+		if len(actionsToQuery) > 0 && !timedOut {
 			select {
 			case <-timeout:
 				timedOut = true
@@ -306,12 +338,19 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				// this should be easier once we implement
 				// action grouping
 			}
-			if timedOut {
-				break
-			}
+		}
+		if timedOut {
+			break
 		}
 	}
 
+	if c.stream {
+		if err := writeStreamedResults(ctx, output, values, receivers, streamed); err != nil {
+			return err
+		}
+		return timedOutError(actionsToQuery)
+	}
+
 	// If we are just dealing with one result, AND we are using the default
 	// format, then pretend we were running it locally.
 	if len(actionsToQuery) == 0 && len(values) == 1 && c.out.Name() == "default" {
@@ -341,20 +380,75 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
-	if n := len(actionsToQuery); n > 0 {
-		// There are action results remaining, so return an error.
-		suffix := ""
-		if n > 1 {
-			suffix = "s"
+	return timedOutError(actionsToQuery)
+}
+
+// timedOutError returns an error naming the receivers of the given
+// actions, whose results were not received in time, or nil if there are
+// none.
+func timedOutError(actionsToQuery []actionQuery) error {
+	n := len(actionsToQuery)
+	if n == 0 {
+		return nil
+	}
+	suffix := ""
+	if n > 1 {
+		suffix = "s"
+	}
+	receivers := make([]string, n)
+	for i, actionToQuery := range actionsToQuery {
+		receivers[i] = names.ReadableString(actionToQuery.receiver.tag)
+	}
+	return errors.Errorf(
+		"timed out waiting for result%s from: %s",
+		suffix, strings.Join(receivers, ", "),
+	)
+}
+
+// writeStreamedResults writes the output of the finished commands whose
+// output was not streamed, followed by a summary of how the commands
+// finished on each receiver. If the commands were run on a single
+// receiver, their exit code is passed through.
+func writeStreamedResults(
+	ctx *cmd.Context,
+	output *action.OutputWriter,
+	values []interface{},
+	receivers []names.Tag,
+	streamed map[names.Tag]bool,
+) error {
+	var summary []string
+	var lastErr error
+	for i, value := range values {
+		result, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.New("couldn't read action output")
 		}
-		receivers := make([]string, n)
-		for i, actionToQuery := range actionsToQuery {
-			receivers[i] = names.ReadableString(actionToQuery.receiver.tag)
+		receiver := receivers[i]
+		name := action.ReceiverName(receiver)
+		if res, ok := result["Error"].(string); ok {
+			summary = append(summary, fmt.Sprintf("%s: error: %s", name, res))
+			lastErr = errors.New(res)
+			continue
+		}
+		if !streamed[receiver] {
+			output.Write(receiver, "stdout", string(formatOutput(result, "Stdout")))
+			output.Write(receiver, "stderr", string(formatOutput(result, "Stderr")))
+		}
+		code, _ := result["ReturnCode"].(int)
+		line := fmt.Sprintf("%s: exit code %d", name, code)
+		if res, ok := result["Message"].(string); ok && res != "" {
+			line += fmt.Sprintf(" (%s)", res)
+		}
+		summary = append(summary, line)
+		if code != 0 {
+			lastErr = cmd.NewRcPassthroughError(code)
 		}
-		return errors.Errorf(
-			"timed out waiting for result%s from: %s",
-			suffix, strings.Join(receivers, ", "),
-		)
+	}
+	for _, line := range summary {
+		fmt.Fprintln(ctx.Stderr, line)
+	}
+	if len(values) == 1 {
+		return lastErr
 	}
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -118,6 +119,10 @@ func (*RunSuite) TestTargetArgParsing(c *gc.C) {
 		machines: []string{"0"},
 		services: []string{"mysql"},
 		units:    []string{"wordpress/0", "wordpress/1"},
+	}, {
+		message:  "stream with a format",
+		args:     []string{"--all", "--stream", "--format=json", "sudo reboot"},
+		errMatch: "--stream cannot be used with --format",
 	}} {
 		c.Log(fmt.Sprintf("%v: %s", i, test.message))
 		cmd := &runCommand{}
@@ -491,6 +496,103 @@ func (s *RunSuite) TestSingleResponse(c *gc.C) {
 	}
 }
 
+func (s *RunSuite) setupStreamMockAPI() *mockRunAPI {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0")
+	mock.setResponse("0", mockResponse{
+		stdout:     "megatron\n",
+		machineTag: "machine-0",
+	})
+	mock.setResponse("mysql/0", mockResponse{
+		stdout:  "migrating\n",
+		stderr:  "slow table\n",
+		code:    "3",
+		message: "exit status 3",
+		unitTag: "unit-mysql-0",
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]:       mock.runResponses["0"],
+		mock.receiverIdMap["mysql/0"]: mock.runResponses["mysql/0"],
+	}
+	machineActionTag := names.NewActionTag(mock.receiverIdMap["0"]).String()
+	unitActionTag := names.NewActionTag(mock.receiverIdMap["mysql/0"]).String()
+	mock.outputMessages = []params.ActionOutputMessage{{
+		ActionTag: machineActionTag,
+		Receiver:  "machine-0",
+		Stream:    "stdout",
+		Data:      "megatron\n",
+	}, {
+		ActionTag: unitActionTag,
+		Receiver:  "unit-mysql-0",
+		Stream:    "stdout",
+		Data:      "migrat",
+	}, {
+		ActionTag: unitActionTag,
+		Receiver:  "unit-mysql-0",
+		Stream:    "stderr",
+		Data:      "slow table\n",
+	}, {
+		ActionTag: unitActionTag,
+		Receiver:  "unit-mysql-0",
+		Stream:    "stdout",
+		Data:      "ing\n",
+	}, {
+		ActionTag: unitActionTag,
+		Receiver:  "unit-mysql-0",
+		Status:    params.ActionCompleted,
+	}, {
+		ActionTag: machineActionTag,
+		Receiver:  "machine-0",
+		Status:    params.ActionCompleted,
+	}}
+	return mock
+}
+
+func (s *RunSuite) TestStream(c *gc.C) {
+	s.setupStreamMockAPI()
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--stream", "--machine=0", "--unit=mysql/0", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, ""+
+		"machine 0: megatron\n"+
+		"mysql/0: migrating\n",
+	)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, ""+
+		"mysql/0: slow table\n"+
+		"machine 0: exit code 0\n"+
+		"mysql/0: exit code 3 (exit status 3)\n",
+	)
+}
+
+func (s *RunSuite) TestStreamSingleResponse(c *gc.C) {
+	s.setupStreamMockAPI()
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--stream", "--unit=mysql/0", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 3")
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "mysql/0: migrating\n")
+	c.Check(cmdtesting.Stderr(context), gc.Equals, ""+
+		"mysql/0: slow table\n"+
+		"mysql/0: exit code 3 (exit status 3)\n",
+	)
+}
+
+func (s *RunSuite) TestStreamNotSupported(c *gc.C) {
+	mock := s.setupStreamMockAPI()
+	mock.watchErr = errors.New("cannot stream action output: not supported")
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(&mockClock{}),
+		"--stream", "--unit=mysql/0", "hostname",
+	)
+	// The output is written once the commands have finished.
+	c.Assert(err, gc.ErrorMatches, "subprocess encountered error code 3")
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "mysql/0: migrating\n")
+	c.Check(cmdtesting.Stderr(context), gc.Equals, ""+
+		"mysql/0: slow table\n"+
+		"mysql/0: exit code 3 (exit status 3)\n",
+	)
+}
+
 func (s *RunSuite) setupMockAPI() *mockRunAPI {
 	mock := &mockRunAPI{}
 	s.PatchValue(&getRunAPIClient, func(_ *runCommand) (RunClient, error) {
@@ -509,6 +611,8 @@ type mockRunAPI struct {
 	runResponses    map[string]params.ActionResult
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
+	outputMessages  []params.ActionOutputMessage
+	watchErr        error
 	block           bool
 }
 
@@ -658,5 +762,17 @@ func (m *mockRunAPI) Actions(actionTags params.Entities) (params.ActionResults,
 	return results, nil
 }
 
+func (m *mockRunAPI) WatchActionOutput(tags []names.ActionTag) (<-chan params.ActionOutputMessage, error) {
+	if m.watchErr != nil {
+		return nil, m.watchErr
+	}
+	messages := make(chan params.ActionOutputMessage, len(m.outputMessages))
+	for _, msg := range m.outputMessages {
+		messages <- msg
+	}
+	close(messages)
+	return messages, nil
+}
+
 // validUUID is a UUID used in tests
 var validUUID = "01234567-89ab-cdef-0123-456789abcdef"
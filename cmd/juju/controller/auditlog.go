@@ -0,0 +1,281 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	apiauditlog "github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+const auditLogDoc = `
+Displays the audit log entries recorded by the controller for API calls
+made by users. Auditing must have been enabled with the
+auditing-enabled controller configuration setting.
+
+Entries can be filtered by the user that made the call, the model it
+was made on, the API facade and method called, and the time at which
+it was made. Methods are specified as <facade> or <facade>.<method>.
+Times may be given in RFC3339 format, as a date and time
+("2006-01-02 15:04"), as a date ("2006-01-02"), or as a duration before
+now ("36h"). Dates and times without a zone are in local time.
+
+The most recent entries matching the filter are displayed, oldest
+first, up to the limit given with --limit.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --method Application.Destroy
+    juju audit-log --model prod --from 2017-09-26 --to 2017-09-27
+    juju audit-log --from 2h --format yaml
+
+See also:
+    controller-config
+`
+
+// NewAuditLogCommand returns a command to query the controller's audit
+// log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{clock: clock.WallClock})
+}
+
+// auditLogCommand displays the controller's audit log entries.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	api   AuditLogAPI
+	clock clock.Clock
+	out   cmd.Output
+
+	user   string
+	model  string
+	method string
+	from   string
+	to     string
+	limit  int
+}
+
+// AuditLogAPI defines the API methods used by the audit-log command.
+type AuditLogAPI interface {
+	Close() error
+	Query(audit.Query) ([]audit.AuditEntry, error)
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Displays the controller's audit log.",
+		Doc:     strings.TrimSpace(auditLogDoc),
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+	f.StringVar(&c.user, "user", "", "Only show calls made by this user")
+	f.StringVar(&c.model, "model", "", "Only show calls made on this model (name or UUID)")
+	f.StringVar(&c.method, "method", "", "Only show calls to this facade or facade method")
+	f.StringVar(&c.from, "from", "", "Only show calls made at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show calls made before this time")
+	f.IntVar(&c.limit, "limit", 100, "Maximum number of entries to show (0 for no limit)")
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.NotValidf("user name %q", c.user)
+	}
+	if c.limit < 0 {
+		return errors.New("limit must not be negative")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *auditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apiauditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	query, err := c.query()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.Query(query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No audit log entries found.")
+		return nil
+	}
+	result := make([]auditLogEntry, len(entries))
+	for i, entry := range entries {
+		result[i] = auditLogEntry{
+			Timestamp:      entry.Timestamp,
+			ModelUUID:      entry.ModelUUID,
+			User:           userName(entry.OriginName),
+			RemoteAddress:  entry.RemoteAddress,
+			ConversationID: entry.ConversationID,
+			Operation:      entry.Operation,
+			Data:           entry.Data,
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+// query builds the audit log query from the command line options.
+func (c *auditLogCommand) query() (audit.Query, error) {
+	query := audit.Query{Limit: c.limit}
+	if c.user != "" {
+		query.OriginName = names.NewUserTag(c.user).String()
+	}
+	if c.model != "" {
+		modelUUID, err := c.modelUUID(c.model)
+		if err != nil {
+			return audit.Query{}, errors.Trace(err)
+		}
+		query.ModelUUID = modelUUID
+	}
+	if c.method != "" {
+		parts := strings.SplitN(c.method, ".", 2)
+		query.Facade = parts[0]
+		if len(parts) == 2 {
+			query.Method = parts[1]
+		}
+	}
+	var err error
+	if c.from != "" {
+		if query.After, err = parseAuditTime(c.from, c.clock.Now()); err != nil {
+			return audit.Query{}, errors.Annotate(err, "invalid --from value")
+		}
+	}
+	if c.to != "" {
+		if query.Before, err = parseAuditTime(c.to, c.clock.Now()); err != nil {
+			return audit.Query{}, errors.Annotate(err, "invalid --to value")
+		}
+	}
+	return query, nil
+}
+
+// modelUUID returns the UUID of the model with the given name or UUID,
+// looking up model names in the client store.
+func (c *auditLogCommand) modelUUID(model string) (string, error) {
+	if utils.IsValidUUIDString(model) {
+		return model, nil
+	}
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	store := c.ClientStore()
+	if !jujuclient.IsQualifiedModelName(model) {
+		account, err := store.AccountDetails(controllerName)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		model = jujuclient.JoinOwnerModelName(names.NewUserTag(account.User), model)
+	}
+	details, err := store.ModelByName(controllerName, model)
+	if errors.IsNotFound(err) {
+		return "", errors.Errorf("model %q not found, specify a model UUID", model)
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	return details.ModelUUID, nil
+}
+
+// auditTimeLayouts are the accepted layouts for absolute times.
+var auditTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseAuditTime parses an absolute time, or a duration before now.
+func parseAuditTime(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d).UTC(), nil
+	}
+	for _, layout := range auditTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, errors.Errorf("cannot parse %q as a time or duration", value)
+}
+
+// userName returns the user name for an audit entry origin, which is
+// normally a user tag.
+func userName(origin string) string {
+	if tag, err := names.ParseUserTag(origin); err == nil {
+		return tag.Id()
+	}
+	return origin
+}
+
+// auditLogEntry defines the serialization behaviour of an audit log
+// entry.
+type auditLogEntry struct {
+	Timestamp      time.Time              `yaml:"timestamp" json:"timestamp"`
+	ModelUUID      string                 `yaml:"model-uuid" json:"model-uuid"`
+	User           string                 `yaml:"user" json:"user"`
+	RemoteAddress  string                 `yaml:"remote-address" json:"remote-address"`
+	ConversationID string                 `yaml:"conversation-id,omitempty" json:"conversation-id,omitempty"`
+	Operation      string                 `yaml:"operation" json:"operation"`
+	Data           map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
+}
+
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Model", "User", "Address", "Operation")
+	for _, entry := range entries {
+		w.Println(
+			entry.Timestamp.UTC().Format("2006-01-02 15:04:05"),
+			entry.ModelUUID,
+			entry.User,
+			entry.RemoteAddress,
+			entry.Operation,
+		)
+	}
+	return tw.Flush()
+}
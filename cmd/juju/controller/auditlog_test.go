@@ -0,0 +1,165 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *testing.Clock
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	store := jujuclient.NewMemStore()
+	store.CurrentControllerName = "mallards"
+	store.Controllers["mallards"] = jujuclient.ControllerDetails{}
+	store.Accounts["mallards"] = jujuclient.AccountDetails{User: "admin"}
+	store.Models["mallards"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/prod": {ModelUUID: coretesting.ModelTag.Id()},
+		},
+	}
+	s.store = store
+	s.clock = testing.NewClock(time.Date(2017, time.September, 27, 12, 0, 0, 0, time.UTC))
+	s.api = &fakeAuditLogAPI{
+		entries: []audit.AuditEntry{{
+			JujuServerVersion: version.MustParse("2.3.0"),
+			ModelUUID:         coretesting.ModelTag.Id(),
+			Timestamp:         time.Date(2017, time.September, 26, 10, 0, 0, 0, time.UTC),
+			RemoteAddress:     "10.0.0.1:54321",
+			OriginType:        "API request",
+			OriginName:        "user-bob",
+			Operation:         "Application:v4 - Destroy",
+			Data:              map[string]interface{}{"request-body": "x"},
+		}},
+	}
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.store, s.clock)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+	_, err = s.run(c, "--user", "not/valid")
+	c.Assert(err, gc.ErrorMatches, `user name "not/valid" not valid`)
+	_, err = s.run(c, "--limit", "-1")
+	c.Assert(err, gc.ErrorMatches, `limit must not be negative`)
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                 Model                                 User  Address         Operation
+2017-09-26 10:00:00  deadbeef-0bad-400d-8000-4b1d0d06f00d  bob   10.0.0.1:54321  Application:v4 - Destroy
+`[1:])
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Query", []interface{}{audit.Query{Limit: 100}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestJSON(c *gc.C) {
+	ctx, err := s.run(c, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `[{"timestamp":"2017-09-26T10:00:00Z",`+
+		`"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","user":"bob",`+
+		`"remote-address":"10.0.0.1:54321","operation":"Application:v4 - Destroy",`+
+		`"data":{"request-body":"x"}}]`+"\n")
+}
+
+func (s *AuditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No audit log entries found.\n")
+}
+
+func (s *AuditLogSuite) TestFilters(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "prod",
+		"--method", "Application.Destroy",
+		"--from", "2017-09-26T00:00:00Z",
+		"--to", "2h",
+		"--limit", "0",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Query", audit.Query{
+		OriginName: "user-bob",
+		ModelUUID:  coretesting.ModelTag.Id(),
+		Facade:     "Application",
+		Method:     "Destroy",
+		After:      time.Date(2017, time.September, 26, 0, 0, 0, 0, time.UTC),
+		Before:     time.Date(2017, time.September, 27, 10, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *AuditLogSuite) TestFilterModelUUIDAndFacade(c *gc.C) {
+	_, err := s.run(c, "--model", coretesting.ModelTag.Id(), "--method", "Application")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Query", audit.Query{
+		ModelUUID: coretesting.ModelTag.Id(),
+		Facade:    "Application",
+		Limit:     100,
+	})
+}
+
+func (s *AuditLogSuite) TestUnknownModel(c *gc.C) {
+	_, err := s.run(c, "--model", "staging")
+	c.Assert(err, gc.ErrorMatches, `model "admin/staging" not found, specify a model UUID`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *AuditLogSuite) TestInvalidTime(c *gc.C) {
+	_, err := s.run(c, "--from", "last tuesday")
+	c.Assert(err, gc.ErrorMatches, `invalid --from value: cannot parse "last tuesday" as a time or duration`)
+}
+
+func (s *AuditLogSuite) TestQueryError(c *gc.C) {
+	s.api.SetErrors(errors.New("permission denied"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	testing.Stub
+	entries []audit.AuditEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeAuditLogAPI) Query(query audit.Query) ([]audit.AuditEntry, error) {
+	f.MethodCall(f, "Query", query)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.entries, nil
+}
@@ -173,3 +173,11 @@ func FmtModelStatus(data ModelData) string {
 func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, error) {
 	return newData(api, ctrUUID)
 }
+
+// NewAuditLogCommandForTest returns an audit-log command with the api
+// and clock provided as specified.
+func NewAuditLogCommandForTest(api AuditLogAPI, store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &auditLogCommand{api: api, clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -5,6 +5,7 @@ package controller
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -26,7 +27,8 @@ Shows detailed information of a controller.`[1:]
 
 var usageShowControllerDetails = `
 Shows extended information about a controller(s) as well as related models
-and user login details.
+and user login details. Controller administrators are also shown the
+controller's backup schedule and its most recent backup.
 
 Examples:
     juju show-controller
@@ -86,6 +88,7 @@ type ControllerAccessAPI interface {
 	ModelConfig() (map[string]interface{}, error)
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 	AllModels() ([]base.UserModel, error)
+	BackupStatus() (params.BackupStatus, error)
 	Close() error
 }
 
@@ -150,6 +153,7 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 			continue
 		}
 		c.convertControllerForShow(&details, controllerName, one, access, allModels, modelStatus)
+		c.convertBackupsForShow(&details, client)
 		controllers[controllerName] = details
 	}
 	return c.out.Write(ctx, controllers)
@@ -204,6 +208,10 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// Backups holds the backup schedule of the controller and its most
+	// recent backup.
+	Backups *BackupDetails `yaml:"backups,omitempty" json:"backups,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -268,6 +276,18 @@ type AccountDetails struct {
 	Password string `yaml:"password,omitempty" json:"password,omitempty"`
 }
 
+// BackupDetails holds details of a controller's backups to show.
+type BackupDetails struct {
+	// Schedule is the schedule on which the controller creates backups.
+	Schedule string `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+
+	// LastBackup is the ID of the most recent backup.
+	LastBackup string `yaml:"last-backup,omitempty" json:"last-backup,omitempty"`
+
+	// LastBackupStarted is when the most recent backup was started.
+	LastBackupStarted string `yaml:"last-backup-started,omitempty" json:"last-backup-started,omitempty"`
+}
+
 func (c *showControllerCommand) convertControllerForShow(
 	controller *ShowControllerDetails,
 	controllerName string,
@@ -310,6 +330,29 @@ func (c *showControllerCommand) convertControllerForShow(
 	}
 }
 
+func (c *showControllerCommand) convertBackupsForShow(controller *ShowControllerDetails, client ControllerAccessAPI) {
+	status, err := client.BackupStatus()
+	if errors.IsNotSupported(err) || params.IsCodeUnauthorized(err) {
+		// Older controllers don't report their backups, and only
+		// controller admins may see them.
+		return
+	}
+	if err != nil {
+		controller.Errors = append(controller.Errors, err.Error())
+		return
+	}
+	if status.Schedule == "" && status.LastBackupID == "" {
+		return
+	}
+	controller.Backups = &BackupDetails{
+		Schedule:   status.Schedule,
+		LastBackup: status.LastBackupID,
+	}
+	if !status.LastBackupStarted.IsZero() {
+		controller.Backups.LastBackupStarted = status.LastBackupStarted.UTC().Format(time.RFC3339)
+	}
+}
+
 func (c *showControllerCommand) convertAccountsForShow(controllerName string, controller *ShowControllerDetails, access string) {
 	storeDetails, err := c.store.AccountDetails(controllerName)
 	if err != nil && !errors.IsNotFound(err) {
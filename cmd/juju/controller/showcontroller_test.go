@@ -4,7 +4,9 @@
 package controller_test
 
 import (
+	"encoding/json"
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -14,6 +16,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -319,6 +322,33 @@ func (s *ShowControllerSuite) TestShowControllerUnrecognizedFlag(c *gc.C) {
 	s.assertShowControllerFailed(c, "-m", "my.world")
 }
 
+func (s *ShowControllerSuite) TestShowControllerWithBackups(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+`
+	s.fakeController.store = s.createTestClientStore(c)
+	s.fakeController.backupStatus = &params.BackupStatus{
+		Schedule:          "@daily",
+		LastBackupID:      "20170601-020000.this-is-another-uuid",
+		LastBackupStarted: time.Date(2017, 6, 1, 2, 0, 0, 0, time.UTC),
+	}
+
+	context, err := s.runShowController(c, "mallards", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	var out map[string]map[string]interface{}
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(context)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out["mallards"]["backups"], jc.DeepEquals, map[string]interface{}{
+		"schedule":            "@daily",
+		"last-backup":         "20170601-020000.this-is-another-uuid",
+		"last-backup-started": "2017-06-01T02:00:00Z",
+	})
+}
+
 func (s *ShowControllerSuite) TestShowControllerUnrecognizedOptionFlag(c *gc.C) {
 	s.expectedErr = `flag provided but not defined: --model`
 	s.assertShowControllerFailed(c, "--model", "still.my.world")
@@ -344,6 +374,7 @@ type fakeController struct {
 	store          jujuclient.ClientStore
 	modelNames     map[string]string
 	machines       map[string][]base.Machine
+	backupStatus   *params.BackupStatus
 }
 
 func (*fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -383,6 +414,13 @@ func (c *fakeController) AllModels() (result []base.UserModel, _ error) {
 	return result, nil
 }
 
+func (c *fakeController) BackupStatus() (params.BackupStatus, error) {
+	if c.backupStatus == nil {
+		return params.BackupStatus{}, errors.NotSupportedf("showing backup status on this controller")
+	}
+	return *c.backupStatus, nil
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
@@ -0,0 +1,110 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewExportBundleCommand returns a fully constructed export bundle command.
+func NewExportBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&exportBundleCommand{})
+}
+
+type exportBundleCommand struct {
+	modelcmd.ModelCommandBase
+	api      ExportBundleAPI
+	Filename string
+}
+
+const exportBundleHelpDoc = `
+Exports the current model configuration as a reusable bundle.
+
+The bundle describes the applications, charms, configuration,
+constraints, placement, relations, endpoint bindings and storage
+directives of the model, so that it can be deployed again with
+"juju deploy". Applications deployed from local charms are exported
+with their local charm URL and need to be edited before the bundle
+can be deployed elsewhere.
+
+If --filename is not used, the bundle is displayed in stdout.
+
+Examples:
+
+    juju export-bundle
+    juju export-bundle --filename mymodel.yaml
+
+See also:
+    deploy
+`
+
+// Info implements Command.
+func (c *exportBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-bundle",
+		Purpose: "Exports the current model configuration as a reusable bundle.",
+		Doc:     exportBundleHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.Filename, "filename", "", "Bundle file")
+}
+
+// Init implements Command.
+func (c *exportBundleCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// ExportBundleAPI specifies the used function calls of the Bundle facade.
+type ExportBundleAPI interface {
+	Close() error
+	ExportBundle() (string, error)
+}
+
+func (c *exportBundleCommand) getAPI() (ExportBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bundle.NewClient(api), nil
+}
+
+// Run implements Command.
+func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	result, err := client.ExportBundle()
+	if err != nil {
+		return err
+	}
+
+	if c.Filename == "" {
+		_, err := fmt.Fprint(ctx.Stdout, result)
+		return err
+	}
+	filename := ctx.AbsPath(c.Filename)
+	if err := ioutil.WriteFile(filename, []byte(result), 0644); err != nil {
+		return errors.Annotate(err, "while saving bundle")
+	}
+	fmt.Fprintf(ctx.Stdout, "Bundle successfully exported to %s\n", filename)
+	return nil
+}
@@ -0,0 +1,21 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+func NewListSecretsCommandForTest(store jujuclient.ClientStore, api ListSecretsAPI) cmd.Command {
+	c := &listSecretsCommand{
+		newAPIFunc: func() (ListSecretsAPI, error) {
+			return api, nil
+		},
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the commands for inspecting the secrets
+// owned by applications in a model.
+package secrets
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	apisecrets "github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// ListSecretsAPI defines the API methods used by the secrets command.
+type ListSecretsAPI interface {
+	ListSecrets(owner string, showSecrets bool) ([]params.Secret, error)
+	Close() error
+}
+
+// listSecretsCommand lists the secrets in a model.
+type listSecretsCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	owner       string
+	showSecrets bool
+
+	newAPIFunc func() (ListSecretsAPI, error)
+}
+
+// NewListSecretsCommand returns a command to list the secrets in a model.
+func NewListSecretsCommand() cmd.Command {
+	c := &listSecretsCommand{}
+	c.newAPIFunc = func() (ListSecretsAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return apisecrets.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+const listSecretsDoc = `
+Lists the secrets added by the applications in the model with secret-add.
+The values of the secrets are only shown if --show-secrets is specified,
+which requires admin access to the model.
+
+Examples:
+    juju secrets
+    juju secrets --owner mysql
+    juju secrets --owner mysql --show-secrets --format yaml
+`
+
+// Info is part of the cmd.Command interface.
+func (c *listSecretsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "secrets",
+		Purpose: "Lists the secrets in a model.",
+		Doc:     listSecretsDoc,
+		Aliases: []string{"list-secrets"},
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *listSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.owner, "owner", "", "Only list the secrets owned by this application")
+	f.BoolVar(&c.showSecrets, "show-secrets", false, "Include the values of the secrets")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": printSecretsTabular,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *listSecretsCommand) Init(args []string) error {
+	if c.owner != "" && !names.IsValidApplication(c.owner) {
+		return errors.NotValidf("application name %q", c.owner)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+type secretOutput struct {
+	Id             string            `yaml:"id" json:"id"`
+	Owner          string            `yaml:"owner" json:"owner"`
+	Label          string            `yaml:"label,omitempty" json:"label,omitempty"`
+	Description    string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Revision       int               `yaml:"revision" json:"revision"`
+	RotateInterval string            `yaml:"rotate-interval,omitempty" json:"rotate-interval,omitempty"`
+	NextRotate     string            `yaml:"next-rotate,omitempty" json:"next-rotate,omitempty"`
+	GrantedTo      []string          `yaml:"granted-to,omitempty" json:"granted-to,omitempty"`
+	Created        string            `yaml:"created" json:"created"`
+	Updated        string            `yaml:"updated" json:"updated"`
+	Value          map[string]string `yaml:"value,omitempty" json:"value,omitempty"`
+}
+
+// Run is part of the cmd.Command interface.
+func (c *listSecretsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	secrets, err := api.ListSecrets(c.owner, c.showSecrets)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(secrets) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No secrets to display.")
+		return nil
+	}
+	result := make([]secretOutput, len(secrets))
+	for i, secret := range secrets {
+		out := secretOutput{
+			Id:          secret.Id,
+			Label:       secret.Label,
+			Description: secret.Description,
+			Revision:    secret.Revision,
+			Created:     formatSecretTime(secret.Created),
+			Updated:     formatSecretTime(secret.Updated),
+			Value:       secret.Value,
+		}
+		owner, err := names.ParseApplicationTag(secret.Owner)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		out.Owner = owner.Id()
+		if secret.RotateInterval > 0 {
+			out.RotateInterval = secret.RotateInterval.String()
+		}
+		if secret.NextRotateTime != nil {
+			out.NextRotate = formatSecretTime(*secret.NextRotateTime)
+		}
+		for _, grant := range secret.Grants {
+			tag, err := names.ParseRelationTag(grant)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			out.GrantedTo = append(out.GrantedTo, tag.Id())
+		}
+		result[i] = out
+	}
+	return c.out.Write(ctx, result)
+}
+
+// printSecretsTabular prints the secrets in tabular format. The values
+// of the secrets are only shown in the yaml and json formats.
+func printSecretsTabular(writer io.Writer, value interface{}) error {
+	secrets, ok := value.([]secretOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "ID\tOwner\tLabel\tRevision\tRotate\tGranted to\tUpdated")
+	for _, s := range secrets {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			s.Id, s.Owner, s.Label, s.Revision, s.RotateInterval, strings.Join(s.GrantedTo, ","), s.Updated,
+		)
+	}
+	return tw.Flush()
+}
+
+func formatSecretTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,116 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ListSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+	api   *mockListSecretsAPI
+}
+
+var _ = gc.Suite(&ListSuite{})
+
+func (s *ListSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Accounts["ctrl"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	created := time.Date(2017, 7, 1, 12, 0, 0, 0, time.UTC)
+	rotate := created.Add(24 * time.Hour)
+	s.api = &mockListSecretsAPI{
+		secrets: []params.Secret{{
+			Id:             "1",
+			Owner:          "application-mysql",
+			Label:          "root",
+			Revision:       2,
+			RotateInterval: 24 * time.Hour,
+			NextRotateTime: &rotate,
+			Grants:         []string{"relation-wordpress.db#mysql.server"},
+			Created:        created,
+			Updated:        created,
+		}},
+	}
+}
+
+func (s *ListSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := secrets.NewListSecretsCommandForTest(s.store, s.api)
+	return cmdtesting.RunCommand(c, command, append([]string{"-m", "admin"}, args...)...)
+}
+
+func (s *ListSuite) TestInitInvalidOwner(c *gc.C) {
+	_, err := s.run(c, "--owner", "foo/0")
+	c.Assert(err, gc.ErrorMatches, `application name "foo/0" not valid`)
+}
+
+func (s *ListSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ListSecrets", "", false)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"ID  Owner  Label  Revision  Rotate   Granted to                 Updated\n"+
+		"1   mysql  root   2         24h0m0s  wordpress:db mysql:server  2017-07-01T12:00:00Z\n",
+	)
+}
+
+func (s *ListSuite) TestListShowSecrets(c *gc.C) {
+	s.api.secrets[0].Value = map[string]string{"password": "s3cret"}
+	ctx, err := s.run(c, "--owner", "mysql", "--show-secrets", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ListSecrets", "mysql", true)
+	var out []map[string]interface{}
+	err = json.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, []map[string]interface{}{{
+		"id":              "1",
+		"owner":           "mysql",
+		"label":           "root",
+		"revision":        float64(2),
+		"rotate-interval": "24h0m0s",
+		"next-rotate":     "2017-07-02T12:00:00Z",
+		"granted-to":      []interface{}{"wordpress:db mysql:server"},
+		"created":         "2017-07-01T12:00:00Z",
+		"updated":         "2017-07-01T12:00:00Z",
+		"value":           map[string]interface{}{"password": "s3cret"},
+	}})
+}
+
+func (s *ListSuite) TestListNone(c *gc.C) {
+	s.api.secrets = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No secrets to display.\n")
+}
+
+type mockListSecretsAPI struct {
+	jujutesting.Stub
+	secrets []params.Secret
+}
+
+func (m *mockListSecretsAPI) ListSecrets(owner string, showSecrets bool) ([]params.Secret, error) {
+	m.MethodCall(m, "ListSecrets", owner, showSecrets)
+	return m.secrets, m.NextErr()
+}
+
+func (m *mockListSecretsAPI) Close() error {
+	return nil
+}
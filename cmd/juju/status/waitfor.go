@@ -0,0 +1,427 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+)
+
+const waitForDoc = `
+Blocks until the given entity of the model reaches the requested
+status, or the timeout expires. The command exits with a non-zero
+status if the timeout expires, or if the entity goes into an error
+state while waiting for another status.
+
+The entity may be one of:
+
+    application <name>: waits until the application has at least one
+        unit, and all of its units have the workload status given by
+        --status (default "active") and the agent status given by
+        --agent-status (default "idle").
+    unit <name>: waits until the unit has the workload and agent
+        statuses given by --status and --agent-status.
+    machine <id>: waits until the machine agent has the status given
+        by --status (default "started").
+    model: waits until no machine or unit of the model is in error.
+
+The model is watched for changes, rather than polled.
+
+Examples:
+    juju wait-for application mysql
+    juju wait-for application mysql --status blocked --timeout 5m
+    juju wait-for unit mysql/0 --agent-status executing
+    juju wait-for machine 0
+    juju wait-for model
+
+See also:
+    show-status
+    show-status-log
+`
+
+// The entity types that wait-for can wait on.
+const (
+	waitForApplication = "application"
+	waitForUnit        = "unit"
+	waitForMachine     = "machine"
+	waitForModel       = "model"
+)
+
+// defaultWaitForTimeout is the time wait-for waits for the condition to
+// hold by default.
+const defaultWaitForTimeout = 10 * time.Minute
+
+// AllWatcher provides the changes to the entities of a model.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// WaitForAPI provides the API methods the wait-for command needs.
+type WaitForAPI interface {
+	Close() error
+	WatchAll() (AllWatcher, error)
+}
+
+// NewWaitForCommand returns a command that waits until an entity of
+// the model reaches a given status.
+func NewWaitForCommand() cmd.Command {
+	return modelcmd.Wrap(&waitForCommand{})
+}
+
+type waitForCommand struct {
+	modelcmd.ModelCommandBase
+
+	entityType  string
+	name        string
+	status      string
+	agentStatus string
+	timeout     time.Duration
+
+	clock      clock.Clock
+	newAPIFunc func() (WaitForAPI, error)
+}
+
+// Info is part of cmd.Command.
+func (c *waitForCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait-for",
+		Args:    "(application|unit|machine) <name> | model",
+		Purpose: "Waits until an entity of the model reaches a given status.",
+		Doc:     waitForDoc,
+	}
+}
+
+// SetFlags is part of cmd.Command.
+func (c *waitForCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.status, "status", "", "The workload status, or machine agent status, to wait for")
+	f.StringVar(&c.agentStatus, "agent-status", "", "The unit agent status to wait for")
+	f.DurationVar(&c.timeout, "timeout", defaultWaitForTimeout, "How long to wait before failing")
+}
+
+// Init is part of cmd.Command.
+func (c *waitForCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no entity type specified")
+	}
+	c.entityType, args = args[0], args[1:]
+	switch c.entityType {
+	case waitForApplication, waitForUnit, waitForMachine:
+		if len(args) == 0 {
+			return errors.Errorf("no %s specified", c.entityType)
+		}
+		c.name, args = args[0], args[1:]
+	case waitForModel:
+	default:
+		return errors.Errorf("entity type %q not valid, expected application, unit, machine or model", c.entityType)
+	}
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+
+	switch c.entityType {
+	case waitForApplication:
+		if !names.IsValidApplication(c.name) {
+			return errors.NotValidf("application name %q", c.name)
+		}
+	case waitForUnit:
+		if !names.IsValidUnit(c.name) {
+			return errors.NotValidf("unit name %q", c.name)
+		}
+	case waitForMachine:
+		if !names.IsValidMachine(c.name) {
+			return errors.NotValidf("machine id %q", c.name)
+		}
+		if c.agentStatus != "" {
+			return errors.New("--agent-status is not valid when waiting for a machine")
+		}
+	case waitForModel:
+		if c.status != "" || c.agentStatus != "" {
+			return errors.New("--status and --agent-status are not valid when waiting for a model")
+		}
+	}
+	if c.timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+
+	switch c.entityType {
+	case waitForApplication, waitForUnit:
+		if c.status == "" {
+			c.status = string(status.Active)
+		}
+		if c.agentStatus == "" {
+			c.agentStatus = string(status.Idle)
+		}
+	case waitForMachine:
+		if c.status == "" {
+			c.status = string(status.Started)
+		}
+	}
+	return nil
+}
+
+// Run is part of cmd.Command.
+func (c *waitForCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "watching model")
+	}
+	defer watcher.Stop()
+
+	type nextResult struct {
+		deltas []multiwatcher.Delta
+		err    error
+	}
+	results := make(chan nextResult)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			select {
+			case results <- nextResult{deltas, err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	clk := c.clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
+	timeout := clk.After(c.timeout)
+	model := newWaitForModel()
+	reason := "no model information received"
+	for {
+		select {
+		case result := <-results:
+			if result.err != nil {
+				return errors.Annotate(result.err, "watching model")
+			}
+			model.update(result.deltas)
+			reason, err = c.check(model)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if reason == "" {
+				ctx.Infof("%s", c.description())
+				return nil
+			}
+			ctx.Verbosef("waiting: %s", reason)
+		case <-timeout:
+			return errors.Errorf("timed out after %v waiting for %s: %s", c.timeout, c.description(), reason)
+		}
+	}
+}
+
+func (c *waitForCommand) newAPI() (WaitForAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return waitForAPIAdapter{client}, nil
+}
+
+// description describes the condition waited for.
+func (c *waitForCommand) description() string {
+	switch c.entityType {
+	case waitForApplication:
+		return fmt.Sprintf("application %q units to be %s/%s", c.name, c.status, c.agentStatus)
+	case waitForUnit:
+		return fmt.Sprintf("unit %q to be %s/%s", c.name, c.status, c.agentStatus)
+	case waitForMachine:
+		return fmt.Sprintf("machine %q to be %s", c.name, c.status)
+	}
+	return "model to have no machines or units in error"
+}
+
+// check returns why the condition waited for does not hold yet, or an
+// empty string if it holds. An error is returned if an entity went
+// into an error state that was not waited for.
+func (c *waitForCommand) check(model *waitForModel) (string, error) {
+	switch c.entityType {
+	case waitForApplication:
+		if _, ok := model.applications[c.name]; !ok {
+			return fmt.Sprintf("application %q not found", c.name), nil
+		}
+		units := model.applicationUnits(c.name)
+		if len(units) == 0 {
+			return fmt.Sprintf("application %q has no units", c.name), nil
+		}
+		var pending []string
+		for _, unit := range units {
+			reason, err := c.checkUnit(unit)
+			if err != nil {
+				return "", errors.Trace(err)
+			}
+			if reason != "" {
+				pending = append(pending, reason)
+			}
+		}
+		return strings.Join(pending, ", "), nil
+	case waitForUnit:
+		unit, ok := model.units[c.name]
+		if !ok {
+			return fmt.Sprintf("unit %q not found", c.name), nil
+		}
+		return c.checkUnit(unit)
+	case waitForMachine:
+		machine, ok := model.machines[c.name]
+		if !ok {
+			return fmt.Sprintf("machine %q not found", c.name), nil
+		}
+		current := machine.AgentStatus.Current
+		if string(current) == c.status {
+			return "", nil
+		}
+		if current == status.Error && c.status != string(status.Error) {
+			return "", errors.Errorf("machine %q is in error: %s", c.name, machine.AgentStatus.Message)
+		}
+		if machine.InstanceStatus.Current == status.ProvisioningError {
+			return "", errors.Errorf("machine %q failed to provision: %s", c.name, machine.InstanceStatus.Message)
+		}
+		return fmt.Sprintf("machine %q is %s", c.name, current), nil
+	}
+	var inError []string
+	for _, id := range model.machineIds() {
+		machine := model.machines[id]
+		if machine.AgentStatus.Current == status.Error || machine.InstanceStatus.Current == status.ProvisioningError {
+			inError = append(inError, "machine "+id)
+		}
+	}
+	for _, name := range model.unitNames() {
+		unit := model.units[name]
+		if unit.WorkloadStatus.Current == status.Error || unit.AgentStatus.Current == status.Error {
+			inError = append(inError, "unit "+name)
+		}
+	}
+	if len(inError) == 0 {
+		return "", nil
+	}
+	return strings.Join(inError, ", ") + " in error", nil
+}
+
+func (c *waitForCommand) checkUnit(unit *multiwatcher.UnitInfo) (string, error) {
+	workload, agent := unit.WorkloadStatus.Current, unit.AgentStatus.Current
+	if string(workload) == c.status && string(agent) == c.agentStatus {
+		return "", nil
+	}
+	if workload == status.Error && c.status != string(status.Error) {
+		return "", errors.Errorf("unit %q is in error: %s", unit.Name, unit.WorkloadStatus.Message)
+	}
+	return fmt.Sprintf("%s is %s/%s", unit.Name, workload, agent), nil
+}
+
+// waitForModel holds the machines, applications and units of a model,
+// as reported by an AllWatcher.
+type waitForModel struct {
+	applications map[string]*multiwatcher.ApplicationInfo
+	units        map[string]*multiwatcher.UnitInfo
+	machines     map[string]*multiwatcher.MachineInfo
+}
+
+func newWaitForModel() *waitForModel {
+	return &waitForModel{
+		applications: make(map[string]*multiwatcher.ApplicationInfo),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+		machines:     make(map[string]*multiwatcher.MachineInfo),
+	}
+}
+
+// update applies the given deltas to the model.
+func (m *waitForModel) update(deltas []multiwatcher.Delta) {
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(m.applications, info.Name)
+			} else {
+				m.applications[info.Name] = info
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(m.units, info.Name)
+			} else {
+				m.units[info.Name] = info
+			}
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(m.machines, info.Id)
+			} else {
+				m.machines[info.Id] = info
+			}
+		}
+	}
+}
+
+// applicationUnits returns the units of the named application, sorted
+// by name.
+func (m *waitForModel) applicationUnits(application string) []*multiwatcher.UnitInfo {
+	var units []*multiwatcher.UnitInfo
+	for _, name := range m.unitNames() {
+		if unit := m.units[name]; unit.Application == application {
+			units = append(units, unit)
+		}
+	}
+	return units
+}
+
+func (m *waitForModel) unitNames() []string {
+	result := make([]string, 0, len(m.units))
+	for name := range m.units {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (m *waitForModel) machineIds() []string {
+	result := make([]string, 0, len(m.machines))
+	for id := range m.machines {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// waitForAPIAdapter adapts an api.Client to the WaitForAPI interface.
+type waitForAPIAdapter struct {
+	*api.Client
+}
+
+// WatchAll is part of the WaitForAPI interface.
+func (a waitForAPIAdapter) WatchAll() (AllWatcher, error) {
+	watcher, err := a.Client.WatchAll()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return watcher, nil
+}
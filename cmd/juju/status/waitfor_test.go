@@ -0,0 +1,230 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/state/multiwatcher"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type waitForSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	clock   *testing.Clock
+	watcher *fakeAllWatcher
+}
+
+var _ = gc.Suite(&waitForSuite{})
+
+func (s *waitForSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.watcher = &fakeAllWatcher{
+		deltas:  make(chan []multiwatcher.Delta, 10),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (s *waitForSuite) runWaitFor(c *gc.C, args ...string) error {
+	store := jujuclient.NewMemStore()
+	store.CurrentControllerName = "foo"
+	store.Controllers["foo"] = jujuclient.ControllerDetails{
+		APIEndpoints: []string{"0.1.2.3:1234"},
+	}
+	store.Models["foo"] = &jujuclient.ControllerModels{
+		CurrentModel: "admin/bar",
+		Models:       map[string]jujuclient.ModelDetails{"admin/bar": {}},
+	}
+	command := &waitForCommand{
+		clock: s.clock,
+		newAPIFunc: func() (WaitForAPI, error) {
+			return &fakeWaitForAPI{watcher: s.watcher}, nil
+		},
+	}
+	command.SetClientStore(store)
+	_, err := cmdtesting.RunCommand(c, modelcmd.Wrap(command), args...)
+	return err
+}
+
+func unitDelta(name, application string, workload, agent status.Status, message string) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    application,
+		WorkloadStatus: multiwatcher.StatusInfo{Current: workload, Message: message},
+		AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+	}}
+}
+
+func applicationDelta(name string) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{Name: name}}
+}
+
+func machineDelta(id string, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{
+		Id:          id,
+		AgentStatus: multiwatcher.StatusInfo{Current: agent},
+	}}
+}
+
+func (s *waitForSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no entity type specified",
+	}, {
+		args: []string{"relation"},
+		err:  `entity type "relation" not valid, expected application, unit, machine or model`,
+	}, {
+		args: []string{"application"},
+		err:  "no application specified",
+	}, {
+		args: []string{"unit", "mysql"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"machine", "0", "--agent-status", "idle"},
+		err:  "--agent-status is not valid when waiting for a machine",
+	}, {
+		args: []string{"model", "--status", "active"},
+		err:  "--status and --agent-status are not valid when waiting for a model",
+	}, {
+		args: []string{"model", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"application", "mysql", "--timeout", "0s"},
+		err:  "timeout must be positive",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := s.runWaitFor(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *waitForSuite) TestApplication(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", "mysql", status.Maintenance, status.Executing, "installing"),
+		unitDelta("mysql/1", "mysql", status.Active, status.Idle, ""),
+	}
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle, ""),
+	}
+	err := s.runWaitFor(c, "application", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.watcher.checkStopped(c)
+}
+
+func (s *waitForSuite) TestApplicationStatus(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", "mysql", status.Blocked, status.Idle, "needs a relation"),
+	}
+	err := s.runWaitFor(c, "application", "mysql", "--status", "blocked")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *waitForSuite) TestUnitError(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", "mysql", status.Error, status.Idle, `hook failed: "install"`),
+	}
+	err := s.runWaitFor(c, "unit", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" is in error: hook failed: "install"`)
+}
+
+func (s *waitForSuite) TestMachine(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		machineDelta("0", status.Pending),
+	}
+	s.watcher.deltas <- []multiwatcher.Delta{
+		machineDelta("0", status.Started),
+	}
+	err := s.runWaitFor(c, "machine", "0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *waitForSuite) TestModelNoErrors(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		machineDelta("0", status.Error),
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle, ""),
+	}
+	s.watcher.deltas <- []multiwatcher.Delta{
+		{Removed: true, Entity: &multiwatcher.MachineInfo{Id: "0"}},
+	}
+	err := s.runWaitFor(c, "model")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *waitForSuite) TestTimeout(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		applicationDelta("mysql"),
+		unitDelta("mysql/0", "mysql", status.Maintenance, status.Executing, "installing"),
+	}
+	go func() {
+		c.Check(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	}()
+	err := s.runWaitFor(c, "application", "mysql", "--timeout", "1m")
+	c.Assert(err, gc.ErrorMatches, `timed out after 1m0s waiting for application "mysql" units to be active/idle: mysql/0 is maintenance/executing`)
+	s.watcher.checkStopped(c)
+}
+
+func (s *waitForSuite) TestWatcherError(c *gc.C) {
+	s.watcher.err = errors.New("boom")
+	err := s.runWaitFor(c, "model")
+	c.Assert(err, gc.ErrorMatches, "watching model: boom")
+}
+
+type fakeWaitForAPI struct {
+	watcher *fakeAllWatcher
+}
+
+func (f *fakeWaitForAPI) Close() error {
+	return nil
+}
+
+func (f *fakeWaitForAPI) WatchAll() (AllWatcher, error) {
+	return f.watcher, nil
+}
+
+type fakeAllWatcher struct {
+	deltas  chan []multiwatcher.Delta
+	stopped chan struct{}
+	err     error
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+	select {
+	case deltas := <-w.deltas:
+		return deltas, nil
+	case <-w.stopped:
+		return nil, errors.New("watcher stopped")
+	}
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	close(w.stopped)
+	return nil
+}
+
+func (w *fakeAllWatcher) checkStopped(c *gc.C) {
+	select {
+	case <-w.stopped:
+	default:
+		c.Fatalf("watcher not stopped")
+	}
+}
@@ -59,6 +59,7 @@ import (
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/instance"
@@ -71,6 +72,7 @@ import (
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/state/statemetrics"
@@ -80,6 +82,7 @@ import (
 	"github.com/juju/juju/watcher"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/dblogpruner"
@@ -1138,6 +1141,18 @@ func (a *MachineAgent) startStateWorkers(
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour, clock.WallClock), nil
 			})
+
+			controllerConfig, err := st.ControllerConfig()
+			if err != nil {
+				return nil, errors.Annotate(err, "getting controller config")
+			}
+			// The backup config is only read here, so changes to
+			// it take effect when the agent is restarted.
+			if controllerConfig.BackupSchedule() != "" {
+				a.startWorkerAfterUpgrade(singularRunner, "backupscheduler", func() (worker.Worker, error) {
+					return newBackupScheduler(st, agentConfig, a.machineId, controllerConfig)
+				})
+			}
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -1145,6 +1160,35 @@ func (a *MachineAgent) startStateWorkers(
 	return runner, nil
 }
 
+// newBackupScheduler returns a worker that creates backups of the
+// controller on the schedule in the controller config.
+func newBackupScheduler(
+	st *state.State, agentConfig agent.Config, machineId string, controllerConfig controller.Config,
+) (worker.Worker, error) {
+	schedule, err := actions.ParseSchedule(controllerConfig.BackupSchedule())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backupPaths := backups.Paths{
+		DataDir: agentConfig.DataDir(),
+		LogsDir: agentConfig.LogDir(),
+	}
+	w, err := backupscheduler.New(backupscheduler.Config{
+		Backups:  backupscheduler.NewStateBackups(st, backupPaths, machineId),
+		Schedule: schedule,
+		Retention: backupscheduler.Retention{
+			KeepLast:   controllerConfig.BackupKeepLast(),
+			KeepDaily:  controllerConfig.BackupKeepDaily(),
+			KeepWeekly: controllerConfig.BackupKeepWeekly(),
+		},
+		Clock: clock.WallClock,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot start backup scheduler worker")
+	}
+	return w, nil
+}
+
 // startModelWorkers starts the set of workers that run for every model
 // in each controller.
 func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (worker.Worker, error) {
@@ -1252,16 +1296,18 @@ func (a *MachineAgent) newAPIserverWorker(
 		return nil, errors.Annotate(err, "cannot fetch the controller config")
 	}
 
+	auditEntrySink, closeAuditEntrySink := newAuditEntrySink(st, logDir, controllerConfig.AuditLogForward())
 	newObserver, err := newObserverFn(
 		controllerConfig,
 		clock.WallClock,
 		jujuversion.Current,
 		agentConfig.Model().Id(),
-		newAuditEntrySink(st, logDir),
+		auditEntrySink,
 		auditErrorHandler,
 		a.prometheusRegistry,
 	)
 	if err != nil {
+		closeAuditEntrySink()
 		return nil, errors.Annotate(err, "cannot create RPC observer factory")
 	}
 	statePool := state.NewStatePool(st)
@@ -1299,8 +1345,13 @@ func (a *MachineAgent) newAPIserverWorker(
 		PrometheusRegisterer:          a.prometheusRegistry,
 	})
 	if err != nil {
+		closeAuditEntrySink()
 		return nil, errors.Annotate(err, "cannot start api server worker")
 	}
+	go func() {
+		server.Wait()
+		closeAuditEntrySink()
+	}()
 
 	return server, nil
 }
@@ -1391,9 +1442,43 @@ func getRateLimitConfig(cfg agent.Config) (apiserver.RateLimitConfig, error) {
 	return result, nil
 }
 
-func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
-	persistFn := st.PutAuditEntryFn()
-	fileSinkFn := audit.NewLogFileSink(logDir)
+// newAuditEntrySink returns the sink that the API server's audit
+// entries are written to, and a function that releases the resources
+// held by the sink once it is no longer used.
+func newAuditEntrySink(st *state.State, logDir string, forward bool) (audit.AuditEntrySinkFn, func()) {
+	sinks := []audit.AuditEntrySinkFn{
+		annotateAuditSink(st.PutAuditEntryFn(), "cannot save audit record to database"),
+		annotateAuditSink(audit.NewLogFileSink(logDir), "cannot save audit record to file"),
+	}
+	closeSink := func() {}
+	if forward {
+		// Audit records are written to the controller model's logs
+		// so that they are sent to any log forwarding sink. The
+		// version is recorded so that the records are valid for
+		// forwarding.
+		dbLogger := state.NewDbLogger(st)
+		closeSink = dbLogger.Close
+		logFn := func(t time.Time, entity, module, location string, level loggo.Level, msg string) error {
+			tag, err := names.ParseTag(entity)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			return dbLogger.LogRecord(&state.LogRecord{
+				Time:     t,
+				Entity:   tag,
+				Version:  jujuversion.Current,
+				Module:   module,
+				Location: location,
+				Level:    level,
+				Message:  msg,
+				Kind:     state.LogKindAudit,
+			})
+		}
+		sinks = append(sinks, annotateAuditSink(
+			audit.NewLogRecordSink(logFn), "cannot save audit record to logs",
+		))
+	}
+	sink := audit.NewMultiSink(sinks...)
 	return func(entry audit.AuditEntry) error {
 		// We don't care about auditing anything but user actions.
 		if _, err := names.ParseUserTag(entry.OriginName); err != nil {
@@ -1403,15 +1488,13 @@ func newAuditEntrySink(st *state.State, logDir string) audit.AuditEntrySinkFn {
 		if strings.HasPrefix(entry.Operation, "Pinger:") {
 			return nil
 		}
-		persistErr := persistFn(entry)
-		sinkErr := fileSinkFn(entry)
-		if persistErr == nil {
-			return errors.Annotate(sinkErr, "cannot save audit record to file")
-		}
-		if sinkErr == nil {
-			return errors.Annotate(persistErr, "cannot save audit record to database")
-		}
-		return errors.Annotate(persistErr, "cannot save audit record to file or database")
+		return sink(entry)
+	}, closeSink
+}
+
+func annotateAuditSink(sink audit.AuditEntrySinkFn, message string) audit.AuditEntrySinkFn {
+	return func(entry audit.AuditEntry) error {
+		return errors.Annotate(sink(entry), message)
 	}
 }
 
@@ -1444,6 +1527,8 @@ func newObserverFn(
 			ctx := &observer.AuditContext{
 				JujuServerVersion: jujuServerVersion,
 				ModelUUID:         modelUUID,
+				ExcludeMethods:    controllerConfig.AuditLogExcludeMethods(),
+				CaptureArgs:       controllerConfig.AuditLogCaptureArgs(),
 			}
 			return observer.NewAudit(ctx, persistAuditEntry, auditErrorHandler)
 		})
@@ -4,6 +4,7 @@
 package model
 
 import (
+	"path/filepath"
 	"time"
 
 	"github.com/juju/utils/clock"
@@ -18,6 +19,7 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -275,6 +277,12 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     statushistorypruner.NewFacade,
 			PruneInterval: config.StatusHistoryPrunerInterval,
 		})),
+		actionSchedulerName: ifNotMigrating(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			NewWorker:     actionscheduler.New,
+			NewFacade:     actionscheduler.NewFacade,
+		})),
 		machineUndertakerName: ifNotMigrating(machineundertaker.Manifold(machineundertaker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -283,8 +291,12 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
-				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
+				// Files are kept apart for each model, under
+				// the controller's log directory.
+				Name: "juju-log-forward",
+				OpenFn: sinks.NewOpenFunc(filepath.Join(
+					config.Agent.CurrentConfig().LogDir(), "logforward", modelTag.Id(),
+				)),
 			}},
 		})),
 	}
@@ -387,6 +399,7 @@ const (
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
+	actionSchedulerName      = "action-scheduler"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
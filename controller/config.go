@@ -6,15 +6,19 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"path"
+	"regexp"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/utils"
 	utilscert "github.com/juju/utils/cert"
+	"github.com/juju/utils/set"
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/actions"
 )
 
 const (
@@ -32,6 +36,79 @@ const (
 	// auditing information.
 	AuditingEnabled = "auditing-enabled"
 
+	// AuditLogMaxSize is the maximum size of the capped audit log
+	// collection, eg "300M".
+	AuditLogMaxSize = "audit-log-max-size"
+
+	// AuditLogForward determines whether audit entries are also
+	// written to the controller model's logs, so that they are sent
+	// to any configured log forwarding sink.
+	AuditLogForward = "audit-log-forward"
+
+	// AuditLogExcludeMethods is a list of the API methods, in the form
+	// "Facade.Method", whose calls are not recorded in the audit log,
+	// eg "Client.FullStatus".
+	AuditLogExcludeMethods = "audit-log-exclude-methods"
+
+	// AuditLogCaptureArgs determines whether the arguments of audited
+	// API calls, and the errors they return, are recorded in the
+	// audit log. Secret values are redacted. It defaults to true.
+	AuditLogCaptureArgs = "audit-log-capture-args"
+
+	// BackupSchedule is the schedule on which the controller creates
+	// backups of itself, in the form accepted for scheduled actions,
+	// eg "@daily" or "0 2 * * *". If empty, backups are only created
+	// on request. The scheduler only runs if this is set when the
+	// controller agent starts, so setting it on a running controller
+	// takes effect once the agent is restarted.
+	BackupSchedule = "backup-schedule"
+
+	// BackupKeepLast is the number of the most recent scheduled
+	// backups that are kept when older ones are pruned.
+	BackupKeepLast = "backup-keep-last"
+
+	// BackupKeepDaily is the number of days for which the most recent
+	// scheduled backup of each day is kept, in addition to those kept
+	// by BackupKeepLast.
+	BackupKeepDaily = "backup-keep-daily"
+
+	// BackupKeepWeekly is the number of weeks for which the most
+	// recent scheduled backup of each week is kept, in addition to
+	// those kept by BackupKeepLast and BackupKeepDaily.
+	BackupKeepWeekly = "backup-keep-weekly"
+
+	// BackupTarget is the URL of the location outside the controller
+	// that backups are copied to once they have been created, either
+	// "file:///<directory>" or "s3://<bucket>[/<prefix>]". If empty,
+	// backups are only stored on the controller. Backups are never
+	// removed from the target by the controller.
+	BackupTarget = "backup-target"
+
+	// BackupS3Endpoint is the URL of the S3-compatible service used by
+	// an s3:// backup target. If empty, the Amazon S3 endpoint for
+	// BackupS3Region is used.
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region of the S3-compatible service used
+	// by an s3:// backup target.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3AccessKey is the access key used to authenticate with
+	// the service used by an s3:// backup target.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to authenticate with
+	// the service used by an s3:// backup target. It is only read by
+	// the controller and is never returned to API clients.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
+	// BackupEncryptionKey is the passphrase that backup archives are
+	// encrypted with by default. When set, every backup the
+	// controller creates, including scheduled backups, is encrypted.
+	// It is only read by the controller and is never returned to API
+	// clients.
+	BackupEncryptionKey = "backup-encryption-key"
+
 	// StatePort is the port used for mongo connections.
 	StatePort = "state-port"
 
@@ -88,6 +165,22 @@ const (
 	// AuditingEnabled config value.
 	DefaultAuditingEnabled = false
 
+	// DefaultAuditLogMaxSizeMB is the maximum size of the audit log
+	// collection.
+	DefaultAuditLogMaxSizeMB = 300 // 300 MB
+
+	// DefaultAuditLogCaptureArgs is whether the arguments of audited
+	// API calls are recorded by default.
+	DefaultAuditLogCaptureArgs = true
+
+	// DefaultBackupKeepLast is the number of the most recent
+	// scheduled backups that are kept by default.
+	DefaultBackupKeepLast = 7
+
+	// DefaultBackupS3Region is the region of the service used by an
+	// s3:// backup target by default.
+	DefaultBackupS3Region = "us-east-1"
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -118,8 +211,22 @@ const (
 var ControllerOnlyConfigAttributes = []string{
 	AllowModelAccessKey,
 	APIPort,
+	AuditLogCaptureArgs,
+	AuditLogExcludeMethods,
+	AuditLogForward,
+	AuditLogMaxSize,
 	AutocertDNSNameKey,
 	AutocertURLKey,
+	BackupEncryptionKey,
+	BackupKeepDaily,
+	BackupKeepLast,
+	BackupKeepWeekly,
+	BackupS3AccessKey,
+	BackupS3Endpoint,
+	BackupS3Region,
+	BackupS3SecretKey,
+	BackupSchedule,
+	BackupTarget,
 	CACertKey,
 	ControllerUUIDKey,
 	IdentityPublicKey,
@@ -143,6 +250,14 @@ func ControllerOnlyAttribute(attr string) bool {
 	return false
 }
 
+// SecretConfigAttributes are attributes whose values are only read by
+// the controller itself, and are removed from the config before it is
+// returned to API clients.
+var SecretConfigAttributes = []string{
+	BackupEncryptionKey,
+	BackupS3SecretKey,
+}
+
 type Config map[string]interface{}
 
 // Validate validates the controller configuration.
@@ -150,6 +265,19 @@ func (c Config) Validate() error {
 	return Validate(c)
 }
 
+// WithoutSecrets returns a copy of the config with the
+// SecretConfigAttributes removed.
+func (c Config) WithoutSecrets() Config {
+	result := make(Config, len(c))
+	for k, v := range c {
+		result[k] = v
+	}
+	for _, attr := range SecretConfigAttributes {
+		delete(result, attr)
+	}
+	return result
+}
+
 // NewConfig creates a new Config from the supplied attributes.
 // Default values will be used where defaults are available.
 //
@@ -191,6 +319,17 @@ func (c Config) asString(name string) string {
 	return value
 }
 
+// asInt returns the named attribute as an integer, returning 0 if it
+// isn't found.
+func (c Config) asInt(name string) int {
+	// Values obtained over the api are encoded as float64.
+	if value, ok := c[name].(float64); ok {
+		return int(value)
+	}
+	value, _ := c[name].(int)
+	return value
+}
+
 // mustString returns the named attribute as an string, panicking if
 // it is not found or is empty.
 func (c Config) mustString(name string) string {
@@ -220,6 +359,117 @@ func (c Config) AuditingEnabled() bool {
 	return false
 }
 
+// AuditLogMaxSizeMB returns the maximum size in MiB of the audit log
+// collection.
+func (c Config) AuditLogMaxSizeMB() int {
+	v, ok := c[AuditLogMaxSize].(string)
+	if !ok {
+		return DefaultAuditLogMaxSizeMB
+	}
+	// Value has already been validated.
+	val, _ := utils.ParseSize(v)
+	return int(val)
+}
+
+// AuditLogForward returns whether audit entries should be forwarded
+// along with the controller model's logs. The default is false.
+func (c Config) AuditLogForward() bool {
+	value, _ := c[AuditLogForward].(bool)
+	return value
+}
+
+// AuditLogExcludeMethods returns the API methods, in the form
+// "Facade.Method", whose calls should not be recorded in the audit
+// log.
+func (c Config) AuditLogExcludeMethods() set.Strings {
+	methods := set.NewStrings()
+	switch value := c[AuditLogExcludeMethods].(type) {
+	case []interface{}:
+		for _, method := range value {
+			methods.Add(method.(string))
+		}
+	case []string:
+		methods = set.NewStrings(value...)
+	}
+	return methods
+}
+
+// AuditLogCaptureArgs returns whether the arguments and errors of
+// audited API calls should be recorded. The default is true.
+func (c Config) AuditLogCaptureArgs() bool {
+	value, ok := c[AuditLogCaptureArgs].(bool)
+	if !ok {
+		return DefaultAuditLogCaptureArgs
+	}
+	return value
+}
+
+// BackupSchedule returns the schedule on which the controller creates
+// backups of itself, or "" if backups are only created on request.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupKeepLast returns the number of the most recent scheduled
+// backups that are kept when older ones are pruned.
+func (c Config) BackupKeepLast() int {
+	if _, ok := c[BackupKeepLast]; !ok {
+		return DefaultBackupKeepLast
+	}
+	return c.asInt(BackupKeepLast)
+}
+
+// BackupKeepDaily returns the number of days for which the most recent
+// scheduled backup of each day is kept. The default is 0.
+func (c Config) BackupKeepDaily() int {
+	return c.asInt(BackupKeepDaily)
+}
+
+// BackupKeepWeekly returns the number of weeks for which the most
+// recent scheduled backup of each week is kept. The default is 0.
+func (c Config) BackupKeepWeekly() int {
+	return c.asInt(BackupKeepWeekly)
+}
+
+// BackupTarget returns the URL of the location that backups are
+// copied to, or "" if backups are only stored on the controller.
+func (c Config) BackupTarget() string {
+	return c.asString(BackupTarget)
+}
+
+// BackupS3Endpoint returns the URL of the S3-compatible service used by
+// an s3:// backup target, or "" to use Amazon S3.
+func (c Config) BackupS3Endpoint() string {
+	return c.asString(BackupS3Endpoint)
+}
+
+// BackupS3Region returns the region of the S3-compatible service used
+// by an s3:// backup target.
+func (c Config) BackupS3Region() string {
+	if v := c.asString(BackupS3Region); v != "" {
+		return v
+	}
+	return DefaultBackupS3Region
+}
+
+// BackupS3AccessKey returns the access key used by an s3:// backup
+// target.
+func (c Config) BackupS3AccessKey() string {
+	return c.asString(BackupS3AccessKey)
+}
+
+// BackupS3SecretKey returns the secret key used by an s3:// backup
+// target.
+func (c Config) BackupS3SecretKey() string {
+	return c.asString(BackupS3SecretKey)
+}
+
+// BackupEncryptionKey returns the passphrase that backup archives are
+// encrypted with by default, or "" if they are not encrypted by default.
+func (c Config) BackupEncryptionKey() string {
+	return c.asString(BackupEncryptionKey)
+}
+
 // ControllerUUID returns the uuid for the model's controller.
 func (c Config) ControllerUUID() string {
 	return c.mustString(ControllerUUIDKey)
@@ -373,6 +623,66 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[AuditLogMaxSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid audit log max size in configuration")
+		}
+	}
+
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := actions.ParseSchedule(v); err != nil {
+			return errors.Annotate(err, "invalid backup schedule in configuration")
+		}
+	}
+
+	if v, ok := c[BackupTarget].(string); ok && v != "" {
+		if err := validateBackupTarget(v); err != nil {
+			return errors.Annotate(err, "invalid backup target in configuration")
+		}
+	}
+
+	for _, name := range []string{BackupKeepLast, BackupKeepDaily, BackupKeepWeekly} {
+		if _, ok := c[name]; ok && c.asInt(name) < 0 {
+			return errors.Errorf("%s: expected non-negative integer, got %d", name, c.asInt(name))
+		}
+	}
+	if c.BackupKeepLast()+c.BackupKeepDaily()+c.BackupKeepWeekly() == 0 {
+		return errors.Errorf("%s, %s and %s are all zero, so no backups would be kept",
+			BackupKeepLast, BackupKeepDaily, BackupKeepWeekly)
+	}
+
+	for _, method := range c.AuditLogExcludeMethods().SortedValues() {
+		if !validAuditMethod.MatchString(method) {
+			return errors.Errorf("invalid audit log exclude method %q, expected Facade.Method", method)
+		}
+	}
+
+	return nil
+}
+
+// validAuditMethod matches the API methods that may be excluded from
+// the audit log.
+var validAuditMethod = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*\.[A-Z][A-Za-z0-9]*$`)
+
+// validateBackupTarget checks that the backup target URL names a
+// directory or an S3 bucket.
+func validateBackupTarget(target string) error {
+	u, err := url.Parse(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Host != "" || !path.IsAbs(u.Path) {
+			return errors.Errorf("%q: expected file:///<directory>", target)
+		}
+	case "s3":
+		if u.Host == "" {
+			return errors.Errorf("%q: expected s3://<bucket>[/<prefix>]", target)
+		}
+	default:
+		return errors.Errorf("%q: expected file or s3 URL", target)
+	}
 	return nil
 }
 
@@ -384,7 +694,21 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 
 var configChecker = schema.FieldMap(schema.Fields{
 	AuditingEnabled:         schema.Bool(),
+	AuditLogMaxSize:         schema.String(),
+	AuditLogForward:         schema.Bool(),
+	AuditLogExcludeMethods:  schema.List(schema.String()),
+	AuditLogCaptureArgs:     schema.Bool(),
 	APIPort:                 schema.ForceInt(),
+	BackupSchedule:          schema.String(),
+	BackupKeepLast:          schema.ForceInt(),
+	BackupKeepDaily:         schema.ForceInt(),
+	BackupKeepWeekly:        schema.ForceInt(),
+	BackupTarget:            schema.String(),
+	BackupS3Endpoint:        schema.String(),
+	BackupS3Region:          schema.String(),
+	BackupS3AccessKey:       schema.String(),
+	BackupS3SecretKey:       schema.String(),
+	BackupEncryptionKey:     schema.String(),
 	StatePort:               schema.ForceInt(),
 	IdentityURL:             schema.String(),
 	IdentityPublicKey:       schema.String(),
@@ -399,6 +723,20 @@ var configChecker = schema.FieldMap(schema.Fields{
 }, schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	AuditingEnabled:         DefaultAuditingEnabled,
+	AuditLogMaxSize:         schema.Omit,
+	AuditLogForward:         schema.Omit,
+	AuditLogExcludeMethods:  schema.Omit,
+	AuditLogCaptureArgs:     schema.Omit,
+	BackupSchedule:          schema.Omit,
+	BackupKeepLast:          schema.Omit,
+	BackupKeepDaily:         schema.Omit,
+	BackupKeepWeekly:        schema.Omit,
+	BackupTarget:            schema.Omit,
+	BackupS3Endpoint:        schema.Omit,
+	BackupS3Region:          schema.Omit,
+	BackupS3AccessKey:       schema.Omit,
+	BackupS3SecretKey:       schema.Omit,
+	BackupEncryptionKey:     schema.Omit,
 	StatePort:               DefaultStatePort,
 	IdentityURL:             schema.Omit,
 	IdentityPublicKey:       schema.Omit,
@@ -121,6 +121,61 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "invalid audit log max size",
+	config: controller.Config{
+		controller.AuditLogMaxSize: "abc",
+		controller.CACertKey:       testing.CACert,
+	},
+	expectError: `invalid audit log max size in configuration: .*`,
+}, {
+	about: "invalid audit log exclude method",
+	config: controller.Config{
+		controller.AuditLogExcludeMethods: []interface{}{"Client.FullStatus", "FullStatus"},
+		controller.CACertKey:              testing.CACert,
+	},
+	expectError: `invalid audit log exclude method "FullStatus", expected Facade.Method`,
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.BackupSchedule: "@fortnightly",
+		controller.CACertKey:      testing.CACert,
+	},
+	expectError: `invalid backup schedule in configuration: .*`,
+}, {
+	about: "negative backup retention",
+	config: controller.Config{
+		controller.BackupKeepDaily: -1,
+		controller.CACertKey:       testing.CACert,
+	},
+	expectError: `backup-keep-daily: expected non-negative integer, got -1`,
+}, {
+	about: "backup retention keeps nothing",
+	config: controller.Config{
+		controller.BackupKeepLast: 0,
+		controller.CACertKey:      testing.CACert,
+	},
+	expectError: `backup-keep-last, backup-keep-daily and backup-keep-weekly are all zero, so no backups would be kept`,
+}, {
+	about: "valid backup target",
+	config: controller.Config{
+		controller.BackupTarget: "s3://juju-backups/controller",
+		controller.CACertKey:    testing.CACert,
+	},
+}, {
+	about: "relative backup target directory",
+	config: controller.Config{
+		controller.BackupTarget: "file://backups",
+		controller.CACertKey:    testing.CACert,
+	},
+	expectError: `invalid backup target in configuration: "file://backups": expected file:///<directory>`,
+}, {
+	about: "unknown backup target scheme",
+	config: controller.Config{
+		controller.BackupTarget: "ftp://backups.example.com/juju",
+		controller.CACertKey:    testing.CACert,
+	},
+	expectError: `invalid backup target in configuration: "ftp://backups.example.com/juju": expected file or s3 URL`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -173,3 +228,85 @@ func (s *ConfigSuite) TestTxnLogConfigValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MaxTxnLogSizeMB(), gc.Equals, 8192)
 }
+
+func (s *ConfigSuite) TestAuditLogConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogMaxSizeMB(), gc.Equals, 300)
+	c.Assert(cfg.AuditLogForward(), jc.IsFalse)
+	c.Assert(cfg.AuditLogExcludeMethods().IsEmpty(), jc.IsTrue)
+	c.Assert(cfg.AuditLogCaptureArgs(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestAuditLogConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"audit-log-max-size":        "1G",
+			"audit-log-forward":         true,
+			"audit-log-exclude-methods": []interface{}{"Client.FullStatus", "Pinger.Ping"},
+			"audit-log-capture-args":    false,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AuditLogMaxSizeMB(), gc.Equals, 1024)
+	c.Assert(cfg.AuditLogForward(), jc.IsTrue)
+	c.Assert(cfg.AuditLogExcludeMethods().SortedValues(), jc.DeepEquals, []string{"Client.FullStatus", "Pinger.Ping"})
+	c.Assert(cfg.AuditLogCaptureArgs(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestBackupConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "")
+	c.Assert(cfg.BackupKeepLast(), gc.Equals, controller.DefaultBackupKeepLast)
+	c.Assert(cfg.BackupKeepDaily(), gc.Equals, 0)
+	c.Assert(cfg.BackupKeepWeekly(), gc.Equals, 0)
+	c.Assert(cfg.BackupTarget(), gc.Equals, "")
+	c.Assert(cfg.BackupS3Region(), gc.Equals, controller.DefaultBackupS3Region)
+	c.Assert(cfg.BackupEncryptionKey(), gc.Equals, "")
+}
+
+func (s *ConfigSuite) TestBackupConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule":       "0 2 * * *",
+			"backup-keep-last":      "3",
+			"backup-keep-daily":     7,
+			"backup-keep-weekly":    4,
+			"backup-encryption-key": "sekrit",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupEncryptionKey(), gc.Equals, "sekrit")
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "0 2 * * *")
+	c.Assert(cfg.BackupKeepLast(), gc.Equals, 3)
+	c.Assert(cfg.BackupKeepDaily(), gc.Equals, 7)
+	c.Assert(cfg.BackupKeepWeekly(), gc.Equals, 4)
+}
+
+func (s *ConfigSuite) TestWithoutSecrets(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-target":         "s3://juju-backups",
+			"backup-s3-access-key":  "access",
+			"backup-s3-secret-key":  "secret",
+			"backup-encryption-key": "sekrit",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	filtered := cfg.WithoutSecrets()
+	c.Assert(filtered.BackupS3AccessKey(), gc.Equals, "access")
+	for _, attr := range []string{controller.BackupS3SecretKey, controller.BackupEncryptionKey} {
+		_, ok := filtered[attr]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", attr))
+	}
+	// The original config is unchanged.
+	c.Assert(cfg.BackupS3SecretKey(), gc.Equals, "secret")
+	c.Assert(cfg.BackupEncryptionKey(), gc.Equals, "sekrit")
+}
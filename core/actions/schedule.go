@@ -0,0 +1,247 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule describes when a scheduled action runs. All times are
+// interpreted in UTC.
+type Schedule interface {
+	// Next returns the first time after the given time that the
+	// schedule is due, or the zero time if it never is.
+	Next(after time.Time) time.Time
+
+	// String returns the specification the schedule was parsed from.
+	String() string
+}
+
+// ParseSchedule parses a schedule specification, which is one of:
+//
+//	a cron expression with five fields: minute, hour, day of month,
+//	    month and day of week, eg "0 2 * * *";
+//	"@every <duration>", eg "@every 6h";
+//	"@hourly", "@daily" (or "@midnight"), "@weekly", "@monthly" or
+//	    "@yearly" (or "@annually").
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(spec[len("@every "):]))
+		if err != nil {
+			return nil, errors.NotValidf("schedule %q", spec)
+		}
+		if interval < time.Minute {
+			return nil, errors.NotValidf("schedule %q with interval less than a minute", spec)
+		}
+		return everySchedule{spec: spec, interval: interval}, nil
+	}
+	expr := spec
+	switch spec {
+	case "@yearly", "@annually":
+		expr = "0 0 1 1 *"
+	case "@monthly":
+		expr = "0 0 1 * *"
+	case "@weekly":
+		expr = "0 0 * * 0"
+	case "@daily", "@midnight":
+		expr = "0 0 * * *"
+	case "@hourly":
+		expr = "0 * * * *"
+	}
+	schedule, err := parseCron(expr)
+	if err != nil {
+		return nil, errors.Annotatef(err, "schedule %q not valid", spec)
+	}
+	schedule.spec = spec
+	return schedule, nil
+}
+
+// everySchedule is due at a fixed interval.
+type everySchedule struct {
+	spec     string
+	interval time.Duration
+}
+
+// Next is part of the Schedule interface.
+func (s everySchedule) Next(after time.Time) time.Time {
+	return after.UTC().Add(s.interval).Truncate(time.Second)
+}
+
+// String is part of the Schedule interface.
+func (s everySchedule) String() string {
+	return s.spec
+}
+
+// cronField holds the values matched by one field of a cron expression.
+type cronField uint64
+
+func (f cronField) has(value int) bool {
+	return f&(1<<uint(value)) != 0
+}
+
+// cronSchedule is due when the time matches a cron expression.
+type cronSchedule struct {
+	spec                          string
+	minute, hour, dom, month, dow cronField
+	// domStar and dowStar record whether the day of month and day
+	// of week fields are unrestricted. If both are restricted, a day
+	// matching either of them matches, as for cron.
+	domStar, dowStar bool
+}
+
+// cronBounds holds the minimum and maximum values of each cron field.
+var cronBounds = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronBounds) {
+		return nil, errors.Errorf("expected %d fields, got %d", len(cronBounds), len(fields))
+	}
+	values := make([]cronField, len(fields))
+	for i, field := range fields {
+		bounds := cronBounds[i]
+		value, err := parseCronField(field, bounds.min, bounds.max)
+		if err != nil {
+			return nil, errors.Annotatef(err, "%s", bounds.name)
+		}
+		values[i] = value
+	}
+	// Sunday may be given as either 0 or 7.
+	dow := values[4]
+	if dow.has(7) {
+		dow |= 1
+	}
+	return &cronSchedule{
+		minute:  values[0],
+		hour:    values[1],
+		dom:     values[2],
+		month:   values[3],
+		dow:     dow,
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, min, max int) (cronField, error) {
+	var result cronField
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.Errorf("invalid step in %q", part)
+			}
+			part = part[:i]
+		}
+		start, end := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], min, max); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if end, err = parseCronValue(bounds[1], min, max); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if start > end {
+				return 0, errors.Errorf("invalid range %q", part)
+			}
+		default:
+			value, err := parseCronValue(part, min, max)
+			if err != nil {
+				return 0, errors.Trace(err)
+			}
+			start = value
+			if step == 1 {
+				end = value
+			}
+		}
+		for value := start; value <= end; value += step {
+			result |= 1 << uint(value)
+		}
+	}
+	return result, nil
+}
+
+func parseCronValue(s string, min, max int) (int, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.Errorf("invalid value %q", s)
+	}
+	if value < min || value > max {
+		return 0, errors.Errorf("value %d out of range [%d-%d]", value, min, max)
+	}
+	return value, nil
+}
+
+// maxCronYears is how far ahead Next looks for a matching time, so that
+// expressions that never match (eg "0 0 31 2 *") terminate.
+const maxCronYears = 5
+
+// Next is part of the Schedule interface.
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	yearLimit := t.Year() + maxCronYears
+
+wrap:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+	for !s.month.has(int(t.Month())) {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		if t.Month() == time.January {
+			goto wrap
+		}
+	}
+	for !s.dayMatches(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		if t.Day() == 1 {
+			goto wrap
+		}
+	}
+	for !s.hour.has(t.Hour()) {
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+		if t.Hour() == 0 {
+			goto wrap
+		}
+	}
+	for !s.minute.has(t.Minute()) {
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto wrap
+		}
+	}
+	return t
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom.has(t.Day())
+	dowMatch := s.dow.has(int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// String is part of the Schedule interface.
+func (s *cronSchedule) String() string {
+	return s.spec
+}
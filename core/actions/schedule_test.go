@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type ScheduleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+// now is a Tuesday.
+var now = time.Date(2017, 10, 17, 13, 45, 30, 0, time.UTC)
+
+func (*ScheduleSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec     string
+		expected []time.Time
+	}{{
+		spec: "0 2 * * *",
+		expected: []time.Time{
+			time.Date(2017, 10, 18, 2, 0, 0, 0, time.UTC),
+			time.Date(2017, 10, 19, 2, 0, 0, 0, time.UTC),
+		},
+	}, {
+		spec: "*/15 * * * *",
+		expected: []time.Time{
+			time.Date(2017, 10, 17, 14, 0, 0, 0, time.UTC),
+			time.Date(2017, 10, 17, 14, 15, 0, 0, time.UTC),
+		},
+	}, {
+		spec: "5/20 9-17 * * 1-5",
+		expected: []time.Time{
+			time.Date(2017, 10, 17, 14, 5, 0, 0, time.UTC),
+			time.Date(2017, 10, 17, 14, 25, 0, 0, time.UTC),
+		},
+	}, {
+		spec: "0 0 1,15 * 1",
+		expected: []time.Time{
+			time.Date(2017, 10, 23, 0, 0, 0, 0, time.UTC),
+			time.Date(2017, 10, 30, 0, 0, 0, 0, time.UTC),
+			time.Date(2017, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, {
+		spec: "30 4 * * 7",
+		expected: []time.Time{
+			time.Date(2017, 10, 22, 4, 30, 0, 0, time.UTC),
+		},
+	}, {
+		spec: "0 0 29 2 *",
+		expected: []time.Time{
+			time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}, {
+		spec:     "0 0 31 2 *",
+		expected: []time.Time{{}},
+	}, {
+		spec: "@hourly",
+		expected: []time.Time{
+			time.Date(2017, 10, 17, 14, 0, 0, 0, time.UTC),
+		},
+	}, {
+		spec: "@daily",
+		expected: []time.Time{
+			time.Date(2017, 10, 18, 0, 0, 0, 0, time.UTC),
+		},
+	}, {
+		spec: "@weekly",
+		expected: []time.Time{
+			time.Date(2017, 10, 22, 0, 0, 0, 0, time.UTC),
+		},
+	}, {
+		spec: "@monthly",
+		expected: []time.Time{
+			time.Date(2017, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}, {
+		spec: "@every 6h",
+		expected: []time.Time{
+			time.Date(2017, 10, 17, 19, 45, 30, 0, time.UTC),
+			time.Date(2017, 10, 18, 1, 45, 30, 0, time.UTC),
+		},
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		schedule, err := actions.ParseSchedule(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.String(), gc.Equals, test.spec)
+		t := now
+		for _, expected := range test.expected {
+			t = schedule.Next(t)
+			c.Check(t, gc.Equals, expected)
+		}
+	}
+}
+
+func (*ScheduleSuite) TestParseScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `schedule "" not valid: expected 5 fields, got 0`,
+	}, {
+		spec: "* * *",
+		err:  `schedule "\* \* \*" not valid: expected 5 fields, got 3`,
+	}, {
+		spec: "61 * * * *",
+		err:  `schedule "61 \* \* \* \*" not valid: minute: value 61 out of range \[0-59\]`,
+	}, {
+		spec: "* * 0 * *",
+		err:  `schedule "\* \* 0 \* \*" not valid: day of month: value 0 out of range \[1-31\]`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `schedule "\*/0 \* \* \* \*" not valid: minute: invalid step in "\*/0"`,
+	}, {
+		spec: "* 5-2 * * *",
+		err:  `schedule "\* 5-2 \* \* \*" not valid: hour: invalid range "5-2"`,
+	}, {
+		spec: "* * * jan *",
+		err:  `schedule "\* \* \* jan \*" not valid: month: invalid value "jan"`,
+	}, {
+		spec: "@every fortnight",
+		err:  `schedule "@every fortnight" not valid`,
+	}, {
+		spec: "@every 30s",
+		err:  `schedule "@every 30s" with interval less than a minute not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := actions.ParseSchedule(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	_, err := actions.ParseSchedule("@every 30s")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
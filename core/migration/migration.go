@@ -68,6 +68,14 @@ type ModelInfo struct {
 	Name                   string
 	AgentVersion           version.Number
 	ControllerAgentVersion version.Number
+
+	// Cloud and CloudRegion name the cloud and region used by the
+	// model, and CloudCredential identifies its credential, if it
+	// has one. They are empty if the source controller did not
+	// report them.
+	Cloud           string
+	CloudRegion     string
+	CloudCredential names.CloudCredentialTag
 }
 
 func (i *ModelInfo) Validate() error {
@@ -23,7 +23,10 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
 )
 
 var logger = loggo.GetLogger("juju.environs.config")
@@ -129,10 +132,51 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogForwardType sets the type of the log forwarding target:
+	// "syslog" (the default), "http", "loki" or "file".
+	LogForwardType = "logforward-type"
+
+	// LogFwdHTTPURL sets the URL to which logs are posted by the
+	// "http" and "loki" log forwarding targets.
+	LogFwdHTTPURL = "logforward-http-url"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// certificate of the log forwarding HTTP server.
+	LogFwdHTTPCACert = "logforward-http-ca-cert"
+
+	// LogFwdFilePath sets the path of the file to which logs are
+	// written by the "file" log forwarding target. The path is
+	// relative to the directory the controller keeps the model's
+	// forwarded logs in, and may not refer to a parent directory.
+	LogFwdFilePath = "logforward-file-path"
+
+	// LogFwdFileMaxSize sets the size the log forwarding file may grow
+	// to before it is rotated, eg "100M".
+	LogFwdFileMaxSize = "logforward-file-max-size"
+
+	// LogFwdFileMaxBackups sets the number of rotated log forwarding
+	// files that are kept.
+	LogFwdFileMaxBackups = "logforward-file-max-backups"
+
+	// LogForwardStatusHistory determines whether status history
+	// entries are written to the model's logs, and so forwarded along
+	// with the agent logs.
+	LogForwardStatusHistory = "logforward-status-history"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// HookTimeoutKey is the maximum time that the uniter allows a hook
+	// to run before killing it, eg "30m". If it is not set, hooks may
+	// run indefinitely.
+	HookTimeoutKey = "hook-timeout"
+
+	// HookTimeoutOverridesKey overrides the hook timeout for individual
+	// hooks, as a space-separated list of hook=duration pairs, eg
+	// "install=1h update-status=1m".
+	HookTimeoutOverridesKey = "hook-timeout-overrides"
+
 	// TransmitVendorMetricsKey is the key for whether the controller sends
 	// metrics collected in this model for anonymized aggregate analytics.
 	TransmitVendorMetricsKey = "transmit-vendor-metrics"
@@ -415,9 +459,10 @@ func ProcessDeprecatedAttributes(attrs map[string]interface{}) map[string]interf
 func CoerceForStorage(attrs map[string]interface{}) map[string]interface{} {
 	coercedAttrs := make(map[string]interface{}, len(attrs))
 	for attrName, attrValue := range attrs {
-		if attrName == ResourceTagsKey {
-			// Resource Tags are specified by the user as a string but transformed
-			// to a map when config is parsed. We want to store as a string.
+		if attrName == ResourceTagsKey || attrName == HookTimeoutOverridesKey {
+			// Resource Tags and hook timeout overrides are specified by the
+			// user as a string but transformed to a map when config is parsed.
+			// We want to store as a string.
 			var tagsSlice []string
 			if tags, ok := attrValue.(map[string]string); ok {
 				for resKey, resValue := range tags {
@@ -469,9 +514,15 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
-	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
+	if v, ok := cfg.defined[LogFwdFileMaxSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid log forwarding file max size in model configuration")
+		}
+	}
+
+	if lfCfg, ok := cfg.LogForwardConfig(); ok {
 		if err := lfCfg.Validate(); err != nil {
-			return errors.Annotate(err, "invalid syslog forwarding config")
+			return errors.Annotatef(err, "invalid %s forwarding config", lfCfg.TargetType())
 		}
 	}
 
@@ -484,6 +535,16 @@ func Validate(cfg, old *Config) error {
 		return errors.Annotate(err, "validating resource tags")
 	}
 
+	if v, ok := cfg.defined[HookTimeoutKey].(string); ok && v != "" {
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid hook timeout in model configuration")
+		}
+	}
+
+	if _, err := cfg.hookTimeoutOverrides(); err != nil {
+		return errors.Annotate(err, "invalid hook timeout overrides in model configuration")
+	}
+
 	if v, ok := cfg.defined[MaxStatusHistoryAge].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid max status history age in model configuration")
@@ -729,6 +790,63 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	return &lfCfg, true
 }
 
+// LogForwardStatusHistory returns whether status history entries
+// should be written to the model's logs, so that they are sent to the
+// log forwarding target.
+func (c *Config) LogForwardStatusHistory() bool {
+	value, _ := c.defined[LogForwardStatusHistory].(bool)
+	return value
+}
+
+// LogForwardConfig returns the configuration of the log forwarding
+// target, which includes the syslog forwarding config.
+func (c *Config) LogForwardConfig() (*target.Config, bool) {
+	var lfCfg target.Config
+	syslogCfg, partial := c.LogFwdSyslog()
+	if partial {
+		lfCfg.Enabled = syslogCfg.Enabled
+		lfCfg.Syslog = *syslogCfg
+	}
+
+	if s, ok := c.defined[LogForwardType]; ok && s != "" {
+		partial = true
+		lfCfg.Type = s.(string)
+	}
+
+	var httpCfg httpjson.RawConfig
+	if s, ok := c.defined[LogFwdHTTPURL]; ok && s != "" {
+		partial = true
+		httpCfg.URL = s.(string)
+	}
+	if s, ok := c.defined[LogFwdHTTPCACert]; ok && s != "" {
+		partial = true
+		httpCfg.CACert = s.(string)
+	}
+	lfCfg.HTTP = httpCfg
+
+	var fileCfg logfile.RawConfig
+	if s, ok := c.defined[LogFwdFilePath]; ok && s != "" {
+		partial = true
+		fileCfg.Path = s.(string)
+	}
+	if s, ok := c.defined[LogFwdFileMaxSize].(string); ok && s != "" {
+		partial = true
+		// Value has already been validated.
+		size, _ := utils.ParseSize(s)
+		fileCfg.MaxSizeMB = int(size)
+	}
+	if n, ok := c.defined[LogFwdFileMaxBackups].(int); ok {
+		partial = true
+		fileCfg.MaxBackups = n
+	}
+	lfCfg.File = fileCfg
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -917,6 +1035,35 @@ func (c *Config) resourceTags() (map[string]string, error) {
 	return v, nil
 }
 
+// HookTimeout returns the maximum time that the named hook may run
+// before the uniter kills it. A zero duration means that the hook may
+// run indefinitely.
+func (c *Config) HookTimeout(hookName string) time.Duration {
+	// Values have already been validated.
+	overrides, _ := c.hookTimeoutOverrides()
+	if timeout, ok := overrides[hookName]; ok {
+		return timeout
+	}
+	timeout, _ := time.ParseDuration(c.asString(HookTimeoutKey))
+	return timeout
+}
+
+func (c *Config) hookTimeoutOverrides() (map[string]time.Duration, error) {
+	v, ok := c.defined[HookTimeoutOverridesKey].(map[string]string)
+	if !ok {
+		return nil, nil
+	}
+	overrides := make(map[string]time.Duration)
+	for hookName, value := range v {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "hook %q", hookName)
+		}
+		overrides[hookName] = timeout
+	}
+	return overrides, nil
+}
+
 // MaxStatusHistoryAge is the maximum age of status history entries
 // before being pruned.
 func (c *Config) MaxStatusHistoryAge() time.Duration {
@@ -998,11 +1145,18 @@ var alwaysOptional = schema.Defaults{
 	AuthorizedKeysKey: schema.Omit,
 	ExtraInfoKey:      schema.Omit,
 
-	LogForwardEnabled:      schema.Omit,
-	LogFwdSyslogHost:       schema.Omit,
-	LogFwdSyslogCACert:     schema.Omit,
-	LogFwdSyslogClientCert: schema.Omit,
-	LogFwdSyslogClientKey:  schema.Omit,
+	LogForwardEnabled:       schema.Omit,
+	LogFwdSyslogHost:        schema.Omit,
+	LogFwdSyslogCACert:      schema.Omit,
+	LogFwdSyslogClientCert:  schema.Omit,
+	LogFwdSyslogClientKey:   schema.Omit,
+	LogForwardType:          schema.Omit,
+	LogFwdHTTPURL:           schema.Omit,
+	LogFwdHTTPCACert:        schema.Omit,
+	LogFwdFilePath:          schema.Omit,
+	LogFwdFileMaxSize:       schema.Omit,
+	LogFwdFileMaxBackups:    schema.Omit,
+	LogForwardStatusHistory: schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1035,6 +1189,8 @@ var alwaysOptional = schema.Defaults{
 	"disable-network-management": schema.Omit,
 	IgnoreMachineAddresses:       schema.Omit,
 	AutomaticallyRetryHooks:      schema.Omit,
+	HookTimeoutKey:               schema.Omit,
+	HookTimeoutOverridesKey:      schema.Omit,
 	"test-mode":                  schema.Omit,
 	TransmitVendorMetricsKey:     schema.Omit,
 	NetBondReconfigureDelayKey:   schema.Omit,
@@ -1366,6 +1522,42 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogForwardType: {
+		Description: `The type of the log forwarding target: syslog, http, loki or file.`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{target.TypeSyslog, target.TypeHTTP, target.TypeLoki, target.TypeFile},
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPURL: {
+		Description: `The URL to which logs are posted by the http and loki log forwarding targets.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the log forwarding HTTP server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdFilePath: {
+		Description: `The path of the file written by the file log forwarding target, relative to the model's directory under logforward in the controller's log directory. It may not be absolute or contain "..".`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdFileMaxSize: {
+		Description: `The size the log forwarding file may grow to before it is rotated, eg "100M".`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdFileMaxBackups: {
+		Description: `The number of rotated log forwarding files that are kept.`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	LogForwardStatusHistory: {
+		Description: `Whether status history entries are forwarded along with the logs.`,
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -1401,6 +1593,16 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	HookTimeoutKey: {
+		Description: "The maximum time a hook may run before it is killed, in human-readable time format (default no limit)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	HookTimeoutOverridesKey: {
+		Description: "Per-hook timeouts that override hook-timeout, as space-separated hook=duration pairs",
+		Type:        environschema.Tattrs,
+		Group:       environschema.EnvironGroup,
+	},
 	TransmitVendorMetricsKey: {
 		Description: "Determines whether metrics declared by charms deployed into this model are sent for anonymized aggregate analytics",
 		Type:        environschema.Tbool,
@@ -23,6 +23,10 @@ import (
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
 	"github.com/juju/juju/testing"
 )
 
@@ -520,6 +524,46 @@ var configTests = []configTest{
 			"syslog-client-key":  serverKey2,
 		}),
 		err: `invalid syslog forwarding config: validating TLS config: parsing client key pair: (crypto/)?tls: private key does not match public key`,
+	}, {
+		about:       "Invalid log forwarding type",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-type": "carrier-pigeon",
+		}),
+		err: `logforward-type: expected one of \[syslog http loki file\], got "carrier-pigeon"`,
+	}, {
+		about:       "Log forwarding to http without URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled": true,
+			"logforward-type":    "http",
+		}),
+		err: `invalid http forwarding config: URL "" not valid`,
+	}, {
+		about:       "Log forwarding to an absolute file path",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":   true,
+			"logforward-type":      "file",
+			"logforward-file-path": "/etc/cron.d/forward",
+		}),
+		err: `invalid file forwarding config: path "/etc/cron.d/forward" not valid`,
+	}, {
+		about:       "Log forwarding to a file outside the log directory",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":   true,
+			"logforward-type":      "file",
+			"logforward-file-path": "../../machine-0.log",
+		}),
+		err: `invalid file forwarding config: path "../../machine-0.log" not valid`,
+	}, {
+		about:       "Invalid log forwarding file max size",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-file-max-size": "abc",
+		}),
+		err: `invalid log forwarding file max size in model configuration: .*`,
 	}, {
 		about:       "net-bond-reconfigure-delay value",
 		useDefaults: config.UseDefaults,
@@ -1112,6 +1156,83 @@ func (s *ConfigSuite) TestStatusHistoryConfigValues(c *gc.C) {
 	c.Assert(cfg.MaxStatusHistorySizeMB(), gc.Equals, uint(8192))
 }
 
+func (s *ConfigSuite) TestLogForwardConfigNotSet(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.LogForwardConfig()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestLogForwardConfigLoki(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled":      true,
+		"logforward-type":         "loki",
+		"logforward-http-url":     "https://loki.example.com/loki/api/v1/push",
+		"logforward-http-ca-cert": testing.CACert,
+	})
+	lfCfg, ok := cfg.LogForwardConfig()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(lfCfg, jc.DeepEquals, &target.Config{
+		Enabled: true,
+		Type:    target.TypeLoki,
+		Syslog:  syslog.RawConfig{Enabled: true},
+		HTTP: httpjson.RawConfig{
+			URL:    "https://loki.example.com/loki/api/v1/push",
+			CACert: testing.CACert,
+		},
+	})
+}
+
+func (s *ConfigSuite) TestLogForwardConfigFile(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"logforward-enabled":          true,
+		"logforward-type":             "file",
+		"logforward-file-path":        "forward.log",
+		"logforward-file-max-size":    "1G",
+		"logforward-file-max-backups": 3,
+	})
+	lfCfg, ok := cfg.LogForwardConfig()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(lfCfg.TargetType(), gc.Equals, target.TypeFile)
+	c.Assert(lfCfg.File, jc.DeepEquals, logfile.RawConfig{
+		Path:       "forward.log",
+		MaxSizeMB:  1024,
+		MaxBackups: 3,
+	})
+}
+
+func (s *ConfigSuite) TestLogForwardStatusHistory(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.LogForwardStatusHistory(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{"logforward-status-history": true})
+	c.Assert(cfg.LogForwardStatusHistory(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestHookTimeout(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HookTimeout("install"), gc.Equals, time.Duration(0))
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"hook-timeout":           "30m",
+		"hook-timeout-overrides": "install=2h update-status=1m",
+	})
+	c.Assert(cfg.HookTimeout("install"), gc.Equals, 2*time.Hour)
+	c.Assert(cfg.HookTimeout("update-status"), gc.Equals, time.Minute)
+	c.Assert(cfg.HookTimeout("config-changed"), gc.Equals, 30*time.Minute)
+}
+
+func (s *ConfigSuite) TestHookTimeoutInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"hook-timeout": "soon",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid hook timeout in model configuration: time: invalid duration "?soon"?`)
+
+	_, err = config.New(config.UseDefaults, sampleConfig.Merge(testing.Attrs{
+		"hook-timeout-overrides": "install=soon",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid hook timeout overrides in model configuration: hook "install": time: invalid duration "?soon"?`)
+}
+
 func (s *ConfigSuite) TestSchemaNoExtra(c *gc.C) {
 	schema, err := config.Schema(nil)
 	c.Assert(err, gc.IsNil)
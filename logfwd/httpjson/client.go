@@ -0,0 +1,253 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a failed post is
+	// retried before giving up.
+	DefaultMaxRetries = 5
+
+	// DefaultRetryDelay is the delay before the first retry of a
+	// failed post. The delay doubles for each further retry.
+	DefaultRetryDelay = time.Second
+
+	// DefaultMaxRetryDelay is the longest delay between retries.
+	DefaultMaxRetryDelay = 30 * time.Second
+)
+
+// Client posts batches of log records to a remote HTTP endpoint.
+type Client struct {
+	// URL is the URL to which the records are posted.
+	URL string
+
+	// Format is the format of the posted records.
+	Format string
+
+	// HTTPClient is used to make the requests.
+	HTTPClient *http.Client
+
+	// Clock is used to wait between retries.
+	Clock clock.Clock
+
+	// MaxRetries is the number of times a failed post is retried.
+	MaxRetries int
+
+	// RetryDelay is the delay before the first retry.
+	RetryDelay time.Duration
+
+	// MaxRetryDelay is the longest delay between retries.
+	MaxRetryDelay time.Duration
+
+	// Abort, if non-nil, is closed to stop any further retries.
+	Abort <-chan struct{}
+}
+
+// Open returns a new client that posts records as specified by the
+// given config. Retries are abandoned when abort is closed.
+func Open(cfg RawConfig, abort <-chan struct{}) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsConfig, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	format := cfg.Format
+	if format == "" {
+		format = FormatJSON
+	}
+	return &Client{
+		URL:    cfg.URL,
+		Format: format,
+		HTTPClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+			Timeout: 30 * time.Second,
+		},
+		Clock:         clock.WallClock,
+		MaxRetries:    DefaultMaxRetries,
+		RetryDelay:    DefaultRetryDelay,
+		MaxRetryDelay: DefaultMaxRetryDelay,
+		Abort:         abort,
+	}, nil
+}
+
+// Close implements io.Closer.
+func (client *Client) Close() error {
+	return nil
+}
+
+// Send posts the records to the remote endpoint, retrying with an
+// increasing delay if the post fails with a network or server error.
+// Retrying stops early if the client's Abort channel is closed.
+func (client *Client) Send(records []logfwd.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	var body interface{}
+	switch client.Format {
+	case FormatLoki:
+		body = lokiRequestFromRecords(records)
+	default:
+		body = jsonRecordsFromRecords(records)
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errors.Annotate(err, "marshalling records")
+	}
+
+	delay := client.RetryDelay
+	for attempt := 0; ; attempt++ {
+		retry, err := client.post(data)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= client.MaxRetries {
+			return errors.Annotatef(err, "sending %d records", len(records))
+		}
+		select {
+		case <-client.Abort:
+			return errors.Annotatef(err, "sending %d records (aborted)", len(records))
+		case <-client.Clock.After(delay):
+		}
+		delay *= 2
+		if client.MaxRetryDelay > 0 && delay > client.MaxRetryDelay {
+			delay = client.MaxRetryDelay
+		}
+	}
+}
+
+// post makes a single post of the data, returning whether the post
+// may be retried if it fails.
+func (client *Client) post(data []byte) (bool, error) {
+	resp, err := client.HTTPClient.Post(client.URL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return true, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(ioutil.Discard, resp.Body)
+		return false, nil
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = errors.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, err
+}
+
+// jsonRecord is the form of a log record posted in FormatJSON.
+type jsonRecord struct {
+	ID              int64     `json:"id"`
+	Timestamp       time.Time `json:"timestamp"`
+	ControllerUUID  string    `json:"controller-uuid"`
+	ModelUUID       string    `json:"model-uuid"`
+	Hostname        string    `json:"hostname,omitempty"`
+	OriginType      string    `json:"origin-type"`
+	OriginName      string    `json:"origin-name,omitempty"`
+	SoftwareName    string    `json:"software-name,omitempty"`
+	SoftwareVersion string    `json:"software-version,omitempty"`
+	Level           string    `json:"level"`
+	Module          string    `json:"module,omitempty"`
+	Location        string    `json:"location,omitempty"`
+	Message         string    `json:"message"`
+}
+
+func jsonRecordsFromRecords(records []logfwd.Record) []jsonRecord {
+	result := make([]jsonRecord, len(records))
+	for i, rec := range records {
+		result[i] = jsonRecord{
+			ID:              rec.ID,
+			Timestamp:       rec.Timestamp.UTC(),
+			ControllerUUID:  rec.Origin.ControllerUUID,
+			ModelUUID:       rec.Origin.ModelUUID,
+			Hostname:        rec.Origin.Hostname,
+			OriginType:      rec.Origin.Type.String(),
+			OriginName:      rec.Origin.Name,
+			SoftwareName:    rec.Origin.Software.Name,
+			SoftwareVersion: softwareVersion(rec.Origin.Software),
+			Level:           rec.Level.String(),
+			Module:          rec.Location.Module,
+			Location:        rec.Location.String(),
+			Message:         rec.Message,
+		}
+	}
+	return result
+}
+
+// lokiRequest is the body of a Loki push API request.
+type lokiRequest struct {
+	Streams []*lokiStream `json:"streams"`
+}
+
+// lokiStream holds the log lines that share a set of labels.
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// lokiRequestFromRecords groups the records into streams labelled by
+// their origin and level. The order of the records within each stream
+// is preserved.
+func lokiRequestFromRecords(records []logfwd.Record) lokiRequest {
+	var request lokiRequest
+	streams := make(map[string]*lokiStream)
+	for _, rec := range records {
+		labels := map[string]string{
+			"controller_uuid": rec.Origin.ControllerUUID,
+			"model_uuid":      rec.Origin.ModelUUID,
+			"origin_type":     rec.Origin.Type.String(),
+			"level":           rec.Level.String(),
+		}
+		if rec.Origin.Name != "" {
+			labels["origin_name"] = rec.Origin.Name
+		}
+		key := fmt.Sprintf("%s %s %s %s %s",
+			rec.Origin.ControllerUUID, rec.Origin.ModelUUID,
+			rec.Origin.Type, rec.Origin.Name, rec.Level,
+		)
+		stream, ok := streams[key]
+		if !ok {
+			stream = &lokiStream{Stream: labels}
+			streams[key] = stream
+			request.Streams = append(request.Streams, stream)
+		}
+		line := rec.Message
+		if loc := rec.Location.String(); loc != "" {
+			line = loc + " " + line
+		}
+		if rec.Location.Module != "" {
+			line = rec.Location.Module + " " + line
+		}
+		stream.Values = append(stream.Values, [2]string{
+			fmt.Sprint(rec.Timestamp.UnixNano()),
+			line,
+		})
+	}
+	return request
+}
+
+func softwareVersion(sw logfwd.Software) string {
+	if sw.Name == "" {
+		return ""
+	}
+	return sw.Version.String()
+}
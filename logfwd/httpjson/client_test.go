@@ -0,0 +1,217 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	server    *httptest.Server
+	bodies    []string
+	responses []int
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.bodies = nil
+	s.responses = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		s.bodies = append(s.bodies, string(body))
+		status := http.StatusNoContent
+		if len(s.responses) > 0 {
+			status, s.responses = s.responses[0], s.responses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *ClientSuite) newClient(format string) *httpjson.Client {
+	return &httpjson.Client{
+		URL:        s.server.URL,
+		Format:     format,
+		HTTPClient: http.DefaultClient,
+		Clock:      clock.WallClock,
+		MaxRetries: 2,
+		RetryDelay: time.Millisecond,
+	}
+}
+
+func (s *ClientSuite) record(id int64, level loggo.Level, message string) logfwd.Record {
+	tag := names.NewMachineTag("99")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	return logfwd.Record{
+		ID:        id,
+		Origin:    logfwd.OriginForMachineAgent(tag, cID, mID, version.MustParse("1.2.3")),
+		Timestamp: time.Unix(12345, 0),
+		Level:     level,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: message,
+	}
+}
+
+func (s *ClientSuite) TestOpen(c *gc.C) {
+	client, err := httpjson.Open(httpjson.RawConfig{URL: "https://logs.example.com"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.URL, gc.Equals, "https://logs.example.com")
+	c.Check(client.Format, gc.Equals, httpjson.FormatJSON)
+	c.Check(client.MaxRetries, gc.Equals, httpjson.DefaultMaxRetries)
+	c.Check(client.RetryDelay, gc.Equals, httpjson.DefaultRetryDelay)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := httpjson.Open(httpjson.RawConfig{URL: "logs.example.com"}, nil)
+	c.Assert(err, gc.ErrorMatches, `URL "logs.example.com" not valid`)
+}
+
+func (s *ClientSuite) TestSendJSON(c *gc.C) {
+	client := s.newClient(httpjson.FormatJSON)
+	err := client.Send([]logfwd.Record{
+		s.record(10, loggo.ERROR, "boom"),
+		s.record(11, loggo.INFO, "hello"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.bodies, gc.HasLen, 1)
+	var posted []map[string]interface{}
+	err = json.Unmarshal([]byte(s.bodies[0]), &posted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(posted, gc.HasLen, 2)
+	c.Check(posted[0], jc.DeepEquals, map[string]interface{}{
+		"id":               float64(10),
+		"timestamp":        time.Unix(12345, 0).UTC().Format(time.RFC3339),
+		"controller-uuid":  "9f484882-2f18-4fd2-967d-db9663db7bea",
+		"model-uuid":       "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"hostname":         "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		"origin-type":      "machine",
+		"origin-name":      "99",
+		"software-name":    "jujud-machine-agent",
+		"software-version": "1.2.3",
+		"level":            "ERROR",
+		"module":           "juju.x.y",
+		"location":         "x/y/spam.go:42",
+		"message":          "boom",
+	})
+	c.Check(posted[1]["id"], gc.Equals, float64(11))
+	c.Check(posted[1]["level"], gc.Equals, "INFO")
+}
+
+func (s *ClientSuite) TestSendLoki(c *gc.C) {
+	client := s.newClient(httpjson.FormatLoki)
+	err := client.Send([]logfwd.Record{
+		s.record(10, loggo.ERROR, "boom"),
+		s.record(11, loggo.INFO, "hello"),
+		s.record(12, loggo.ERROR, "bang"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.bodies, gc.HasLen, 1)
+	var posted interface{}
+	err = json.Unmarshal([]byte(s.bodies[0]), &posted)
+	c.Assert(err, jc.ErrorIsNil)
+	labels := func(level string) map[string]interface{} {
+		return map[string]interface{}{
+			"controller_uuid": "9f484882-2f18-4fd2-967d-db9663db7bea",
+			"model_uuid":      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			"origin_type":     "machine",
+			"origin_name":     "99",
+			"level":           level,
+		}
+	}
+	c.Check(posted, jc.DeepEquals, map[string]interface{}{
+		"streams": []interface{}{
+			map[string]interface{}{
+				"stream": labels("ERROR"),
+				"values": []interface{}{
+					[]interface{}{"12345000000000", "juju.x.y x/y/spam.go:42 boom"},
+					[]interface{}{"12345000000000", "juju.x.y x/y/spam.go:42 bang"},
+				},
+			},
+			map[string]interface{}{
+				"stream": labels("INFO"),
+				"values": []interface{}{
+					[]interface{}{"12345000000000", "juju.x.y x/y/spam.go:42 hello"},
+				},
+			},
+		},
+	})
+}
+
+func (s *ClientSuite) TestSendNoRecords(c *gc.C) {
+	client := s.newClient(httpjson.FormatJSON)
+	err := client.Send(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.bodies, gc.HasLen, 0)
+}
+
+func (s *ClientSuite) TestSendRetriesServerErrors(c *gc.C) {
+	s.responses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+	client := s.newClient(httpjson.FormatJSON)
+	err := client.Send([]logfwd.Record{s.record(10, loggo.INFO, "hello")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.bodies, gc.HasLen, 3)
+	c.Check(s.bodies[2], gc.Equals, s.bodies[0])
+}
+
+func (s *ClientSuite) TestSendGivesUpAfterMaxRetries(c *gc.C) {
+	s.responses = []int{
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+		http.StatusOK,
+	}
+	client := s.newClient(httpjson.FormatJSON)
+	err := client.Send([]logfwd.Record{s.record(10, loggo.INFO, "hello")})
+	c.Assert(err, gc.ErrorMatches, `sending 1 records: 500 Internal Server Error: `)
+	c.Assert(s.bodies, gc.HasLen, 3)
+}
+
+func (s *ClientSuite) TestSendStopsRetryingWhenAborted(c *gc.C) {
+	s.responses = []int{http.StatusServiceUnavailable, http.StatusOK}
+	abort := make(chan struct{})
+	close(abort)
+	client := s.newClient(httpjson.FormatJSON)
+	client.RetryDelay = time.Hour
+	client.Abort = abort
+	err := client.Send([]logfwd.Record{s.record(10, loggo.INFO, "hello")})
+	c.Assert(err, gc.ErrorMatches, `sending 1 records \(aborted\): 503 Service Unavailable: `)
+	c.Assert(s.bodies, gc.HasLen, 1)
+}
+
+func (s *ClientSuite) TestSendDoesNotRetryClientErrors(c *gc.C) {
+	s.responses = []int{http.StatusBadRequest}
+	client := s.newClient(httpjson.FormatJSON)
+	err := client.Send([]logfwd.Record{s.record(10, loggo.INFO, "hello")})
+	c.Assert(err, gc.ErrorMatches, `sending 1 records: 400 Bad Request: `)
+	c.Assert(s.bodies, gc.HasLen, 1)
+}
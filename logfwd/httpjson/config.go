@@ -0,0 +1,74 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+const (
+	// FormatJSON is the format in which each batch of records is
+	// posted as a JSON array of records.
+	FormatJSON = "json"
+
+	// FormatLoki is the format in which each batch of records is
+	// posted as a Loki push API request.
+	FormatLoki = "loki"
+)
+
+// RawConfig holds the raw configuration data for forwarding logs to a
+// remote HTTP endpoint.
+type RawConfig struct {
+	// URL is the http or https URL to which the records are posted.
+	URL string
+
+	// Format is the format of the posted records, FormatJSON or
+	// FormatLoki. If empty, FormatJSON is used.
+	Format string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If
+	// empty, the system certificates are used.
+	CACert string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.Annotate(err, "parsing URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL %q", cfg.URL)
+	}
+	switch cfg.Format {
+	case "", FormatJSON, FormatLoki:
+	default:
+		return errors.NotValidf("format %q", cfg.Format)
+	}
+	if cfg.CACert != "" {
+		if _, err := cfg.tlsConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	}
+	return nil
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+	return &tls.Config{RootCAs: rootCAs}, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL:    "https://logs.example.com/loki/api/v1/push",
+		Format: httpjson.FormatLoki,
+		CACert: coretesting.CACert,
+	}
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateDefaultFormat(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL: "http://10.0.0.1:8080/logs",
+	}
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateBadURL(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL: "ftp://logs.example.com",
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `URL "ftp://logs.example.com" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingURL(c *gc.C) {
+	var cfg httpjson.RawConfig
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `URL "" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadFormat(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL:    "https://logs.example.com",
+		Format: "xml",
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `format "xml" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		URL:    "https://logs.example.com",
+		CACert: "abc",
+	}
+	err := cfg.Validate()
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: no certificates found`)
+}
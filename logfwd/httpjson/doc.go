@@ -0,0 +1,8 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The httpjson package holds the tools needed to perform log forwarding
+// from Juju to a remote HTTP endpoint, posting batches of records as
+// JSON. Both a generic JSON format and the Loki push API format are
+// supported.
+package httpjson
@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// Client writes log records to a file, one record per line.
+type Client struct {
+	// Writer is the file writer this client wraps.
+	Writer io.WriteCloser
+}
+
+// Open returns a client that writes to the rotating file specified by
+// the given config, relative to the given directory. The file's
+// directory is created if necessary.
+func Open(cfg RawConfig, dir string) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	path := filepath.Join(dir, cfg.Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, errors.Annotate(err, "creating log directory")
+	}
+	maxSize := cfg.MaxSizeMB
+	if maxSize == 0 {
+		maxSize = DefaultMaxSizeMB
+	}
+	maxBackups := cfg.MaxBackups
+	if maxBackups == 0 {
+		maxBackups = DefaultMaxBackups
+	}
+	return &Client{
+		Writer: &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    maxSize,
+			MaxBackups: maxBackups,
+			Compress:   true,
+		},
+	}, nil
+}
+
+// Close closes the underlying file.
+func (client Client) Close() error {
+	return errors.Trace(client.Writer.Close())
+}
+
+// Send writes the records to the file.
+func (client Client) Send(records []logfwd.Record) error {
+	var buf bytes.Buffer
+	for _, rec := range records {
+		buf.WriteString(formatRecord(rec))
+		buf.WriteByte('\n')
+	}
+	if _, err := client.Writer.Write(buf.Bytes()); err != nil {
+		return errors.Annotate(err, "writing log records")
+	}
+	return nil
+}
+
+// formatRecord returns the line written for the record, which has the
+// same layout as the lines shown by debug-log, prefixed with the
+// model UUID.
+func formatRecord(rec logfwd.Record) string {
+	origin := rec.Origin.Name
+	if rec.Origin.Type != logfwd.OriginTypeUnknown {
+		// Format the origin as a tag, e.g. "unit-mysql-0".
+		origin = fmt.Sprintf("%s-%s", rec.Origin.Type, strings.Replace(rec.Origin.Name, "/", "-", -1))
+	}
+	return fmt.Sprintf("%s %s: %s %s %s %s %s",
+		rec.Origin.ModelUUID,
+		origin,
+		rec.Timestamp.UTC().Format(time.RFC3339Nano),
+		rec.Level,
+		rec.Location.Module,
+		rec.Location,
+		rec.Message,
+	)
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/logfile"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		cfg    logfile.RawConfig
+		expect string
+	}{{
+		cfg: logfile.RawConfig{Path: "forward.log", MaxSizeMB: 10, MaxBackups: 2},
+	}, {
+		cfg: logfile.RawConfig{Path: "app/forward.log"},
+	}, {
+		cfg:    logfile.RawConfig{},
+		expect: `path "" not valid`,
+	}, {
+		cfg:    logfile.RawConfig{Path: "/var/log/juju/forward.log"},
+		expect: `path "/var/log/juju/forward.log" not valid`,
+	}, {
+		cfg:    logfile.RawConfig{Path: "../machine-0.log"},
+		expect: `path "../machine-0.log" not valid`,
+	}, {
+		cfg:    logfile.RawConfig{Path: "app/../../forward.log"},
+		expect: `path "app/../../forward.log" not valid`,
+	}, {
+		cfg:    logfile.RawConfig{Path: "forward.log", MaxSizeMB: -1},
+		expect: `negative max size not valid`,
+	}, {
+		cfg:    logfile.RawConfig{Path: "forward.log", MaxBackups: -1},
+		expect: `negative max backups not valid`,
+	}} {
+		c.Logf("test %d", i)
+		err := test.cfg.Validate()
+		if test.expect == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expect)
+		}
+	}
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	dir := c.MkDir()
+	client, err := logfile.Open(logfile.RawConfig{Path: "logs/forward.log"}, dir)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	location := logfwd.SourceLocation{
+		Module:   "juju.x.y",
+		Filename: "x/y/spam.go",
+		Line:     42,
+	}
+	err = client.Send([]logfwd.Record{{
+		Origin:    logfwd.OriginForMachineAgent(names.NewMachineTag("99"), cID, mID, ver),
+		Timestamp: time.Unix(12345, 0),
+		Level:     loggo.ERROR,
+		Location:  location,
+		Message:   "boom",
+	}, {
+		Origin:    logfwd.OriginForUnitAgent(names.NewUnitTag("mysql/0"), cID, mID, ver),
+		Timestamp: time.Unix(12346, 0),
+		Level:     loggo.INFO,
+		Location:  location,
+		Message:   "hello",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "logs", "forward.log"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, ""+
+		"deadbeef-2f18-4fd2-967d-db9663db7bea machine-99: 1970-01-01T03:25:45Z ERROR juju.x.y x/y/spam.go:42 boom\n"+
+		"deadbeef-2f18-4fd2-967d-db9663db7bea unit-mysql-0: 1970-01-01T03:25:46Z INFO juju.x.y x/y/spam.go:42 hello\n",
+	)
+}
@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// DefaultMaxSizeMB is the size a log file may grow to before it
+	// is rotated.
+	DefaultMaxSizeMB = 100
+
+	// DefaultMaxBackups is the number of rotated log files that are
+	// kept.
+	DefaultMaxBackups = 10
+)
+
+// RawConfig holds the raw configuration data for forwarding logs to a
+// local file.
+type RawConfig struct {
+	// Path is the path of the log file, relative to the directory
+	// the controller keeps the model's forwarded logs in. It may not
+	// be absolute or refer to a parent directory, so that a model's
+	// configuration cannot be used to write elsewhere on the
+	// controller.
+	Path string
+
+	// MaxSizeMB is the size in MiB the file may grow to before it is
+	// rotated. If zero, DefaultMaxSizeMB is used.
+	MaxSizeMB int
+
+	// MaxBackups is the number of rotated files that are kept. If
+	// zero, DefaultMaxBackups is used.
+	MaxBackups int
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.Path == "" || filepath.IsAbs(cfg.Path) {
+		return errors.NotValidf("path %q", cfg.Path)
+	}
+	for _, part := range strings.Split(filepath.ToSlash(cfg.Path), "/") {
+		if part == ".." {
+			return errors.NotValidf("path %q", cfg.Path)
+		}
+	}
+	if cfg.MaxSizeMB < 0 {
+		return errors.NotValidf("negative max size")
+	}
+	if cfg.MaxBackups < 0 {
+		return errors.NotValidf("negative max backups")
+	}
+	return nil
+}
@@ -26,10 +26,12 @@ func (s *OriginTypeSuite) TestZeroValue(c *gc.C) {
 
 func (s *OriginTypeSuite) TestParseOriginTypeValid(c *gc.C) {
 	tests := map[string]logfwd.OriginType{
-		"unknown": logfwd.OriginTypeUnknown,
-		"user":    logfwd.OriginTypeUser,
-		"machine": logfwd.OriginTypeMachine,
-		"unit":    logfwd.OriginTypeUnit,
+		"unknown":        logfwd.OriginTypeUnknown,
+		"user":           logfwd.OriginTypeUser,
+		"machine":        logfwd.OriginTypeMachine,
+		"unit":           logfwd.OriginTypeUnit,
+		"audit":          logfwd.OriginTypeAudit,
+		"status-history": logfwd.OriginTypeStatusHistory,
 	}
 	for str, expected := range tests {
 		c.Logf("trying %q", str)
@@ -55,10 +57,12 @@ func (s *OriginTypeSuite) TestParseOriginTypeInvalid(c *gc.C) {
 
 func (s *OriginTypeSuite) TestString(c *gc.C) {
 	tests := map[logfwd.OriginType]string{
-		logfwd.OriginTypeUnknown: "unknown",
-		logfwd.OriginTypeUser:    "user",
-		logfwd.OriginTypeMachine: "machine",
-		logfwd.OriginTypeUnit:    "unit",
+		logfwd.OriginTypeUnknown:       "unknown",
+		logfwd.OriginTypeUser:          "user",
+		logfwd.OriginTypeMachine:       "machine",
+		logfwd.OriginTypeUnit:          "unit",
+		logfwd.OriginTypeAudit:         "audit",
+		logfwd.OriginTypeStatusHistory: "status-history",
 	}
 	for ot, expected := range tests {
 		c.Logf("trying %q", ot)
@@ -75,6 +79,8 @@ func (s *OriginTypeSuite) TestValidateValid(c *gc.C) {
 		logfwd.OriginTypeUser,
 		logfwd.OriginTypeMachine,
 		logfwd.OriginTypeUnit,
+		logfwd.OriginTypeAudit,
+		logfwd.OriginTypeStatusHistory,
 	}
 	for _, ot := range tests {
 		c.Logf("trying %q", ot)
@@ -104,10 +110,12 @@ func (s *OriginTypeSuite) TestValidateInvalid(c *gc.C) {
 
 func (s *OriginTypeSuite) TestValidateNameValid(c *gc.C) {
 	tests := map[logfwd.OriginType]string{
-		logfwd.OriginTypeUnknown: "",
-		logfwd.OriginTypeUser:    "a-user",
-		logfwd.OriginTypeMachine: "99",
-		logfwd.OriginTypeUnit:    "svc-a/0",
+		logfwd.OriginTypeUnknown:       "",
+		logfwd.OriginTypeUser:          "a-user",
+		logfwd.OriginTypeMachine:       "99",
+		logfwd.OriginTypeUnit:          "svc-a/0",
+		logfwd.OriginTypeAudit:         "user-a-user",
+		logfwd.OriginTypeStatusHistory: "unit-svc-a-0",
 	}
 	for ot, name := range tests {
 		c.Logf("trying %q + %q", ot, name)
@@ -139,6 +147,14 @@ func (s *OriginTypeSuite) TestValidateNameInvalid(c *gc.C) {
 		ot:   logfwd.OriginTypeUnit,
 		name: "...",
 		err:  `bad unit name`,
+	}, {
+		ot:   logfwd.OriginTypeAudit,
+		name: "a-user",
+		err:  `bad entity tag`,
+	}, {
+		ot:   logfwd.OriginTypeStatusHistory,
+		name: "svc-a/0",
+		err:  `bad entity tag`,
 	}}
 	for _, test := range tests {
 		c.Logf("trying %q + %q", test.ot, test.name)
@@ -24,13 +24,17 @@ const (
 	OriginTypeUser               = iota
 	OriginTypeMachine
 	OriginTypeUnit
+	OriginTypeAudit
+	OriginTypeStatusHistory
 )
 
 var originTypes = map[OriginType]string{
-	OriginTypeUnknown: "unknown",
-	OriginTypeUser:    names.UserTagKind,
-	OriginTypeMachine: names.MachineTagKind,
-	OriginTypeUnit:    names.UnitTagKind,
+	OriginTypeUnknown:       "unknown",
+	OriginTypeUser:          names.UserTagKind,
+	OriginTypeMachine:       names.MachineTagKind,
+	OriginTypeUnit:          names.UnitTagKind,
+	OriginTypeAudit:         "audit",
+	OriginTypeStatusHistory: "status-history",
 }
 
 // OriginType is the "enum" type for the different kinds of log record
@@ -85,6 +89,12 @@ func (ot OriginType) ValidateName(name string) error {
 		if !names.IsValidUnit(name) {
 			return errors.NewNotValid(nil, "bad unit name")
 		}
+	case OriginTypeAudit, OriginTypeStatusHistory:
+		// Audit and status history records are named for the
+		// entity they are about, in tag form.
+		if _, err := names.ParseTag(name); err != nil {
+			return errors.NewNotValid(nil, "bad entity tag")
+		}
 	}
 	return nil
 }
@@ -129,6 +139,18 @@ func originForAgent(oType OriginType, tag names.Tag, controller, model string, v
 	return origin
 }
 
+// OriginForAudit populates a new origin for an audit record of an
+// action taken by the given entity.
+func OriginForAudit(tag names.Tag, controller, model string, ver version.Number) Origin {
+	return originForJuju(OriginTypeAudit, tag.String(), controller, model, ver)
+}
+
+// OriginForStatusHistory populates a new origin for a status history
+// record of the given entity.
+func OriginForStatusHistory(tag names.Tag, controller, model string, ver version.Number) Origin {
+	return originForJuju(OriginTypeStatusHistory, tag.String(), controller, model, ver)
+}
+
 // OriginForJuju populates a new origin for the juju client.
 func OriginForJuju(tag names.Tag, controller, model string, ver version.Number) (Origin, error) {
 	oType, err := ParseOriginType(tag.Kind())
@@ -78,6 +78,35 @@ func (s *OriginSuite) TestOriginForJuju(c *gc.C) {
 	})
 }
 
+func (s *OriginSuite) TestOriginForAudit(c *gc.C) {
+	tag := names.NewUserTag("bob")
+
+	origin := logfwd.OriginForAudit(tag, validOrigin.ControllerUUID, validOrigin.ModelUUID, validOrigin.Software.Version)
+
+	c.Check(origin, jc.DeepEquals, logfwd.Origin{
+		ControllerUUID: validOrigin.ControllerUUID,
+		ModelUUID:      validOrigin.ModelUUID,
+		Type:           logfwd.OriginTypeAudit,
+		Name:           "user-bob",
+		Software: logfwd.Software{
+			PrivateEnterpriseNumber: 28978,
+			Name:    "juju",
+			Version: version.MustParse("2.0.1"),
+		},
+	})
+	c.Check(origin.Validate(), jc.ErrorIsNil)
+}
+
+func (s *OriginSuite) TestOriginForStatusHistory(c *gc.C) {
+	tag := names.NewUnitTag("mysql/0")
+
+	origin := logfwd.OriginForStatusHistory(tag, validOrigin.ControllerUUID, validOrigin.ModelUUID, validOrigin.Software.Version)
+
+	c.Check(origin.Type, gc.Equals, logfwd.OriginTypeStatusHistory)
+	c.Check(origin.Name, gc.Equals, "unit-mysql-0")
+	c.Check(origin.Validate(), jc.ErrorIsNil)
+}
+
 func (s *OriginSuite) TestValidateValid(c *gc.C) {
 	origin := validOrigin
 
@@ -112,6 +112,19 @@ func (client Client) Send(records []logfwd.Record) error {
 	return nil
 }
 
+// maxAppNameLen is the maximum length of an RFC 5424 APP-NAME.
+const maxAppNameLen = 48
+
+// appName returns the syslog APP-NAME for records from the given
+// origin, truncated to the maximum length allowed.
+func appName(origin logfwd.Origin) rfc5424.AppName {
+	name := origin.Software.Name + "-" + origin.ModelUUID
+	if len(name) > maxAppNameLen {
+		name = name[:maxAppNameLen]
+	}
+	return rfc5424.AppName(name)
+}
+
 func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 	msg := rfc5424.Message{
 		Header: rfc5424.Header{
@@ -123,7 +136,7 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 			Hostname: rfc5424.Hostname{
 				FQDN: rec.Origin.Hostname,
 			},
-			AppName: rfc5424.AppName((rec.Origin.Software.Name + "-" + rec.Origin.ModelUUID)[:48]),
+			AppName: appName(rec.Origin),
 		},
 		StructuredData: rfc5424.StructuredData{
 			&sdelements.Origin{
@@ -186,6 +186,31 @@ func (s *ClientSuite) TestSendLogLevels(c *gc.C) {
 	}
 }
 
+func (s *ClientSuite) TestSendStatusHistory(c *gc.C) {
+	tag := names.NewUnitTag("mysql/0")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	rec := logfwd.Record{
+		Origin:    logfwd.OriginForStatusHistory(tag, cID, mID, ver),
+		Timestamp: time.Unix(12345, 0),
+		Level:     loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module: "juju.status-history",
+			Line:   -1,
+		},
+		Message: `{"kind":"workload","status":"error"}`,
+	}
+	client := syslog.Client{Sender: s.sender}
+
+	err := client.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	msg := s.stub.Calls()[0].Args[0].(rfc5424.Message)
+	c.Check(msg.AppName, gc.Equals, rfc5424.AppName("juju-deadbeef-2f18-4fd2-967d-db9663db7bea"))
+	c.Check(msg.Severity, gc.Equals, rfc5424.SeverityError)
+}
+
 type stubSenderOpener struct {
 	stub *testing.Stub
 
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The target package holds the configuration of the target to which a
+// model's logs are forwarded.
+package target
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
+	"github.com/juju/juju/logfwd/syslog"
+)
+
+// The following are the supported log forwarding target types.
+const (
+	// TypeSyslog forwards logs to a remote syslog (RFC 5424) host.
+	TypeSyslog = "syslog"
+
+	// TypeHTTP posts logs as JSON to a remote HTTP endpoint.
+	TypeHTTP = "http"
+
+	// TypeLoki posts logs to a Loki push API endpoint.
+	TypeLoki = "loki"
+
+	// TypeFile writes logs to a rotating file on the controller.
+	TypeFile = "file"
+)
+
+// Config holds the configuration of a log forwarding target. Only the
+// configuration for the selected type is used.
+type Config struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// Type is the type of the target. If empty, TypeSyslog is used.
+	Type string
+
+	// Syslog holds the configuration used by TypeSyslog.
+	Syslog syslog.RawConfig
+
+	// HTTP holds the configuration used by TypeHTTP and TypeLoki.
+	HTTP httpjson.RawConfig
+
+	// File holds the configuration used by TypeFile.
+	File logfile.RawConfig
+}
+
+// TargetType returns the type of the target, defaulting to TypeSyslog.
+func (cfg Config) TargetType() string {
+	if cfg.Type == "" {
+		return TypeSyslog
+	}
+	return cfg.Type
+}
+
+// Validate ensures that the config is currently valid. The
+// configuration of the selected type is only validated when log
+// forwarding is enabled, with the exception of syslog which is always
+// validated.
+func (cfg Config) Validate() error {
+	switch cfg.TargetType() {
+	case TypeSyslog:
+		return errors.Trace(cfg.Syslog.Validate())
+	case TypeHTTP, TypeLoki:
+		if !cfg.Enabled {
+			return nil
+		}
+		return errors.Trace(cfg.HTTPConfig().Validate())
+	case TypeFile:
+		if !cfg.Enabled {
+			return nil
+		}
+		return errors.Trace(cfg.File.Validate())
+	}
+	return errors.NotValidf("log forwarding type %q", cfg.Type)
+}
+
+// HTTPConfig returns the HTTP configuration with the format implied by
+// the target type.
+func (cfg Config) HTTPConfig() httpjson.RawConfig {
+	httpCfg := cfg.HTTP
+	if cfg.Type == TypeLoki {
+		httpCfg.Format = httpjson.FormatLoki
+	} else {
+		httpCfg.Format = httpjson.FormatJSON
+	}
+	return httpCfg
+}
@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package target_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestTargetTypeDefault(c *gc.C) {
+	c.Check(target.Config{}.TargetType(), gc.Equals, target.TypeSyslog)
+	c.Check(target.Config{Type: target.TypeFile}.TargetType(), gc.Equals, target.TypeFile)
+}
+
+func (s *ConfigSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		about  string
+		cfg    target.Config
+		expect string
+	}{{
+		about: "zero value",
+	}, {
+		about:  "enabled syslog without host",
+		cfg:    target.Config{Enabled: true, Syslog: syslog.RawConfig{Enabled: true}},
+		expect: `Host "" not valid`,
+	}, {
+		about: "disabled http without URL",
+		cfg:   target.Config{Type: target.TypeHTTP},
+	}, {
+		about:  "enabled http without URL",
+		cfg:    target.Config{Enabled: true, Type: target.TypeHTTP},
+		expect: `URL "" not valid`,
+	}, {
+		about: "enabled loki",
+		cfg: target.Config{
+			Enabled: true,
+			Type:    target.TypeLoki,
+			HTTP:    httpjson.RawConfig{URL: "https://loki.example.com/loki/api/v1/push"},
+		},
+	}, {
+		about:  "enabled file without path",
+		cfg:    target.Config{Enabled: true, Type: target.TypeFile},
+		expect: `path "" not valid`,
+	}, {
+		about: "enabled file",
+		cfg: target.Config{
+			Enabled: true,
+			Type:    target.TypeFile,
+			File:    logfile.RawConfig{Path: "forward.log"},
+		},
+	}, {
+		about:  "unknown type",
+		cfg:    target.Config{Type: "carrier-pigeon"},
+		expect: `log forwarding type "carrier-pigeon" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		err := test.cfg.Validate()
+		if test.expect == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expect)
+		}
+	}
+}
+
+func (s *ConfigSuite) TestHTTPConfigFormat(c *gc.C) {
+	cfg := target.Config{
+		Type: target.TypeLoki,
+		HTTP: httpjson.RawConfig{URL: "https://loki.example.com"},
+	}
+	c.Check(cfg.HTTPConfig(), jc.DeepEquals, httpjson.RawConfig{
+		URL:    "https://loki.example.com",
+		Format: httpjson.FormatLoki,
+	})
+	cfg.Type = target.TypeHTTP
+	c.Check(cfg.HTTPConfig().Format, gc.Equals, httpjson.FormatJSON)
+}
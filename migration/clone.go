@@ -0,0 +1,118 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/utils/set"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/state"
+)
+
+// StateCloneExporter describes the interface on state required to
+// export a copy of a model.
+type StateCloneExporter interface {
+	// ExportPartial generates an abstract representation of a model,
+	// skipping the aspects given in the config.
+	ExportPartial(state.ExportConfig) (description.Model, error)
+}
+
+// cloneExportConfig skips the machines and units of a model, along with
+// everything that only makes sense for them, so that the copy is
+// created without any agents.
+var cloneExportConfig = state.ExportConfig{
+	SkipActions:          true,
+	SkipIPAddresses:      true,
+	SkipLinkLayerDevices: true,
+	SkipMachines:         true,
+	SkipSSHHostKeys:      true,
+	SkipStatusHistory:    true,
+	SkipStorage:          true,
+	SkipUnits:            true,
+}
+
+// ExportModelClone serializes a copy of the model for StateCloneExporter
+// (typically a *state.State) with the given name and UUID. The copy
+// keeps the model's config, constraints, applications and relations,
+// but has no machines or units. It can be imported with ImportModel,
+// either on the same controller or another one, once the charms it
+// lists have been uploaded, followed by its resources with
+// UploadResources.
+func ExportModelClone(st StateCloneExporter, name, uuid string) (migration.SerializedModel, error) {
+	model, err := st.ExportPartial(cloneExportConfig)
+	if err != nil {
+		return migration.SerializedModel{}, errors.Trace(err)
+	}
+	model.UpdateConfig(map[string]interface{}{
+		config.NameKey: name,
+		config.UUIDKey: uuid,
+	})
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return migration.SerializedModel{}, errors.Trace(err)
+	}
+	charms := set.NewStrings()
+	var resources []migration.SerializedModelResource
+	for _, app := range model.Applications() {
+		charms.Add(app.CharmURL())
+		for _, res := range app.Resources() {
+			appRev, err := cloneResource(app.Name(), res.Name(), res.ApplicationRevision())
+			if err != nil {
+				return migration.SerializedModel{}, errors.Annotatef(err, "resource %s/%s", app.Name(), res.Name())
+			}
+			// The clone has no units, so there are no unit
+			// revisions to copy.
+			resources = append(resources, migration.SerializedModelResource{
+				ApplicationRevision: appRev,
+			})
+		}
+	}
+	return migration.SerializedModel{
+		Bytes:     bytes,
+		Charms:    charms.SortedValues(),
+		Resources: resources,
+	}, nil
+}
+
+// cloneResource returns the application revision of a resource in the
+// form that UploadResources expects.
+func cloneResource(app, name string, rev description.ResourceRevision) (resource.Resource, error) {
+	var empty resource.Resource
+	type_, err := charmresource.ParseType(rev.Type())
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin())
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	var fp charmresource.Fingerprint
+	if rev.FingerprintHex() != "" {
+		if fp, err = charmresource.ParseFingerprint(rev.FingerprintHex()); err != nil {
+			return empty, errors.Annotate(err, "invalid fingerprint")
+		}
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        type_,
+				Path:        rev.Path(),
+				Description: rev.Description(),
+			},
+			Origin:      origin,
+			Revision:    rev.Revision(),
+			Size:        rev.Size(),
+			Fingerprint: fp,
+		},
+		ApplicationID: app,
+		Username:      rev.Username(),
+		Timestamp:     rev.Timestamp(),
+	}, nil
+}
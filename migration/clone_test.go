@@ -0,0 +1,113 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/juju/description"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
+
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/resource/resourcetesting"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type CloneSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&CloneSuite{})
+
+func (s *CloneSuite) SetUpTest(c *gc.C) {
+	// The clone is imported into the same controller, which needs a
+	// registered provider; see ImportSuite.
+	s.InitialConfig = testing.CustomModelConfig(c, dummy.SampleConfig())
+	s.StateSuite.SetUpTest(c)
+}
+
+func (s *CloneSuite) TestExportModelClone(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	app, err := unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+
+	uuid := utils.MustNewUUID().String()
+	serialized, err := migration.ExportModelClone(s.State, "staging", uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.Charms, jc.DeepEquals, []string{curl.String()})
+
+	model, err := description.Deserialize(serialized.Bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Tag().Id(), gc.Equals, uuid)
+	c.Check(model.Config()["name"], gc.Equals, "staging")
+	c.Check(model.Machines(), gc.HasLen, 0)
+	apps := model.Applications()
+	c.Assert(apps, gc.HasLen, 1)
+	c.Check(apps[0].Name(), gc.Equals, app.Name())
+	c.Check(apps[0].Units(), gc.HasLen, 0)
+}
+
+func (s *CloneSuite) TestExportModelCloneResources(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	const body = "ham"
+	res := resourcetesting.NewResource(c, nil, "spam", app.Name(), body).Resource
+	_, err = st.SetResource(app.Name(), res.Username, res.Resource, bytes.NewBufferString(body))
+	c.Assert(err, jc.ErrorIsNil)
+	csRes := resourcetesting.NewCharmResource(c, "spam", body)
+	err = st.SetCharmStoreResources(app.Name(), []charmresource.Resource{csRes}, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+
+	serialized, err := migration.ExportModelClone(s.State, "staging", utils.MustNewUUID().String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(serialized.Resources, gc.HasLen, 1)
+	appRev := serialized.Resources[0].ApplicationRevision
+	c.Check(appRev.ApplicationID, gc.Equals, app.Name())
+	c.Check(appRev.Name, gc.Equals, "spam")
+	c.Check(appRev.Fingerprint, gc.DeepEquals, res.Fingerprint)
+	c.Check(appRev.Size, gc.Equals, res.Size)
+	c.Check(serialized.Resources[0].UnitRevisions, gc.HasLen, 0)
+}
+
+func (s *CloneSuite) TestImportModelClone(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	uuid := utils.MustNewUUID().String()
+	serialized, err := migration.ExportModelClone(s.State, "staging", uuid)
+	c.Assert(err, jc.ErrorIsNil)
+
+	dbModel, dbState, err := migration.ImportModel(s.State, serialized.Bytes)
+	c.Assert(err, jc.ErrorIsNil)
+	defer dbState.Close()
+
+	c.Check(dbModel.UUID(), gc.Equals, uuid)
+	c.Check(dbModel.Name(), gc.Equals, "staging")
+	c.Check(dbModel.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+
+	machines, err := dbState.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machines, gc.HasLen, 0)
+	clonedApp, err := dbState.Application(app.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := clonedApp.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(units, gc.HasLen, 0)
+
+	// The source model is untouched.
+	units, err = app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(units, gc.HasLen, 1)
+}
@@ -159,6 +159,43 @@ func UploadBinaries(config UploadBinariesConfig) error {
 	return nil
 }
 
+// UploadCharms sends the given charms stored in the source blobstore
+// to the target controller. Unlike UploadBinaries, no tools or
+// resources are sent.
+func UploadCharms(charms []string, downloader CharmDownloader, uploader CharmUploader) error {
+	if downloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
+	}
+	if uploader == nil {
+		return errors.NotValidf("missing CharmUploader")
+	}
+	return errors.Trace(uploadCharms(UploadBinariesConfig{
+		Charms:          charms,
+		CharmDownloader: downloader,
+		CharmUploader:   uploader,
+	}))
+}
+
+// UploadResources sends the given resources stored in the source
+// controller to the target controller. Unlike UploadBinaries, no charms
+// or tools are sent.
+func UploadResources(
+	resources []migration.SerializedModelResource,
+	downloader ResourceDownloader, uploader ResourceUploader,
+) error {
+	if downloader == nil {
+		return errors.NotValidf("missing ResourceDownloader")
+	}
+	if uploader == nil {
+		return errors.NotValidf("missing ResourceUploader")
+	}
+	return errors.Trace(uploadResources(UploadBinariesConfig{
+		Resources:          resources,
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+	}))
+}
+
 func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err error) {
 	tempFile, err := ioutil.TempFile("", "juju-migrate-binary")
 	if err != nil {
@@ -204,6 +204,43 @@ func (s *ImportSuite) TestWrongCharmURLAssigned(c *gc.C) {
 		"charm local:foo/bar-2 unexpectedly assigned local:foo/bar-1")
 }
 
+func (s *ImportSuite) TestUploadCharms(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{}
+
+	err := migration.UploadCharms([]string{
+		"local:trusty/magic-10",
+		"local:trusty/magic-2",
+	}, downloader, uploader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	expectedCharms := []string{"local:trusty/magic-2", "local:trusty/magic-10"}
+	c.Assert(downloader.charms, jc.DeepEquals, expectedCharms)
+	c.Assert(uploader.charms, jc.DeepEquals, expectedCharms)
+	c.Assert(downloader.uris, gc.HasLen, 0)
+	c.Assert(downloader.resources, gc.HasLen, 0)
+}
+
+func (s *ImportSuite) TestUploadResources(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{resources: make(map[string]string)}
+
+	appRes := resourcetesting.NewResource(c, nil, "blob0", "app0", "blob0").Resource
+	placeholder := resourcetesting.NewPlaceholderResource(c, "blob1", "app1")
+	err := migration.UploadResources([]coremigration.SerializedModelResource{
+		{ApplicationRevision: appRes},
+		{ApplicationRevision: placeholder},
+	}, downloader, uploader)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(downloader.resources, jc.DeepEquals, []string{"app0/blob0"})
+	c.Assert(uploader.resources, jc.DeepEquals, map[string]string{
+		"app0/blob0": "blob0",
+	})
+	c.Assert(downloader.charms, gc.HasLen, 0)
+	c.Assert(downloader.uris, gc.HasLen, 0)
+}
+
 type fakeDownloader struct {
 	charms    []string
 	uris      []string
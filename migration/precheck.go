@@ -32,8 +32,13 @@ type PrecheckBackend interface {
 	AllMachines() ([]PrecheckMachine, error)
 	AllApplications() ([]PrecheckApplication, error)
 	ControllerBackend() (PrecheckBackendCloser, error)
+	Cloud(name string) (cloud.Cloud, error)
 	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	HasActionSchedules() (bool, error)
+	HasSecrets() (bool, error)
+	HasTrustedApplications() (bool, error)
+	HasApplicationRelationSettings() (bool, error)
 }
 
 // PrecheckBackendCloser adds the Close method to the standard
@@ -92,46 +97,107 @@ type PrecheckUnit interface {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the model to be migrated.
 func SourcePrecheck(backend PrecheckBackend) error {
-	if err := checkModel(backend); err != nil {
+	failures, err := SourcePrecheckFailures(backend)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	return firstFailure(failures)
+}
 
-	if err := checkMachines(backend); err != nil {
-		return errors.Trace(err)
+// SourcePrecheckFailures runs the same checks as SourcePrecheck, but
+// rather than stopping at the first precondition that is not met, it
+// returns all of them. The error is only non-nil if the checks could
+// not be completed.
+func SourcePrecheckFailures(backend PrecheckBackend) ([]error, error) {
+	var failures precheckFailures
+	if err := checkModel(backend, &failures); err != nil {
+		return nil, errors.Trace(err)
 	}
 
-	if err := checkApplications(backend); err != nil {
-		return errors.Trace(err)
+	if err := checkMachines(backend, &failures); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := checkApplications(backend, &failures); err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
-		return errors.Annotate(err, "checking cleanups")
+		return nil, errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		failures.add(errors.New("cleanup needed"))
+	}
+
+	// Action schedules are not supported by the model description,
+	// so they would be lost in migration.
+	if hasSchedules, err := backend.HasActionSchedules(); err != nil {
+		return nil, errors.Annotate(err, "checking action schedules")
+	} else if hasSchedules {
+		failures.add(errors.New("model has action schedules, which cannot be migrated"))
+	}
+
+	// Nor are secrets.
+	if hasSecrets, err := backend.HasSecrets(); err != nil {
+		return nil, errors.Annotate(err, "checking secrets")
+	} else if hasSecrets {
+		failures.add(errors.New("model has secrets, which cannot be migrated"))
+	}
+
+	// Nor is the trust granted to applications.
+	if hasTrusted, err := backend.HasTrustedApplications(); err != nil {
+		return nil, errors.Annotate(err, "checking trusted applications")
+	} else if hasTrusted {
+		failures.add(errors.New("model has trusted applications, which cannot be migrated"))
+	}
+
+	// Nor are application-level relation settings.
+	if hasSettings, err := backend.HasApplicationRelationSettings(); err != nil {
+		return nil, errors.Annotate(err, "checking application relation settings")
+	} else if hasSettings {
+		failures.add(errors.New("model has application relation settings, which cannot be migrated"))
 	}
 
 	// Check the source controller.
 	controllerBackend, err := backend.ControllerBackend()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 	defer controllerBackend.Close()
-	if err := checkController(controllerBackend); err != nil {
-		return errors.Annotate(err, "controller")
+	var controllerFailures precheckFailures
+	if err := checkController(controllerBackend, &controllerFailures); err != nil {
+		return nil, errors.Annotate(err, "controller")
 	}
-	return nil
+	for _, failure := range controllerFailures {
+		failures.add(errors.Annotate(failure, "controller"))
+	}
+	return failures, nil
+}
+
+// precheckFailures accumulates the preconditions for migration that
+// are found not to be met.
+type precheckFailures []error
+
+func (f *precheckFailures) add(failure error) {
+	*f = append(*f, failure)
 }
 
-func checkModel(backend PrecheckBackend) error {
+func firstFailure(failures []error) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	return failures[0]
+}
+
+func checkModel(backend PrecheckBackend, failures *precheckFailures) error {
 	model, err := backend.Model()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		failures.add(errors.Errorf("model is %s", model.Life()))
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		failures.add(errors.New("model is being imported as part of another migration"))
 	}
 	if credTag, found := model.CloudCredential(); found {
 		creds, err := backend.CloudCredential(credTag)
@@ -139,7 +205,7 @@ func checkModel(backend PrecheckBackend) error {
 			return errors.Trace(err)
 		}
 		if creds.Revoked {
-			return errors.New("model has revoked credentials")
+			failures.add(errors.New("model has revoked credentials"))
 		}
 	}
 	return nil
@@ -149,9 +215,23 @@ func checkModel(backend PrecheckBackend) error {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo) error {
-	if err := modelInfo.Validate(); err != nil {
+	failures, err := TargetPrecheckFailures(backend, modelInfo)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	return firstFailure(failures)
+}
+
+// TargetPrecheckFailures runs the same checks as TargetPrecheck, but
+// rather than stopping at the first precondition that is not met, it
+// returns all of them. The error is only non-nil if the checks could
+// not be completed.
+func TargetPrecheckFailures(backend PrecheckBackend, modelInfo coremigration.ModelInfo) ([]error, error) {
+	if err := modelInfo.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var failures precheckFailures
 
 	// This check is necessary because there is a window between the
 	// REAP phase and then end of the DONE phase where a model's
@@ -161,47 +241,85 @@ func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo)
 	//
 	// See also https://lpad.tv/1611391
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
-		return errors.Annotate(err, "checking for active migration")
+		return nil, errors.Annotate(err, "checking for active migration")
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		failures.add(errors.New("model is being migrated out of target controller"))
 	}
 
 	controllerVersion, err := backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		return nil, errors.Annotate(err, "retrieving model version")
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
+		failures.add(errors.Errorf("model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion))
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+		failures.add(errors.Errorf("source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion))
 	}
 
-	if err := checkController(backend); err != nil {
-		return errors.Trace(err)
+	if err := checkController(backend, &failures); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := checkTargetCloud(backend, modelInfo, &failures); err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	// Check for conflicts with existing models
 	models, err := backend.AllModels()
 	if err != nil {
-		return errors.Annotate(err, "retrieving models")
+		return nil, errors.Annotate(err, "retrieving models")
 	}
 	for _, model := range models {
 		// If the model is importing then it's probably left behind
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			failures.add(errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID))
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			failures.add(errors.Errorf("model named %q already exists", model.Name()))
+		}
+	}
+
+	return failures, nil
+}
+
+// checkTargetCloud checks that the model's cloud, region and cloud
+// credential can be used on the target controller. A credential that
+// doesn't exist yet is fine, as it is created when the model is
+// imported.
+func checkTargetCloud(backend PrecheckBackend, modelInfo coremigration.ModelInfo, failures *precheckFailures) error {
+	if modelInfo.Cloud != "" {
+		modelCloud, err := backend.Cloud(modelInfo.Cloud)
+		if errors.IsNotFound(err) {
+			failures.add(errors.Errorf("cloud %q not found on target controller", modelInfo.Cloud))
+		} else if err != nil {
+			return errors.Annotate(err, "retrieving cloud")
+		} else if modelInfo.CloudRegion != "" {
+			if _, err := cloud.RegionByName(modelCloud.Regions, modelInfo.CloudRegion); err != nil {
+				failures.add(errors.Errorf("cloud %q has no region %q on target controller",
+					modelInfo.Cloud, modelInfo.CloudRegion))
+			}
 		}
 	}
 
+	if modelInfo.CloudCredential != (names.CloudCredentialTag{}) {
+		creds, err := backend.CloudCredential(modelInfo.CloudCredential)
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Annotate(err, "retrieving cloud credential")
+		}
+		if creds.Revoked {
+			failures.add(errors.Errorf("cloud credential %q is revoked on target controller",
+				modelInfo.CloudCredential.Id()))
+		}
+	}
 	return nil
 }
 
@@ -222,26 +340,26 @@ func versionToMajMin(ver version.Number) version.Number {
 	return ver
 }
 
-func checkController(backend PrecheckBackend) error {
+func checkController(backend PrecheckBackend, failures *precheckFailures) error {
 	model, err := backend.Model()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		failures.add(errors.Errorf("model is %s", model.Life()))
 	}
 
 	if upgrading, err := backend.IsUpgrading(); err != nil {
 		return errors.Annotate(err, "checking for upgrades")
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		failures.add(errors.New("upgrade in progress"))
 	}
 
-	err = checkMachines(backend)
+	err = checkMachines(backend, failures)
 	return errors.Trace(err)
 }
 
-func checkMachines(backend PrecheckBackend) error {
+func checkMachines(backend PrecheckBackend, failures *precheckFailures) error {
 	modelVersion, err := backend.AgentVersion()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model version")
@@ -252,37 +370,47 @@ func checkMachines(backend PrecheckBackend) error {
 		return errors.Annotate(err, "retrieving machines")
 	}
 	for _, machine := range machines {
-		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+		failure, err := checkMachine(machine, modelVersion)
+		if err != nil {
+			return errors.Trace(err)
 		}
-
-		if statusInfo, err := machine.InstanceStatus(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
-		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+		if failure != nil {
+			failures.add(failure)
 		}
+	}
+	return nil
+}
 
-		if statusInfo, err := common.MachineStatus(machine); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
-		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
-		}
+// checkMachine returns the first precondition that the machine does
+// not meet, or an error if it could not be checked.
+func checkMachine(machine PrecheckMachine, modelVersion version.Number) (failure, err error) {
+	if machine.Life() != state.Alive {
+		return errors.Errorf("machine %s is %s", machine.Id(), machine.Life()), nil
+	}
 
-		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
-		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
-		}
+	if statusInfo, err := machine.InstanceStatus(); err != nil {
+		return nil, errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
+	} else if statusInfo.Status != status.Running {
+		return newStatusError("machine %s not running", machine.Id(), statusInfo.Status), nil
+	}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
-			return errors.Trace(err)
-		}
+	if statusInfo, err := common.MachineStatus(machine); err != nil {
+		return nil, errors.Annotatef(err, "retrieving machine %s status", machine.Id())
+	} else if statusInfo.Status != status.Started {
+		return newStatusError("machine %s agent not functioning at this time",
+			machine.Id(), statusInfo.Status), nil
 	}
-	return nil
+
+	if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
+		return nil, errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
+	} else if rebootAction != state.ShouldDoNothing {
+		return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction), nil
+	}
+
+	return checkAgentTools(modelVersion, machine, "machine "+machine.Id())
 }
 
-func checkApplications(backend PrecheckBackend) error {
+func checkApplications(backend PrecheckBackend, failures *precheckFailures) error {
 	modelVersion, err := backend.AgentVersion()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model version")
@@ -293,9 +421,10 @@ func checkApplications(backend PrecheckBackend) error {
 	}
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return errors.Errorf("application %s is %s", app.Name(), app.Life())
+			failures.add(errors.Errorf("application %s is %s", app.Name(), app.Life()))
+			continue
 		}
-		err := checkUnits(app, modelVersion)
+		err := checkUnits(app, modelVersion, failures)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -304,72 +433,84 @@ func checkApplications(backend PrecheckBackend) error {
 		if err != nil {
 			return errors.Annotate(err, "checking resources")
 		}
-		if len(resources) > 0 {
-			resName := resources[0].Name
-			return errors.Errorf("resource %q is pending for application %s", resName, app.Name())
+		for _, res := range resources {
+			failures.add(errors.Errorf("resource %q is pending for application %s", res.Name, app.Name()))
 		}
 	}
 	return nil
 }
 
-func checkUnits(app PrecheckApplication, modelVersion version.Number) error {
+func checkUnits(app PrecheckApplication, modelVersion version.Number, failures *precheckFailures) error {
 	units, err := app.AllUnits()
 	if err != nil {
 		return errors.Annotatef(err, "retrieving units for %s", app.Name())
 	}
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		failures.add(errors.Errorf("application %s is below its minimum units threshold", app.Name()))
 	}
 
 	appCharmURL, _ := app.CharmURL()
 
 	for _, unit := range units {
-		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
-		}
-
-		if err := checkUnitAgentStatus(unit); err != nil {
-			return errors.Trace(err)
-		}
-
-		if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+		failure, err := checkUnit(unit, appCharmURL, modelVersion)
+		if err != nil {
 			return errors.Trace(err)
 		}
-
-		unitCharmURL, _ := unit.CharmURL()
-		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+		if failure != nil {
+			failures.add(failure)
 		}
 	}
 	return nil
 }
 
-func checkUnitAgentStatus(unit PrecheckUnit) error {
+// checkUnit returns the first precondition that the unit does not
+// meet, or an error if it could not be checked.
+func checkUnit(unit PrecheckUnit, appCharmURL *charm.URL, modelVersion version.Number) (failure, err error) {
+	if unit.Life() != state.Alive {
+		return errors.Errorf("unit %s is %s", unit.Name(), unit.Life()), nil
+	}
+
+	if failure, err := checkUnitAgentStatus(unit); failure != nil || err != nil {
+		return failure, errors.Trace(err)
+	}
+
+	if failure, err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); failure != nil || err != nil {
+		return failure, errors.Trace(err)
+	}
+
+	unitCharmURL, _ := unit.CharmURL()
+	if appCharmURL.String() != unitCharmURL.String() {
+		return errors.Errorf("unit %s is upgrading", unit.Name()), nil
+	}
+	return nil, nil
+}
+
+func checkUnitAgentStatus(unit PrecheckUnit) (failure, err error) {
 	statusData, _ := common.UnitStatus(unit)
 	if statusData.Err != nil {
-		return errors.Annotatef(statusData.Err, "retrieving unit %s status", unit.Name())
+		return nil, errors.Annotatef(statusData.Err, "retrieving unit %s status", unit.Name())
 	}
 	agentStatus := statusData.Status.Status
 	switch agentStatus {
 	case status.Idle, status.Executing:
 		// These two are fine.
 	default:
-		return newStatusError("unit %s not idle or executing", unit.Name(), agentStatus)
+		return newStatusError("unit %s not idle or executing", unit.Name(), agentStatus), nil
 	}
-	return nil
+	return nil, nil
 }
 
-func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
+func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) (failure, err error) {
 	tools, err := agent.AgentTools()
 	if err != nil {
-		return errors.Annotatef(err, "retrieving tools for %s", agentLabel)
+		return nil, errors.Annotatef(err, "retrieving tools for %s", agentLabel)
 	}
 	agentVersion := tools.Version.Number
 	if agentVersion != modelVersion {
 		return errors.Errorf("%s tools don't match model (%s != %s)",
-			agentLabel, agentVersion, modelVersion)
+			agentLabel, agentVersion, modelVersion), nil
 	}
-	return nil
+	return nil, nil
 }
 
 type agentToolsGetter interface {
@@ -105,6 +105,58 @@ func (s *precheckShim) ListPendingResources(app string) ([]resource.Resource, er
 	return resources, nil
 }
 
+// HasActionSchedules implements PrecheckBackend.
+func (s *precheckShim) HasActionSchedules() (bool, error) {
+	schedules, err := s.State.ActionSchedules()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return len(schedules) > 0, nil
+}
+
+// HasSecrets implements PrecheckBackend.
+func (s *precheckShim) HasSecrets() (bool, error) {
+	secrets, err := s.State.AllSecrets()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return len(secrets) > 0, nil
+}
+
+// HasTrustedApplications implements PrecheckBackend.
+func (s *precheckShim) HasTrustedApplications() (bool, error) {
+	apps, err := s.State.AllApplications()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, app := range apps {
+		if app.IsTrusted() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// HasApplicationRelationSettings implements PrecheckBackend.
+func (s *precheckShim) HasApplicationRelationSettings() (bool, error) {
+	relations, err := s.State.AllRelations()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	for _, relation := range relations {
+		for _, ep := range relation.Endpoints() {
+			settings, err := relation.ApplicationSettings(ep.ApplicationName)
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			if len(settings) > 0 {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 // ControllerBackend implements PrecheckBackend.
 func (s *precheckShim) ControllerBackend() (PrecheckBackendCloser, error) {
 	model, err := s.State.ControllerModel()
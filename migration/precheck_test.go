@@ -108,6 +108,62 @@ func (*SourcePrecheckSuite) TestCleanupsNeeded(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cleanup needed")
 }
 
+func (*SourcePrecheckSuite) TestActionSchedulesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionSchedulesErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking action schedules: boom")
+}
+
+func (*SourcePrecheckSuite) TestActionSchedules(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionSchedules = true
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has action schedules, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestSecretsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.secretsErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking secrets: boom")
+}
+
+func (*SourcePrecheckSuite) TestSecrets(c *gc.C) {
+	backend := newFakeBackend()
+	backend.secrets = true
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has secrets, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestTrustedApplicationsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.trustedAppsErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking trusted applications: boom")
+}
+
+func (*SourcePrecheckSuite) TestTrustedApplications(c *gc.C) {
+	backend := newFakeBackend()
+	backend.trustedApps = true
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has trusted applications, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestApplicationRelationSettingsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.appRelationSettingsErr = errors.New("boom")
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking application relation settings: boom")
+}
+
+func (*SourcePrecheckSuite) TestApplicationRelationSettings(c *gc.C) {
+	backend := newFakeBackend()
+	backend.appRelationSettings = true
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has application relation settings, which cannot be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestIsUpgradingError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.controllerBackend.isUpgradingErr = errors.New("boom")
@@ -316,6 +372,49 @@ func (s *SourcePrecheckSuite) TestProvisioningControllerMachine(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "controller: machine 0 not running (allocating)")
 }
 
+func (s *SourcePrecheckSuite) TestFailures(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.apps = []migration.PrecheckApplication{
+		&fakeApp{
+			name: "foo",
+			life: state.Dying,
+		},
+		&fakeApp{
+			name:  "bar",
+			units: []migration.PrecheckUnit{&fakeUnit{name: "bar/0", lost: true}},
+		},
+	}
+	backend.cleanupNeeded = true
+	backend.controllerBackend = newHappyBackend()
+	backend.controllerBackend.isUpgrading = true
+
+	failures, err := migration.SourcePrecheckFailures(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errorStrings(failures), jc.DeepEquals, []string{
+		"machine 0 is dying",
+		"application foo is dying",
+		"unit bar/0 not idle or executing (lost)",
+		"cleanup needed",
+		"controller: upgrade in progress",
+	})
+}
+
+func (s *SourcePrecheckSuite) TestFailuresNone(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	failures, err := migration.SourcePrecheckFailures(backend)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 0)
+}
+
+func (s *SourcePrecheckSuite) TestFailuresError(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.cleanupErr = errors.New("boom")
+	failures, err := migration.SourcePrecheckFailures(backend)
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+	c.Assert(failures, gc.IsNil)
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -523,6 +622,117 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestCloud(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cloud = cloud.Cloud{
+		Name:    "dummy",
+		Regions: []cloud.Region{{Name: "dummy-region"}},
+	}
+	s.modelInfo.Cloud = "dummy"
+	s.modelInfo.CloudRegion = "dummy-region"
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("dummy/owner/cred")
+
+	c.Assert(migration.TargetPrecheck(backend, s.modelInfo), jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestCloudNotFound(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cloudErr = errors.NotFoundf("cloud \"dummy\"")
+	s.modelInfo.Cloud = "dummy"
+
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `cloud "dummy" not found on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestCloudError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cloudErr = errors.New("boom")
+	s.modelInfo.Cloud = "dummy"
+
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, "retrieving cloud: boom")
+}
+
+func (s *TargetPrecheckSuite) TestCloudRegionNotFound(c *gc.C) {
+	backend := newFakeBackend()
+	backend.cloud = cloud.Cloud{
+		Name:    "dummy",
+		Regions: []cloud.Region{{Name: "dummy-region"}},
+	}
+	s.modelInfo.Cloud = "dummy"
+	s.modelInfo.CloudRegion = "other-region"
+
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `cloud "dummy" has no region "other-region" on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestCloudCredentialNotFound(c *gc.C) {
+	backend := newFakeBackend()
+	backend.credentialsErr = errors.NotFoundf("cloud credential")
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("dummy/owner/cred")
+
+	c.Assert(migration.TargetPrecheck(backend, s.modelInfo), jc.ErrorIsNil)
+}
+
+func (s *TargetPrecheckSuite) TestCloudCredentialError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.credentialsErr = errors.New("boom")
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("dummy/owner/cred")
+
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, "retrieving cloud credential: boom")
+}
+
+func (s *TargetPrecheckSuite) TestCloudCredentialRevoked(c *gc.C) {
+	backend := newFakeBackend()
+	backend.credentials = cloud.NewCredential(cloud.UserPassAuthType, nil)
+	backend.credentials.Revoked = true
+	s.modelInfo.CloudCredential = names.NewCloudCredentialTag("dummy/owner/cred")
+
+	err := migration.TargetPrecheck(backend, s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, `cloud credential "dummy/owner/cred" is revoked on target controller`)
+}
+
+func (s *TargetPrecheckSuite) TestFailures(c *gc.C) {
+	backend := newBackendWithRebootingMachine()
+	backend.migrationActive = true
+	backend.isUpgrading = true
+	backend.models = []migration.PrecheckModel{
+		&fakeModel{uuid: "uuid", name: modelName, owner: modelOwner},
+	}
+
+	sourceVersion := backendVersion
+	sourceVersion.Minor++
+	s.modelInfo.AgentVersion = sourceVersion
+	s.modelInfo.ControllerAgentVersion = sourceVersion
+
+	failures, err := migration.TargetPrecheckFailures(backend, s.modelInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errorStrings(failures), jc.DeepEquals, []string{
+		"model is being migrated out of target controller",
+		"model has higher version than target controller (1.3.3 > 1.2.3)",
+		"source controller has higher version than target controller (1.3.3 > 1.2.3)",
+		"upgrade in progress",
+		"machine 0 is scheduled to reboot",
+		`model named "model-name" already exists`,
+	})
+}
+
+func (s *TargetPrecheckSuite) TestFailuresInvalidModelInfo(c *gc.C) {
+	s.modelInfo.UUID = ""
+	failures, err := migration.TargetPrecheckFailures(newHappyBackend(), s.modelInfo)
+	c.Assert(err, gc.ErrorMatches, "empty UUID not valid")
+	c.Assert(failures, gc.IsNil)
+}
+
+func errorStrings(errs []error) []string {
+	var out []string
+	for _, err := range errs {
+		out = append(out, err.Error())
+	}
+	return out
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -649,12 +859,27 @@ type fakeBackend struct {
 	apps       []migration.PrecheckApplication
 	allAppsErr error
 
+	cloud    cloud.Cloud
+	cloudErr error
+
 	credentials    cloud.Credential
 	credentialsErr error
 
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	actionSchedules    bool
+	actionSchedulesErr error
+
+	secrets    bool
+	secretsErr error
+
+	trustedApps    bool
+	trustedAppsErr error
+
+	appRelationSettings    bool
+	appRelationSettingsErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -686,6 +911,10 @@ func (b *fakeBackend) IsMigrationActive(string) (bool, error) {
 	return b.migrationActive, b.migrationActiveErr
 }
 
+func (b *fakeBackend) Cloud(name string) (cloud.Cloud, error) {
+	return b.cloud, b.cloudErr
+}
+
 func (b *fakeBackend) CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error) {
 	return b.credentials, b.credentialsErr
 }
@@ -703,6 +932,22 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) HasActionSchedules() (bool, error) {
+	return b.actionSchedules, b.actionSchedulesErr
+}
+
+func (b *fakeBackend) HasSecrets() (bool, error) {
+	return b.secrets, b.secretsErr
+}
+
+func (b *fakeBackend) HasTrustedApplications() (bool, error) {
+	return b.trustedApps, b.trustedAppsErr
+}
+
+func (b *fakeBackend) HasApplicationRelationSettings() (bool, error) {
+	return b.appRelationSettings, b.appRelationSettingsErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackendCloser, error) {
 	if b.controllerBackend == nil {
 		return b, nil
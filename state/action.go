@@ -104,6 +104,26 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Messages holds the progress messages logged by the action while it
+	// was running.
+	Messages []ActionMessage `bson:"messages"`
+}
+
+const (
+	// maxActionMessages is the number of progress messages kept for
+	// an action. Older messages are discarded as new ones are logged.
+	maxActionMessages = 100
+
+	// maxActionMessageSize is the length of the longest progress
+	// message kept. Longer messages are truncated.
+	maxActionMessageSize = 1024
+)
+
+// ActionMessage represents a progress message logged by an action.
+type ActionMessage struct {
+	Timestamp time.Time `bson:"timestamp"`
+	Message   string    `bson:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +182,11 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Messages
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -201,6 +226,35 @@ func (a *action) Begin() (Action, error) {
 	return a.st.Action(a.Id())
 }
 
+// Log adds a timestamped progress message to the action. It asserts
+// that the action is currently running. Only the most recent
+// maxActionMessages messages are kept, each truncated to
+// maxActionMessageSize.
+func (a *action) Log(message string) error {
+	if len(message) > maxActionMessageSize {
+		message = message[:maxActionMessageSize]
+	}
+	err := a.st.runTransaction([]txn.Op{
+		{
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: bson.D{{"status", ActionRunning}},
+			Update: bson.D{{"$push", bson.D{
+				{"messages", bson.D{
+					{"$each", []ActionMessage{{
+						Timestamp: a.st.NowToTheSecond(),
+						Message:   message,
+					}}},
+					{"$slice", -maxActionMessages},
+				}},
+			}}},
+		}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot log message to action %q: action not running", a.Id())
+	}
+	return errors.Trace(err)
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
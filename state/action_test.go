@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -463,6 +464,118 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestOutput(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	other, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	output := []state.ActionOutput{
+		{Seq: 0, Stream: state.ActionStdout, Data: "starting\n", Timestamp: now},
+		{Seq: 1, Stream: state.ActionStderr, Data: "warning\n", Timestamp: now.Add(time.Second)},
+		{Seq: 2, Stream: state.ActionStdout, Data: "done\n", Timestamp: now.Add(2 * time.Second)},
+	}
+	err = a.AddOutput(output[2], output[0], output[1])
+	c.Assert(err, jc.ErrorIsNil)
+	// Resent output is ignored.
+	err = a.AddOutput(output[1])
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := a.Output(-1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, output)
+
+	later, err := a.Output(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(later, jc.DeepEquals, output[1:])
+
+	none, err := other.Output(-1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(none, gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestAddOutputLimits(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	long := strings.Repeat("x", state.MaxActionOutputChunkSize+1)
+	err = a.AddOutput(
+		state.ActionOutput{Seq: 0, Stream: state.ActionStdout, Data: long},
+		state.ActionOutput{Seq: state.MaxActionOutputChunks, Stream: state.ActionStdout, Data: "dropped"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := a.Output(-1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all[0].Data, gc.Equals, long[:state.MaxActionOutputChunkSize])
+}
+
+func (s *ActionSuite) TestAddOutputInvalidStream(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.AddOutput(state.ActionOutput{Stream: "stdin", Data: "x"})
+	c.Assert(err, gc.ErrorMatches, `action output stream "stdin" not valid`)
+}
+
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Messages(), gc.HasLen, 0)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.Log("copying files")
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("compressing")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Check(messages[0].Message, gc.Equals, "copying files")
+	c.Check(messages[1].Message, gc.Equals, "compressing")
+	c.Check(messages[0].Timestamp.IsZero(), jc.IsFalse)
+}
+
+func (s *ActionSuite) TestLogLimits(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i := 0; i <= state.MaxActionMessages; i++ {
+		err = a.Log(fmt.Sprintf("step %d", i))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	long := strings.Repeat("x", state.MaxActionMessageSize+1)
+	err = a.Log(long)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, state.MaxActionMessages)
+	c.Check(messages[0].Message, gc.Equals, "step 2")
+	c.Check(messages[len(messages)-1].Message, gc.Equals, long[:state.MaxActionMessageSize])
+}
+
+func (s *ActionSuite) TestLogNotRunning(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("copying files")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("copying files")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action not running`)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// ActionStdout identifies output written to an action's
+	// standard output.
+	ActionStdout = "stdout"
+
+	// ActionStderr identifies output written to an action's
+	// standard error.
+	ActionStderr = "stderr"
+)
+
+const (
+	// maxActionOutputChunks is the number of chunks of output kept
+	// for an action. Any later output is dropped.
+	maxActionOutputChunks = 1000
+
+	// maxActionOutputChunkSize is the size of the largest chunk of
+	// output kept. Longer chunks are truncated.
+	maxActionOutputChunkSize = 64 * 1024
+
+	// actionOutputTTL is how long output is kept after it is
+	// recorded. Output is only kept so that it can be streamed while
+	// the action runs; the action's results are kept with the action.
+	actionOutputTTL = 24 * time.Hour
+)
+
+// ActionOutput is a chunk of the output written by a running action.
+type ActionOutput struct {
+	// Seq orders the chunks written by an action, starting from zero.
+	Seq int
+
+	// Stream is either ActionStdout or ActionStderr.
+	Stream string
+
+	// Data holds the output.
+	Data string
+
+	// Timestamp is the time at which the output was written.
+	Timestamp time.Time
+}
+
+// actionOutputDoc records a chunk of the output written by a running
+// action. Output is written often and read rarely, so it is kept apart
+// from the action document and written without transactions.
+type actionOutputDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	ActionID  string `bson:"action-id"`
+	Seq       int    `bson:"seq"`
+	Stream    string `bson:"stream"`
+	Data      string `bson:"data"`
+	Timestamp int64  `bson:"timestamp"`
+
+	// Created is the time at which the output was recorded, from
+	// which the document expires.
+	Created time.Time `bson:"created"`
+}
+
+// AddOutput records output written by the running action. Chunks
+// that have already been recorded are ignored, so that the output may
+// be resent after a failure. Only the first maxActionOutputChunks
+// chunks are kept, each truncated to maxActionOutputChunkSize, and
+// they expire after actionOutputTTL.
+func (a *action) AddOutput(output ...ActionOutput) error {
+	outputs, closer := a.st.db().GetCollection(actionOutputC)
+	defer closer()
+	outputsW := outputs.Writeable()
+
+	now := a.st.clock.Now()
+	for _, out := range output {
+		if out.Stream != ActionStdout && out.Stream != ActionStderr {
+			return errors.NotValidf("action output stream %q", out.Stream)
+		}
+		if out.Seq < 0 || out.Seq >= maxActionOutputChunks {
+			continue
+		}
+		data := out.Data
+		if len(data) > maxActionOutputChunkSize {
+			data = data[:maxActionOutputChunkSize]
+		}
+		doc := &actionOutputDoc{
+			DocId:     fmt.Sprintf("%s:%d", a.Id(), out.Seq),
+			ActionID:  a.Id(),
+			Seq:       out.Seq,
+			Stream:    out.Stream,
+			Data:      data,
+			Timestamp: out.Timestamp.UnixNano(),
+			Created:   now,
+		}
+		if err := outputsW.Insert(doc); err != nil && !mgo.IsDup(err) {
+			return errors.Annotatef(err, "cannot add output for action %q", a.Id())
+		}
+	}
+	return nil
+}
+
+// Output returns the output recorded for the action with a sequence
+// number greater than after, in order. Pass -1 to return all output.
+func (a *action) Output(after int) ([]ActionOutput, error) {
+	outputs, closer := a.st.db().GetCollection(actionOutputC)
+	defer closer()
+
+	var docs []actionOutputDoc
+	query := bson.D{
+		{"action-id", a.Id()},
+		{"seq", bson.D{{"$gt", after}}},
+	}
+	if err := outputs.Find(query).Sort("seq").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot read output for action %q", a.Id())
+	}
+	result := make([]ActionOutput, len(docs))
+	for i, doc := range docs {
+		result[i] = ActionOutput{
+			Seq:       doc.Seq,
+			Stream:    doc.Stream,
+			Data:      doc.Data,
+			Timestamp: time.Unix(0, doc.Timestamp).UTC(),
+		}
+	}
+	return result, nil
+}
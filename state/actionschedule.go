@@ -0,0 +1,473 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// actionScheduleDoc records an action that is enqueued on its receivers
+// at a given time, or repeatedly on a schedule.
+type actionScheduleDoc struct {
+	DocId     string `bson:"_id"`
+	Id        string `bson:"id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Receivers holds the tags of the applications and units on which
+	// the action is enqueued. The action is enqueued on all the units
+	// of an application at the time it runs.
+	Receivers []string `bson:"receivers"`
+
+	// Name is the name of the action.
+	Name string `bson:"name"`
+
+	// Parameters holds the parameters of the action.
+	Parameters map[string]interface{} `bson:"parameters"`
+
+	// Schedule holds the schedule specification of a recurring
+	// action, as understood by actions.ParseSchedule. It is empty for
+	// an action that runs once.
+	Schedule string `bson:"schedule,omitempty"`
+
+	// NextRun is the time, in unix nanoseconds, at which the action is
+	// next enqueued.
+	NextRun int64 `bson:"next-run"`
+
+	// LastRun is the time, in unix nanoseconds, at which the action
+	// was last enqueued, or zero if it has not been.
+	LastRun int64 `bson:"last-run"`
+
+	// Owner is the tag of the user that scheduled the action.
+	Owner string `bson:"owner"`
+
+	// Created is the time, in unix nanoseconds, at which the action
+	// was scheduled.
+	Created int64 `bson:"created"`
+}
+
+// ActionScheduleArgs holds the arguments for scheduling an action.
+type ActionScheduleArgs struct {
+	// Receivers holds the tags of the applications and units on
+	// which the action is enqueued.
+	Receivers []names.Tag
+
+	// Name is the name of the action.
+	Name string
+
+	// Parameters holds the parameters of the action.
+	Parameters map[string]interface{}
+
+	// Schedule, if set, is the schedule on which the action is
+	// repeatedly enqueued.
+	Schedule string
+
+	// At, if set, is the time at which the action is enqueued once.
+	// Exactly one of Schedule and At must be set.
+	At time.Time
+
+	// Owner is the user scheduling the action.
+	Owner names.UserTag
+}
+
+// ActionSchedule represents an action that is enqueued on its
+// receivers at a given time, or repeatedly on a schedule.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// Id returns the id of the schedule.
+func (s *ActionSchedule) Id() string {
+	return s.doc.Id
+}
+
+// Receivers returns the tags of the applications and units on which
+// the action is enqueued.
+func (s *ActionSchedule) Receivers() []string {
+	return s.doc.Receivers
+}
+
+// Name returns the name of the scheduled action.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Parameters returns the parameters of the scheduled action.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Schedule returns the schedule of a recurring action, or an empty
+// string if the action runs once.
+func (s *ActionSchedule) Schedule() string {
+	return s.doc.Schedule
+}
+
+// NextRun returns the time at which the action is next enqueued.
+func (s *ActionSchedule) NextRun() time.Time {
+	return time.Unix(0, s.doc.NextRun).UTC()
+}
+
+// LastRun returns the time at which the action was last enqueued, or
+// the zero time if it has not been.
+func (s *ActionSchedule) LastRun() time.Time {
+	return unixNanoToTime0(s.doc.LastRun)
+}
+
+// Owner returns the tag of the user that scheduled the action.
+func (s *ActionSchedule) Owner() names.UserTag {
+	return names.NewUserTag(s.doc.Owner)
+}
+
+// Created returns the time at which the action was scheduled.
+func (s *ActionSchedule) Created() time.Time {
+	return time.Unix(0, s.doc.Created).UTC()
+}
+
+// AddActionSchedule schedules an action to be enqueued on the given
+// receivers, either once at the given time or repeatedly on the given
+// schedule.
+func (st *State) AddActionSchedule(args ActionScheduleArgs) (_ *ActionSchedule, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot schedule action %q", args.Name)
+
+	if args.Name == "" {
+		return nil, errors.New("no action name given")
+	}
+	if len(args.Receivers) == 0 {
+		return nil, errors.New("no receivers given")
+	}
+	now := st.clock.Now()
+	var nextRun time.Time
+	switch {
+	case args.Schedule != "" && !args.At.IsZero():
+		return nil, errors.New("cannot specify both a schedule and a time")
+	case args.Schedule != "":
+		schedule, err := actions.ParseSchedule(args.Schedule)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		nextRun = schedule.Next(now)
+		if nextRun.IsZero() {
+			return nil, errors.Errorf("schedule %q never runs", args.Schedule)
+		}
+	case !args.At.IsZero():
+		if !args.At.After(now) {
+			return nil, errors.Errorf("time %s is in the past", args.At.UTC().Format(time.RFC3339))
+		}
+		nextRun = args.At
+	default:
+		return nil, errors.New("no schedule or time given")
+	}
+
+	receivers := make([]string, len(args.Receivers))
+	for i, tag := range args.Receivers {
+		if err := st.checkScheduledAction(tag, args.Name, args.Parameters); err != nil {
+			return nil, errors.Trace(err)
+		}
+		receivers[i] = tag.String()
+	}
+
+	seq, err := st.sequence("actionschedule")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	doc := actionScheduleDoc{
+		DocId:      st.docID(id),
+		Id:         id,
+		ModelUUID:  st.ModelUUID(),
+		Receivers:  receivers,
+		Name:       args.Name,
+		Parameters: args.Parameters,
+		Schedule:   args.Schedule,
+		NextRun:    nextRun.UnixNano(),
+		Owner:      args.Owner.Id(),
+		Created:    now.UnixNano(),
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// checkScheduledAction checks that the named action is defined by the
+// charm of the given application or unit, and that the parameters are
+// valid for it.
+func (st *State) checkScheduledAction(tag names.Tag, name string, parameters map[string]interface{}) error {
+	var specs ActionSpecsByName
+	switch tag := tag.(type) {
+	case names.ApplicationTag:
+		application, err := st.Application(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ch, _, err := application.Charm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if chActions := ch.Actions(); chActions != nil {
+			specs = chActions.ActionSpecs
+		}
+	case names.UnitTag:
+		unit, err := st.Unit(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if specs, err = unit.ActionSpecs(); err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.NotValidf("action receiver %q", tag)
+	}
+	spec, ok := actions.PredefinedActionsSpec[name]
+	if !ok {
+		if spec, ok = specs[name]; !ok {
+			return errors.Errorf("action %q not defined on %s", name, names.ReadableString(tag))
+		}
+	}
+	return errors.Trace(spec.ValidateParams(parameters))
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (st *State) ActionSchedule(id string) (*ActionSchedule, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// ActionSchedules returns all the action schedules of the model,
+// ordered by the time they next run.
+func (st *State) ActionSchedules() ([]*ActionSchedule, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(nil).Sort("next-run").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	result := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		result[i] = &ActionSchedule{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// WatchActionSchedules returns a NotifyWatcher that notifies when an
+// action schedule is added, removed or run.
+func (st *State) WatchActionSchedules() NotifyWatcher {
+	return newNotifyCollWatcher(st, actionSchedulesC, isLocalID(st))
+}
+
+// Remove removes the action schedule. Actions already enqueued by the
+// schedule are not affected.
+func (s *ActionSchedule) Remove() error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Remove: true,
+	}}
+	err := s.st.runTransaction(ops)
+	return errors.Annotatef(err, "cannot remove action schedule %q", s.doc.Id)
+}
+
+// Run enqueues the scheduled action on each of the units of its
+// receivers, and records the run. A recurring schedule is moved on to
+// its next run time after now; a schedule that runs once is removed.
+// The enqueued actions are returned, along with an error describing
+// the units on which the action could not be enqueued, if any.
+func (s *ActionSchedule) Run(now time.Time) ([]Action, error) {
+	var ops []txn.Op
+	assert := bson.D{{"next-run", s.doc.NextRun}}
+	if s.doc.Schedule == "" {
+		ops = []txn.Op{{
+			C:      actionSchedulesC,
+			Id:     s.doc.DocId,
+			Assert: assert,
+			Remove: true,
+		}}
+	} else {
+		schedule, err := actions.ParseSchedule(s.doc.Schedule)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		nextRun := schedule.Next(now)
+		if nextRun.IsZero() {
+			ops = []txn.Op{{
+				C:      actionSchedulesC,
+				Id:     s.doc.DocId,
+				Assert: assert,
+				Remove: true,
+			}}
+		} else {
+			ops = []txn.Op{{
+				C:      actionSchedulesC,
+				Id:     s.doc.DocId,
+				Assert: assert,
+				Update: bson.D{{"$set", bson.D{
+					{"next-run", nextRun.UnixNano()},
+					{"last-run", now.UnixNano()},
+				}}},
+			}}
+		}
+	}
+	// The schedule is moved on before the action is enqueued, so that
+	// it is never enqueued twice for the same run.
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.Errorf("action schedule %q already run or removed", s.doc.Id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot update action schedule %q", s.doc.Id)
+	}
+
+	units, err := s.units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var enqueued []Action
+	var failures []string
+	for _, unit := range units {
+		action, err := unit.AddAction(s.doc.Name, s.doc.Parameters)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", unit.Name(), err))
+			continue
+		}
+		enqueued = append(enqueued, action)
+	}
+	if len(failures) > 0 {
+		return enqueued, errors.Errorf(
+			"cannot enqueue action %q on %s", s.doc.Name, strings.Join(failures, ", "),
+		)
+	}
+	return enqueued, nil
+}
+
+// units returns the units on which the scheduled action is enqueued.
+// Receivers that no longer exist are skipped.
+func (s *ActionSchedule) units() ([]*Unit, error) {
+	var result []*Unit
+	seen := make(map[string]bool)
+	add := func(unit *Unit) {
+		if !seen[unit.Name()] {
+			seen[unit.Name()] = true
+			result = append(result, unit)
+		}
+	}
+	for _, receiver := range s.doc.Receivers {
+		tag, err := names.ParseTag(receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch tag := tag.(type) {
+		case names.ApplicationTag:
+			application, err := s.st.Application(tag.Id())
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			units, err := application.AllUnits()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, unit := range units {
+				add(unit)
+			}
+		case names.UnitTag:
+			unit, err := s.st.Unit(tag.Id())
+			if errors.IsNotFound(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			add(unit)
+		}
+	}
+	return result, nil
+}
+
+// removeApplicationActionSchedulesOps returns the operations that
+// remove the named application, and any of its units, from the
+// receivers of the action schedules. Schedules are keyed by receiver
+// name, so they must not outlive the application for another
+// application of the same name to inherit.
+func removeApplicationActionSchedulesOps(st *State, applicationName string) ([]txn.Op, error) {
+	pattern := fmt.Sprintf("^(%s|%s-[0-9]+)$",
+		regexp.QuoteMeta(names.NewApplicationTag(applicationName).String()),
+		regexp.QuoteMeta(names.UnitTagKind+"-"+applicationName),
+	)
+	isRemoved := regexp.MustCompile(pattern).MatchString
+	query := bson.D{{"receivers", bson.D{{"$regex", pattern}}}}
+	return removeActionScheduleReceiversOps(st, query, isRemoved)
+}
+
+// removeUnitActionSchedulesOps returns the operations that remove the
+// named unit from the receivers of the action schedules.
+func removeUnitActionSchedulesOps(st *State, unitName string) ([]txn.Op, error) {
+	receiver := names.NewUnitTag(unitName).String()
+	isRemoved := func(r string) bool { return r == receiver }
+	return removeActionScheduleReceiversOps(st, bson.D{{"receivers", receiver}}, isRemoved)
+}
+
+// removeActionScheduleReceiversOps returns the operations that remove
+// the receivers for which isRemoved returns true from the action
+// schedules matching the query. Schedules left with no receivers are
+// removed.
+func removeActionScheduleReceiversOps(st *State, query bson.D, isRemoved func(string) bool) ([]txn.Op, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		var removed, remaining []string
+		for _, receiver := range doc.Receivers {
+			if isRemoved(receiver) {
+				removed = append(removed, receiver)
+			} else {
+				remaining = append(remaining, receiver)
+			}
+		}
+		op := txn.Op{
+			C:      actionSchedulesC,
+			Id:     doc.DocId,
+			Assert: bson.D{{"receivers", doc.Receivers}},
+		}
+		if len(remaining) == 0 {
+			op.Remove = true
+		} else {
+			op.Update = bson.D{{"$pullAll", bson.D{{"receivers", removed}}}}
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
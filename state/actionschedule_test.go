@@ -0,0 +1,247 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	application *state.Application
+	unit        *state.Unit
+	unit2       *state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingService(c, "dummy", ch)
+	curl, _ := s.application.CharmURL()
+	var err error
+	s.unit, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.SetCharmURL(curl), jc.ErrorIsNil)
+	s.unit2, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit2.SetCharmURL(curl), jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	now := s.Clock.Now()
+	schedule, err := s.State.AddActionSchedule(state.ActionScheduleArgs{
+		Receivers:  []names.Tag{s.application.ApplicationTag()},
+		Name:       "snapshot",
+		Parameters: map[string]interface{}{"outfile": "nightly.bz2"},
+		Schedule:   "@every 24h",
+		Owner:      names.NewUserTag("bob"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Id(), gc.Equals, "0")
+	c.Check(schedule.Receivers(), jc.DeepEquals, []string{"application-dummy"})
+	c.Check(schedule.Name(), gc.Equals, "snapshot")
+	c.Check(schedule.Schedule(), gc.Equals, "@every 24h")
+	c.Check(schedule.Owner(), gc.Equals, names.NewUserTag("bob"))
+	c.Check(schedule.LastRun().IsZero(), jc.IsTrue)
+	c.Check(schedule.NextRun().Unix(), gc.Equals, now.Add(24*time.Hour).Unix())
+
+	schedules, err := s.State.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	c.Check(schedules[0].Id(), gc.Equals, "0")
+	c.Check(schedules[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "nightly.bz2"})
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	tag := s.application.ApplicationTag()
+	for i, test := range []struct {
+		args state.ActionScheduleArgs
+		err  string
+	}{{
+		args: state.ActionScheduleArgs{Receivers: []names.Tag{tag}, Schedule: "@daily"},
+		err:  `cannot schedule action "": no action name given`,
+	}, {
+		args: state.ActionScheduleArgs{Name: "snapshot", Schedule: "@daily"},
+		err:  `cannot schedule action "snapshot": no receivers given`,
+	}, {
+		args: state.ActionScheduleArgs{Receivers: []names.Tag{tag}, Name: "snapshot"},
+		err:  `cannot schedule action "snapshot": no schedule or time given`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Receivers: []names.Tag{tag}, Name: "snapshot",
+			Schedule: "@daily", At: s.Clock.Now().Add(time.Hour),
+		},
+		err: `cannot schedule action "snapshot": cannot specify both a schedule and a time`,
+	}, {
+		args: state.ActionScheduleArgs{Receivers: []names.Tag{tag}, Name: "snapshot", Schedule: "@fortnightly"},
+		err:  `cannot schedule action "snapshot": schedule "@fortnightly" not valid: .*`,
+	}, {
+		args: state.ActionScheduleArgs{Receivers: []names.Tag{tag}, Name: "snapshot", At: s.Clock.Now().Add(-time.Hour)},
+		err:  `cannot schedule action "snapshot": time .* is in the past`,
+	}, {
+		args: state.ActionScheduleArgs{Receivers: []names.Tag{tag}, Name: "backup", Schedule: "@daily"},
+		err:  `cannot schedule action "backup": action "backup" not defined on application dummy`,
+	}, {
+		args: state.ActionScheduleArgs{
+			Receivers: []names.Tag{tag}, Name: "snapshot", Schedule: "@daily",
+			Parameters: map[string]interface{}{"outfile": 42},
+		},
+		err: `cannot schedule action "snapshot": .*`,
+	}, {
+		args: state.ActionScheduleArgs{Receivers: []names.Tag{names.NewApplicationTag("mysql")}, Name: "snapshot", Schedule: "@daily"},
+		err:  `cannot schedule action "snapshot": application "mysql" not found`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestRunRecurring(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule(state.ActionScheduleArgs{
+		Receivers: []names.Tag{s.application.ApplicationTag(), s.unit.UnitTag()},
+		Name:      "snapshot",
+		Schedule:  "@every 1h",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := schedule.NextRun()
+	enqueued, err := schedule.Run(now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enqueued, gc.HasLen, 2)
+	receivers := []string{enqueued[0].Receiver(), enqueued[1].Receiver()}
+	c.Check(receivers, jc.SameContents, []string{s.unit.Name(), s.unit2.Name()})
+	c.Check(enqueued[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+
+	schedule, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.LastRun().Unix(), gc.Equals, now.Unix())
+	c.Check(schedule.NextRun().Unix(), gc.Equals, now.Add(time.Hour).Unix())
+}
+
+func (s *ActionScheduleSuite) TestRunOnceRemoves(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule(state.ActionScheduleArgs{
+		Receivers: []names.Tag{s.unit2.UnitTag()},
+		Name:      "snapshot",
+		At:        s.Clock.Now().Add(time.Hour),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	enqueued, err := schedule.Run(schedule.NextRun())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enqueued, gc.HasLen, 1)
+	c.Check(enqueued[0].Receiver(), gc.Equals, s.unit2.Name())
+
+	_, err = s.State.ActionSchedule(schedule.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = schedule.Run(schedule.NextRun())
+	c.Check(err, gc.ErrorMatches, `action schedule "0" already run or removed`)
+}
+
+func (s *ActionScheduleSuite) TestRemove(c *gc.C) {
+	schedule, err := s.State.AddActionSchedule(state.ActionScheduleArgs{
+		Receivers: []names.Tag{s.unit.UnitTag()},
+		Name:      "snapshot",
+		Schedule:  "0 2 * * *",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = schedule.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedules, err := s.State.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, receivers ...names.Tag) *state.ActionSchedule {
+	schedule, err := s.State.AddActionSchedule(state.ActionScheduleArgs{
+		Receivers: receivers,
+		Name:      "snapshot",
+		Schedule:  "@daily",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) removeUnit(c *gc.C, unit *state.Unit) {
+	err := unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) TestRemovedWithUnit(c *gc.C) {
+	both := s.addSchedule(c, s.application.ApplicationTag(), s.unit.UnitTag())
+	unitOnly := s.addSchedule(c, s.unit.UnitTag())
+	other := s.addSchedule(c, s.unit2.UnitTag())
+
+	s.removeUnit(c, s.unit)
+
+	schedule, err := s.State.ActionSchedule(both.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Receivers(), jc.DeepEquals, []string{"application-dummy"})
+	_, err = s.State.ActionSchedule(unitOnly.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	schedule, err = s.State.ActionSchedule(other.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Receivers(), jc.DeepEquals, []string{"unit-dummy-1"})
+}
+
+func (s *ActionScheduleSuite) TestRemovedWithApplication(c *gc.C) {
+	s.addSchedule(c, s.application.ApplicationTag())
+	s.addSchedule(c, s.unit.UnitTag(), s.unit2.UnitTag())
+
+	err := s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.removeUnit(c, s.unit)
+	// Removing the last unit removes the application.
+	s.removeUnit(c, s.unit2)
+	err = s.application.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	schedules, err := s.State.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedules, gc.HasLen, 0)
+
+	// A new application of the same name does not inherit the
+	// removed application's schedules.
+	s.AddTestingService(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	schedules, err = s.State.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.State.WatchActionSchedules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	schedule, err := s.State.AddActionSchedule(state.ActionScheduleArgs{
+		Receivers: []names.Tag{s.unit.UnitTag()},
+		Name:      "snapshot",
+		Schedule:  "@hourly",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	_, err = schedule.Run(schedule.NextRun())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = schedule.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
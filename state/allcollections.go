@@ -19,6 +19,14 @@ var (
 	txnLogSizeTests = 1000000
 )
 
+// The capped collection used for audit entries defaults to 300MB,
+// unless overridden by controller config. It's tweaked in
+// export_test.go to 1MB for the same reason as the transaction log.
+var (
+	auditLogSize      = 300 * 1024 * 1024
+	auditLogSizeTests = 1000000
+)
+
 // allCollections should be the single source of truth for information about
 // any collection we use. It's broken up into 4 main sections:
 //
@@ -259,6 +267,19 @@ func allCollections() collectionSchema {
 		},
 		minUnitsC: {},
 
+		// This collection holds the key/value state that charms store
+		// for their units.
+		unitStatesC: {},
+
+		// This collection holds the most recent hook runs of each unit,
+		// so that slow or failing hooks can be diagnosed.
+		hookRunsC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "unit", "started"},
+			}},
+		},
+
 		// This collection holds documents that indicate units which are queued
 		// to be assigned to machines. It is used exclusively by the
 		// AssignUnitWorker.
@@ -351,6 +372,41 @@ func allCollections() collectionSchema {
 		},
 		actionNotificationsC: {},
 
+		// This collection holds the output written by running actions,
+		// so that it can be streamed to clients. The output expires
+		// once it is no longer needed for streaming.
+		actionOutputC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "action-id", "seq"},
+			}, {
+				Key:         []string{"created"},
+				ExpireAfter: actionOutputTTL,
+			}},
+		},
+
+		// This collection holds the schedules on which actions are
+		// enqueued by the action scheduler worker.
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "next-run"},
+			}},
+		},
+
+		// -----
+
+		// These collections hold the secrets owned by applications,
+		// and the key used to encrypt their values. The key is never
+		// dumped, so that dumps do not reveal the secrets.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner"},
+			}},
+		},
+		secretKeysC: {
+			noDump: true,
+		},
+
 		// -----
 
 		// This collection holds information associated with charm payloads.
@@ -412,9 +468,23 @@ func allCollections() collectionSchema {
 
 		// metrics; status-history; logs; ..?
 
+		// This collection holds the audit entries recorded by the API
+		// server. It is capped so that the oldest entries are discarded
+		// once it reaches its maximum size.
 		auditingC: {
 			global:    true,
 			rawAccess: true,
+			explicitCreate: &mgo.CollectionInfo{
+				Capped:   true,
+				MaxBytes: auditLogSize,
+			},
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "timestamp"},
+			}, {
+				Key: []string{"origin-name", "timestamp"},
+			}, {
+				Key: []string{"timestamp"},
+			}},
 		},
 	}
 	if featureflag.Enabled(feature.CrossModelRelations) {
@@ -452,7 +522,9 @@ func allCollections() collectionSchema {
 // inspection.
 const (
 	actionNotificationsC     = "actionnotifications"
+	actionOutputC            = "actionoutput"
 	actionresultsC           = "actionresults"
+	actionSchedulesC         = "actionschedules"
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
 	autocertCacheC           = "autocertCache"
@@ -475,6 +547,7 @@ const (
 	globalSettingsC          = "globalSettings"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
+	hookRunsC                = "hookruns"
 	instanceDataC            = "instanceData"
 	leasesC                  = "leases"
 	machinesC                = "machines"
@@ -499,6 +572,8 @@ const (
 	relationScopesC          = "relationscopes"
 	relationsC               = "relations"
 	restoreInfoC             = "restoreInfo"
+	secretKeysC              = "secretkeys"
+	secretsC                 = "secrets"
 	sequenceC                = "sequence"
 	applicationsC            = "applications"
 	endpointBindingsC        = "endpointbindings"
@@ -519,6 +594,7 @@ const (
 	txnLogC                  = "txns.log"
 	txnsC                    = "txns"
 	unitsC                   = "units"
+	unitStatesC              = "unitstates"
 	upgradeInfoC             = "upgradeInfo"
 	userLastLoginC           = "userLastLogin"
 	usermodelnameC           = "usermodelname"
@@ -52,6 +52,7 @@ type applicationDoc struct {
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+	Trust                bool       `bson:"trust,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -289,6 +290,19 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	// so it's safe to do this additonal cleanup.
 	ops = append(ops, finalAppCharmRemoveOps(name, curl)...)
 
+	// Secrets are owned by the application's name, so they must not
+	// outlive it for another application of the same name to inherit.
+	secretOps, err := removeApplicationSecretsOps(a.st, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, secretOps...)
+	scheduleOps, err := removeApplicationActionSchedulesOps(a.st, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, scheduleOps...)
+
 	globalKey := a.globalKey()
 	ops = append(ops,
 		removeEndpointBindingsOp(globalKey),
@@ -334,6 +348,28 @@ func (a *Application) setExposed(exposed bool) (err error) {
 	return nil
 }
 
+// IsTrusted returns whether the application has been granted access to
+// the model's cloud credential.
+func (a *Application) IsTrusted() bool {
+	return a.doc.Trust
+}
+
+// SetTrust grants or revokes the application's access to the model's
+// cloud credential.
+func (a *Application) SetTrust(trust bool) error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"trust", trust}}}},
+	}}
+	if err := a.st.runTransaction(ops); err != nil {
+		return errors.Errorf("cannot set trust for application %q to %v: %v", a, trust, onAbort(err, errNotAlive))
+	}
+	a.doc.Trust = trust
+	return nil
+}
+
 // Charm returns the application's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (a *Application) Charm() (ch *Charm, force bool, err error) {
@@ -1335,6 +1371,7 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 			Remove: true,
 		},
 		removeMeterStatusOp(a.st, u.globalMeterStatusKey()),
+		removeUnitStateOp(a.st, u.globalKey()),
 		removeStatusOp(a.st, u.globalAgentKey()),
 		removeStatusOp(a.st, u.globalKey()),
 		removeConstraintsOp(a.st, u.globalAgentKey()),
@@ -1361,8 +1398,15 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		// The application's removal also removes the unit from the
+		// action schedules.
 		return append(ops, removeOps...), nil
 	}
+	scheduleOps, err := removeUnitActionSchedulesOps(a.st, u.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, scheduleOps...)
 	svcOp := txn.Op{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
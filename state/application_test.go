@@ -1393,6 +1393,28 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestApplicationTrust(c *gc.C) {
+	c.Assert(s.mysql.IsTrusted(), jc.IsFalse)
+
+	err := s.mysql.SetTrust(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsTrue)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsTrue)
+
+	err = s.mysql.SetTrust(false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsTrusted(), jc.IsFalse)
+
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetTrust(true)
+	c.Assert(err, gc.ErrorMatches, `cannot set trust for application "mysql" to true: .*`)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit(state.AddUnitParams{})
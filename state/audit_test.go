@@ -0,0 +1,86 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type auditSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) addEntries(c *gc.C, entries ...audit.AuditEntry) {
+	put := s.State.PutAuditEntryFn()
+	for _, entry := range entries {
+		err := put(entry)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *auditSuite) entry(user, operation string, t time.Time) audit.AuditEntry {
+	return audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         s.State.ModelUUID(),
+		Timestamp:         t,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "API request",
+		OriginName:        user,
+		Operation:         operation,
+	}
+}
+
+func (s *auditSuite) TestAuditEntries(c *gc.C) {
+	t0 := time.Date(2017, time.September, 26, 10, 0, 0, 0, time.UTC)
+	e0 := s.entry("user-bob", "Application:v4 - Destroy", t0)
+	e1 := s.entry("user-mary", "Application:v4 - Destroy", t0.Add(time.Minute))
+	e2 := s.entry("user-bob", "Client:v1 - FullStatus", t0.Add(2*time.Minute))
+	e3 := s.entry("user-bob", "Application:v4 - Deploy", t0.Add(3*time.Minute))
+	s.addEntries(c, e0, e1, e2, e3)
+
+	for i, test := range []struct {
+		query    audit.Query
+		expected []audit.AuditEntry
+	}{{
+		query:    audit.Query{},
+		expected: []audit.AuditEntry{e0, e1, e2, e3},
+	}, {
+		query:    audit.Query{OriginName: "user-bob"},
+		expected: []audit.AuditEntry{e0, e2, e3},
+	}, {
+		query:    audit.Query{Facade: "Application", Method: "Destroy"},
+		expected: []audit.AuditEntry{e0, e1},
+	}, {
+		query:    audit.Query{After: t0.Add(time.Minute), Before: t0.Add(3 * time.Minute)},
+		expected: []audit.AuditEntry{e1, e2},
+	}, {
+		query: audit.Query{
+			After:  t0.Add(time.Minute).In(time.FixedZone("NZDT", 13*60*60)),
+			Before: t0.Add(3 * time.Minute).In(time.FixedZone("PDT", -7*60*60)),
+		},
+		expected: []audit.AuditEntry{e1, e2},
+	}, {
+		query:    audit.Query{Limit: 2},
+		expected: []audit.AuditEntry{e2, e3},
+	}, {
+		query:    audit.Query{OriginName: "user-bob", Before: t0.Add(3 * time.Minute), Limit: 2},
+		expected: []audit.AuditEntry{e0, e2},
+	}, {
+		query: audit.Query{ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+	}} {
+		c.Logf("test %d: %+v", i, test.query)
+		entries, err := s.State.AuditEntries(test.query)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(entries, jc.DeepEquals, test.expected)
+	}
+}
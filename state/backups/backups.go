@@ -84,8 +84,9 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates and stores a new juju backup archive. It updates
-	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error
+	// the provided metadata. If key is not empty, the archive is
+	// encrypted with it.
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, key string) error
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -119,7 +120,7 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 
 // Create creates and stores a new juju backup archive and updates the
 // provided metadata.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
+func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, key string) error {
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
 
@@ -147,6 +148,16 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 	if err != nil {
 		return errors.Annotate(err, "while creating backup archive")
 	}
+	if key != "" {
+		result, err = encryptResult(result, key)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// The CA private key is kept only in the encrypted archive,
+		// rather than alongside it in the clear.
+		meta.Encrypted = true
+		meta.CAPrivateKey = ""
+	}
 	defer result.archiveFile.Close()
 
 	// Finalize the metadata.
@@ -76,7 +76,12 @@ func (b *backups) Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (na
 
 	defer backupReader.Close()
 
-	workspace, err := NewArchiveWorkspaceReader(backupReader)
+	archive, err := OpenArchive(backupReader, args.Key)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot open backup %q", backupId)
+	}
+
+	workspace, err := NewArchiveWorkspaceReader(archive)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
 	}
@@ -58,7 +58,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, "")
 
 	c.Check(err, gc.ErrorMatches, expected)
 }
@@ -98,7 +98,7 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, "")
 
 	// Test the call values.
 	s.Storage.CheckCalled(c, "spam", meta, archiveFile, "Add", "Metadata")
@@ -134,6 +134,33 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	c.Check(string(data), gc.Equals, "<compressed tarball>")
 }
 
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 20, "<checksum>")
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return nil, nil
+	})
+	s.setStored("spam")
+
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju"), mongo.Mongo32wt}
+	meta := backupstesting.NewMetadataStarted()
+	meta.CAPrivateKey = "ca-private-key"
+	err := s.api.Create(meta, &paths, &dbInfo, "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(meta.Encrypted, jc.IsTrue)
+	c.Check(meta.CAPrivateKey, gc.Equals, "")
+	c.Check(meta.Size(), jc.GreaterThan, int64(20))
+	c.Check(meta.Checksum(), gc.Not(gc.Equals), "<checksum>")
+	c.Check(s.Storage.FileArg, gc.Not(gc.Equals), archiveFile)
+}
+
 func (s *backupsSuite) TestCreateFailToListFiles(c *gc.C) {
 	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
 		return nil, errors.New("failed!")
@@ -0,0 +1,251 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/hash"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Encrypted archives start with a header made up of encryptedMagic
+// and a random salt, from which and the passphrase the AES-256 key is
+// derived with scrypt. The archive follows, split into chunks that are
+// each sealed with AES-GCM. Every chunk is preceded by its sealed
+// length, whose top bit marks the last chunk; the length is
+// authenticated along with the chunk, so that an archive cannot be
+// truncated or reordered without decryption failing.
+const (
+	encryptedMagic     = "juju-backup-encrypted-v1\n"
+	encryptedSaltSize  = 16
+	encryptedChunkSize = 64 * 1024
+	finalChunkFlag     = 1 << 31
+
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// EncryptArchive writes the backup archive read from r to w, encrypted
+// with a key derived from the given passphrase.
+func EncryptArchive(w io.Writer, r io.Reader, passphrase string) error {
+	salt := make([]byte, encryptedSaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return errors.Annotate(err, "generating salt")
+	}
+	aead, err := newArchiveCipher(passphrase, salt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.WriteString(w, encryptedMagic); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := w.Write(salt); err != nil {
+		return errors.Trace(err)
+	}
+
+	buf := make([]byte, encryptedChunkSize)
+	header := make([]byte, 4)
+	for counter := uint64(0); ; counter++ {
+		n, err := io.ReadFull(r, buf)
+		final := err == io.EOF || err == io.ErrUnexpectedEOF
+		if err != nil && !final {
+			return errors.Trace(err)
+		}
+		length := uint32(n + aead.Overhead())
+		if final {
+			length |= finalChunkFlag
+		}
+		binary.BigEndian.PutUint32(header, length)
+		sealed := aead.Seal(nil, chunkNonce(aead, counter), buf[:n], header)
+		if _, err := w.Write(header); err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := w.Write(sealed); err != nil {
+			return errors.Trace(err)
+		}
+		if final {
+			return nil
+		}
+	}
+}
+
+// DecryptArchive returns a reader of the backup archive encrypted in
+// r, decrypting it with a key derived from the given passphrase. The
+// first chunk is decrypted before DecryptArchive returns, so a wrong
+// passphrase is reported immediately.
+func DecryptArchive(r io.Reader, passphrase string) (io.Reader, error) {
+	magic := make([]byte, len(encryptedMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, errors.Annotate(err, "reading encrypted archive header")
+	}
+	if string(magic) != encryptedMagic {
+		return nil, errors.New("backup archive is not encrypted")
+	}
+	salt := make([]byte, encryptedSaltSize)
+	if _, err := io.ReadFull(r, salt); err != nil {
+		return nil, errors.Annotate(err, "reading encrypted archive header")
+	}
+	aead, err := newArchiveCipher(passphrase, salt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	d := &archiveDecrypter{r: r, aead: aead}
+	if err := d.next(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return d, nil
+}
+
+// IsEncryptedArchive reports whether the backup archive read from r is
+// encrypted. The returned reader yields the whole archive, including
+// the bytes read to check it.
+func IsEncryptedArchive(r io.Reader) (io.Reader, bool, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(encryptedMagic))
+	if err != nil && err != io.EOF {
+		return nil, false, errors.Trace(err)
+	}
+	return br, string(magic) == encryptedMagic, nil
+}
+
+// OpenArchive returns a reader of the unencrypted contents of the
+// backup archive read from r. An encrypted archive is decrypted with
+// the given passphrase.
+func OpenArchive(r io.Reader, passphrase string) (io.Reader, error) {
+	r, encrypted, err := IsEncryptedArchive(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !encrypted {
+		return r, nil
+	}
+	if passphrase == "" {
+		return nil, errors.New("backup archive is encrypted and no key was given")
+	}
+	return DecryptArchive(r, passphrase)
+}
+
+func newArchiveCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if passphrase == "" {
+		return nil, errors.New("empty encryption key")
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, 32)
+	if err != nil {
+		return nil, errors.Annotate(err, "deriving encryption key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce for the chunk with the given index. The
+// key is unique to each archive, so the index alone is enough to never
+// reuse a nonce.
+func chunkNonce(aead cipher.AEAD, counter uint64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], counter)
+	return nonce
+}
+
+type archiveDecrypter struct {
+	r       io.Reader
+	aead    cipher.AEAD
+	counter uint64
+	plain   []byte
+	final   bool
+}
+
+// Read is part of the io.Reader interface.
+func (d *archiveDecrypter) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.final {
+			return 0, io.EOF
+		}
+		if err := d.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+// next reads and decrypts the next chunk of the archive.
+func (d *archiveDecrypter) next() error {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(d.r, header); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errors.New("encrypted backup archive is truncated")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	length := binary.BigEndian.Uint32(header)
+	final := length&finalChunkFlag != 0
+	length &^= finalChunkFlag
+	if length > uint32(encryptedChunkSize+d.aead.Overhead()) {
+		return errors.New("encrypted backup archive is corrupt")
+	}
+	sealed := make([]byte, length)
+	if _, err := io.ReadFull(d.r, sealed); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errors.New("encrypted backup archive is truncated")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	plain, err := d.aead.Open(sealed[:0], chunkNonce(d.aead, d.counter), sealed, header)
+	if err != nil {
+		return errors.New("cannot decrypt backup archive: wrong key or corrupt archive")
+	}
+	d.counter++
+	d.plain = plain
+	d.final = final
+	return nil
+}
+
+// encryptResult returns the result of encrypting the archive of the
+// given create result. The original archive is closed.
+func encryptResult(result *createResult, passphrase string) (_ *createResult, err error) {
+	defer result.archiveFile.Close()
+
+	file, err := ioutil.TempFile("", tempPrefix)
+	if err != nil {
+		return nil, errors.Annotate(err, "while creating encrypted archive file")
+	}
+	// As with the unencrypted archive, the file is removed but the
+	// open handle remains readable.
+	defer os.Remove(file.Name())
+	defer func() {
+		if err != nil {
+			file.Close()
+		}
+	}()
+
+	hasher := hash.NewHashingWriter(file, sha1.New())
+	if err := EncryptArchive(hasher, result.archiveFile, passphrase); err != nil {
+		return nil, errors.Annotate(err, "while encrypting archive")
+	}
+	size, err := file.Seek(0, os.SEEK_CUR)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := file.Seek(0, os.SEEK_SET); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &createResult{
+		archiveFile: file,
+		size:        size,
+		checksum:    hasher.Base64Sum(),
+	}, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type encryptSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&encryptSuite{})
+
+func (s *encryptSuite) encrypt(c *gc.C, data, key string) []byte {
+	var buf bytes.Buffer
+	err := backups.EncryptArchive(&buf, strings.NewReader(data), key)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *encryptSuite) TestRoundTrip(c *gc.C) {
+	// The archive spans several chunks, the last of them partial.
+	data := strings.Repeat("<compressed tarball>", 10000)
+	encrypted := s.encrypt(c, data, "sekrit")
+	c.Assert(bytes.Contains(encrypted, []byte("<compressed tarball>")), jc.IsFalse)
+
+	r, err := backups.DecryptArchive(bytes.NewReader(encrypted), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	decrypted, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(decrypted), gc.Equals, data)
+}
+
+func (s *encryptSuite) TestRoundTripEmpty(c *gc.C) {
+	encrypted := s.encrypt(c, "", "sekrit")
+	r, err := backups.DecryptArchive(bytes.NewReader(encrypted), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	decrypted, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decrypted, gc.HasLen, 0)
+}
+
+func (s *encryptSuite) TestEncryptEmptyKey(c *gc.C) {
+	var buf bytes.Buffer
+	err := backups.EncryptArchive(&buf, strings.NewReader("data"), "")
+	c.Assert(err, gc.ErrorMatches, "empty encryption key")
+}
+
+func (s *encryptSuite) TestDecryptWrongKey(c *gc.C) {
+	encrypted := s.encrypt(c, "<compressed tarball>", "sekrit")
+	_, err := backups.DecryptArchive(bytes.NewReader(encrypted), "wrong")
+	c.Assert(err, gc.ErrorMatches, "cannot decrypt backup archive: wrong key or corrupt archive")
+}
+
+func (s *encryptSuite) TestDecryptTruncated(c *gc.C) {
+	data := strings.Repeat("<compressed tarball>", 10000)
+	encrypted := s.encrypt(c, data, "sekrit")
+	// Drop the final chunk, leaving an archive made of whole chunks.
+	truncated := encrypted[:len(encrypted)-(len(data)%(64*1024))-4-16]
+
+	r, err := backups.DecryptArchive(bytes.NewReader(truncated), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ioutil.ReadAll(r)
+	c.Assert(err, gc.ErrorMatches, "encrypted backup archive is truncated")
+}
+
+func (s *encryptSuite) TestDecryptNotEncrypted(c *gc.C) {
+	_, err := backups.DecryptArchive(strings.NewReader(strings.Repeat("x", 100)), "sekrit")
+	c.Assert(err, gc.ErrorMatches, "backup archive is not encrypted")
+}
+
+func (s *encryptSuite) TestOpenArchive(c *gc.C) {
+	encrypted := s.encrypt(c, "<compressed tarball>", "sekrit")
+	r, err := backups.OpenArchive(bytes.NewReader(encrypted), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "<compressed tarball>")
+}
+
+func (s *encryptSuite) TestOpenArchiveNotEncrypted(c *gc.C) {
+	r, err := backups.OpenArchive(strings.NewReader("<compressed tarball>"), "")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "<compressed tarball>")
+}
+
+func (s *encryptSuite) TestOpenArchiveNoKey(c *gc.C) {
+	encrypted := s.encrypt(c, "<compressed tarball>", "sekrit")
+	_, err := backups.OpenArchive(bytes.NewReader(encrypted), "")
+	c.Assert(err, gc.ErrorMatches, "backup archive is encrypted and no key was given")
+}
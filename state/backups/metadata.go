@@ -68,6 +68,14 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Scheduled records whether the backup was created by the
+	// controller's backup schedule, rather than on request. Only
+	// scheduled backups are pruned by the controller.
+	Scheduled bool
+
+	// Encrypted records whether the backup archive is encrypted.
+	Encrypted bool
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -167,6 +175,8 @@ type flatMetadata struct {
 	Hostname    string
 	Version     version.Number
 	Series      string
+	Scheduled   bool
+	Encrypted   bool
 
 	CACert       string
 	CAPrivateKey string
@@ -190,6 +200,8 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 		Hostname:     m.Origin.Hostname,
 		Version:      m.Origin.Version,
 		Series:       m.Origin.Series,
+		Scheduled:    m.Scheduled,
+		Encrypted:    m.Encrypted,
 		CACert:       m.CACert,
 		CAPrivateKey: m.CAPrivateKey,
 	}
@@ -241,6 +253,8 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 		Version:  flat.Version,
 		Series:   flat.Series,
 	}
+	meta.Scheduled = flat.Scheduled
+	meta.Encrypted = flat.Encrypted
 
 	// TODO(wallyworld) - put these in a separate file.
 	meta.CACert = flat.CACert
@@ -61,6 +61,8 @@ func (s *metadataSuite) TestAsJSONBuffer(c *gc.C) {
 		`"Hostname":"myhost",`+
 		`"Version":"1.21-alpha3",`+
 		`"Series":"trusty",`+
+		`"Scheduled":false,`+
+		`"Encrypted":false,`+
 		`"CACert":"ca-cert",`+
 		`"CAPrivateKey":"ca-private-key"`+
 		`}`+"\n")
@@ -48,6 +48,9 @@ type storageMetaDoc struct {
 	Finished int64  `bson:"finished,minsize"`
 	Notes    string `bson:"notes,omitempty"`
 
+	Scheduled bool `bson:"scheduled,omitempty"`
+	Encrypted bool `bson:"encrypted,omitempty"`
+
 	// origin
 
 	Model    string         `bson:"model"`
@@ -124,6 +127,8 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Scheduled = doc.Scheduled
+	meta.Encrypted = doc.Encrypted
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -175,6 +180,8 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Scheduled = meta.Scheduled
+	doc.Encrypted = meta.Encrypted
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -0,0 +1,149 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package target
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state/backups"
+)
+
+// NewDirectory returns a Target that stores backups in the given
+// directory, which may be a mounted network filesystem. The directory
+// is created if it does not exist.
+func NewDirectory(dir string) Target {
+	return &directory{dir: dir}
+}
+
+type directory struct {
+	dir string
+}
+
+// validID matches the backup IDs that may be used as file names in the
+// directory. IDs are of the form "YYYYMMDD-hhmmss.<model UUID>"; anything
+// containing a path separator or starting with a dot is rejected, so
+// that an ID cannot refer to a file outside the directory.
+var validID = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z._-]*$`)
+
+func validateID(id string) error {
+	if !validID.MatchString(id) {
+		return errors.NotValidf("backup ID %q", id)
+	}
+	return nil
+}
+
+// String is part of the Target interface.
+func (d *directory) String() string {
+	return "file://" + d.dir
+}
+
+// Put is part of the Target interface.
+func (d *directory) Put(meta *backups.Metadata, archive io.Reader) error {
+	if err := validateID(meta.ID()); err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.MkdirAll(d.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	data, err := encodeMetadata(meta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The metadata is written last, so that only complete backups
+	// are listed.
+	if err := d.writeFile(meta.ID()+archiveSuffix, archive); err != nil {
+		return errors.Annotate(err, "writing archive")
+	}
+	if err := d.writeFile(meta.ID()+metadataSuffix, bytes.NewReader(data)); err != nil {
+		return errors.Annotate(err, "writing metadata")
+	}
+	return nil
+}
+
+// writeFile atomically writes the contents of r to the named file in
+// the directory.
+func (d *directory) writeFile(name string, r io.Reader) error {
+	f, err := ioutil.TempFile(d.dir, name+".tmp")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return errors.Trace(err)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(os.Rename(f.Name(), filepath.Join(d.dir, name)))
+}
+
+// List is part of the Target interface.
+func (d *directory) List() ([]*backups.Metadata, error) {
+	names, err := filepath.Glob(filepath.Join(d.dir, "*"+metadataSuffix))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var metas []*backups.Metadata
+	for _, name := range names {
+		meta, err := d.readMetadata(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		metas = append(metas, meta)
+	}
+	return metas, nil
+}
+
+// Get is part of the Target interface.
+func (d *directory) Get(id string) (*backups.Metadata, io.ReadCloser, error) {
+	if err := validateID(id); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	meta, err := d.readMetadata(filepath.Join(d.dir, id+metadataSuffix))
+	if os.IsNotExist(errors.Cause(err)) {
+		return nil, nil, errors.NotFoundf("backup %q in %s", id, d)
+	} else if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	archive, err := os.Open(filepath.Join(d.dir, id+archiveSuffix))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return meta, archive, nil
+}
+
+func (d *directory) readMetadata(path string) (*backups.Metadata, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := decodeMetadata(data)
+	return meta, errors.Annotatef(err, "reading %s", path)
+}
+
+// Remove is part of the Target interface.
+func (d *directory) Remove(id string) error {
+	if err := validateID(id); err != nil {
+		return errors.Trace(err)
+	}
+	// The metadata is removed first, so that the backup is no longer
+	// listed even if the archive cannot be removed.
+	for _, suffix := range []string{metadataSuffix, archiveSuffix} {
+		err := os.Remove(filepath.Join(d.dir, id+suffix))
+		if os.IsNotExist(err) {
+			return errors.NotFoundf("backup %q in %s", id, d)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
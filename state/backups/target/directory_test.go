@@ -0,0 +1,46 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package target_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups/target"
+)
+
+type directorySuite struct {
+	targetSuite
+}
+
+var _ = gc.Suite(&directorySuite{})
+
+func (s *directorySuite) SetUpTest(c *gc.C) {
+	s.targetSuite.SetUpTest(c)
+	// The directory is created when the first backup is stored.
+	s.target = target.NewDirectory(filepath.Join(c.MkDir(), "backups"))
+}
+
+func (s *directorySuite) TestInvalidID(c *gc.C) {
+	root := c.MkDir()
+	outside := filepath.Join(root, "outside.json")
+	err := ioutil.WriteFile(outside, []byte("{}"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.target = target.NewDirectory(filepath.Join(root, "backups"))
+
+	for _, id := range []string{"../outside", "..", "", "a/b", ".hidden"} {
+		c.Logf("id %q", id)
+		_, _, err := s.target.Get(id)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		err = s.target.Remove(id)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+	_, err = os.Stat(outside)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,198 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package target
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+
+	"github.com/juju/juju/state/backups"
+)
+
+// s3ListMax is the maximum number of keys requested in each bucket
+// listing.
+const s3ListMax = 1000
+
+// S3Config holds the configuration for a Target that stores backups
+// in an S3-compatible object store.
+type S3Config struct {
+	// Endpoint is the URL of the object store. If empty, the Amazon
+	// S3 endpoint for Region is used.
+	Endpoint string
+
+	// Region is the region of the object store.
+	Region string
+
+	// AccessKey and SecretKey are used to authenticate with the
+	// object store.
+	AccessKey string
+	SecretKey string
+
+	// Bucket is the name of the bucket that backups are stored in.
+	// The bucket must already exist.
+	Bucket string
+
+	// Prefix, if non-empty, is prepended to the keys of the backups
+	// stored in the bucket.
+	Prefix string
+}
+
+// Validate checks that the config is valid.
+func (config S3Config) Validate() error {
+	if config.Bucket == "" {
+		return errors.NotValidf("empty Bucket")
+	}
+	if config.Region == "" {
+		return errors.NotValidf("empty Region")
+	}
+	if config.Endpoint == "" {
+		if _, ok := aws.Regions[config.Region]; !ok {
+			return errors.NotValidf("unknown S3 region %q without Endpoint", config.Region)
+		}
+	}
+	return nil
+}
+
+// NewS3 returns a Target that stores backups in an S3-compatible
+// object store.
+func NewS3(config S3Config) (Target, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating config")
+	}
+	region, ok := aws.Regions[config.Region]
+	if config.Endpoint != "" {
+		if !ok {
+			region = aws.Region{Name: config.Region}
+		}
+		// Address buckets by path, as S3-compatible services
+		// generally don't support virtual-hosted buckets.
+		region.S3Endpoint = config.Endpoint
+		region.S3BucketEndpoint = ""
+	}
+	auth := aws.Auth{
+		AccessKey: config.AccessKey,
+		SecretKey: config.SecretKey,
+	}
+	bucket, err := s3.New(auth, region).Bucket(config.Bucket)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	prefix := config.Prefix
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &s3Target{
+		bucket: bucket,
+		prefix: prefix,
+		url:    "s3://" + config.Bucket + "/" + prefix,
+	}, nil
+}
+
+type s3Target struct {
+	bucket *s3.Bucket
+	prefix string
+	url    string
+}
+
+// String is part of the Target interface.
+func (t *s3Target) String() string {
+	return t.url
+}
+
+// Put is part of the Target interface.
+func (t *s3Target) Put(meta *backups.Metadata, archive io.Reader) error {
+	data, err := encodeMetadata(meta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The metadata is written last, so that only complete backups
+	// are listed.
+	key := t.prefix + meta.ID()
+	if err := t.bucket.PutReader(key+archiveSuffix, archive, meta.Size(), "application/x-gzip", s3.Private); err != nil {
+		return errors.Annotate(err, "writing archive")
+	}
+	if err := t.bucket.PutReader(key+metadataSuffix, bytes.NewReader(data), int64(len(data)), "application/json", s3.Private); err != nil {
+		return errors.Annotate(err, "writing metadata")
+	}
+	return nil
+}
+
+// List is part of the Target interface.
+func (t *s3Target) List() ([]*backups.Metadata, error) {
+	var metas []*backups.Metadata
+	marker := ""
+	for {
+		resp, err := t.bucket.List(t.prefix, "/", marker, s3ListMax)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, key := range resp.Contents {
+			marker = key.Key
+			if !strings.HasSuffix(key.Key, metadataSuffix) {
+				continue
+			}
+			meta, err := t.readMetadata(key.Key)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			metas = append(metas, meta)
+		}
+		if !resp.IsTruncated || len(resp.Contents) == 0 {
+			return metas, nil
+		}
+	}
+}
+
+// Get is part of the Target interface.
+func (t *s3Target) Get(id string) (*backups.Metadata, io.ReadCloser, error) {
+	key := t.prefix + id
+	meta, err := t.readMetadata(key + metadataSuffix)
+	if isS3NotFound(err) {
+		return nil, nil, errors.NotFoundf("backup %q in %s", id, t)
+	} else if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	archive, err := t.bucket.GetReader(key + archiveSuffix)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return meta, archive, nil
+}
+
+func (t *s3Target) readMetadata(key string) (*backups.Metadata, error) {
+	data, err := t.bucket.Get(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := decodeMetadata(data)
+	return meta, errors.Annotatef(err, "reading %s", key)
+}
+
+// Remove is part of the Target interface.
+func (t *s3Target) Remove(id string) error {
+	key := t.prefix + id
+	if _, err := t.readMetadata(key + metadataSuffix); isS3NotFound(err) {
+		return errors.NotFoundf("backup %q in %s", id, t)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for _, suffix := range []string{metadataSuffix, archiveSuffix} {
+		if err := t.bucket.Del(key + suffix); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// isS3NotFound reports whether the error was returned by the object
+// store for a key that does not exist.
+func isS3NotFound(err error) bool {
+	s3err, ok := errors.Cause(err).(*s3.Error)
+	return ok && (s3err.StatusCode == 404 || s3err.Code == "NoSuchKey")
+}
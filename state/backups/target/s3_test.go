@@ -0,0 +1,45 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package target_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+	"gopkg.in/amz.v3/s3/s3test"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups/target"
+)
+
+// s3Suite runs the target tests against a local stand-in for an
+// S3-compatible object store.
+type s3Suite struct {
+	targetSuite
+	srv *s3test.Server
+}
+
+var _ = gc.Suite(&s3Suite{})
+
+func (s *s3Suite) SetUpTest(c *gc.C) {
+	s.targetSuite.SetUpTest(c)
+	srv, err := s3test.NewServer(&s3test.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.srv = srv
+	s.AddCleanup(func(*gc.C) { srv.Quit() })
+
+	region := aws.Region{Name: "test", S3Endpoint: srv.URL()}
+	bucket, err := s3.New(aws.Auth{}, region).Bucket("juju-backups")
+	c.Assert(err, jc.ErrorIsNil)
+	err = bucket.PutBucket(s3.Private)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.target, err = target.NewS3(target.S3Config{
+		Endpoint: srv.URL(),
+		Region:   "test",
+		Bucket:   "juju-backups",
+		Prefix:   "controller",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
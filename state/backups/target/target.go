@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package target provides locations outside the controller that
+// backup archives are copied to, so that they survive the loss of the
+// controller they were taken from.
+package target
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+)
+
+var logger = loggo.GetLogger("juju.state.backups.target")
+
+const (
+	archiveSuffix  = ".tar.gz"
+	metadataSuffix = ".json"
+)
+
+// Target is a location outside the controller that stores backup
+// archives along with their metadata.
+type Target interface {
+	// Put stores the backup archive with the given metadata. The
+	// metadata must have its ID and size set.
+	Put(meta *backups.Metadata, archive io.Reader) error
+
+	// List returns the metadata for all backups stored on the target.
+	List() ([]*backups.Metadata, error)
+
+	// Get returns the metadata and archive of the backup with the
+	// given ID. It returns an error satisfying errors.IsNotFound if
+	// there is no such backup.
+	Get(id string) (*backups.Metadata, io.ReadCloser, error)
+
+	// Remove deletes the backup with the given ID from the target.
+	Remove(id string) error
+
+	// String returns the URL of the target.
+	String() string
+}
+
+// New returns the backup target configured for the controller. It
+// returns an error satisfying errors.IsNotFound if the controller has
+// no backup target.
+func New(cfg controller.Config) (Target, error) {
+	target := cfg.BackupTarget()
+	if target == "" {
+		return nil, errors.NotFoundf("backup target")
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch u.Scheme {
+	case "file":
+		return NewDirectory(u.Path), nil
+	case "s3":
+		return NewS3(S3Config{
+			Endpoint:  cfg.BackupS3Endpoint(),
+			Region:    cfg.BackupS3Region(),
+			AccessKey: cfg.BackupS3AccessKey(),
+			SecretKey: cfg.BackupS3SecretKey(),
+			Bucket:    u.Host,
+			Prefix:    strings.Trim(u.Path, "/"),
+		})
+	}
+	return nil, errors.NotValidf("backup target %q", target)
+}
+
+// Export copies the stored backup with the given ID to the target.
+func Export(t Target, stor backups.Backups, id string) error {
+	meta, archive, err := stor.Get(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+	if err := t.Put(meta, archive); err != nil {
+		return errors.Annotatef(err, "copying backup %q to %s", id, t)
+	}
+	logger.Infof("copied backup %q to %s", id, t)
+	return nil
+}
+
+// Import copies the backup with the given ID from the target into the
+// controller's backup storage.
+func Import(t Target, stor backups.Backups, id string) error {
+	meta, archive, err := t.Get(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+	if _, err := stor.Add(archive, meta); err != nil {
+		return errors.Annotatef(err, "storing backup %q from %s", id, t)
+	}
+	logger.Infof("copied backup %q from %s", id, t)
+	return nil
+}
+
+// encodeMetadata returns the metadata in the form stored on targets.
+func encodeMetadata(meta *backups.Metadata) ([]byte, error) {
+	r, err := meta.AsJSONBuffer()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := ioutil.ReadAll(r)
+	return data, errors.Trace(err)
+}
+
+// decodeMetadata returns the metadata stored on a target.
+func decodeMetadata(data []byte) (*backups.Metadata, error) {
+	meta, err := backups.NewMetadataJSONReader(bytes.NewReader(data))
+	return meta, errors.Trace(err)
+}
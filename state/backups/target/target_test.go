@@ -0,0 +1,142 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package target_test
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/backups/target"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+// archiveData is the content of the test backup archives. Its length
+// matches the size of the metadata returned by backupstesting.NewMetadata.
+const archiveData = "0123456789"
+
+// targetSuite holds tests that every Target implementation must pass.
+type targetSuite struct {
+	testing.IsolationSuite
+	target target.Target
+}
+
+func (s *targetSuite) put(c *gc.C, meta *backups.Metadata) {
+	err := s.target.Put(meta, bytes.NewReader([]byte(archiveData)))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *targetSuite) checkMetadata(c *gc.C, obtained, expected *backups.Metadata) {
+	c.Check(obtained.ID(), gc.Equals, expected.ID())
+	c.Check(obtained.Size(), gc.Equals, expected.Size())
+	c.Check(obtained.Checksum(), gc.Equals, expected.Checksum())
+	c.Check(obtained.Started.Unix(), gc.Equals, expected.Started.Unix())
+	c.Check(obtained.Origin, jc.DeepEquals, expected.Origin)
+}
+
+func (s *targetSuite) TestPutGet(c *gc.C) {
+	meta := backupstesting.NewMetadata()
+	s.put(c, meta)
+
+	obtained, archive, err := s.target.Get(meta.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	s.checkMetadata(c, obtained, meta)
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, archiveData)
+}
+
+func (s *targetSuite) TestGetNotFound(c *gc.C) {
+	_, _, err := s.target.Get("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *targetSuite) TestList(c *gc.C) {
+	metas, err := s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metas, gc.HasLen, 0)
+
+	meta := backupstesting.NewMetadata()
+	s.put(c, meta)
+	metas, err = s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metas, gc.HasLen, 1)
+	s.checkMetadata(c, metas[0], meta)
+}
+
+func (s *targetSuite) TestRemove(c *gc.C) {
+	meta := backupstesting.NewMetadata()
+	s.put(c, meta)
+	err := s.target.Remove(meta.ID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	metas, err := s.target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(metas, gc.HasLen, 0)
+
+	err = s.target.Remove(meta.ID())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *targetSuite) TestExportImport(c *gc.C) {
+	meta := backupstesting.NewMetadata()
+	stor := &backupstesting.FakeBackups{
+		Meta:    meta,
+		Archive: ioutil.NopCloser(bytes.NewReader([]byte(archiveData))),
+	}
+	err := target.Export(s.target, stor, meta.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stor.IDArg, gc.Equals, meta.ID())
+
+	err = target.Import(s.target, stor, meta.ID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stor.Calls, jc.DeepEquals, []string{"Get", "Add"})
+	s.checkMetadata(c, stor.MetaArg, meta)
+	data, err := ioutil.ReadAll(stor.ArchiveArg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, archiveData)
+}
+
+type newSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&newSuite{})
+
+func (s *newSuite) TestNotConfigured(c *gc.C) {
+	_, err := target.New(controller.Config{})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *newSuite) TestDirectory(c *gc.C) {
+	t, err := target.New(controller.Config{
+		controller.BackupTarget: "file:///srv/backups",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t.String(), gc.Equals, "file:///srv/backups")
+}
+
+func (s *newSuite) TestS3(c *gc.C) {
+	t, err := target.New(controller.Config{
+		controller.BackupTarget:     "s3://juju-backups/controller/",
+		controller.BackupS3Endpoint: "http://127.0.0.1:9000",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(t.String(), gc.Equals, "s3://juju-backups/controller/")
+}
+
+func (s *newSuite) TestS3UnknownRegion(c *gc.C) {
+	_, err := target.New(controller.Config{
+		controller.BackupTarget:   "s3://juju-backups",
+		controller.BackupS3Region: "nowhere",
+	})
+	c.Assert(err, gc.ErrorMatches, `validating config: unknown S3 region "nowhere" without Endpoint not valid`)
+}
@@ -168,6 +168,10 @@ type collectionInfo struct {
 	// very careful analysis; and then, please, just don't do it anyway. If you
 	// need raw mgo, use a rawAccess collection.
 	rawAccess bool
+
+	// noDump collections hold keys that protect other data, and are
+	// never included in database dumps.
+	noDump bool
 }
 
 // collectionSchema defines the set of collections used in juju.
@@ -189,6 +193,15 @@ func (schema collectionSchema) Create(
 					spec.MaxBytes = maxSize * 1024 * 1024
 				}
 			}
+			// The audit log collection size is only overridden if it
+			// has been explicitly configured.
+			if name == auditingC && settings != nil {
+				if _, ok := (*settings)[controller.AuditLogMaxSize]; ok {
+					maxSize := settings.AuditLogMaxSizeMB()
+					logger.Infof("overriding max audit log collection size: %dM", maxSize)
+					spec.MaxBytes = maxSize * 1024 * 1024
+				}
+			}
 			if err := createCollection(rawCollection, spec); err != nil {
 				message := fmt.Sprintf("cannot create collection %q", name)
 				return maybeUnauthorized(err, message)
@@ -7,7 +7,8 @@ import "github.com/juju/errors"
 
 // DumpAll returns a map of collection names to a slice of documents
 // in that collection. Every document that is related to the current
-// model is returned in the map.
+// model is returned in the map, except for those in collections that
+// hold encryption keys.
 func (st *State) DumpAll() (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 	// Add in the model document itself.
@@ -17,7 +18,7 @@ func (st *State) DumpAll() (map[string]interface{}, error) {
 	}
 	result[modelsC] = doc
 	for name, info := range allCollections() {
-		if !info.global {
+		if !info.global && !info.noDump {
 			docs, err := getAllModelDocs(st, name)
 			if err != nil {
 				return nil, errors.Trace(err)
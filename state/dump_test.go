@@ -38,3 +38,17 @@ func (s *dumpSuite) TestDumpAll(c *gc.C) {
 	c.Check(initialCollections.Contains("leases"), jc.IsTrue)
 	c.Check(initialCollections.Contains("statuses"), jc.IsTrue)
 }
+
+func (s *dumpSuite) TestDumpAllExcludesSecretsKey(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	_, err := s.State.AddSecret(state.SecretArgs{
+		Owner: app.ApplicationTag(),
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	value, err := s.State.DumpAll()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value["secrets"], gc.NotNil)
+	c.Check(value["secretkeys"], gc.IsNil)
+}
@@ -46,6 +46,12 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+	MaxHookRuns       = maxHookRuns
+
+	MaxActionOutputChunks    = maxActionOutputChunks
+	MaxActionOutputChunkSize = maxActionOutputChunkSize
+	MaxActionMessages        = maxActionMessages
+	MaxActionMessageSize     = maxActionMessageSize
 )
 
 var (
@@ -233,6 +239,7 @@ func addCharm(c *gc.C, st *State, series string, ch charm.Charm) *Charm {
 
 func init() {
 	txnLogSize = txnLogSizeTests
+	auditLogSize = auditLogSizeTests
 }
 
 // TxnRevno returns the txn-revno field of the document
@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxHookRuns is the number of hook runs recorded for each unit; older
+// runs are discarded as new ones are recorded.
+const maxHookRuns = 100
+
+// HookRun records a single run of one of a unit's hooks.
+type HookRun struct {
+	// Hook is the name of the hook that was run.
+	Hook string
+
+	// Started is the time at which the hook started running.
+	Started time.Time
+
+	// Duration is how long the hook took to run.
+	Duration time.Duration
+
+	// Result is the outcome of the run, e.g. "success", "failure"
+	// or "timeout".
+	Result string
+}
+
+// hookRunDoc records a run of one of a unit's hooks. Hook runs are
+// written often and only kept for diagnosis, so they are written
+// without transactions.
+type hookRunDoc struct {
+	ModelUUID string `bson:"model-uuid"`
+	Unit      string `bson:"unit"`
+	Hook      string `bson:"hook"`
+	Started   int64  `bson:"started"`
+	Duration  int64  `bson:"duration"`
+	Result    string `bson:"result"`
+}
+
+// RecordHookRun records a run of one of the unit's hooks. Only the most
+// recent runs of each unit are kept.
+func (u *Unit) RecordHookRun(run HookRun) error {
+	if run.Hook == "" {
+		return errors.NotValidf("empty hook name")
+	}
+	runs, closer := u.st.db().GetCollection(hookRunsC)
+	defer closer()
+	runsW := runs.Writeable()
+
+	doc := &hookRunDoc{
+		Unit:     u.Name(),
+		Hook:     run.Hook,
+		Started:  run.Started.UnixNano(),
+		Duration: int64(run.Duration),
+		Result:   run.Result,
+	}
+	if err := runsW.Insert(doc); err != nil {
+		return errors.Annotatef(err, "cannot record hook run for unit %q", u)
+	}
+
+	// Discard the runs that are older than the oldest one kept.
+	var oldest hookRunDoc
+	err := runs.Find(bson.D{{"unit", u.Name()}}).Sort("-started").Skip(maxHookRuns - 1).One(&oldest)
+	if err == mgo.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot prune hook runs for unit %q", u)
+	}
+	_, err = runsW.RemoveAll(bson.D{
+		{"unit", u.Name()},
+		{"started", bson.D{{"$lt", oldest.Started}}},
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot prune hook runs for unit %q", u)
+	}
+	return nil
+}
+
+// HookRuns returns up to n of the unit's most recent hook runs, newest
+// first. If n is zero, all of the recorded runs are returned.
+func (u *Unit) HookRuns(n int) ([]HookRun, error) {
+	if n < 0 {
+		return nil, errors.NotValidf("negative hook run count")
+	}
+	runs, closer := u.st.db().GetCollection(hookRunsC)
+	defer closer()
+
+	var docs []hookRunDoc
+	query := runs.Find(bson.D{{"unit", u.Name()}}).Sort("-started")
+	if n > 0 {
+		query = query.Limit(n)
+	}
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot read hook runs for unit %q", u)
+	}
+	result := make([]HookRun, len(docs))
+	for i, doc := range docs {
+		result[i] = HookRun{
+			Hook:     doc.Hook,
+			Started:  time.Unix(0, doc.Started).UTC(),
+			Duration: time.Duration(doc.Duration),
+			Result:   doc.Result,
+		}
+	}
+	return result, nil
+}
+
+func eraseHookRuns(st *State, unitName string) error {
+	runs, closer := st.db().GetCollection(hookRunsC)
+	defer closer()
+	runsW := runs.Writeable()
+
+	if _, err := runsW.RemoveAll(bson.D{{"unit", unitName}}); err != nil {
+		return err
+	}
+	return nil
+}
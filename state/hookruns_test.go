@@ -0,0 +1,92 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type HookRunsSuite struct {
+	ConnSuite
+	unit *state.Unit
+	now  time.Time
+}
+
+var _ = gc.Suite(&HookRunsSuite{})
+
+func (s *HookRunsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = factory.NewFactory(s.State).MakeUnit(c, nil)
+	s.now = time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *HookRunsSuite) recordRun(c *gc.C, hook string, offset time.Duration) state.HookRun {
+	run := state.HookRun{
+		Hook:     hook,
+		Started:  s.now.Add(offset),
+		Duration: 3 * time.Second,
+		Result:   "success",
+	}
+	err := s.unit.RecordHookRun(run)
+	c.Assert(err, jc.ErrorIsNil)
+	return run
+}
+
+func (s *HookRunsSuite) TestHookRunsEmpty(c *gc.C) {
+	runs, err := s.unit.HookRuns(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, gc.HasLen, 0)
+}
+
+func (s *HookRunsSuite) TestRecordHookRun(c *gc.C) {
+	install := s.recordRun(c, "install", 0)
+	configChanged := s.recordRun(c, "config-changed", time.Minute)
+
+	runs, err := s.unit.HookRuns(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, jc.DeepEquals, []state.HookRun{configChanged, install})
+
+	runs, err = s.unit.HookRuns(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, jc.DeepEquals, []state.HookRun{configChanged})
+}
+
+func (s *HookRunsSuite) TestRecordHookRunInvalid(c *gc.C) {
+	err := s.unit.RecordHookRun(state.HookRun{Started: s.now})
+	c.Assert(err, gc.ErrorMatches, "empty hook name not valid")
+}
+
+func (s *HookRunsSuite) TestHookRunsNegativeCount(c *gc.C) {
+	_, err := s.unit.HookRuns(-1)
+	c.Assert(err, gc.ErrorMatches, "negative hook run count not valid")
+}
+
+func (s *HookRunsSuite) TestRecordHookRunPrunes(c *gc.C) {
+	for i := 0; i < state.MaxHookRuns+1; i++ {
+		s.recordRun(c, "update-status", time.Duration(i)*time.Minute)
+	}
+	runs, err := s.unit.HookRuns(0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(runs, gc.HasLen, state.MaxHookRuns)
+	c.Assert(runs[0].Started, gc.Equals, s.now.Add(time.Duration(state.MaxHookRuns)*time.Minute))
+	c.Assert(runs[len(runs)-1].Started, gc.Equals, s.now.Add(time.Minute))
+}
+
+func (s *HookRunsSuite) TestHookRunsRemovedWithUnit(c *gc.C) {
+	s.recordRun(c, "stop", 0)
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := s.MgoSuite.Session.DB("juju").C("hookruns").Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
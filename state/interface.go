@@ -209,6 +209,9 @@ type Action interface {
 	// Results returns the structured output of the action and any error.
 	Results() (map[string]interface{}, string)
 
+	// Messages returns the progress messages logged by the action.
+	Messages() []ActionMessage
+
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
 	ActionTag() names.ActionTag
@@ -220,6 +223,17 @@ type Action interface {
 	// Finish removes action from the pending queue and captures the output
 	// and end state of the action.
 	Finish(results ActionResults) (Action, error)
+
+	// Log adds a timestamped progress message to the action. It asserts
+	// that the action is currently running.
+	Log(message string) error
+
+	// AddOutput records output written by the running action.
+	AddOutput(output ...ActionOutput) error
+
+	// Output returns the output recorded for the action with a
+	// sequence number greater than after, in order.
+	Output(after int) ([]ActionOutput, error)
 }
 
 // ApplicationEntity represents a local or remote application.
@@ -4,8 +4,13 @@
 package audit
 
 import (
+	"fmt"
+	"regexp"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/mongo/utils"
@@ -21,10 +26,13 @@ type auditEntryDoc struct {
 	// ModelID is the ID of the model the audit entry was written on.
 	ModelUUID string `bson:"model-uuid"`
 
-	// Timestamp is when the audit entry was written. It is marshaled
-	// to a bytestream via time.Time::MarshalText and can be
-	// unmarshaled via time.Time::UnmarshalText.
-	Timestamp string `bson:"timestamp"`
+	// ConversationID identifies the API connection the audited call
+	// was made over.
+	ConversationID string `bson:"conversation-id,omitempty"`
+
+	// Timestamp is when the audit entry was written, in UTC. It is
+	// stored as a date so that entries can be queried by time.
+	Timestamp time.Time `bson:"timestamp"`
 
 	// RemoteAddress is the IP of the machine from which the
 	// audit-event was triggered.
@@ -56,29 +64,88 @@ func PutAuditEntryFn(
 		if err := auditEntry.Validate(); err != nil {
 			return errors.Trace(err)
 		}
-		auditEntryDoc, err := auditEntryDocFromAuditEntry(auditEntry)
-		if err != nil {
-			return errors.Trace(err)
-		}
+		auditEntryDoc := auditEntryDocFromAuditEntry(auditEntry)
 		return errors.Trace(insertDoc(collectionName, auditEntryDoc))
 	}
 }
 
-func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, error) {
-
-	timeAsBlob, err := auditEntry.Timestamp.MarshalText()
-	if err != nil {
-		return auditEntryDoc{}, errors.Trace(err)
-	}
-
+func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) auditEntryDoc {
 	return auditEntryDoc{
 		JujuServerVersion: auditEntry.JujuServerVersion,
 		ModelUUID:         auditEntry.ModelUUID,
-		Timestamp:         string(timeAsBlob),
+		ConversationID:    auditEntry.ConversationID,
+		Timestamp:         auditEntry.Timestamp.UTC(),
 		RemoteAddress:     auditEntry.RemoteAddress,
 		OriginType:        auditEntry.OriginType,
 		OriginName:        auditEntry.OriginName,
 		Operation:         auditEntry.Operation,
 		Data:              utils.EscapeKeys(auditEntry.Data),
-	}, nil
+	}
+}
+
+// QueryAuditEntriesFn creates a closure which when passed an
+// audit.Query will return the matching entries from the audit
+// collection, oldest first. The findDocs function must populate the
+// docs slice pointer with the documents matching the selector, most
+// recent first, returning at most limit documents if limit is
+// non-zero.
+func QueryAuditEntriesFn(
+	collectionName string,
+	findDocs func(collectionName string, selector bson.D, limit int, docs interface{}) error,
+) func(audit.Query) ([]audit.AuditEntry, error) {
+	return func(query audit.Query) ([]audit.AuditEntry, error) {
+		var docs []auditEntryDoc
+		if err := findDocs(collectionName, querySelector(query), query.Limit, &docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries := make([]audit.AuditEntry, len(docs))
+		for i, doc := range docs {
+			entries[len(docs)-1-i] = auditEntryFromAuditEntryDoc(doc)
+		}
+		return entries, nil
+	}
+}
+
+// querySelector returns the mongo selector for the audit entry
+// documents matching the query.
+func querySelector(query audit.Query) bson.D {
+	var sel bson.D
+	if query.OriginName != "" {
+		sel = append(sel, bson.DocElem{"origin-name", query.OriginName})
+	}
+	if query.ModelUUID != "" {
+		sel = append(sel, bson.DocElem{"model-uuid", query.ModelUUID})
+	}
+	if query.Facade != "" {
+		pattern := fmt.Sprintf("^%s:v[0-9]+ - ", regexp.QuoteMeta(query.Facade))
+		if query.Method != "" {
+			pattern += regexp.QuoteMeta(query.Method) + "$"
+		}
+		sel = append(sel, bson.DocElem{"operation", bson.RegEx{Pattern: pattern}})
+	}
+	var window bson.D
+	if !query.After.IsZero() {
+		window = append(window, bson.DocElem{"$gte", query.After.UTC()})
+	}
+	if !query.Before.IsZero() {
+		window = append(window, bson.DocElem{"$lt", query.Before.UTC()})
+	}
+	if len(window) > 0 {
+		sel = append(sel, bson.DocElem{"timestamp", window})
+	}
+	return sel
+}
+
+func auditEntryFromAuditEntryDoc(doc auditEntryDoc) audit.AuditEntry {
+	return audit.AuditEntry{
+		JujuServerVersion: doc.JujuServerVersion,
+		ModelUUID:         doc.ModelUUID,
+		ConversationID:    doc.ConversationID,
+		Timestamp:         doc.Timestamp.UTC(),
+		RemoteAddress:     doc.RemoteAddress,
+		OriginType:        doc.OriginType,
+		OriginName:        doc.OriginName,
+		Operation:         doc.Operation,
+		Data:              utils.UnescapeKeys(doc.Data),
+	}
 }
@@ -4,6 +4,8 @@
 package audit_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -51,13 +53,10 @@ func (*AuditSuite) TestPutAuditEntry_PersistAuditEntry(c *gc.C) {
 		serializedAuditDoc, err := bson.Marshal(docs[0])
 		c.Assert(err, jc.ErrorIsNil)
 
-		requestedTimeBlob, err := requested.Timestamp.MarshalText()
-		c.Assert(err, jc.ErrorIsNil)
-
 		c.Check(string(serializedAuditDoc), jc.BSONEquals, map[string]interface{}{
 			"juju-server-version": requested.JujuServerVersion,
 			"model-uuid":          requested.ModelUUID,
-			"timestamp":           string(requestedTimeBlob),
+			"timestamp":           requested.Timestamp,
 			"remote-address":      "8.8.8.8",
 			"origin-type":         requested.OriginType,
 			"origin-name":         requested.OriginName,
@@ -111,3 +110,114 @@ func (*AuditSuite) TestPutAuditEntry_ValidateAuditEntry(c *gc.C) {
 	err := putAuditEntry(auditEntry)
 	c.Check(err, gc.ErrorMatches, validationErr.Error())
 }
+
+func (*AuditSuite) TestQueryAuditEntries(c *gc.C) {
+	modelUUID := utils.MustNewUUID().String()
+	t0 := time.Date(2017, time.September, 26, 10, 0, 0, 0, time.UTC)
+	entries := []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("1.0.0"),
+		ModelUUID:         modelUUID,
+		Timestamp:         t0,
+		RemoteAddress:     "8.8.8.8",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "Application:v4 - Destroy",
+		Data:              map[string]interface{}{"$a.b": "c"},
+	}, {
+		JujuServerVersion: version.MustParse("1.0.0"),
+		ModelUUID:         modelUUID,
+		Timestamp:         t0.Add(time.Minute),
+		RemoteAddress:     "8.8.8.8",
+		OriginType:        "API request",
+		OriginName:        "user-bob",
+		Operation:         "Application:v4 - Deploy",
+	}}
+
+	// Marshal the entries as they would be stored, most recent first.
+	var stored []bson.Raw
+	for i := len(entries) - 1; i >= 0; i-- {
+		var docs []interface{}
+		put := stateaudit.PutAuditEntryFn("audit.log", func(_ string, d ...interface{}) error {
+			docs = append(docs, d...)
+			return nil
+		})
+		c.Assert(put(entries[i]), jc.ErrorIsNil)
+		data, err := bson.Marshal(docs[0])
+		c.Assert(err, jc.ErrorIsNil)
+		stored = append(stored, bson.Raw{Kind: 3, Data: data})
+	}
+
+	var selector bson.D
+	findDocs := func(collectionName string, sel bson.D, limit int, docs interface{}) error {
+		c.Check(collectionName, gc.Equals, "audit.log")
+		c.Check(limit, gc.Equals, 10)
+		selector = sel
+		raw, err := bson.Marshal(bson.M{"docs": stored})
+		c.Assert(err, jc.ErrorIsNil)
+		var result struct {
+			Docs bson.Raw `bson:"docs"`
+		}
+		c.Assert(bson.Unmarshal(raw, &result), jc.ErrorIsNil)
+		return result.Docs.Unmarshal(docs)
+	}
+	query := stateaudit.QueryAuditEntriesFn("audit.log", findDocs)
+	result, err := query(audit.Query{
+		OriginName: "user-bob",
+		ModelUUID:  modelUUID,
+		Facade:     "Application",
+		After:      t0,
+		Limit:      10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entries)
+	c.Assert(selector, jc.DeepEquals, bson.D{
+		{"origin-name", "user-bob"},
+		{"model-uuid", modelUUID},
+		{"operation", bson.RegEx{Pattern: "^Application:v[0-9]+ - "}},
+		{"timestamp", bson.D{{"$gte", t0}}},
+	})
+}
+
+func (*AuditSuite) TestQueryAuditEntriesTimeSelector(c *gc.C) {
+	var selector bson.D
+	findDocs := func(_ string, sel bson.D, _ int, _ interface{}) error {
+		selector = sel
+		return nil
+	}
+	nzdt := time.FixedZone("NZDT", 13*60*60)
+	after := time.Date(2017, time.September, 26, 23, 0, 0, 500, nzdt)
+	before := after.Add(time.Hour)
+	query := stateaudit.QueryAuditEntriesFn("audit.log", findDocs)
+	_, err := query(audit.Query{After: after, Before: before})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(selector, jc.DeepEquals, bson.D{
+		{"timestamp", bson.D{
+			{"$gte", time.Date(2017, time.September, 26, 10, 0, 0, 500, time.UTC)},
+			{"$lt", time.Date(2017, time.September, 26, 11, 0, 0, 500, time.UTC)},
+		}},
+	})
+}
+
+func (*AuditSuite) TestQueryAuditEntriesMethodSelector(c *gc.C) {
+	var selector bson.D
+	findDocs := func(_ string, sel bson.D, _ int, _ interface{}) error {
+		selector = sel
+		return nil
+	}
+	query := stateaudit.QueryAuditEntriesFn("audit.log", findDocs)
+	result, err := query(audit.Query{Facade: "Application", Method: "Destroy"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 0)
+	c.Assert(selector, jc.DeepEquals, bson.D{
+		{"operation", bson.RegEx{Pattern: "^Application:v[0-9]+ - Destroy$"}},
+	})
+}
+
+func (*AuditSuite) TestQueryAuditEntriesPropagatesError(c *gc.C) {
+	findDocs := func(string, bson.D, int, interface{}) error {
+		return errors.New("boom")
+	}
+	query := stateaudit.QueryAuditEntriesFn("audit.log", findDocs)
+	_, err := query(audit.Query{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
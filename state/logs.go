@@ -201,8 +201,25 @@ type logDoc struct {
 	Location string        `bson:"l"` // "filename:lineno"
 	Level    int           `bson:"v"`
 	Message  string        `bson:"x"`
+	Kind     LogKind       `bson:"k,omitempty"`
 }
 
+// LogKind identifies log records that are written by the controller
+// itself, rather than received from an agent. Agents cannot set the
+// kind of the records they write.
+type LogKind string
+
+const (
+	// LogKindAgent is the kind of records logged by agents.
+	LogKindAgent LogKind = ""
+
+	// LogKindAudit is the kind of records of audited API calls.
+	LogKindAudit LogKind = "audit"
+
+	// LogKindStatusHistory is the kind of records of status changes.
+	LogKindStatusHistory LogKind = "status-history"
+)
+
 type DbLogger struct {
 	logsColl  *mgo.Collection
 	modelUUID string
@@ -232,6 +249,28 @@ func (logger *DbLogger) Log(t time.Time, entity string, module string, location
 	})
 }
 
+// LogRecord writes the given log record to the database. The record's
+// ID and model UUID are ignored. Unlike Log, LogRecord records the
+// record's kind, so it must only be used for records that originate
+// in the controller.
+func (logger *DbLogger) LogRecord(rec *LogRecord) error {
+	var ver string
+	if rec.Version != version.Zero {
+		ver = rec.Version.String()
+	}
+	return logger.logsColl.Insert(&logDoc{
+		Id:       bson.NewObjectId(),
+		Time:     rec.Time.UnixNano(),
+		Entity:   rec.Entity.String(),
+		Version:  ver,
+		Module:   rec.Module,
+		Location: rec.Location,
+		Level:    int(rec.Level),
+		Message:  rec.Message,
+		Kind:     rec.Kind,
+	})
+}
+
 // Close cleans up resources used by the DbLogger instance.
 func (logger *DbLogger) Close() {
 	if logger.logsColl != nil {
@@ -313,6 +352,9 @@ type LogRecord struct {
 	Module   string
 	Location string
 	Message  string
+
+	// Kind identifies records written by the controller itself.
+	Kind LogKind
 }
 
 // LogTailerParams specifies the filtering a LogTailer should apply to
@@ -711,6 +753,7 @@ func logDocToRecord(modelUUID string, doc *logDoc) (*LogRecord, error) {
 		Module:   doc.Module,
 		Location: doc.Location,
 		Message:  doc.Message,
+		Kind:     doc.Kind,
 	}
 	return rec, nil
 }
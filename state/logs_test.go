@@ -194,6 +194,36 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
 }
 
+func (s *LogsSuite) TestDbLoggerLogRecord(c *gc.C) {
+	logger := state.NewDbLogger(s.State)
+	defer logger.Close()
+	t0 := truncateDBTime(coretesting.ZeroTime())
+	err := logger.LogRecord(&state.LogRecord{
+		Time:     t0,
+		Entity:   names.NewUserTag("bob"),
+		Version:  jujuversion.Current,
+		Module:   "some.where",
+		Location: "foo.go:99",
+		Level:    loggo.INFO,
+		Message:  "all is well",
+		Kind:     state.LogKindAudit,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var docs []bson.M
+	err = s.logsColl.Find(nil).All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(docs, gc.HasLen, 1)
+	c.Assert(docs[0]["t"], gc.Equals, t0.UnixNano())
+	c.Assert(docs[0]["n"], gc.Equals, "user-bob")
+	c.Assert(docs[0]["r"], gc.Equals, jujuversion.Current.String())
+	c.Assert(docs[0]["m"], gc.Equals, "some.where")
+	c.Assert(docs[0]["l"], gc.Equals, "foo.go:99")
+	c.Assert(docs[0]["v"], gc.Equals, int(loggo.INFO))
+	c.Assert(docs[0]["x"], gc.Equals, "all is well")
+	c.Assert(docs[0]["k"], gc.Equals, "audit")
+}
+
 func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	dbLogger := state.NewEntityDbLogger(s.State, names.NewMachineTag("22"), jujuversion.Current)
 	defer dbLogger.Close()
@@ -510,6 +510,23 @@ func (s *MigrationExportSuite) TestServiceLeadership(c *gc.C) {
 	})
 }
 
+func (s *MigrationExportSuite) TestMachinesAndUnitsSkipped(c *gc.C) {
+	s.makeApplicationWithLeader(c, "mysql", 2, 1)
+
+	model, err := s.State.ExportPartial(state.ExportConfig{
+		SkipMachines: true,
+		SkipUnits:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(model.Machines(), gc.HasLen, 0)
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Check(applications[0].Name(), gc.Equals, "mysql")
+	c.Check(applications[0].Units(), gc.HasLen, 0)
+	c.Check(applications[0].Leader(), gc.Equals, "")
+}
+
 func (s *MigrationExportSuite) TestUnitsOpenPorts(c *gc.C) {
 	unit := s.Factory.MakeUnit(c, nil)
 	err := unit.OpenPorts("tcp", 1234, 2345)
@@ -1104,6 +1121,23 @@ func (s *MigrationExportSuite) TestStorage(c *gc.C) {
 	})
 }
 
+func (s *MigrationExportSuite) TestStorageSkipped(c *gc.C) {
+	s.makeUnitWithStorage(c)
+
+	model, err := s.State.ExportPartial(state.ExportConfig{
+		SkipStorage: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(model.Storages(), gc.HasLen, 0)
+	c.Check(model.Volumes(), gc.HasLen, 0)
+	c.Check(model.Filesystems(), gc.HasLen, 0)
+	// The storage constraints of the application are kept.
+	apps := model.Applications()
+	c.Assert(apps, gc.HasLen, 1)
+	c.Check(apps[0].StorageConstraints(), gc.HasLen, 2)
+}
+
 func (s *MigrationExportSuite) TestStoragePools(c *gc.C) {
 	pm := poolmanager.New(state.NewStateSettings(s.State), provider.CommonStorageProviders())
 	_, err := pm.Create("test-pool", provider.LoopProviderType, map[string]interface{}{
@@ -932,11 +932,20 @@ func (i *importer) unit(s description.Application, u description.Unit) error {
 	}
 
 	unit := newUnit(i.st, udoc)
-	if annotations := u.Annotations(); len(annotations) > 0 {
+	annotations, charmState, err := splitCharmStateAnnotation(u.Annotations())
+	if err != nil {
+		return errors.Annotatef(err, "unit %s", u.Name())
+	}
+	if len(annotations) > 0 {
 		if err := i.st.SetAnnotations(unit, annotations); err != nil {
 			return errors.Trace(err)
 		}
 	}
+	if len(charmState) > 0 {
+		if err := unit.SetCharmState(charmState); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if err := i.importStatusHistory(unit.globalKey(), u.WorkloadStatusHistory()); err != nil {
 		return errors.Trace(err)
 	}
@@ -41,6 +41,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		applicationsC,
 		unitsC,
 		meterStatusC, // red / green status for metrics of units
+		unitStatesC,  // carried in the unit annotations
 		payloadsC,
 		"resources",
 
@@ -158,6 +159,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
 
+		// Action output is only kept so that it can be streamed to
+		// clients while the action runs.
+		actionOutputC,
+
+		// Hook runs are only kept for diagnosis, and are recorded
+		// afresh by the units once migrated.
+		hookRunsC,
+
 		// Global settings store controller specific configuration settings
 		// and are not to be migrated.
 		globalSettingsC,
@@ -190,6 +199,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		tokensC,
 		remoteEntitiesC,
 		externalControllersC,
+		// Action schedules are not yet supported by the model
+		// description, so the migration prechecks fail for models
+		// that have them.
+		actionSchedulesC,
+		// Secrets are not yet supported by the model description,
+		// so the migration prechecks fail for models that have them.
+		secretsC,
+		secretKeysC,
 	)
 
 	envCollections := set.NewStrings()
@@ -351,6 +368,9 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// Trust is not yet supported by the model description, so
+		// models with trusted applications are not migrated.
+		"Trust",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -659,6 +679,8 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// Progress messages are not part of the model description.
+		"Messages",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -345,7 +345,11 @@ func (st *State) UpdateModelConfig(updateAttrs map[string]interface{}, removeAtt
 
 	modelSettings.Update(validAttrs)
 	_, ops := modelSettings.settingsUpdateOps()
-	return modelSettings.write(ops)
+	if err := modelSettings.write(ops); err != nil {
+		return errors.Trace(err)
+	}
+	st.statusHistoryLogging.reset()
+	return nil
 }
 
 type modelConfigSourceFunc func() (attrValues, error)
@@ -585,6 +585,7 @@ func newState(
 		database:               db,
 		newPolicy:              newPolicy,
 		runTransactionObserver: runTransactionObserver,
+		statusHistoryLogging:   &statusHistoryLogging{},
 	}
 	if newPolicy != nil {
 		st.policy = newPolicy(st)
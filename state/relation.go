@@ -17,6 +17,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/feature"
 )
 
@@ -411,6 +412,133 @@ func (r *Relation) globalScope() string {
 	return fmt.Sprintf("r#%d", r.doc.Id)
 }
 
+// applicationSettingsKey returns the key of the settings document holding
+// the application-level settings of the named application in the
+// relation. The key shares the relation's settings prefix, so the
+// document is removed along with the unit settings when the relation is.
+func (r *Relation) applicationSettingsKey(appName string) string {
+	return fmt.Sprintf("r#%d#app#%s", r.doc.Id, appName)
+}
+
+// ApplicationSettings returns the application-level settings of the
+// named application within the relation. These are shared by all the
+// units of the application, and may only be written by its leader.
+func (r *Relation) ApplicationSettings(appName string) (map[string]interface{}, error) {
+	if _, err := r.Endpoint(appName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc, err := readSettingsDoc(r.st, settingsC, r.applicationSettingsKey(appName))
+	if errors.IsNotFound(err) {
+		return map[string]interface{}{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read settings for application %q in relation %q", appName, r)
+	}
+	return copyMap(doc.Settings, unescapeReplacer.Replace), nil
+}
+
+// UpdateApplicationSettings updates the application-level settings of the
+// named application within the relation with the supplied values, but
+// will fail if the supplied leadership token loses validity. Empty values
+// in the supplied map will be cleared in the database.
+func (r *Relation) UpdateApplicationSettings(appName string, token leadership.Token, updates map[string]string) error {
+	if _, err := r.Endpoint(appName); err != nil {
+		return errors.Trace(err)
+	}
+	key := r.applicationSettingsKey(appName)
+	sets := bson.M{}
+	unsets := bson.M{}
+	for unescapedKey, value := range updates {
+		key := escapeReplacer.Replace(unescapedKey)
+		if value == "" {
+			unsets[key] = 1
+		} else {
+			sets[key] = value
+		}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := r.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		ops := []txn.Op{{
+			C:      relationsC,
+			Id:     r.doc.DocID,
+			Assert: txn.DocExists,
+		}}
+		doc, err := readSettingsDoc(r.st, settingsC, key)
+		if errors.IsNotFound(err) {
+			if len(sets) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			values := make(map[string]interface{})
+			for k, v := range updates {
+				if v != "" {
+					values[k] = v
+				}
+			}
+			return append(ops, createSettingsOp(settingsC, key, values)), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if isNullSettingsChange(doc.Settings, sets, unsets) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return append(ops, txn.Op{
+			C:      settingsC,
+			Id:     key,
+			Assert: bson.D{{"version", doc.Version}},
+			Update: setUnsetUpdateSettings(sets, unsets),
+		}), nil
+	}
+	err := r.st.run(buildTxnWithLeadership(buildTxn, token))
+	return errors.Annotatef(err, "cannot write settings for application %q in relation %q", appName, r)
+}
+
+// SetRemoteApplicationSettings replaces the application-level settings of
+// the named remote application within the relation. It is used to record
+// the settings published by the application's leader in the remote model,
+// so no leadership check is made here.
+func (r *Relation) SetRemoteApplicationSettings(appName string, settings map[string]interface{}) error {
+	if _, err := r.Endpoint(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := r.st.RemoteApplication(appName); err != nil {
+		return errors.Trace(err)
+	}
+	key := r.applicationSettingsKey(appName)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := readSettingsDoc(r.st, settingsC, key); errors.IsNotFound(err) {
+			return []txn.Op{createSettingsOp(settingsC, key, settings)}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		op, _, err := replaceSettingsOp(r.st, settingsC, key, settings)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{op}, nil
+	}
+	err := r.st.run(buildTxn)
+	return errors.Annotatef(err, "cannot write settings for application %q in relation %q", appName, r)
+}
+
+// isNullSettingsChange returns whether applying the supplied sets and
+// unsets to the raw settings map would leave it unchanged.
+func isNullSettingsChange(rawMap map[string]interface{}, sets, unsets bson.M) bool {
+	for key := range unsets {
+		if _, found := rawMap[key]; found {
+			return false
+		}
+	}
+	for key, value := range sets {
+		if current := rawMap[key]; current != value {
+			return false
+		}
+	}
+	return true
+}
+
 // relationSettingsCleanupChange removes the settings doc.
 type relationSettingsCleanupChange struct {
 	Prefix string
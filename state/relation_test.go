@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -330,6 +332,101 @@ func (s *RelationSuite) TestIsCrossModelNope(c *gc.C) {
 	c.Assert(result, jc.IsFalse)
 }
 
+func (s *RelationSuite) TestApplicationSettings(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := relation.ApplicationSettings("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+
+	err = relation.UpdateApplicationSettings("mysql", &fakeToken{}, map[string]string{
+		"host":     "db.example.com",
+		"some.key": "value",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = relation.UpdateApplicationSettings("mysql", &fakeToken{}, map[string]string{
+		"host": "",
+		"port": "3306",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err = relation.ApplicationSettings("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{
+		"some.key": "value",
+		"port":     "3306",
+	})
+
+	// The settings of each application are kept separately.
+	settings, err = relation.ApplicationSettings("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, gc.HasLen, 0)
+
+	_, err = relation.ApplicationSettings("riak")
+	c.Assert(err, gc.ErrorMatches, `application "riak" is not a member of "wordpress:db mysql:server"`)
+
+	// Removing the relation removes the application settings.
+	err = relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoApplicationSettings(c, relation, "mysql")
+}
+
+func (s *RelationSuite) assertNoApplicationSettings(c *gc.C, relation *state.Relation, appName string) {
+	err := s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ReadSettings("settings", fmt.Sprintf("r#%d#app#%s", relation.Id(), appName))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RelationSuite) TestUpdateApplicationSettingsTokenError(c *gc.C) {
+	s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = relation.UpdateApplicationSettings("mysql", &failToken{}, map[string]string{"host": "db"})
+	c.Assert(err, gc.ErrorMatches, `cannot write settings for application "mysql" in relation "wordpress:db mysql:server": prerequisites failed: something bad happened`)
+}
+
+func (s *RelationSuite) TestSetRemoteApplicationSettings(c *gc.C) {
+	_, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name:        "remote-wordpress",
+		SourceModel: names.NewModelTag("source-model"),
+		Endpoints: []charm.Relation{{
+			Interface: "mysql",
+			Limit:     1,
+			Name:      "db",
+			Role:      charm.RoleRequirer,
+			Scope:     charm.ScopeGlobal,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("remote-wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	relation, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = relation.SetRemoteApplicationSettings("remote-wordpress", map[string]interface{}{"a": "1", "b": "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = relation.SetRemoteApplicationSettings("remote-wordpress", map[string]interface{}{"b": "3"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := relation.ApplicationSettings("remote-wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{"b": "3"})
+
+	err = relation.SetRemoteApplicationSettings("mysql", map[string]interface{}{"a": "1"})
+	c.Assert(err, gc.ErrorMatches, `remote application "mysql" not found`)
+}
+
 func assertNoRelations(c *gc.C, srv *state.Application) {
 	rels, err := srv.Relations()
 	c.Assert(err, jc.ErrorIsNil)
@@ -533,6 +533,34 @@ func (s *RelationUnitSuite) TestProReqWatchScope(c *gc.C) {
 	s.testProReqWatchScope(c, prr.pru0, prr.pru1, prr.rru0, prr.rru1, prr.watches)
 }
 
+func (s *RelationUnitSuite) TestWatchApplicationSettings(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.pru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := prr.rru0.Watch()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewRelationUnitsWatcherC(c, s.State, w)
+	wc.AssertChange([]string{"mysql/0"}, nil)
+	wc.AssertNoChange()
+
+	// A change to the requirer's own application settings is not
+	// reported to the requirer.
+	err = prr.rel.UpdateApplicationSettings("wordpress", &fakeToken{}, map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	// A change to the provider's application settings is reported as
+	// a change to all the provider units in scope.
+	err = prr.pru1.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange([]string{"mysql/1"}, nil)
+	err = prr.rel.UpdateApplicationSettings("mysql", &fakeToken{}, map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange([]string{"mysql/0", "mysql/1"}, nil)
+	wc.AssertNoChange()
+}
+
 func (s *RelationUnitSuite) TestRemoteProReqWatchScope(c *gc.C) {
 	prr := newRemoteProReqRelation(c, &s.ConnSuite)
 	s.testProReqWatchScope(c, prr.pru0, prr.pru1, prr.rru0, prr.rru1, prr.watches)
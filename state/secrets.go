@@ -0,0 +1,564 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// secretDoc records a secret owned by an application. The secret's
+// value is stored encrypted with the model's secrets key.
+type secretDoc struct {
+	DocId     string `bson:"_id"`
+	Id        string `bson:"id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// Owner is the name of the application that owns the secret.
+	Owner string `bson:"owner"`
+
+	// Label and Description are free-form text supplied by the
+	// owner to help identify the secret.
+	Label       string `bson:"label,omitempty"`
+	Description string `bson:"description,omitempty"`
+
+	// Revision is incremented each time the secret's value changes.
+	Revision int `bson:"revision"`
+
+	// Data holds the secret's key/value pairs, JSON encoded and
+	// encrypted with the model's secrets key.
+	Data []byte `bson:"data"`
+
+	// RotateInterval is the interval, in nanoseconds, at which the
+	// owner is asked to rotate the secret, or zero if it is not.
+	RotateInterval int64 `bson:"rotate-interval,omitempty"`
+
+	// NextRotate is the time, in unix nanoseconds, at which the owner
+	// is next asked to rotate the secret, or zero if it is not.
+	NextRotate int64 `bson:"next-rotate,omitempty"`
+
+	// Grants holds the keys of the relations that have been granted
+	// access to the secret.
+	Grants []string `bson:"grants"`
+
+	// Created and Updated are the times, in unix nanoseconds, at
+	// which the secret was created and its value last changed.
+	Created int64 `bson:"created"`
+	Updated int64 `bson:"updated"`
+}
+
+// secretKeyDoc holds the key used to encrypt the values of the
+// secrets in a model.
+type secretKeyDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Key       []byte `bson:"key"`
+}
+
+const secretKeyDocId = "secrets"
+
+// SecretArgs holds the arguments for adding a secret.
+type SecretArgs struct {
+	// Owner is the application that owns the secret.
+	Owner names.ApplicationTag
+
+	// Label and Description help identify the secret.
+	Label       string
+	Description string
+
+	// Data holds the secret's key/value pairs.
+	Data map[string]string
+
+	// RotateInterval, if positive, is the interval at which the owner
+	// is asked to rotate the secret.
+	RotateInterval time.Duration
+}
+
+// Secret represents a secret owned by an application, whose value may
+// be read by the units of the owner and of any relation the secret is
+// granted to.
+type Secret struct {
+	st  *State
+	doc secretDoc
+}
+
+// Id returns the id of the secret.
+func (s *Secret) Id() string {
+	return s.doc.Id
+}
+
+// Owner returns the tag of the application that owns the secret.
+func (s *Secret) Owner() names.ApplicationTag {
+	return names.NewApplicationTag(s.doc.Owner)
+}
+
+// Label returns the label of the secret.
+func (s *Secret) Label() string {
+	return s.doc.Label
+}
+
+// Description returns the description of the secret.
+func (s *Secret) Description() string {
+	return s.doc.Description
+}
+
+// Revision returns the revision of the secret's value.
+func (s *Secret) Revision() int {
+	return s.doc.Revision
+}
+
+// RotateInterval returns the interval at which the owner is asked to
+// rotate the secret, or zero if it is not.
+func (s *Secret) RotateInterval() time.Duration {
+	return time.Duration(s.doc.RotateInterval)
+}
+
+// NextRotateTime returns the time at which the owner is next asked to
+// rotate the secret, or the zero time if it is not.
+func (s *Secret) NextRotateTime() time.Time {
+	return unixNanoToTime0(s.doc.NextRotate)
+}
+
+// Grants returns the tags of the relations that have been granted
+// access to the secret.
+func (s *Secret) Grants() []names.RelationTag {
+	result := make([]names.RelationTag, len(s.doc.Grants))
+	for i, key := range s.doc.Grants {
+		result[i] = names.NewRelationTag(key)
+	}
+	return result
+}
+
+// Created returns the time at which the secret was created.
+func (s *Secret) Created() time.Time {
+	return time.Unix(0, s.doc.Created).UTC()
+}
+
+// Updated returns the time at which the secret's value last changed.
+func (s *Secret) Updated() time.Time {
+	return time.Unix(0, s.doc.Updated).UTC()
+}
+
+// Value returns the decrypted key/value pairs of the secret.
+func (s *Secret) Value() (map[string]string, error) {
+	key, err := s.st.secretsKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := decryptSecretData(key, s.doc.Data)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read secret %q", s.doc.Id)
+	}
+	return data, nil
+}
+
+// AddSecret adds a secret owned by an application.
+func (st *State) AddSecret(args SecretArgs) (_ *Secret, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot add secret")
+
+	if len(args.Data) == 0 {
+		return nil, errors.New("no secret data given")
+	}
+	if args.RotateInterval < 0 {
+		return nil, errors.NotValidf("negative rotate interval")
+	}
+	application, err := st.Application(args.Owner.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if application.Life() != Alive {
+		return nil, errors.Errorf("application %q is not alive", application.Name())
+	}
+	key, err := st.secretsKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, err := encryptSecretData(key, args.Data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	seq, err := st.sequence("secret")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	now := st.clock.Now()
+	doc := secretDoc{
+		DocId:          st.docID(id),
+		Id:             id,
+		ModelUUID:      st.ModelUUID(),
+		Owner:          application.Name(),
+		Label:          args.Label,
+		Description:    args.Description,
+		Revision:       1,
+		Data:           data,
+		RotateInterval: int64(args.RotateInterval),
+		Created:        now.UnixNano(),
+		Updated:        now.UnixNano(),
+	}
+	if args.RotateInterval > 0 {
+		doc.NextRotate = now.Add(args.RotateInterval).UnixNano()
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     application.doc.DocID,
+		Assert: isAliveDoc,
+	}, {
+		C:      secretsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.Errorf("application %q is not alive", application.Name())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// Secret returns the secret with the given id.
+func (st *State) Secret(id string) (*Secret, error) {
+	secrets, closer := st.db().GetCollection(secretsC)
+	defer closer()
+
+	var doc secretDoc
+	err := secrets.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", id)
+	}
+	return &Secret{st: st, doc: doc}, nil
+}
+
+// AllSecrets returns all the secrets in the model.
+func (st *State) AllSecrets() ([]*Secret, error) {
+	secrets, closer := st.db().GetCollection(secretsC)
+	defer closer()
+
+	var docs []secretDoc
+	if err := secrets.Find(nil).Sort("owner", "created").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get secrets")
+	}
+	result := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		result[i] = &Secret{st: st, doc: doc}
+	}
+	return result, nil
+}
+
+// ReadableSecrets returns the secrets whose values may be read by the
+// units of the named application: those it owns, and those granted to
+// a relation it takes part in.
+func (st *State) ReadableSecrets(applicationName string) ([]*Secret, error) {
+	secrets, err := st.AllSecrets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []*Secret
+	for _, secret := range secrets {
+		readable, err := secret.CanRead(applicationName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if readable {
+			result = append(result, secret)
+		}
+	}
+	return result, nil
+}
+
+// CanRead reports whether the units of the named application may read
+// the secret's value.
+func (s *Secret) CanRead(applicationName string) (bool, error) {
+	if s.doc.Owner == applicationName {
+		return true, nil
+	}
+	for _, key := range s.doc.Grants {
+		relation, err := s.st.KeyRelation(key)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		if _, err := relation.Endpoint(applicationName); err == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// WatchApplicationSecrets returns a NotifyWatcher that notifies when a
+// secret that the units of the named application may read is added,
+// changed or removed, or when the application gains or loses access to
+// a secret. Changes to other secrets are not reported.
+func (st *State) WatchApplicationSecrets(applicationName string) NotifyWatcher {
+	// readable holds the ids of the secrets last known to be readable
+	// by the application, so that their removal or revocation is
+	// reported even though they can no longer be read.
+	readable := make(map[string]bool)
+	if secrets, err := st.ReadableSecrets(applicationName); err != nil {
+		watchLogger.Errorf("cannot get secrets readable by %q: %v", applicationName, err)
+	} else {
+		for _, secret := range secrets {
+			readable[secret.Id()] = true
+		}
+	}
+	isLocal := isLocalID(st)
+	filter := func(key interface{}) bool {
+		if !isLocal(key) {
+			return false
+		}
+		id := st.localID(key.(string))
+		wasReadable := readable[id]
+		secret, err := st.Secret(id)
+		if errors.IsNotFound(err) {
+			delete(readable, id)
+			return wasReadable
+		} else if err != nil {
+			watchLogger.Errorf("cannot get secret %q: %v", id, err)
+			return true
+		}
+		canRead, err := secret.CanRead(applicationName)
+		if err != nil {
+			watchLogger.Errorf("cannot check access to secret %q: %v", id, err)
+			return true
+		}
+		if canRead {
+			readable[id] = true
+		} else {
+			delete(readable, id)
+		}
+		return canRead || wasReadable
+	}
+	return newNotifyCollWatcher(st, secretsC, filter)
+}
+
+// Update stores a new value for the secret and increments its revision.
+// If the secret is rotated, its next rotation time is moved on.
+func (s *Secret) Update(data map[string]string) error {
+	if len(data) == 0 {
+		return errors.Errorf("cannot update secret %q: no secret data given", s.doc.Id)
+	}
+	key, err := s.st.secretsKey()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	encrypted, err := encryptSecretData(key, data)
+	if err != nil {
+		return errors.Annotatef(err, "cannot update secret %q", s.doc.Id)
+	}
+	now := s.st.clock.Now()
+	set := bson.D{
+		{"data", encrypted},
+		{"updated", now.UnixNano()},
+	}
+	if s.doc.RotateInterval > 0 {
+		set = append(set, bson.DocElem{"next-rotate", now.Add(s.RotateInterval()).UnixNano()})
+	}
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     s.doc.DocId,
+		Assert: bson.D{{"revision", s.doc.Revision}},
+		Update: bson.D{
+			{"$set", set},
+			{"$inc", bson.D{{"revision", 1}}},
+		},
+	}}
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("cannot update secret %q: secret changed or removed", s.doc.Id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot update secret %q", s.doc.Id)
+	}
+	s.doc.Data = encrypted
+	s.doc.Revision++
+	s.doc.Updated = now.UnixNano()
+	return nil
+}
+
+// Grant gives the units of the applications in the relation access to
+// the secret.
+func (s *Secret) Grant(relation *Relation) error {
+	if _, err := relation.Endpoint(s.doc.Owner); err != nil {
+		return errors.Errorf(
+			"cannot grant secret %q to relation %q: application %q is not in the relation",
+			s.doc.Id, relation, s.doc.Owner,
+		)
+	}
+	ops := []txn.Op{{
+		C:      relationsC,
+		Id:     relation.doc.DocID,
+		Assert: isAliveDoc,
+	}, {
+		C:      secretsC,
+		Id:     s.doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$addToSet", bson.D{{"grants", relation.String()}}}},
+	}}
+	if err := s.st.runTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("cannot grant secret %q to relation %q: relation or secret removed", s.doc.Id, relation)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot grant secret %q", s.doc.Id)
+	}
+	s.doc.Grants = append(s.doc.Grants, relation.String())
+	return nil
+}
+
+// Revoke removes the access to the secret previously granted to the
+// relation with the given key.
+func (s *Secret) Revoke(relationKey string) error {
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     s.doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$pull", bson.D{{"grants", relationKey}}}},
+	}}
+	if err := s.st.runTransaction(ops); err != nil {
+		return errors.Annotatef(err, "cannot revoke secret %q", s.doc.Id)
+	}
+	var grants []string
+	for _, key := range s.doc.Grants {
+		if key != relationKey {
+			grants = append(grants, key)
+		}
+	}
+	s.doc.Grants = grants
+	return nil
+}
+
+// Remove removes the secret.
+func (s *Secret) Remove() error {
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     s.doc.DocId,
+		Remove: true,
+	}}
+	err := s.st.runTransaction(ops)
+	return errors.Annotatef(err, "cannot remove secret %q", s.doc.Id)
+}
+
+// removeApplicationSecretsOps returns the operations that remove the
+// secrets owned by the named application.
+func removeApplicationSecretsOps(st *State, applicationName string) ([]txn.Op, error) {
+	secrets, closer := st.db().GetCollection(secretsC)
+	defer closer()
+
+	var docs []struct {
+		DocId string `bson:"_id"`
+	}
+	if err := secrets.Find(bson.D{{"owner", applicationName}}).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get secrets of application %q", applicationName)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      secretsC,
+			Id:     doc.DocId,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// secretsKey returns the key used to encrypt the model's secrets,
+// creating it if necessary.
+func (st *State) secretsKey() ([]byte, error) {
+	keys, closer := st.db().GetCollection(secretKeysC)
+	defer closer()
+
+	var doc secretKeyDoc
+	err := keys.FindId(secretKeyDocId).One(&doc)
+	if err == nil {
+		return doc.Key, nil
+	} else if err != mgo.ErrNotFound {
+		return nil, errors.Annotate(err, "cannot get secrets key")
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, errors.Annotate(err, "cannot generate secrets key")
+	}
+	doc = secretKeyDoc{
+		DocId:     st.docID(secretKeyDocId),
+		ModelUUID: st.ModelUUID(),
+		Key:       key,
+	}
+	ops := []txn.Op{{
+		C:      secretKeysC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.runTransaction(ops); err == txn.ErrAborted {
+		// Another client created the key first; use theirs.
+		if err := keys.FindId(secretKeyDocId).One(&doc); err != nil {
+			return nil, errors.Annotate(err, "cannot get secrets key")
+		}
+		return doc.Key, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot create secrets key")
+	}
+	return key, nil
+}
+
+// encryptSecretData encrypts the JSON encoding of the data with the
+// given key using AES-GCM. The random nonce is prepended to the result.
+func encryptSecretData(key []byte, data map[string]string) ([]byte, error) {
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// decryptSecretData reverses encryptSecretData.
+func decryptSecretData(key, encrypted []byte) (map[string]string, error) {
+	gcm, err := newSecretCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(encrypted) < gcm.NonceSize() {
+		return nil, errors.New("secret data too short")
+	}
+	nonce, ciphertext := encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var data map[string]string
+	if err := json.Unmarshal(plaintext, &data); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+func newSecretCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
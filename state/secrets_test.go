@@ -0,0 +1,241 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	mysql     *state.Application
+	wordpress *state.Application
+	relation  *state.Relation
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.mysql = s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	s.wordpress = s.AddTestingService(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingService(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsSuite) addSecret(c *gc.C, rotate time.Duration) *state.Secret {
+	secret, err := s.State.AddSecret(state.SecretArgs{
+		Owner:          s.mysql.ApplicationTag(),
+		Label:          "root",
+		Description:    "root password",
+		Data:           map[string]string{"password": "s3cret"},
+		RotateInterval: rotate,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return secret
+}
+
+func (s *SecretsSuite) TestAddSecret(c *gc.C) {
+	now := s.Clock.Now()
+	secret := s.addSecret(c, time.Hour)
+	c.Check(secret.Id(), gc.Equals, "0")
+	c.Check(secret.Owner(), gc.Equals, s.mysql.ApplicationTag())
+	c.Check(secret.Label(), gc.Equals, "root")
+	c.Check(secret.Description(), gc.Equals, "root password")
+	c.Check(secret.Revision(), gc.Equals, 1)
+	c.Check(secret.RotateInterval(), gc.Equals, time.Hour)
+	c.Check(secret.NextRotateTime().Unix(), gc.Equals, now.Add(time.Hour).Unix())
+	c.Check(secret.Grants(), gc.HasLen, 0)
+
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+
+	secret, err = s.State.Secret("0")
+	c.Assert(err, jc.ErrorIsNil)
+	value, err = secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "s3cret"})
+}
+
+func (s *SecretsSuite) TestAddSecretNoRotation(c *gc.C) {
+	secret := s.addSecret(c, 0)
+	c.Check(secret.RotateInterval(), gc.Equals, time.Duration(0))
+	c.Check(secret.NextRotateTime().IsZero(), jc.IsTrue)
+}
+
+func (s *SecretsSuite) TestAddSecretInvalid(c *gc.C) {
+	_, err := s.State.AddSecret(state.SecretArgs{Owner: s.mysql.ApplicationTag()})
+	c.Check(err, gc.ErrorMatches, "cannot add secret: no secret data given")
+
+	_, err = s.State.AddSecret(state.SecretArgs{
+		Owner: names.NewApplicationTag("postgresql"),
+		Data:  map[string]string{"password": "s3cret"},
+	})
+	c.Check(err, gc.ErrorMatches, `cannot add secret: application "postgresql" not found`)
+}
+
+func (s *SecretsSuite) TestValueStoredEncrypted(c *gc.C) {
+	s.addSecret(c, 0)
+	secrets, closer := state.GetRawCollection(s.State, "secrets")
+	defer closer()
+	var doc map[string]interface{}
+	err := secrets.Find(nil).One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(doc["data"], gc.Not(gc.DeepEquals), []byte(`{"password":"s3cret"}`))
+	c.Check(string(doc["data"].([]byte)), gc.Not(jc.Contains), "s3cret")
+}
+
+func (s *SecretsSuite) TestUpdate(c *gc.C) {
+	secret := s.addSecret(c, time.Hour)
+	s.Clock.Advance(30 * time.Minute)
+	err := secret.Update(map[string]string{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Revision(), gc.Equals, 2)
+
+	secret, err = s.State.Secret(secret.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Revision(), gc.Equals, 2)
+	c.Check(secret.NextRotateTime().Unix(), gc.Equals, s.Clock.Now().Add(time.Hour).Unix())
+	value, err := secret.Value()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "n3w"})
+}
+
+func (s *SecretsSuite) TestUpdateStale(c *gc.C) {
+	secret := s.addSecret(c, 0)
+	stale, err := s.State.Secret(secret.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = secret.Update(map[string]string{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = stale.Update(map[string]string{"password": "0ld"})
+	c.Assert(err, gc.ErrorMatches, `cannot update secret "0": secret changed or removed`)
+}
+
+func (s *SecretsSuite) TestGrantAndCanRead(c *gc.C) {
+	secret := s.addSecret(c, 0)
+	for _, app := range []string{"wordpress", "logging"} {
+		readable, err := secret.CanRead(app)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(readable, jc.IsFalse)
+	}
+	readable, err := secret.CanRead("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(readable, jc.IsTrue)
+
+	err = secret.Grant(s.relation)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), jc.DeepEquals, []names.RelationTag{s.relation.Tag().(names.RelationTag)})
+
+	readable, err = secret.CanRead("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(readable, jc.IsTrue)
+	readable, err = secret.CanRead("logging")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(readable, jc.IsFalse)
+
+	secrets, err := s.State.ReadableSecrets("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 1)
+	c.Check(secrets[0].Id(), gc.Equals, secret.Id())
+
+	err = secret.Revoke(s.relation.String())
+	c.Assert(err, jc.ErrorIsNil)
+	secrets, err = s.State.ReadableSecrets("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets, gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestGrantNotInRelation(c *gc.C) {
+	secret, err := s.State.AddSecret(state.SecretArgs{
+		Owner: names.NewApplicationTag("logging"),
+		Data:  map[string]string{"token": "t0ken"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = secret.Grant(s.relation)
+	c.Assert(err, gc.ErrorMatches, `cannot grant secret "0" to relation "wordpress:db mysql:server": application "logging" is not in the relation`)
+}
+
+func (s *SecretsSuite) TestRemove(c *gc.C) {
+	secret := s.addSecret(c, 0)
+	err := secret.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Secret(secret.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	secrets, err := s.State.AllSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets, gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestRemovedWithApplication(c *gc.C) {
+	secret := s.addSecret(c, 0)
+	err := s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Secret(secret.Id())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	// A new application of the same name cannot read the
+	// removed application's secrets.
+	s.AddTestingService(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	secrets, err := s.State.ReadableSecrets("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secrets, gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestWatchApplicationSecrets(c *gc.C) {
+	w := s.State.WatchApplicationSecrets("wordpress")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	// Secrets that wordpress cannot read are not reported.
+	secret := s.addSecret(c, 0)
+	wc.AssertNoChange()
+	err := secret.Update(map[string]string{"password": "n3w"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = secret.Grant(s.relation)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = secret.Update(map[string]string{"password": "n3w3r"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Losing access is reported, and later changes are not.
+	err = secret.Revoke(s.relation.String())
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	err = secret.Update(map[string]string{"password": "n3w3st"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *SecretsSuite) TestWatchApplicationSecretsOwned(c *gc.C) {
+	w := s.State.WatchApplicationSecrets("mysql")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	secret := s.addSecret(c, 0)
+	wc.AssertOneChange()
+
+	err := secret.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -94,6 +94,10 @@ type State struct {
 	// represented by this state runs.
 	cloudName string
 
+	// statusHistoryLogging caches whether status history is written
+	// to the model's logs.
+	statusHistoryLogging *statusHistoryLogging
+
 	// leaseClientId is used by the lease infrastructure to
 	// differentiate between machines whose clocks may be
 	// relatively-skewed.
@@ -981,6 +985,7 @@ type AddApplicationArgs struct {
 	Placement        []*instance.Placement
 	Constraints      constraints.Value
 	Resources        map[string]string
+	Trust            bool
 }
 
 // AddApplication creates a new application, running the supplied charm, with the
@@ -1158,6 +1163,7 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		Channel:       string(args.Channel),
 		RelationCount: len(peers),
 		Life:          Alive,
+		Trust:         args.Trust,
 	}
 
 	app := newApplication(st, appDoc)
@@ -2184,6 +2190,23 @@ func (st *State) PutAuditEntryFn() func(audit.AuditEntry) error {
 	return stateaudit.PutAuditEntryFn(auditingC, insert)
 }
 
+// AuditEntries returns the audit entries matching the given query,
+// oldest first.
+func (st *State) AuditEntries(query audit.Query) ([]audit.AuditEntry, error) {
+	find := func(collectionName string, selector bson.D, limit int, docs interface{}) error {
+		collection, closeCollection := st.db().GetCollection(collectionName)
+		defer closeCollection()
+
+		q := collection.Find(selector).Sort("-timestamp")
+		if limit > 0 {
+			q = q.Limit(limit)
+		}
+		return errors.Trace(q.All(docs))
+	}
+	entries, err := stateaudit.QueryAuditEntriesFn(auditingC, find)(query)
+	return entries, errors.Annotate(err, "cannot get audit entries")
+}
+
 // SetSLA sets the SLA on the current connected model.
 func (st *State) SetSLA(level, owner string, credentials []byte) error {
 	model, err := st.Model()
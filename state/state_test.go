@@ -1438,6 +1438,16 @@ func (s *StateSuite) TestAddApplication(c *gc.C) {
 	c.Assert(ch.URL(), gc.DeepEquals, ch.URL())
 }
 
+func (s *StateSuite) TestAddApplicationTrusted(c *gc.C) {
+	ch := s.AddTestingCharm(c, "dummy")
+	app, err := s.State.AddApplication(state.AddApplicationArgs{Name: "dummy", Charm: ch, Trust: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsTrusted(), jc.IsTrue)
+	app, err = s.State.Application("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsTrusted(), jc.IsTrue)
+}
+
 func (s *StateSuite) TestAddApplicationWithNilConfigValues(c *gc.C) {
 	ch := s.AddTestingCharm(c, "dummy")
 	insettings := charm.Settings{"tuning": nil}
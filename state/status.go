@@ -4,10 +4,15 @@
 package state
 
 import (
+	"encoding/json"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -16,6 +21,7 @@ import (
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/status"
+	jujuversion "github.com/juju/juju/version"
 )
 
 // statusDoc represents a entity status in Mongodb.  The implicit
@@ -193,6 +199,125 @@ func probablyUpdateStatusHistory(st *State, globalKey string, doc statusDoc) {
 	if err := historyW.Insert(historyDoc); err != nil {
 		logger.Errorf("failed to write status history: %v", err)
 	}
+	if err := logStatusHistory(st, globalKey, doc); err != nil {
+		logger.Errorf("failed to log status history: %v", err)
+	}
+}
+
+// statusHistoryLogMessage is the message of the log records written
+// for status history entries.
+type statusHistoryLogMessage struct {
+	Kind    status.HistoryKind     `json:"kind"`
+	Status  status.Status          `json:"status"`
+	Message string                 `json:"message,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// logStatusHistory writes the status history entry to the model's logs,
+// if enabled in the model config, so that it is sent to the log
+// forwarding target along with the agent logs. Only the status of
+// units and machines is logged.
+func logStatusHistory(st *State, globalKey string, doc statusDoc) error {
+	tag, kind, ok := statusHistoryEntity(globalKey)
+	if !ok {
+		return nil
+	}
+	enabled, err := st.statusHistoryLogging.enabled(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !enabled {
+		return nil
+	}
+	msg, err := json.Marshal(statusHistoryLogMessage{
+		Kind:    kind,
+		Status:  doc.Status,
+		Message: doc.StatusInfo,
+		Data:    utils.UnescapeKeys(doc.StatusData),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	level := loggo.INFO
+	if doc.Status == status.Error {
+		level = loggo.ERROR
+	}
+	dbLogger := NewDbLogger(st)
+	defer dbLogger.Close()
+	return dbLogger.LogRecord(&LogRecord{
+		Time:    time.Unix(0, doc.Updated),
+		Entity:  tag,
+		Version: jujuversion.Current,
+		Level:   level,
+		Module:  status.HistoryLogModule,
+		Message: string(msg),
+		Kind:    LogKindStatusHistory,
+	})
+}
+
+// statusHistoryLoggingTTL is how long the model's
+// logforward-status-history setting is cached, so that the model
+// config is not read for every status change.
+const statusHistoryLoggingTTL = time.Minute
+
+// statusHistoryLogging caches whether status history is written to a
+// model's logs.
+type statusHistoryLogging struct {
+	mu      sync.Mutex
+	value   bool
+	expires time.Time
+}
+
+// enabled reports whether status history is written to the logs of
+// the given state's model, reading the model config if the cached
+// value has expired.
+func (l *statusHistoryLogging) enabled(st *State) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := st.clock.Now()
+	if now.Before(l.expires) {
+		return l.value, nil
+	}
+	cfg, err := st.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	l.value = cfg.LogForwardStatusHistory()
+	l.expires = now.Add(statusHistoryLoggingTTL)
+	return l.value, nil
+}
+
+// reset discards the cached value, so that it is read again from the
+// model config.
+func (l *statusHistoryLogging) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.expires = time.Time{}
+}
+
+// statusHistoryEntity returns the tag of the entity with the given
+// status global key, and the kind of status history entry recorded
+// for it. It returns false if the key is not that of a unit or machine
+// status.
+func statusHistoryEntity(globalKey string) (names.Tag, status.HistoryKind, bool) {
+	parts := strings.Split(globalKey, "#")
+	switch {
+	case len(parts) == 3 && parts[0] == "u" && parts[2] == "charm":
+		return names.NewUnitTag(parts[1]), status.KindWorkload, true
+	case len(parts) == 2 && parts[0] == "u":
+		return names.NewUnitTag(parts[1]), status.KindUnitAgent, true
+	case len(parts) == 2 && parts[0] == "m":
+		if names.IsContainerMachine(parts[1]) {
+			return names.NewMachineTag(parts[1]), status.KindContainer, true
+		}
+		return names.NewMachineTag(parts[1]), status.KindMachine, true
+	case len(parts) == 3 && parts[0] == "m" && parts[2] == "instance":
+		if names.IsContainerMachine(parts[1]) {
+			return names.NewMachineTag(parts[1]), status.KindContainerInstance, true
+		}
+		return names.NewMachineTag(parts[1]), status.KindMachineInstance, true
+	}
+	return nil, "", false
 }
 
 func eraseStatusHistory(st *State, globalKey string) error {
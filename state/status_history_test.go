@@ -7,16 +7,19 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/juju/loggo"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/status"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
+	jujuversion "github.com/juju/juju/version"
 )
 
 type StatusHistorySuite struct {
@@ -266,3 +269,46 @@ func (s *StatusHistorySuite) TestStatusHistoryFiltersByDateAndDelta(c *gc.C) {
 	c.Assert(history[1].Message, gc.Equals, "waiting for machine")
 	c.Assert(history[2].Message, gc.Equals, "2 days ago")
 }
+
+func (s *StatusHistorySuite) statusHistoryLogs(c *gc.C) []bson.M {
+	logs := s.State.MongoSession().DB("logs").C("logs." + s.State.ModelUUID())
+	var docs []bson.M
+	err := logs.Find(bson.M{"m": status.HistoryLogModule}).Sort("t").All(&docs)
+	c.Assert(err, jc.ErrorIsNil)
+	return docs
+}
+
+func (s *StatusHistorySuite) TestStatusHistoryNotLoggedByDefault(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	now := time.Now()
+	err := unit.SetStatus(status.StatusInfo{Status: status.Active, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.statusHistoryLogs(c), gc.HasLen, 0)
+}
+
+func (s *StatusHistorySuite) TestStatusHistoryLogged(c *gc.C) {
+	err := s.State.UpdateModelConfig(map[string]interface{}{
+		"logforward-status-history": true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	unit := s.Factory.MakeUnit(c, nil)
+
+	now := time.Now()
+	err = unit.SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "hook failed",
+		Data:    map[string]interface{}{"hook": "install"},
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	docs := s.statusHistoryLogs(c)
+	c.Assert(docs, gc.Not(gc.HasLen), 0)
+	doc := docs[len(docs)-1]
+	c.Check(doc["n"], gc.Equals, unit.Tag().String())
+	c.Check(doc["t"], gc.Equals, now.UnixNano())
+	c.Check(doc["r"], gc.Equals, jujuversion.Current.String())
+	c.Check(doc["v"], gc.Equals, int(loggo.ERROR))
+	c.Check(doc["k"], gc.Equals, "status-history")
+	c.Check(doc["x"], gc.Equals, `{"kind":"workload","status":"error","message":"hook failed","data":{"hook":"install"}}`)
+}
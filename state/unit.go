@@ -348,6 +348,9 @@ func (u *Unit) eraseHistory() error {
 	if err := eraseStatusHistory(u.st, u.globalWorkloadVersionKey()); err != nil {
 		return errors.Annotate(err, "version")
 	}
+	if err := eraseHookRuns(u.st, u.Name()); err != nil {
+		return errors.Annotate(err, "hook runs")
+	}
 	return nil
 }
 
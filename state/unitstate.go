@@ -0,0 +1,177 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// charmStateAnnotationKey is the unit annotation used to carry a
+// unit's charm state through model migration, as the model description
+// has no field for it. SetAnnotations rejects keys containing ".", so
+// the key cannot clash with annotations set by users.
+const charmStateAnnotationKey = "juju.charm-state"
+
+// unitStateDoc records the key/value state that a charm stores for one
+// of its units. Keys are escaped so that they can be stored in mongo.
+type unitStateDoc struct {
+	DocID     string            `bson:"_id"`
+	ModelUUID string            `bson:"model-uuid"`
+	State     map[string]string `bson:"state"`
+}
+
+// CharmState returns the key/value state that the unit's charm has
+// stored. A unit whose charm has stored nothing has an empty state.
+func (u *Unit) CharmState() (map[string]string, error) {
+	doc, err := u.unitStateDoc()
+	if errors.IsNotFound(err) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read charm state for unit %q", u)
+	}
+	return unescapeCharmState(doc.State), nil
+}
+
+// SetCharmState replaces the key/value state that the unit's charm has
+// stored. The state may be set while the unit is dying, so that charms
+// can record their progress in departed and stop hooks.
+func (u *Unit) SetCharmState(state map[string]string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); errors.IsNotFound(err) {
+				return nil, ErrDead
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if u.Life() == Dead {
+				return nil, ErrDead
+			}
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		doc, err := u.unitStateDoc()
+		if errors.IsNotFound(err) {
+			if len(state) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return append(ops, txn.Op{
+				C:      unitStatesC,
+				Id:     u.st.docID(u.globalKey()),
+				Assert: txn.DocMissing,
+				Insert: &unitStateDoc{
+					ModelUUID: u.st.ModelUUID(),
+					State:     escapeCharmState(state),
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if charmStateEqual(unescapeCharmState(doc.State), state) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return append(ops, txn.Op{
+			C:      unitStatesC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"state", escapeCharmState(state)}}}},
+		}), nil
+	}
+	return errors.Annotatef(u.st.run(buildTxn), "cannot set charm state for unit %q", u)
+}
+
+func (u *Unit) unitStateDoc() (*unitStateDoc, error) {
+	unitStates, closer := u.st.db().GetCollection(unitStatesC)
+	defer closer()
+
+	var doc unitStateDoc
+	err := unitStates.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("charm state for unit %q", u)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
+
+// removeUnitStateOp returns the operation needed to remove the charm
+// state document associated with the given globalKey.
+func removeUnitStateOp(st *State, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitStatesC,
+		Id:     st.docID(globalKey),
+		Remove: true,
+	}
+}
+
+func escapeCharmState(state map[string]string) map[string]string {
+	out := make(map[string]string)
+	for k, v := range state {
+		out[escapeReplacer.Replace(k)] = v
+	}
+	return out
+}
+
+func unescapeCharmState(state map[string]string) map[string]string {
+	out := make(map[string]string)
+	for k, v := range state {
+		out[unescapeReplacer.Replace(k)] = v
+	}
+	return out
+}
+
+func charmStateEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if other, ok := b[k]; !ok || other != v {
+			return false
+		}
+	}
+	return true
+}
+
+// addCharmStateAnnotation returns a copy of the annotations with the
+// charm state encoded under charmStateAnnotationKey.
+func addCharmStateAnnotation(annotations, charmState map[string]string) (map[string]string, error) {
+	encoded, err := json.Marshal(charmState)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string)
+	for k, v := range annotations {
+		result[k] = v
+	}
+	result[charmStateAnnotationKey] = string(encoded)
+	return result, nil
+}
+
+// splitCharmStateAnnotation separates the charm state encoded by
+// addCharmStateAnnotation from the rest of the annotations.
+func splitCharmStateAnnotation(annotations map[string]string) (map[string]string, map[string]string, error) {
+	encoded, ok := annotations[charmStateAnnotationKey]
+	if !ok {
+		return annotations, nil, nil
+	}
+	var charmState map[string]string
+	if err := json.Unmarshal([]byte(encoded), &charmState); err != nil {
+		return nil, nil, errors.Annotate(err, "cannot decode charm state")
+	}
+	result := make(map[string]string)
+	for k, v := range annotations {
+		if k != charmStateAnnotationKey {
+			result[k] = v
+		}
+	}
+	return result, charmState, nil
+}
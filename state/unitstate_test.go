@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type UnitStateSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitStateSuite{})
+
+func (s *UnitStateSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = factory.NewFactory(s.State).MakeUnit(c, nil)
+}
+
+func (s *UnitStateSuite) TestCharmStateEmpty(c *gc.C) {
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+}
+
+func (s *UnitStateSuite) TestSetCharmState(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"leader.id": "3", "$peers": "a,b"})
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"leader.id": "3", "$peers": "a,b"})
+
+	err = s.unit.SetCharmState(map[string]string{"bootstrapped": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err = s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"bootstrapped": "true"})
+}
+
+func (s *UnitStateSuite) TestSetCharmStateDying(c *gc.C) {
+	// A unit whose agent has started is left dying when destroyed.
+	now := coretesting.NonZeroTime()
+	err := s.unit.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Life(), gc.Equals, state.Dying)
+
+	err = s.unit.SetCharmState(map[string]string{"stopped": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitStateSuite) TestSetCharmStateDead(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmState(map[string]string{"stopped": "true"})
+	c.Assert(err, gc.ErrorMatches, `cannot set charm state for unit ".*": not found or dead`)
+}
+
+func (s *UnitStateSuite) TestCharmStateRemovedWithUnit(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"stopped": "true"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := s.MgoSuite.Session.DB("juju").C("unitstates").Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -855,3 +856,74 @@ func SplitLogCollections(st *State) error {
 	}
 	return nil
 }
+
+// UpgradeAuditLogCollection converts the audit entry timestamps stored
+// as text by earlier versions to dates, then caps the audit log
+// collection and creates its indexes, as done for new controllers.
+func UpgradeAuditLogCollection(st *State) error {
+	coll, closer := st.getRawCollection(auditingC)
+	defer closer()
+
+	// The timestamps are converted first, as documents in a capped
+	// collection cannot change size.
+	iter := coll.Find(bson.D{{"timestamp", bson.D{{"$type", "string"}}}}).Iter()
+	var doc struct {
+		Id        bson.ObjectId `bson:"_id"`
+		Timestamp string        `bson:"timestamp"`
+	}
+	for iter.Next(&doc) {
+		var timestamp time.Time
+		if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+			return errors.Annotatef(err, "parsing timestamp of audit entry %q", doc.Id.Hex())
+		}
+		err := coll.UpdateId(doc.Id, bson.D{{"$set", bson.D{{"timestamp", timestamp.UTC()}}}})
+		if err != nil {
+			return errors.Annotatef(err, "updating audit entry %q", doc.Id.Hex())
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Annotate(err, "reading audit entries")
+	}
+
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	maxBytes := auditLogSize
+	if _, ok := cfg[controller.AuditLogMaxSize]; ok {
+		maxBytes = cfg.AuditLogMaxSizeMB() * 1024 * 1024
+	}
+	collNames, err := coll.Database.CollectionNames()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !set.NewStrings(collNames...).Contains(auditingC) {
+		spec := &mgo.CollectionInfo{Capped: true, MaxBytes: maxBytes}
+		if err := createCollection(coll, spec); err != nil {
+			return errors.Annotate(err, "creating audit log collection")
+		}
+	} else {
+		var stats struct {
+			Capped bool `bson:"capped"`
+		}
+		if err := coll.Database.Run(bson.D{{"collStats", auditingC}}, &stats); err != nil {
+			return errors.Annotate(err, "reading audit log collection stats")
+		}
+		if !stats.Capped {
+			err := coll.Database.Run(bson.D{
+				{"convertToCapped", auditingC},
+				{"size", maxBytes},
+			}, nil)
+			if err != nil {
+				return errors.Annotate(err, "capping audit log collection")
+			}
+		}
+	}
+	// Converting the collection drops its indexes.
+	for _, index := range allCollections()[auditingC].indexes {
+		if err := coll.EnsureIndex(index); err != nil {
+			return errors.Annotate(err, "creating audit log index")
+		}
+	}
+	return nil
+}
@@ -1413,3 +1413,57 @@ func (s *upgradesSuite) TestSplitLogsHandlesNoLogsCollection(c *gc.C) {
 	err = SplitLogCollections(s.state)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *upgradesSuite) TestUpgradeAuditLogCollection(c *gc.C) {
+	coll, closer := s.state.getRawCollection(auditingC)
+	defer closer()
+
+	// Replace the audit log with an uncapped collection holding an
+	// entry written by an earlier version.
+	err := coll.DropCollection()
+	c.Assert(err, jc.ErrorIsNil)
+	t0 := time.Date(2017, time.September, 26, 12, 0, 0, 0, time.FixedZone("NZDT", 13*60*60))
+	text, err := t0.MarshalText()
+	c.Assert(err, jc.ErrorIsNil)
+	id := bson.NewObjectId()
+	err = coll.Insert(bson.M{"_id": id, "timestamp": string(text), "operation": "Client:v1 - FullStatus"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	check := func() {
+		var doc struct {
+			Timestamp time.Time `bson:"timestamp"`
+		}
+		err := coll.FindId(id).One(&doc)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(doc.Timestamp.Equal(t0), jc.IsTrue)
+
+		var stats struct {
+			Capped bool `bson:"capped"`
+		}
+		err = coll.Database.Run(bson.D{{"collStats", auditingC}}, &stats)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(stats.Capped, jc.IsTrue)
+
+		indexes, err := coll.Indexes()
+		c.Assert(err, jc.ErrorIsNil)
+		var keys [][]string
+		for _, index := range indexes {
+			keys = append(keys, index.Key)
+		}
+		c.Check(keys, jc.SameContents, [][]string{
+			{"_id"},
+			{"model-uuid", "timestamp"},
+			{"origin-name", "timestamp"},
+			{"timestamp"},
+		})
+	}
+
+	err = UpgradeAuditLogCollection(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	check()
+
+	// Running it again is fine.
+	err = UpgradeAuditLogCollection(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	check()
+}
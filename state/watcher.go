@@ -913,18 +913,34 @@ func (w *RelationScopeWatcher) loop() error {
 // relationUnitsWatcher sends notifications of units entering and leaving the
 // scope of a RelationUnit, and changes to the settings of those units known
 // to have entered.
+//
+// Changes to the application-level settings of the watched units'
+// application are reported as changes to the settings of every unit in
+// scope: the reported version of each unit's settings is the sum of the
+// versions of its own settings and of the application settings.
 type relationUnitsWatcher struct {
 	commonWatcher
-	sw       *RelationScopeWatcher
-	watching set.Strings
-	updates  chan watcher.Change
-	out      chan params.RelationUnitsChange
+	sw         *RelationScopeWatcher
+	watching   set.Strings
+	updates    chan watcher.Change
+	appKey     string
+	appVersion int64
+	appUpdates chan watcher.Change
+	out        chan params.RelationUnitsChange
 }
 
 // Watch returns a watcher that notifies of changes to conterpart units in
 // the relation.
 func (ru *RelationUnit) Watch() RelationUnitsWatcher {
-	return newRelationUnitsWatcher(ru.st, ru.WatchScope())
+	var appKey string
+	role := counterpartRole(ru.endpoint.Role)
+	for _, ep := range ru.relation.Endpoints() {
+		if ep.Role == role {
+			appKey = ru.relation.applicationSettingsKey(ep.ApplicationName)
+			break
+		}
+	}
+	return newRelationUnitsWatcher(ru.st, ru.WatchScope(), appKey)
 }
 
 // WatchUnits returns a watcher that notifies of changes to the units of the
@@ -946,16 +962,29 @@ func (r *Relation) watchUnits(applicationName string, counterpart bool) (Relatio
 	if counterpart {
 		role = counterpartRole(role)
 	}
+	var appKey string
+	for _, ep := range r.doc.Endpoints {
+		if ep.Role == role {
+			appKey = r.applicationSettingsKey(ep.ApplicationName)
+			break
+		}
+	}
 	rsw := watchRelationScope(r.st, r.globalScope(), role, "")
-	return newRelationUnitsWatcher(r.st, rsw), nil
+	return newRelationUnitsWatcher(r.st, rsw, appKey), nil
 }
 
-func newRelationUnitsWatcher(backend modelBackend, sw *RelationScopeWatcher) RelationUnitsWatcher {
+// newRelationUnitsWatcher returns a watcher for the units in the scope
+// watched by sw. If appKey is not empty, it is the key of the settings
+// document holding the application-level settings of the units'
+// application.
+func newRelationUnitsWatcher(backend modelBackend, sw *RelationScopeWatcher, appKey string) RelationUnitsWatcher {
 	w := &relationUnitsWatcher{
 		commonWatcher: newCommonWatcher(backend),
 		sw:            sw,
 		watching:      make(set.Strings),
 		updates:       make(chan watcher.Change),
+		appKey:        appKey,
+		appUpdates:    make(chan watcher.Change),
 		out:           make(chan params.RelationUnitsChange),
 	}
 	go func() {
@@ -997,10 +1026,42 @@ func (w *relationUnitsWatcher) mergeSettings(changes *params.RelationUnitsChange
 	if err := readSettingsDocInto(w.backend, settingsC, key, &doc); err != nil {
 		return -1, err
 	}
-	setRelationUnitChangeVersion(changes, key, doc.Version)
+	setRelationUnitChangeVersion(changes, key, doc.Version+w.appVersion)
 	return doc.TxnRevno, nil
 }
 
+// readAppSettings reads the version of the application settings node,
+// and returns its mgo/txn revision number; or -1 if it does not exist.
+func (w *relationUnitsWatcher) readAppSettings() (int64, error) {
+	var doc struct {
+		TxnRevno int64 `bson:"txn-revno"`
+		Version  int64 `bson:"version"`
+	}
+	err := readSettingsDocInto(w.backend, settingsC, w.appKey, &doc)
+	if errors.IsNotFound(err) {
+		w.appVersion = 0
+		return -1, nil
+	} else if err != nil {
+		return -1, err
+	}
+	w.appVersion = doc.Version
+	return doc.TxnRevno, nil
+}
+
+// mergeAppSettings reports all units known to be in scope as changed,
+// following a change to the application settings.
+func (w *relationUnitsWatcher) mergeAppSettings(changes *params.RelationUnitsChange) error {
+	if _, err := w.readAppSettings(); err != nil {
+		return err
+	}
+	for _, docID := range w.watching.Values() {
+		if _, err := w.mergeSettings(changes, docID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // mergeScope starts and stops settings watches on the units entering and
 // leaving the scope in the supplied RelationScopeChange event, and applies
 // the expressed changes to the supplied RelationUnitsChange event.
@@ -1045,7 +1106,11 @@ func (w *relationUnitsWatcher) finish() {
 	for _, watchedValue := range w.watching.Values() {
 		w.watcher.Unwatch(settingsC, watchedValue, w.updates)
 	}
+	if w.appKey != "" {
+		w.watcher.Unwatch(settingsC, w.backend.docID(w.appKey), w.appUpdates)
+	}
 	close(w.updates)
+	close(w.appUpdates)
 	close(w.out)
 	w.tomb.Done()
 }
@@ -1056,6 +1121,13 @@ func (w *relationUnitsWatcher) loop() (err error) {
 		changes     params.RelationUnitsChange
 		out         chan<- params.RelationUnitsChange
 	)
+	if w.appKey != "" {
+		revno, err := w.readAppSettings()
+		if err != nil {
+			return err
+		}
+		w.watcher.Watch(settingsC, w.backend.docID(w.appKey), revno, w.appUpdates)
+	}
 	for {
 		select {
 		case <-w.watcher.Dead():
@@ -1083,6 +1155,14 @@ func (w *relationUnitsWatcher) loop() (err error) {
 				return err
 			}
 			out = w.out
+		case <-w.appUpdates:
+			if err := w.mergeAppSettings(&changes); err != nil {
+				return err
+			}
+			// The initial event is sent once the scope is known.
+			if sentInitial && !emptyRelationUnitsChanges(&changes) {
+				out = w.out
+			}
 		case out <- changes:
 			sentInitial = true
 			changes = params.RelationUnitsChange{}
@@ -11,6 +11,10 @@ import (
 	"github.com/juju/utils/set"
 )
 
+// HistoryLogModule is the module of the log records written for status
+// history entries, so that they may be sent to a log forwarding target.
+const HistoryLogModule = "juju.status-history"
+
 // StatusHistoryFilter holds arguments that can be use to filter a status history backlog.
 type StatusHistoryFilter struct {
 	// Size indicates how many results are expected at most.
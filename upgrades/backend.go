@@ -30,6 +30,7 @@ type StateBackend interface {
 	AddStatusHistoryPruneSettings() error
 	AddStorageInstanceConstraints() error
 	SplitLogCollections() error
+	UpgradeAuditLogCollection() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -112,6 +113,10 @@ func (s stateBackend) SplitLogCollections() error {
 	return state.SplitLogCollections(s.st)
 }
 
+func (s stateBackend) UpgradeAuditLogCollection() error {
+	return state.UpgradeAuditLogCollection(s.st)
+}
+
 type modelShim struct {
 	st *state.State
 	m  *state.Model
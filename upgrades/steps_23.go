@@ -0,0 +1,17 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+// stateStepsFor23 returns upgrade steps for Juju 2.3 that manipulate state directly.
+func stateStepsFor23() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "cap audit log collection and store timestamps as dates",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().UpgradeAuditLogCollection()
+			},
+		},
+	}
+}
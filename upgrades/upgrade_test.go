@@ -720,6 +720,7 @@ func (s *upgradeSuite) TestStateUpgradeOperationsVersions(c *gc.C) {
 		"2.0.0",
 		"2.1.0",
 		"2.2.0",
+		"2.3.0",
 	})
 }
 
@@ -0,0 +1,179 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides a worker that enqueues scheduled
+// actions on their receivers when they are due.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// RetryDelay is how long the worker waits before trying again to run
+// action schedules that could not be run.
+const RetryDelay = time.Minute
+
+// Facade represents an API that implements action scheduling.
+type Facade interface {
+	WatchActionSchedules() (watcher.NotifyWatcher, error)
+	ActionSchedules() ([]params.ActionSchedule, error)
+	RunActionSchedules(ids []string) ([]error, error)
+}
+
+// Config holds all necessary attributes to start an action scheduler
+// worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Facade == nil {
+		return errors.New("missing Facade")
+	}
+	if c.Clock == nil {
+		return errors.New("missing Clock")
+	}
+	return nil
+}
+
+// New returns a worker.Worker that enqueues scheduled actions.
+func New(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w := &Worker{
+		config: conf,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// NewFacade returns a new action scheduler facade.
+func NewFacade(caller base.APICaller) Facade {
+	return actionscheduler.NewAPI(caller)
+}
+
+// Worker enqueues scheduled actions when they are due.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	schedulesWatcher, err := w.config.Facade.WatchActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(schedulesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var timer clock.Timer
+	var timerCh <-chan time.Time
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case _, ok := <-schedulesWatcher.Changes():
+			if !ok {
+				return errors.New("action schedules watcher closed")
+			}
+		case <-timerCh:
+			timerCh = nil
+		}
+
+		wait, err := w.runDueSchedules()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if timer != nil {
+			timer.Stop()
+			timerCh = nil
+		}
+		if wait > 0 {
+			logger.Debugf("next action schedule due in %v", wait)
+			timer = w.config.Clock.NewTimer(wait)
+			timerCh = timer.Chan()
+		}
+	}
+}
+
+// runDueSchedules runs the action schedules that are due, and returns
+// how long to wait until the next one is, or zero if there are none.
+func (w *Worker) runDueSchedules() (time.Duration, error) {
+	schedules, err := w.config.Facade.ActionSchedules()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot get action schedules")
+	}
+	now := w.config.Clock.Now()
+	var due []string
+	var wait time.Duration
+	for _, schedule := range schedules {
+		if !schedule.NextRun.After(now) {
+			due = append(due, schedule.Id)
+			continue
+		}
+		if d := schedule.NextRun.Sub(now); wait == 0 || d < wait {
+			wait = d
+		}
+	}
+	if len(due) == 0 {
+		return wait, nil
+	}
+
+	errs, err := w.config.Facade.RunActionSchedules(due)
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot run action schedules")
+	}
+	for i, err := range errs {
+		if err == nil {
+			logger.Infof("ran action schedule %q", due[i])
+			continue
+		}
+		if params.IsCodeNotFound(err) {
+			// The schedule was removed since we listed it.
+			continue
+		}
+		logger.Errorf("cannot run action schedule %q: %v", due[i], err)
+		if wait == 0 || RetryDelay < wait {
+			wait = RetryDelay
+		}
+	}
+	return wait, nil
+}
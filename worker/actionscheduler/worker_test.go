@@ -0,0 +1,190 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/watcher"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type workerSuite struct {
+	coretesting.BaseSuite
+	clock  *testing.Clock
+	facade *fakeFacade
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.facade = &fakeFacade{
+		watcher: &mockNotifyWatcher{
+			changes: make(chan struct{}, 1),
+			stopped: make(chan struct{}),
+		},
+		ran: make(chan []string, 1),
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C) {
+	w, err := actionscheduler.New(actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		workertest.CleanKill(c, w)
+	})
+}
+
+func (s *workerSuite) assertRan(c *gc.C, expect ...string) {
+	select {
+	case ids := <-s.facade.ran:
+		c.Assert(ids, jc.DeepEquals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for schedules to run")
+	}
+}
+
+func (s *workerSuite) assertNotRan(c *gc.C) {
+	select {
+	case ids := <-s.facade.ran:
+		c.Fatalf("unexpected run of %v", ids)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	_, err := actionscheduler.New(actionscheduler.Config{Clock: s.clock})
+	c.Check(err, gc.ErrorMatches, "missing Facade")
+	_, err = actionscheduler.New(actionscheduler.Config{Facade: s.facade})
+	c.Check(err, gc.ErrorMatches, "missing Clock")
+}
+
+func (s *workerSuite) TestRunsDueSchedules(c *gc.C) {
+	now := s.clock.Now()
+	s.facade.setSchedules(
+		params.ActionSchedule{Id: "0", NextRun: now.Add(-time.Minute)},
+		params.ActionSchedule{Id: "1", NextRun: now},
+		params.ActionSchedule{Id: "2", NextRun: now.Add(time.Hour)},
+	)
+	s.startWorker(c)
+	s.facade.watcher.changes <- struct{}{}
+	s.assertRan(c, "0", "1")
+	s.assertNotRan(c)
+}
+
+func (s *workerSuite) TestRunsWhenNextDue(c *gc.C) {
+	now := s.clock.Now()
+	s.facade.setSchedules(
+		params.ActionSchedule{Id: "0", NextRun: now.Add(time.Hour)},
+		params.ActionSchedule{Id: "1", NextRun: now.Add(2 * time.Hour)},
+	)
+	s.startWorker(c)
+	s.facade.watcher.changes <- struct{}{}
+
+	err := s.clock.WaitAdvance(time.Hour-time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNotRan(c)
+
+	s.clock.Advance(time.Second)
+	s.assertRan(c, "0")
+}
+
+func (s *workerSuite) TestRetriesFailedSchedules(c *gc.C) {
+	s.facade.setSchedules(params.ActionSchedule{Id: "0", NextRun: s.clock.Now()})
+	s.facade.runErrors = []error{&params.Error{Message: "boom"}}
+	s.startWorker(c)
+	s.facade.watcher.changes <- struct{}{}
+	s.assertRan(c, "0")
+
+	err := s.clock.WaitAdvance(actionscheduler.RetryDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRan(c, "0")
+}
+
+func (s *workerSuite) TestFacadeError(c *gc.C) {
+	s.facade.listErr = errors.New("boom")
+	w, err := actionscheduler.New(actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	s.facade.watcher.changes <- struct{}{}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot get action schedules: boom")
+}
+
+type fakeFacade struct {
+	mu        sync.Mutex
+	watcher   *mockNotifyWatcher
+	schedules []params.ActionSchedule
+	listErr   error
+	runErrors []error
+	ran       chan []string
+}
+
+func (f *fakeFacade) setSchedules(schedules ...params.ActionSchedule) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.schedules = schedules
+}
+
+// WatchActionSchedules is part of the Facade interface.
+func (f *fakeFacade) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	return f.watcher, nil
+}
+
+// ActionSchedules is part of the Facade interface.
+func (f *fakeFacade) ActionSchedules() ([]params.ActionSchedule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.schedules, f.listErr
+}
+
+// RunActionSchedules is part of the Facade interface.
+func (f *fakeFacade) RunActionSchedules(ids []string) ([]error, error) {
+	f.ran <- ids
+	errs := make([]error, len(ids))
+	copy(errs, f.runErrors)
+	return errs, nil
+}
+
+type mockNotifyWatcher struct {
+	mu      sync.Mutex
+	changes chan struct{}
+	stopped chan struct{}
+}
+
+func (w *mockNotifyWatcher) Changes() watcher.NotifyChannel {
+	return w.changes
+}
+
+func (w *mockNotifyWatcher) Kill() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	select {
+	case <-w.stopped:
+	default:
+		close(w.stopped)
+	}
+}
+
+func (w *mockNotifyWatcher) Wait() error {
+	<-w.stopped
+	return nil
+}
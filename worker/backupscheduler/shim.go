@@ -0,0 +1,117 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/backups/target"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+// NewStateBackups returns a Backups that creates backups of the
+// controller as the backups facade does, recording the given machine
+// as their origin.
+func NewStateBackups(st *state.State, paths backups.Paths, machineID string) Backups {
+	return &stateBackups{
+		st:        st,
+		paths:     paths,
+		machineID: machineID,
+	}
+}
+
+type stateBackups struct {
+	st        *state.State
+	paths     backups.Paths
+	machineID string
+}
+
+// Create is part of the Backups interface.
+func (b *stateBackups) Create() (*backups.Metadata, error) {
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return nil, errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(b.st.MongoConnectionInfo(), session, mongoVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := b.st.Machine(b.machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	controllerConfig, err := b.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	meta, err := backups.NewMetadataState(b.st, b.machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = "scheduled backup"
+	meta.Scheduled = true
+
+	backupsMethods := backups.NewBackups(stor)
+	key := controllerConfig.BackupEncryptionKey()
+	if err := backupsMethods.Create(meta, &b.paths, dbInfo, key); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// The backup is stored on the controller, so failing to copy it
+	// to the backup target doesn't fail the scheduled backup.
+	if err := copyToTarget(controllerConfig, backupsMethods, meta.ID()); err != nil {
+		logger.Errorf("cannot copy scheduled backup %q to backup target: %v", meta.ID(), err)
+	}
+	return meta, nil
+}
+
+func copyToTarget(controllerConfig controller.Config, backupsMethods backups.Backups, id string) error {
+	t, err := target.New(controllerConfig)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return target.Export(t, backupsMethods, id)
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]*backups.Metadata, error) {
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	return backups.NewBackups(stor).List()
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	return backups.NewBackups(stor).Remove(id)
+}
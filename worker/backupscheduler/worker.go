@@ -0,0 +1,230 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker that creates backups of
+// the controller on a schedule, and prunes the scheduled backups that
+// fall outside the configured retention policy.
+//
+// The machine agent only starts the worker if backup-schedule is set in
+// the controller config when the agent starts; changes to the schedule
+// or retention policy take effect when the agent is next restarted.
+package backupscheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// Backups exposes the backup operations needed by the worker.
+type Backups interface {
+	// Create creates and stores a new scheduled backup of the
+	// controller, and returns its metadata.
+	Create() (*backups.Metadata, error)
+
+	// List returns the metadata for all stored backups.
+	List() ([]*backups.Metadata, error)
+
+	// Remove deletes the stored backup with the given ID.
+	Remove(id string) error
+}
+
+// Retention describes which scheduled backups are kept when the
+// others are pruned. A backup is kept if it satisfies any of the
+// rules.
+type Retention struct {
+	// KeepLast is the number of the most recent backups to keep.
+	KeepLast int
+
+	// KeepDaily is the number of days for which the most recent
+	// backup of each day is kept.
+	KeepDaily int
+
+	// KeepWeekly is the number of weeks for which the most recent
+	// backup of each week is kept.
+	KeepWeekly int
+}
+
+// Config holds all necessary attributes to start a backup scheduler
+// worker.
+type Config struct {
+	Backups   Backups
+	Schedule  actions.Schedule
+	Retention Retention
+	Clock     clock.Clock
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Backups == nil {
+		return errors.New("missing Backups")
+	}
+	if c.Schedule == nil {
+		return errors.New("missing Schedule")
+	}
+	if c.Clock == nil {
+		return errors.New("missing Clock")
+	}
+	r := c.Retention
+	if r.KeepLast < 0 || r.KeepDaily < 0 || r.KeepWeekly < 0 {
+		return errors.New("negative Retention")
+	}
+	return nil
+}
+
+// New returns a worker.Worker that creates backups of the controller
+// on the configured schedule.
+func New(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w := &Worker{
+		config: conf,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// Worker creates scheduled backups and prunes old ones.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		now := w.config.Clock.Now()
+		next := w.config.Schedule.Next(now)
+		if next.IsZero() {
+			logger.Infof("backup schedule %q is never due", w.config.Schedule)
+			<-w.catacomb.Dying()
+			return w.catacomb.ErrDying()
+		}
+		wait := next.Sub(now)
+		logger.Debugf("next scheduled backup due in %v", wait)
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(wait):
+		}
+
+		// A failed backup or prune must not stop later backups from
+		// being created, so errors are only logged.
+		meta, err := w.config.Backups.Create()
+		if err != nil {
+			logger.Errorf("cannot create scheduled backup: %v", err)
+			continue
+		}
+		logger.Infof("created scheduled backup %q", meta.ID())
+		if err := w.prune(); err != nil {
+			logger.Errorf("cannot prune scheduled backups: %v", err)
+		}
+	}
+}
+
+// prune removes the scheduled backups that are not kept by the
+// configured retention policy.
+func (w *Worker) prune() error {
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return errors.Annotate(err, "listing backups")
+	}
+	for _, id := range expired(all, w.config.Retention) {
+		if err := w.config.Backups.Remove(id); err != nil {
+			return errors.Annotatef(err, "removing backup %q", id)
+		}
+		logger.Infof("removed expired scheduled backup %q", id)
+	}
+	return nil
+}
+
+// expired returns the IDs of the scheduled backups in the given list
+// that are not kept by the retention policy. Backups that were not
+// created by the schedule are never expired, and the newest scheduled
+// backup is always kept so that pruning cannot remove the backup that
+// was just created.
+func expired(all []*backups.Metadata, retention Retention) []string {
+	var scheduled []*backups.Metadata
+	for _, meta := range all {
+		if meta.Scheduled {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	sort.Sort(byStartedDesc(scheduled))
+
+	keep := make(map[string]bool)
+	if len(scheduled) > 0 {
+		keep[scheduled[0].ID()] = true
+	}
+	for i := 0; i < retention.KeepLast && i < len(scheduled); i++ {
+		keep[scheduled[i].ID()] = true
+	}
+	keepNewestPerPeriod(scheduled, retention.KeepDaily, keep, func(t time.Time) interface{} {
+		year, month, day := t.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	})
+	keepNewestPerPeriod(scheduled, retention.KeepWeekly, keep, func(t time.Time) interface{} {
+		year, week := t.ISOWeek()
+		return [2]int{year, week}
+	})
+
+	var ids []string
+	for _, meta := range scheduled {
+		if !keep[meta.ID()] {
+			ids = append(ids, meta.ID())
+		}
+	}
+	return ids
+}
+
+// keepNewestPerPeriod marks the newest backup in each of the n most
+// recent periods as kept. The backups must be sorted newest first, and
+// period returns the period that a UTC time falls within.
+func keepNewestPerPeriod(
+	sorted []*backups.Metadata, n int, keep map[string]bool,
+	period func(time.Time) interface{},
+) {
+	seen := make(map[interface{}]bool)
+	for _, meta := range sorted {
+		if len(seen) >= n {
+			return
+		}
+		p := period(meta.Started.UTC())
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		keep[meta.ID()] = true
+	}
+}
+
+type byStartedDesc []*backups.Metadata
+
+func (b byStartedDesc) Len() int           { return len(b) }
+func (b byStartedDesc) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byStartedDesc) Less(i, j int) bool { return b[i].Started.After(b[j].Started) }
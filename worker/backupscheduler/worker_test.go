@@ -0,0 +1,278 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state/backups"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type workerSuite struct {
+	coretesting.BaseSuite
+	clock   *testing.Clock
+	backups *fakeBackups
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.backups = &fakeBackups{
+		clock:   s.clock,
+		created: make(chan string, 1),
+	}
+}
+
+func (s *workerSuite) startWorker(c *gc.C, retention backupscheduler.Retention) {
+	schedule, err := actions.ParseSchedule("@daily")
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := backupscheduler.New(backupscheduler.Config{
+		Backups:   s.backups,
+		Schedule:  schedule,
+		Retention: retention,
+		Clock:     s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		workertest.CleanKill(c, w)
+	})
+}
+
+func (s *workerSuite) assertCreated(c *gc.C) string {
+	var id string
+	select {
+	case id = <-s.backups.created:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for backup to be created")
+	}
+	return id
+}
+
+func (s *workerSuite) assertNotCreated(c *gc.C) {
+	select {
+	case id := <-s.backups.created:
+		c.Fatalf("unexpected backup %q", id)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	schedule, err := actions.ParseSchedule("@daily")
+	c.Assert(err, jc.ErrorIsNil)
+	for i, test := range []struct {
+		config backupscheduler.Config
+		err    string
+	}{{
+		config: backupscheduler.Config{Schedule: schedule, Clock: s.clock},
+		err:    "missing Backups",
+	}, {
+		config: backupscheduler.Config{Backups: s.backups, Clock: s.clock},
+		err:    "missing Schedule",
+	}, {
+		config: backupscheduler.Config{Backups: s.backups, Schedule: schedule},
+		err:    "missing Clock",
+	}, {
+		config: backupscheduler.Config{
+			Backups:   s.backups,
+			Schedule:  schedule,
+			Clock:     s.clock,
+			Retention: backupscheduler.Retention{KeepDaily: -1},
+		},
+		err: "negative Retention",
+	}} {
+		c.Logf("test %d", i)
+		_, err := backupscheduler.New(test.config)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *workerSuite) TestCreatesWhenDue(c *gc.C) {
+	s.startWorker(c, backupscheduler.Retention{KeepLast: 1})
+
+	err := s.clock.WaitAdvance(12*time.Hour-time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNotCreated(c)
+
+	s.clock.Advance(time.Second)
+	first := s.assertCreated(c)
+
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	second := s.assertCreated(c)
+
+	// Only the most recent backup is kept.
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backups.ids(), jc.DeepEquals, []string{second})
+	c.Assert(first, gc.Not(gc.Equals), second)
+}
+
+func (s *workerSuite) TestPruneKeepsUnscheduled(c *gc.C) {
+	s.backups.add("manual", s.clock.Now().Add(-time.Hour), false)
+	s.startWorker(c, backupscheduler.Retention{})
+
+	err := s.clock.WaitAdvance(12*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	id := s.assertCreated(c)
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.backups.ids(), jc.SameContents, []string{"manual", id})
+}
+
+func (s *workerSuite) TestCreateErrorNotFatal(c *gc.C) {
+	s.backups.createErr = errors.New("boom")
+	s.startWorker(c, backupscheduler.Retention{KeepLast: 1})
+
+	err := s.clock.WaitAdvance(12*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCreated(c)
+
+	// The worker carries on and tries again at the next scheduled time.
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCreated(c)
+}
+
+type expiredSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&expiredSuite{})
+
+// dailyBackups returns scheduled backups taken at 02:00 UTC on each of
+// the n days up to and including Sunday 2017-06-04, newest first.
+func dailyBackups(n int) []*backups.Metadata {
+	last := time.Date(2017, 6, 4, 2, 0, 0, 0, time.UTC)
+	var metas []*backups.Metadata
+	for i := 0; i < n; i++ {
+		started := last.AddDate(0, 0, -i)
+		metas = append(metas, newMetadata(started.Format("2006-01-02"), started, true))
+	}
+	return metas
+}
+
+func (s *expiredSuite) TestKeepLast(c *gc.C) {
+	expired := backupscheduler.Expired(dailyBackups(5), backupscheduler.Retention{KeepLast: 3})
+	c.Assert(expired, jc.DeepEquals, []string{"2017-06-01", "2017-05-31"})
+}
+
+func (s *expiredSuite) TestAlwaysKeepsNewest(c *gc.C) {
+	expired := backupscheduler.Expired(dailyBackups(3), backupscheduler.Retention{})
+	c.Assert(expired, jc.DeepEquals, []string{"2017-06-03", "2017-06-02"})
+}
+
+func (s *expiredSuite) TestKeepDaily(c *gc.C) {
+	metas := dailyBackups(3)
+	// A second backup on the most recent day is superseded by the
+	// later one.
+	metas = append(metas, newMetadata("early", time.Date(2017, 6, 4, 1, 0, 0, 0, time.UTC), true))
+	expired := backupscheduler.Expired(metas, backupscheduler.Retention{KeepDaily: 2})
+	c.Assert(expired, jc.DeepEquals, []string{"early", "2017-06-02"})
+}
+
+func (s *expiredSuite) TestKeepWeekly(c *gc.C) {
+	// 2017-06-04 is a Sunday, so the 21 backups cover the ISO weeks
+	// starting on 15, 22 and 29 May.
+	metas := dailyBackups(21)
+	expired := backupscheduler.Expired(metas, backupscheduler.Retention{
+		KeepLast:   1,
+		KeepWeekly: 2,
+	})
+	var expect []string
+	for _, meta := range metas {
+		if id := meta.ID(); id != "2017-06-04" && id != "2017-05-28" {
+			expect = append(expect, id)
+		}
+	}
+	c.Assert(expired, jc.DeepEquals, expect)
+}
+
+func (s *expiredSuite) TestIgnoresUnscheduled(c *gc.C) {
+	metas := []*backups.Metadata{
+		newMetadata("manual", time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), false),
+	}
+	c.Assert(backupscheduler.Expired(metas, backupscheduler.Retention{}), gc.HasLen, 0)
+}
+
+func newMetadata(id string, started time.Time, scheduled bool) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Scheduled = scheduled
+	return meta
+}
+
+type fakeBackups struct {
+	mu        sync.Mutex
+	clock     *testing.Clock
+	metas     []*backups.Metadata
+	count     int
+	createErr error
+	created   chan string
+}
+
+func (f *fakeBackups) add(id string, started time.Time, scheduled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.metas = append(f.metas, newMetadata(id, started, scheduled))
+}
+
+func (f *fakeBackups) ids() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var ids []string
+	for _, meta := range f.metas {
+		ids = append(ids, meta.ID())
+	}
+	return ids
+}
+
+// Create is part of the Backups interface.
+func (f *fakeBackups) Create() (*backups.Metadata, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.count++
+	id := fmt.Sprintf("backup-%d", f.count)
+	defer func() { f.created <- id }()
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	meta := newMetadata(id, f.clock.Now(), true)
+	f.metas = append(f.metas, meta)
+	return meta, nil
+}
+
+// List is part of the Backups interface.
+func (f *fakeBackups) List() ([]*backups.Metadata, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*backups.Metadata(nil), f.metas...), nil
+}
+
+// Remove is part of the Backups interface.
+func (f *fakeBackups) Remove(id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, meta := range f.metas {
+		if meta.ID() == id {
+			f.metas = append(f.metas[:i], f.metas[i+1:]...)
+			return nil
+		}
+	}
+	return errors.NotFoundf("backup %q", id)
+}
@@ -76,7 +76,7 @@ type OpenLogForwarderArgs struct {
 	OpenLogStream LogStreamFn
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -118,6 +118,7 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 		Config:   cfg,
 		Caller:   lf.args.Caller,
 		OpenSink: lf.args.OpenSink,
+		Abort:    lf.catacomb.Dying(),
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -145,7 +146,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to log sink")
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -230,7 +231,7 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
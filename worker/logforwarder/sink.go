@@ -4,7 +4,7 @@
 package logforwarder
 
 import (
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
 	"github.com/juju/juju/watcher"
 )
 
@@ -15,7 +15,7 @@ type LogForwardConfig interface {
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
 	// LogForwardConfig returns the current log forward configuration.
-	LogForwardConfig() (*syslog.RawConfig, bool, error)
+	LogForwardConfig() (*target.Config, bool, error)
 }
 
 type LogSinkSpec struct {
@@ -26,8 +26,10 @@ type LogSinkSpec struct {
 	OpenFn LogSinkFn
 }
 
-// LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+// LogSinkFn is a function that opens a log sink. The abort channel is
+// closed when the sink's owner is stopping, so that the sink can give
+// up on any slow operations.
+type LogSinkFn func(cfg *target.Config, abort <-chan struct{}) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
@@ -0,0 +1,28 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/logfile"
+	"github.com/juju/juju/logfwd/target"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenFile returns a sink which writes the log messages to be
+// forwarded to a rotating file local to the controller, in the given
+// directory.
+func OpenFile(cfg *target.Config, dir string) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := logfile.Open(cfg.File, dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
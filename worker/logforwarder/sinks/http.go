@@ -0,0 +1,28 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/target"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenHTTP returns a sink which posts the log messages to be forwarded
+// to an HTTP endpoint, as JSON or as Loki push requests. Failed posts
+// are no longer retried once abort is closed.
+func OpenHTTP(cfg *target.Config, abort <-chan struct{}) (*logforwarder.LogSink, error) {
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := httpjson.Open(cfg.HTTPConfig(), abort)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
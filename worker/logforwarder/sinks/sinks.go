@@ -0,0 +1,29 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/target"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// NewOpenFunc returns a function that opens a sink of the type
+// selected in the given config, used to receive log messages to be
+// forwarded. The files written by the file target are created in
+// fileDir.
+func NewOpenFunc(fileDir string) logforwarder.LogSinkFn {
+	return func(cfg *target.Config, abort <-chan struct{}) (*logforwarder.LogSink, error) {
+		switch cfg.TargetType() {
+		case target.TypeSyslog:
+			return OpenSyslog(cfg)
+		case target.TypeHTTP, target.TypeLoki:
+			return OpenHTTP(cfg, abort)
+		case target.TypeFile:
+			return OpenFile(cfg, fileDir)
+		}
+		return nil, errors.NotValidf("log forwarding type %q", cfg.Type)
+	}
+}
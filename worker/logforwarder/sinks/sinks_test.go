@@ -0,0 +1,65 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
+	"github.com/juju/juju/logfwd/target"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type sinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&sinksSuite{})
+
+func (s *sinksSuite) TestOpenHTTP(c *gc.C) {
+	sink, err := sinks.NewOpenFunc(c.MkDir())(&target.Config{
+		Enabled: true,
+		Type:    target.TypeLoki,
+		HTTP:    httpjson.RawConfig{URL: "https://loki.example.com/loki/api/v1/push"},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	client, ok := sink.SendCloser.(*httpjson.Client)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(client.URL, gc.Equals, "https://loki.example.com/loki/api/v1/push")
+	c.Check(client.Format, gc.Equals, httpjson.FormatLoki)
+}
+
+func (s *sinksSuite) TestOpenFile(c *gc.C) {
+	dir := c.MkDir()
+	sink, err := sinks.NewOpenFunc(dir)(&target.Config{
+		Enabled: true,
+		Type:    target.TypeFile,
+		File:    logfile.RawConfig{Path: "forward.log"},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	defer sink.Close()
+	client, ok := sink.SendCloser.(*logfile.Client)
+	c.Assert(ok, jc.IsTrue)
+	writer, ok := client.Writer.(*lumberjack.Logger)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(writer.Filename, gc.Equals, filepath.Join(dir, "forward.log"))
+}
+
+func (s *sinksSuite) TestOpenNotEnabled(c *gc.C) {
+	for _, targetType := range []string{target.TypeSyslog, target.TypeHTTP, target.TypeLoki, target.TypeFile} {
+		_, err := sinks.NewOpenFunc(c.MkDir())(&target.Config{Type: targetType}, nil)
+		c.Check(err, gc.ErrorMatches, "log forwarding not enabled")
+	}
+}
+
+func (s *sinksSuite) TestOpenUnknownType(c *gc.C) {
+	_, err := sinks.NewOpenFunc(c.MkDir())(&target.Config{Enabled: true, Type: "carrier-pigeon"}, nil)
+	c.Assert(err, gc.ErrorMatches, `log forwarding type "carrier-pigeon" not valid`)
+}
@@ -8,15 +8,16 @@ import (
 
 	"github.com/juju/juju/logfwd"
 	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
 	"github.com/juju/juju/worker/logforwarder"
 )
 
 // OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
+func OpenSyslog(cfg *target.Config) (*logforwarder.LogSink, error) {
 	if !cfg.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
-	client, err := syslog.Open(*cfg)
+	client, err := syslog.Open(cfg.Syslog)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
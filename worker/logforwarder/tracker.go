@@ -10,13 +10,13 @@ import (
 	"github.com/juju/juju/api/base"
 	logfwdapi "github.com/juju/juju/api/logfwd"
 	"github.com/juju/juju/logfwd"
-	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/logfwd/target"
 )
 
 // TrackingSinkArgs holds the args to OpenTrackingSender.
 type TrackingSinkArgs struct {
 	// Config is the logging config that will be used.
-	Config *syslog.RawConfig
+	Config *target.Config
 
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
@@ -27,12 +27,16 @@ type TrackingSinkArgs struct {
 	// OpenSink is the function that opens the underlying log sink that
 	// will be wrapped.
 	OpenSink LogSinkFn
+
+	// Abort is closed when the sink should abandon any slow
+	// operations, such as retries.
+	Abort <-chan struct{}
 }
 
 // OpenTrackingSink opens a log record sender to use with a worker.
 // The sender also tracks records that were successfully sent.
 func OpenTrackingSink(args TrackingSinkArgs) (*LogSink, error) {
-	sink, err := args.OpenSink(args.Config)
+	sink, err := args.OpenSink(args.Config, args.Abort)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
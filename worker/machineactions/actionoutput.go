@@ -0,0 +1,151 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machineactions
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machineactions"
+)
+
+// actionOutputSendInterval is how often output written by a running
+// action is sent to the controller.
+const actionOutputSendInterval = time.Second
+
+// maxActionOutputChunkSize is the size to which consecutive writes to
+// the same stream are combined before they are sent. The controller
+// keeps a limited number of chunks for each action.
+const maxActionOutputChunkSize = 64 * 1024
+
+// actionOutput records the output written by a running action, and
+// periodically sends it to the controller so that it can be streamed
+// to clients while the action runs. Output that cannot be sent is
+// dropped, as streaming is only a convenience; the action's results
+// are recorded as usual when it finishes. It is copied from the
+// uniter's ActionOutput, as machineactions.Action is copied from the
+// uniter's Action.
+type actionOutput struct {
+	facade Facade
+	tag    names.ActionTag
+	clock  clock.Clock
+
+	mu          sync.Mutex
+	seq         int
+	pending     []machineactions.ActionOutput
+	unsupported bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newActionOutput returns an actionOutput that sends the output
+// written by the action with the given tag using the supplied facade.
+// The actionOutput must be closed when the action finishes.
+func newActionOutput(facade Facade, tag names.ActionTag, clock clock.Clock) *actionOutput {
+	o := &actionOutput{
+		facade: facade,
+		tag:    tag,
+		clock:  clock,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go o.loop()
+	return o
+}
+
+// Stdout returns a writer that records output written to the action's
+// standard output.
+func (o *actionOutput) Stdout() io.Writer {
+	return &actionOutputWriter{o, "stdout"}
+}
+
+// Stderr returns a writer that records output written to the action's
+// standard error.
+func (o *actionOutput) Stderr() io.Writer {
+	return &actionOutputWriter{o, "stderr"}
+}
+
+// Close sends any output that has not yet been sent, and stops the
+// actionOutput.
+func (o *actionOutput) Close() error {
+	close(o.stop)
+	<-o.done
+	return o.send()
+}
+
+func (o *actionOutput) record(stream string, data []byte) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.unsupported {
+		return
+	}
+	if n := len(o.pending); n > 0 {
+		last := &o.pending[n-1]
+		if last.Stream == stream && len(last.Data)+len(data) <= maxActionOutputChunkSize {
+			last.Data += string(data)
+			return
+		}
+	}
+	o.pending = append(o.pending, machineactions.ActionOutput{
+		Seq:       o.seq,
+		Stream:    stream,
+		Data:      string(data),
+		Timestamp: o.clock.Now(),
+	})
+	o.seq++
+}
+
+func (o *actionOutput) loop() {
+	defer close(o.done)
+	for {
+		select {
+		case <-o.stop:
+			return
+		case <-o.clock.After(actionOutputSendInterval):
+			if err := o.send(); err != nil {
+				logger.Warningf("cannot send output for action %q: %v", o.tag.Id(), err)
+			}
+		}
+	}
+}
+
+func (o *actionOutput) send() error {
+	o.mu.Lock()
+	pending := o.pending
+	o.pending = nil
+	o.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+	err := o.facade.AddActionOutput(o.tag, pending)
+	if errors.IsNotSupported(err) {
+		// The controller is too old to stream the output, so
+		// stop recording it.
+		logger.Debugf("not streaming output for action %q: %v", o.tag.Id(), err)
+		o.mu.Lock()
+		o.unsupported = true
+		o.mu.Unlock()
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// actionOutputWriter records everything written to it as output
+// written to one of the action's streams.
+type actionOutputWriter struct {
+	output *actionOutput
+	stream string
+}
+
+// Write is part of the io.Writer interface.
+func (w *actionOutputWriter) Write(data []byte) (int, error) {
+	w.output.record(w.stream, data)
+	return len(data), nil
+}
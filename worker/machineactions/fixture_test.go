@@ -6,6 +6,7 @@ package machineactions_test
 
 import (
 	"errors"
+	"io"
 
 	"github.com/juju/testing"
 	"gopkg.in/juju/names.v2"
@@ -19,8 +20,8 @@ import (
 
 var actionNotFoundErr = errors.New("action not found")
 
-func mockHandleAction(stub *testing.Stub) func(string, map[string]interface{}) (map[string]interface{}, error) {
-	return func(name string, params map[string]interface{}) (map[string]interface{}, error) {
+func mockHandleAction(stub *testing.Stub) func(string, map[string]interface{}, io.Writer, io.Writer) (map[string]interface{}, error) {
+	return func(name string, params map[string]interface{}, stdout, stderr io.Writer) (map[string]interface{}, error) {
 		stub.AddCall("HandleAction", name)
 		return nil, stub.NextErr()
 	}
@@ -63,6 +64,12 @@ func (mock *mockFacade) ActionFinish(tag names.ActionTag, status string, results
 	return mock.stub.NextErr()
 }
 
+// AddActionOutput is part of the machineactions.Facade interface.
+func (mock *mockFacade) AddActionOutput(tag names.ActionTag, output []machineactions.ActionOutput) error {
+	mock.stub.AddCall("AddActionOutput", tag, output)
+	return mock.stub.NextErr()
+}
+
 // Watch is part of the machineactions.Facade interface.
 func (mock *mockFacade) WatchActionNotifications(agent names.MachineTag) (watcher.StringsWatcher, error) {
 	mock.stub.AddCall("WatchActionNotifications", agent)
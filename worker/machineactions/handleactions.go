@@ -5,15 +5,21 @@
 package machineactions
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"os"
+	osexec "os/exec"
+	"strings"
+	"syscall"
 	"time"
 	"unicode/utf8"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/exec"
+	jujuos "github.com/juju/utils/os"
 
 	"github.com/juju/juju/core/actions"
 )
@@ -23,7 +29,9 @@ var RunAsUser = "ubuntu"
 
 // HandleAction receives a name and a map of parameters for a given machine action.
 // It will handle that action in a specific way and return a results map suitable for ActionFinish.
-func HandleAction(name string, params map[string]interface{}) (results map[string]interface{}, err error) {
+// If stdout and stderr are not nil, the output of the action is also written to them as it is
+// produced, so that it can be streamed to clients.
+func HandleAction(name string, params map[string]interface{}, stdout, stderr io.Writer) (results map[string]interface{}, err error) {
 	spec, ok := actions.PredefinedActionsSpec[name]
 	if !ok {
 		return nil, errors.Errorf("unexpected action %s", name)
@@ -34,13 +42,13 @@ func HandleAction(name string, params map[string]interface{}) (results map[strin
 
 	switch name {
 	case actions.JujuRunActionName:
-		return handleJujuRunAction(params)
+		return handleJujuRunAction(params, stdout, stderr)
 	default:
 		return nil, errors.Errorf("unexpected action %s", name)
 	}
 }
 
-func handleJujuRunAction(params map[string]interface{}) (results map[string]interface{}, err error) {
+func handleJujuRunAction(params map[string]interface{}, stdout, stderr io.Writer) (results map[string]interface{}, err error) {
 	// The spec checks that the parameters are available so we don't need to check again here
 	command, _ := params["command"].(string)
 	logger.Tracef("juju run %q", command)
@@ -49,7 +57,12 @@ func handleJujuRunAction(params map[string]interface{}) (results map[string]inte
 	// But due to serialization it comes out as float64
 	timeout, _ := params["timeout"].(float64)
 
-	res, err := runCommandWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	var res *exec.ExecResponse
+	if stdout != nil && stderr != nil && jujuos.HostOS() != jujuos.Windows {
+		res, err = runStreamedCommand(command, time.Duration(timeout), clock.WallClock, stdout, stderr)
+	} else {
+		res, err = runCommandWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -87,6 +100,59 @@ func runCommandWithTimeout(command string, timeout time.Duration, clock clock.Cl
 	return cmd.WaitWithCancel(cancel)
 }
 
+// runStreamedCommand runs the command as runCommandWithTimeout does,
+// and also writes its output to stdout and stderr as it is produced.
+// It is not supported on Windows.
+func runStreamedCommand(command string, timeout time.Duration, clock clock.Clock, stdout, stderr io.Writer) (*exec.ExecResponse, error) {
+	var outBuf, errBuf bytes.Buffer
+	ps := osexec.Command("/bin/bash", "-s")
+	if RunAsUser != "" {
+		ps = osexec.Command("su", RunAsUser, "--login", "--command", "/bin/bash -s")
+	}
+	ps.Env = os.Environ()
+	ps.Stdin = strings.NewReader(command)
+	ps.Stdout = io.MultiWriter(&outBuf, stdout)
+	ps.Stderr = io.MultiWriter(&errBuf, stderr)
+	setProcessGroup(ps)
+	if err := ps.Start(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	var timedOut <-chan time.Time
+	if timeout != 0 {
+		timedOut = clock.After(timeout)
+	}
+	var err error
+	select {
+	case err = <-done:
+	case <-timedOut:
+		// Don't wait for the output to be closed, as commands
+		// started outside the process group may still hold it.
+		if err := killProcessGroup(ps.Process); err != nil {
+			logger.Errorf("cannot kill juju-run: %v", err)
+		}
+		return nil, exec.ErrCancelled
+	}
+
+	code := 0
+	if err != nil {
+		exitErr, ok := err.(*osexec.ExitError)
+		if !ok {
+			return nil, errors.Trace(err)
+		}
+		code = exitErr.Sys().(syscall.WaitStatus).ExitStatus()
+	}
+	return &exec.ExecResponse{
+		Code:   code,
+		Stdout: outBuf.Bytes(),
+		Stderr: errBuf.Bytes(),
+	}, nil
+}
+
 func encodeBytes(input []byte) (value string, encoding string) {
 	if utf8.Valid(input) {
 		value = string(input)
@@ -5,6 +5,8 @@
 package machineactions_test
 
 import (
+	"bytes"
+	"runtime"
 	"strings"
 
 	"github.com/juju/errors"
@@ -31,13 +33,13 @@ func (s *HandleSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *HandleSuite) TestInvalidAction(c *gc.C) {
-	results, err := machineactions.HandleAction("invalid", nil)
+	results, err := machineactions.HandleAction("invalid", nil, nil, nil)
 	c.Assert(err, gc.ErrorMatches, "unexpected action invalid")
 	c.Assert(results, gc.IsNil)
 }
 
 func (s *HandleSuite) TestValidActionInvalidParams(c *gc.C) {
-	results, err := machineactions.HandleAction(actions.JujuRunActionName, nil)
+	results, err := machineactions.HandleAction(actions.JujuRunActionName, nil, nil, nil)
 	c.Assert(err, gc.ErrorMatches, "invalid action parameters")
 	c.Assert(results, gc.IsNil)
 }
@@ -48,7 +50,7 @@ func (s *HandleSuite) TestTimeoutRun(c *gc.C) {
 		"timeout": float64(1),
 	}
 
-	results, err := machineactions.HandleAction(actions.JujuRunActionName, params)
+	results, err := machineactions.HandleAction(actions.JujuRunActionName, params, nil, nil)
 	c.Assert(errors.Cause(err), gc.Equals, exec.ErrCancelled)
 	c.Assert(results, gc.IsNil)
 }
@@ -59,7 +61,7 @@ func (s *HandleSuite) TestSuccessfulRun(c *gc.C) {
 		"timeout": float64(0),
 	}
 
-	results, err := machineactions.HandleAction(actions.JujuRunActionName, params)
+	results, err := machineactions.HandleAction(actions.JujuRunActionName, params, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results["Code"], gc.Equals, "0")
 	c.Assert(strings.TrimRight(results["Stdout"].(string), "\r\n"), gc.Equals, "1")
@@ -72,9 +74,43 @@ func (s *HandleSuite) TestErrorRun(c *gc.C) {
 		"timeout": float64(0),
 	}
 
-	results, err := machineactions.HandleAction(actions.JujuRunActionName, params)
+	results, err := machineactions.HandleAction(actions.JujuRunActionName, params, nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results["Code"], gc.Equals, "42")
 	c.Assert(results["Stdout"], gc.Equals, "")
 	c.Assert(results["Stderr"], gc.Equals, "")
 }
+
+func (s *HandleSuite) TestStreamedRun(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("output is not streamed on windows")
+	}
+	params := map[string]interface{}{
+		"command": "echo 1; echo 2 >&2; exit 42",
+		"timeout": float64(0),
+	}
+
+	var stdout, stderr bytes.Buffer
+	results, err := machineactions.HandleAction(actions.JujuRunActionName, params, &stdout, &stderr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results["Code"], gc.Equals, "42")
+	c.Assert(results["Stdout"], gc.Equals, "1\n")
+	c.Assert(results["Stderr"], gc.Equals, "2\n")
+	c.Assert(stdout.String(), gc.Equals, "1\n")
+	c.Assert(stderr.String(), gc.Equals, "2\n")
+}
+
+func (s *HandleSuite) TestStreamedTimeoutRun(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("output is not streamed on windows")
+	}
+	params := map[string]interface{}{
+		"command": "sleep 100",
+		"timeout": float64(1),
+	}
+
+	var stdout, stderr bytes.Buffer
+	results, err := machineactions.HandleAction(actions.JujuRunActionName, params, &stdout, &stderr)
+	c.Assert(errors.Cause(err), gc.Equals, exec.ErrCancelled)
+	c.Assert(results, gc.IsNil)
+}
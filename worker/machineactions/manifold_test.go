@@ -5,6 +5,8 @@
 package machineactions_test
 
 import (
+	"io"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -175,6 +177,6 @@ type fakeWorker struct {
 	worker.Worker
 }
 
-var fakeHandleAction = func(name string, params map[string]interface{}) (results map[string]interface{}, err error) {
+var fakeHandleAction = func(name string, params map[string]interface{}, stdout, stderr io.Writer) (results map[string]interface{}, err error) {
 	return nil, nil
 }
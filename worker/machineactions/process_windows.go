@@ -0,0 +1,21 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build windows
+
+package machineactions
+
+import (
+	"os"
+	"os/exec"
+)
+
+// setProcessGroup does nothing on windows, where processes are not
+// grouped.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessGroup kills the given process. Any children it has
+// started are left running.
+func killProcessGroup(process *os.Process) error {
+	return process.Kill()
+}
@@ -5,8 +5,11 @@
 package machineactions
 
 import (
+	"io"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 	worker "gopkg.in/juju/worker.v1"
 
@@ -25,13 +28,14 @@ type Facade interface {
 	Action(names.ActionTag) (*machineactions.Action, error)
 	ActionBegin(names.ActionTag) error
 	ActionFinish(tag names.ActionTag, status string, results map[string]interface{}, message string) error
+	AddActionOutput(tag names.ActionTag, output []machineactions.ActionOutput) error
 }
 
 // WorkerConfig defines the worker's dependencies.
 type WorkerConfig struct {
 	Facade       Facade
 	MachineTag   names.MachineTag
-	HandleAction func(name string, params map[string]interface{}) (results map[string]interface{}, err error)
+	HandleAction func(name string, params map[string]interface{}, stdout, stderr io.Writer) (results map[string]interface{}, err error)
 }
 
 // Validate returns an error if the configuration is not complete.
@@ -112,7 +116,11 @@ func (h *handler) Handle(_ <-chan struct{}, actionsSlice []string) error {
 		// We try to handle the action. The result returned from handling the action is
 		// sent through using ActionFinish. We only stop the loop if ActionFinish fails.
 		var finishErr error
-		results, err := h.config.HandleAction(action.Name(), action.Params())
+		output := newActionOutput(h.config.Facade, actionTag, clock.WallClock)
+		results, err := h.config.HandleAction(action.Name(), action.Params(), output.Stdout(), output.Stderr())
+		if err := output.Close(); err != nil {
+			logger.Warningf("cannot send output for action %q: %v", actionId, err)
+		}
 		if err != nil {
 			finishErr = h.config.Facade.ActionFinish(actionTag, params.ActionFailed, nil, err.Error())
 		} else {
@@ -5,12 +5,16 @@
 package machineactions_test
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
+	apimachineactions "github.com/juju/juju/api/machineactions"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/worker/machineactions"
 	"github.com/juju/juju/worker/workertest"
@@ -180,6 +184,39 @@ func (*WorkerSuite) TestFailHandlingSecondActionSendAllResults(c *gc.C) {
 	stub.CheckCalls(c, successfulCalls)
 }
 
+func (*WorkerSuite) TestActionOutputSent(c *gc.C) {
+	stub := &testing.Stub{}
+	config := defaultConfig(stub)
+	config.HandleAction = func(name string, params map[string]interface{}, stdout, stderr io.Writer) (map[string]interface{}, error) {
+		stub.AddCall("HandleAction", name)
+		fmt.Fprint(stdout, "hello ")
+		fmt.Fprint(stdout, "world")
+		fmt.Fprint(stderr, "oops")
+		return nil, stub.NextErr()
+	}
+	worker, err := machineactions.NewMachineActionsWorker(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	workertest.CheckAlive(c, worker)
+	workertest.CleanKill(c, worker)
+
+	// The output of the first action is sent once it has been
+	// handled, before its results are recorded.
+	calls := stub.Calls()
+	c.Assert(len(calls) > 6, jc.IsTrue)
+	c.Assert(calls[5].FuncName, gc.Equals, "AddActionOutput")
+	c.Assert(calls[5].Args[0], gc.Equals, firstActionTag)
+	output := calls[5].Args[1].([]apimachineactions.ActionOutput)
+	c.Assert(output, gc.HasLen, 2)
+	c.Check(output[0].Seq, gc.Equals, 0)
+	c.Check(output[0].Stream, gc.Equals, "stdout")
+	c.Check(output[0].Data, gc.Equals, "hello world")
+	c.Check(output[1].Seq, gc.Equals, 1)
+	c.Check(output[1].Stream, gc.Equals, "stderr")
+	c.Check(output[1].Data, gc.Equals, "oops")
+	c.Assert(calls[6].FuncName, gc.Equals, "ActionFinish")
+}
+
 func (*WorkerSuite) TestWorkerNoErr(c *gc.C) {
 	stub := &testing.Stub{}
 	worker, err := machineactions.NewMachineActionsWorker(defaultConfig(stub))
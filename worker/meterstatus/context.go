@@ -69,6 +69,11 @@ func (ctx *limitedContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
 }
 
+// StreamActionOutput implements runner.Context.
+func (ctx *limitedContext) StreamActionOutput() (*context.ActionOutput, error) {
+	return nil, jujuc.ErrRestrictedContext
+}
+
 // Flush implementes runner.Context.
 func (ctx *limitedContext) Flush(_ string, err error) error {
 	return err
@@ -80,6 +85,9 @@ func (ctx *limitedContext) HasExecutionSetUnitStatus() bool { return false }
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *limitedContext) ResetExecutionSetUnitStatus() {}
 
+// HookTimeout implements runner.Context.
+func (ctx *limitedContext) HookTimeout(string) time.Duration { return 0 }
+
 // Id implements runner.Context.
 func (ctx *limitedContext) Id() string { return ctx.id }
 
@@ -76,12 +76,20 @@ func (ctx *hookContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
 }
 
+// StreamActionOutput implements runner.Context.
+func (ctx *hookContext) StreamActionOutput() (*context.ActionOutput, error) {
+	return nil, jujuc.ErrRestrictedContext
+}
+
 // HasExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 
 // ResetExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) ResetExecutionSetUnitStatus() {}
 
+// HookTimeout implements runner.Context.
+func (ctx *hookContext) HookTimeout(string) time.Duration { return 0 }
+
 // Id implements runner.Context.
 func (ctx *hookContext) Id() string { return ctx.id }
 
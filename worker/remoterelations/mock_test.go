@@ -161,6 +161,20 @@ func (m *mockRelationsFacade) RelationUnitSettings(relationUnits []params.Relati
 	return result, nil
 }
 
+func (m *mockRelationsFacade) RelationApplicationSettings(relationApplications []params.RelationApplication) ([]params.SettingsResult, error) {
+	m.stub.MethodCall(m, "RelationApplicationSettings", relationApplications)
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	result := make([]params.SettingsResult, len(relationApplications))
+	for i := range relationApplications {
+		result[i].Settings = map[string]string{
+			"shared": "value",
+		}
+	}
+	return result, nil
+}
+
 func (m *mockRelationsFacade) PublishLocalRelationChange(change params.RemoteRelationChangeEvent) error {
 	m.stub.MethodCall(m, "PublishLocalRelationChange", change)
 	if err := m.stub.NextErr(); err != nil {
@@ -61,6 +61,10 @@ type RemoteRelationsFacade interface {
 	// given relation units in the local model.
 	RelationUnitSettings([]params.RelationUnit) ([]params.SettingsResult, error)
 
+	// RelationApplicationSettings returns the application settings for
+	// the given applications and relations in the local model.
+	RelationApplicationSettings([]params.RelationApplication) ([]params.SettingsResult, error)
+
 	// Relations returns information about the relations
 	// with the specified keys in the local model.
 	Relations(keys []string) ([]params.RemoteRelationResult, error)
@@ -688,6 +692,27 @@ func (w *relationUnitsWatcher) relationUnitsChangeEvent(
 			}
 			event.ChangedUnits = append(event.ChangedUnits, change)
 		}
+
+		// The application settings are shared by all the units, so
+		// publish them along with any unit change.
+		appName, err := names.UnitApplication(changedUnitNames[0])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appResults, err := w.facade.RelationApplicationSettings([]params.RelationApplication{{
+			Relation:    w.relationTag.String(),
+			Application: names.NewApplicationTag(appName).String(),
+		}})
+		if err != nil {
+			return nil, errors.Annotate(err, "fetching relation application settings")
+		}
+		if appResults[0].Error != nil {
+			return nil, errors.Annotatef(appResults[0].Error, "fetching relation application settings for %v", appName)
+		}
+		event.ApplicationSettings = make(map[string]interface{})
+		for k, v := range appResults[0].Settings {
+			event.ApplicationSettings[k] = v
+		}
 	}
 	return event, nil
 }
@@ -286,6 +286,10 @@ func (s *remoteRelationsSuite) TestRemoteRelationsChangedNotifies(c *gc.C) {
 			[]params.RelationUnit{{
 				Relation: "relation-db2.db#django.db",
 				Unit:     "unit-unit-1"}}}},
+		{"RelationApplicationSettings", []interface{}{
+			[]params.RelationApplication{{
+				Relation:    "relation-db2.db#django.db",
+				Application: "application-unit"}}}},
 		{"PublishLocalRelationChange", []interface{}{
 			params.RemoteRelationChangeEvent{
 				Life: params.Alive,
@@ -299,8 +303,9 @@ func (s *remoteRelationsSuite) TestRemoteRelationsChangedNotifies(c *gc.C) {
 					UnitId:   1,
 					Settings: map[string]interface{}{"foo": "bar"},
 				}},
-				DepartedUnits: []int{2},
-				Macaroon:      mac,
+				DepartedUnits:       []int{2},
+				ApplicationSettings: map[string]interface{}{"shared": "value"},
+				Macaroon:            mac,
 			},
 		}},
 	}
@@ -16,6 +16,8 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+	SecretChanged         hooks.Kind = "secret-changed"
+	SecretRotate          hooks.Kind = "secret-rotate"
 )
 
 // Info holds details required to execute a hook. Not all fields are
@@ -37,6 +39,10 @@ type Info struct {
 
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
+
+	// SecretId is the id of the secret relevant to the hook. It is only
+	// set when Kind indicates a secret hook.
+	SecretId string `yaml:"secret-id,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -60,6 +66,11 @@ func (hi Info) Validate() error {
 	// TODO(fwereade): define these in charm/hooks...
 	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
 		return nil
+	case SecretChanged, SecretRotate:
+		if hi.SecretId == "" {
+			return fmt.Errorf("%q hook requires a secret id", hi.Kind)
+		}
+		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
 }
@@ -50,6 +50,9 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.SecretChanged}, `"secret-changed" hook requires a secret id`},
+	{hook.Info{Kind: hook.SecretChanged, SecretId: "3"}, ""},
+	{hook.Info{Kind: hook.SecretRotate, SecretId: "3"}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
@@ -40,6 +40,8 @@ func (rh *runHook) String() string {
 		}
 	case rh.info.Kind.IsStorage():
 		suffix = fmt.Sprintf(" (%s)", rh.info.StorageId)
+	case rh.info.SecretId != "":
+		suffix = fmt.Sprintf(" (%s)", rh.info.SecretId)
 	}
 	return fmt.Sprintf("run %s%s hook", rh.info.Kind, suffix)
 }
@@ -103,6 +105,15 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case cause == context.ErrReboot:
 		err = ErrNeedsReboot
 	case err == nil:
+	case runner.IsHookTimeoutError(cause):
+		logger.Errorf("hook %q failed: %v", rh.name, err)
+		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
+		return stateChange{
+			Kind:        RunHook,
+			Step:        Pending,
+			Hook:        &rh.info,
+			HookTimeout: rh.runner.Context().HookTimeout(rh.name),
+		}.apply(state), ErrHookFailed
 	default:
 		logger.Errorf("hook %q failed: %v", rh.name, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
@@ -5,6 +5,7 @@ package operation
 
 import (
 	"os"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
@@ -93,6 +94,11 @@ type State struct {
 	// Charm describes the charm being deployed by an Install or Upgrade
 	// operation, and is otherwise blank.
 	CharmURL *charm.URL `yaml:"charm,omitempty"`
+
+	// HookTimeout holds the timeout after which the current hook was
+	// killed, if Kind is RunHook and the hook timed out. It is otherwise
+	// zero.
+	HookTimeout time.Duration `yaml:"hook-timeout,omitempty"`
 }
 
 // validate returns an error if the state violates expectations.
@@ -165,6 +171,7 @@ type stateChange struct {
 	ActionId        *string
 	CharmURL        *charm.URL
 	HasRunStatusSet bool
+	HookTimeout     time.Duration
 }
 
 func (change stateChange) apply(state State) *State {
@@ -173,6 +180,7 @@ func (change stateChange) apply(state State) *State {
 	state.Hook = change.Hook
 	state.ActionId = change.ActionId
 	state.CharmURL = change.CharmURL
+	state.HookTimeout = change.HookTimeout
 	state.StatusSet = state.StatusSet || change.HasRunStatusSet
 	return &state
 }
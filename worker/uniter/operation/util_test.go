@@ -4,6 +4,8 @@
 package operation_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	utilexec "github.com/juju/utils/exec"
@@ -263,6 +265,11 @@ type MockContext struct {
 	actionData      *context.ActionData
 	setStatusCalled bool
 	status          jujuc.StatusInfo
+	hookTimeout     time.Duration
+}
+
+func (mock *MockContext) HookTimeout(hookName string) time.Duration {
+	return mock.hookTimeout
 }
 
 func (mock *MockContext) ActionData() (*context.ActionData, error) {
@@ -6,6 +6,7 @@ package remotestate_test
 import (
 	"sync"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -192,6 +193,8 @@ type mockUnit struct {
 	storageWatcher        *mockStringsWatcher
 	actionWatcher         *mockStringsWatcher
 	relationsWatcher      *mockStringsWatcher
+	secretsWatcher        *mockNotifyWatcher
+	secrets               []params.Secret
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -238,6 +241,17 @@ func (u *mockUnit) WatchRelations() (watcher.StringsWatcher, error) {
 	return u.relationsWatcher, nil
 }
 
+func (u *mockUnit) Secrets() ([]params.Secret, error) {
+	return u.secrets, nil
+}
+
+func (u *mockUnit) WatchSecrets() (watcher.NotifyWatcher, error) {
+	if u.secretsWatcher == nil {
+		return nil, errors.NotImplementedf("WatchSecrets")
+	}
+	return u.secretsWatcher, nil
+}
+
 type mockService struct {
 	tag                   names.ApplicationTag
 	life                  params.Life
@@ -4,6 +4,8 @@
 package remotestate
 
 import (
+	"time"
+
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -68,6 +70,10 @@ type Snapshot struct {
 	// Commands is the list of IDs of commands to be
 	// executed by this unit.
 	Commands []string
+
+	// Secrets contains the details of the secrets
+	// the unit may read, keyed by secret id.
+	Secrets map[string]SecretSnapshot
 }
 
 type RelationSnapshot struct {
@@ -83,3 +89,18 @@ type StorageSnapshot struct {
 	Attached bool
 	Location string
 }
+
+// SecretSnapshot has information relating to a secret
+// the unit may read.
+type SecretSnapshot struct {
+	// Revision is the current revision of the secret's value.
+	Revision int
+
+	// Owned reports whether the secret is owned by the
+	// unit's application.
+	Owned bool
+
+	// NextRotateTime is the time at which the secret is
+	// due to be rotated, if it has a rotate interval.
+	NextRotateTime *time.Time
+}
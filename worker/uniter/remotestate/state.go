@@ -35,6 +35,11 @@ type Unit interface {
 	// WatchRelation returns a watcher that fires when relations
 	// relevant for this unit change.
 	WatchRelations() (watcher.StringsWatcher, error)
+	// Secrets returns the details of the secrets the unit may read.
+	Secrets() ([]params.Secret, error)
+	// WatchSecrets returns a watcher that fires when the secrets
+	// the unit may read change.
+	WatchSecrets() (watcher.NotifyWatcher, error)
 }
 
 type Application interface {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 
@@ -36,6 +37,7 @@ type RemoteStateWatcher struct {
 	updateStatusChannel       func() <-chan time.Time
 	commandChannel            <-chan string
 	retryHookChannel          <-chan struct{}
+	clock                     clock.Clock
 
 	catacomb catacomb.Catacomb
 
@@ -53,11 +55,18 @@ type WatcherConfig struct {
 	CommandChannel      <-chan string
 	RetryHookChannel    <-chan struct{}
 	UnitTag             names.UnitTag
+
+	// Clock is used to signal a remote state change when a secret
+	// owned by the unit's application is due to be rotated.
+	Clock clock.Clock
 }
 
 // NewWatcher returns a RemoteStateWatcher that handles state changes pertaining to the
 // supplied unit.
 func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
+	if config.Clock == nil {
+		return nil, errors.NotValidf("nil Clock")
+	}
 	w := &RemoteStateWatcher{
 		st:                        config.State,
 		relations:                 make(map[names.RelationTag]*relationUnitsWatcher),
@@ -68,6 +77,7 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		updateStatusChannel:       config.UpdateStatusChannel,
 		commandChannel:            config.CommandChannel,
 		retryHookChannel:          config.RetryHookChannel,
+		clock:                     config.Clock,
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -76,6 +86,7 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		current: Snapshot{
 			Relations: make(map[int]RelationSnapshot),
 			Storage:   make(map[names.StorageTag]StorageSnapshot),
+			Secrets:   make(map[string]SecretSnapshot),
 		},
 	}
 	err := catacomb.Invoke(catacomb.Plan{
@@ -127,6 +138,10 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	copy(snapshot.Actions, w.current.Actions)
 	snapshot.Commands = make([]string, len(w.current.Commands))
 	copy(snapshot.Commands, w.current.Commands)
+	snapshot.Secrets = make(map[string]SecretSnapshot)
+	for id, secretSnapshot := range w.current.Secrets {
+		snapshot.Secrets[id] = secretSnapshot
+	}
 	return snapshot
 }
 
@@ -257,6 +272,27 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	// Controllers that do not support secrets have no secrets
+	// watcher, in which case the secrets are never changed.
+	var seenSecretsChange bool
+	var secretsChanges watcher.NotifyChannel
+	secretsw, err := w.unit.WatchSecrets()
+	if errors.IsNotImplemented(err) {
+		logger.Debugf("secrets not supported by the controller")
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := w.catacomb.Add(secretsw); err != nil {
+			return errors.Trace(err)
+		}
+		secretsChanges = secretsw.Changes()
+		requiredEvents++
+	}
+	// secretRotateTimer fires when the earliest rotation of a secret
+	// owned by the unit's application is due, so that the rotation is
+	// not delayed until some other remote state change.
+	var secretRotateTimer <-chan time.Time
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -385,6 +421,21 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenStorageChange)
 
+		case _, ok := <-secretsChanges:
+			logger.Debugf("got secrets change: ok=%t", ok)
+			if !ok {
+				return errors.New("secrets watcher closed")
+			}
+			if err := w.secretsChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			secretRotateTimer = w.secretRotateTimer()
+			observedEvent(&seenSecretsChange)
+
+		case <-secretRotateTimer:
+			logger.Debugf("secret rotate timer triggered")
+			secretRotateTimer = nil
+
 		case <-waitMinion:
 			logger.Debugf("got leadership change: minion")
 			if err := w.leadershipChanged(false); err != nil {
@@ -532,6 +583,48 @@ func (w *RemoteStateWatcher) leadershipChanged(isLeader bool) error {
 	return nil
 }
 
+// secretsChanged responds to changes to the secrets the unit may read.
+func (w *RemoteStateWatcher) secretsChanged() error {
+	secrets, err := w.unit.Secrets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owner := w.service.Tag().String()
+	snapshots := make(map[string]SecretSnapshot)
+	for _, secret := range secrets {
+		snapshots[secret.Id] = SecretSnapshot{
+			Revision:       secret.Revision,
+			Owned:          secret.Owner == owner,
+			NextRotateTime: secret.NextRotateTime,
+		}
+	}
+	w.mu.Lock()
+	w.current.Secrets = snapshots
+	w.mu.Unlock()
+	return nil
+}
+
+// secretRotateTimer returns a channel that receives a value when the
+// earliest rotation of a secret owned by the unit's application is due,
+// or nil if none of them are rotated.
+func (w *RemoteStateWatcher) secretRotateTimer() <-chan time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var earliest *time.Time
+	for _, secret := range w.current.Secrets {
+		if !secret.Owned || secret.NextRotateTime == nil {
+			continue
+		}
+		if earliest == nil || secret.NextRotateTime.Before(*earliest) {
+			earliest = secret.NextRotateTime
+		}
+	}
+	if earliest == nil {
+		return nil
+	}
+	return w.clock.After(earliest.Sub(w.clock.Now()))
+}
+
 // relationsChanged responds to service relation changes.
 func (w *RemoteStateWatcher) relationsChanged(keys []string) error {
 	w.mu.Lock()
@@ -53,6 +53,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			storageWatcher:        newMockStringsWatcher(),
 			actionWatcher:         newMockStringsWatcher(),
 			relationsWatcher:      newMockStringsWatcher(),
+			secretsWatcher:        newMockNotifyWatcher(),
 		},
 		relations:                 make(map[names.RelationTag]*mockRelation),
 		storageAttachment:         make(map[params.StorageAttachmentId]params.StorageAttachment),
@@ -76,6 +77,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 		LeadershipTracker:   s.leadership,
 		UnitTag:             s.st.unit.tag,
 		UpdateStatusChannel: statusTicker,
+		Clock:               s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -94,6 +96,7 @@ func (s *WatcherSuite) TestInitialSnapshot(c *gc.C) {
 	c.Assert(snap, jc.DeepEquals, remotestate.Snapshot{
 		Relations: map[int]remotestate.RelationSnapshot{},
 		Storage:   map[names.StorageTag]remotestate.StorageSnapshot{},
+		Secrets:   map[string]remotestate.SecretSnapshot{},
 	})
 }
 
@@ -110,6 +113,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.service.serviceWatcher.changes <- struct{}{}
 	s.st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.relationsWatcher.changes <- []string{}
+	s.st.unit.secretsWatcher.changes <- struct{}{}
 	s.leadership.claimTicket.ch <- struct{}{}
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
 }
@@ -123,6 +127,9 @@ func signalAll(st *mockState, l *mockLeadershipTracker) {
 	st.unit.service.serviceWatcher.changes <- struct{}{}
 	st.unit.service.leaderSettingsWatcher.changes <- struct{}{}
 	st.unit.relationsWatcher.changes <- []string{}
+	if st.unit.secretsWatcher != nil {
+		st.unit.secretsWatcher.changes <- struct{}{}
+	}
 	l.claimTicket.ch <- struct{}{}
 }
 
@@ -142,6 +149,7 @@ func (s *WatcherSuite) TestSnapshot(c *gc.C) {
 		ConfigVersion:         2, // config settings and addresses
 		LeaderSettingsVersion: 1,
 		Leader:                true,
+		Secrets:               map[string]remotestate.SecretSnapshot{},
 	})
 }
 
@@ -183,6 +191,9 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	s.st.unit.relationsWatcher.changes <- []string{}
 	assertOneChange()
 
+	s.st.unit.secretsWatcher.changes <- struct{}{}
+	assertOneChange()
+
 	s.clock.Advance(statusTickDuration + 1)
 	assertOneChange()
 }
@@ -548,6 +559,103 @@ func (s *WatcherSuite) TestUpdateStatusTicker(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().UpdateStatusVersion, gc.Equals, initial.UpdateStatusVersion+2)
 }
 
+func (s *WatcherSuite) TestSecretsChanged(c *gc.C) {
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	rotate := time.Date(2017, 7, 1, 0, 0, 0, 0, time.UTC)
+	s.st.unit.secrets = []params.Secret{{
+		Id:             "1",
+		Owner:          "application-mysql",
+		Revision:       2,
+		NextRotateTime: &rotate,
+	}, {
+		Id:       "2",
+		Owner:    "application-wordpress",
+		Revision: 5,
+	}}
+	s.st.unit.secretsWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Secrets, jc.DeepEquals, map[string]remotestate.SecretSnapshot{
+		"1": {Revision: 2, Owned: true, NextRotateTime: &rotate},
+		"2": {Revision: 5},
+	})
+}
+
+// restartWatcher replaces the suite's remote state watcher with one
+// that has no update-status timer, so that the tests can advance the
+// clock without triggering it.
+func (s *WatcherSuite) restartWatcher(c *gc.C) {
+	s.watcher.Kill()
+	c.Assert(s.watcher.Wait(), jc.ErrorIsNil)
+
+	// Stopping the watcher stops the underlying watchers too,
+	// so replace them for the new remote state watcher.
+	s.st.unit.unitWatcher = newMockNotifyWatcher()
+	s.st.unit.addressesWatcher = newMockNotifyWatcher()
+	s.st.unit.configSettingsWatcher = newMockNotifyWatcher()
+	s.st.unit.storageWatcher = newMockStringsWatcher()
+	s.st.unit.actionWatcher = newMockStringsWatcher()
+	s.st.unit.relationsWatcher = newMockStringsWatcher()
+	s.st.unit.service.serviceWatcher = newMockNotifyWatcher()
+	s.st.unit.service.leaderSettingsWatcher = newMockNotifyWatcher()
+	if s.st.unit.secretsWatcher != nil {
+		s.st.unit.secretsWatcher = newMockNotifyWatcher()
+	}
+
+	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
+		State:               s.st,
+		LeadershipTracker:   s.leadership,
+		UnitTag:             s.st.unit.tag,
+		UpdateStatusChannel: func() <-chan time.Time { return nil },
+		Clock:               s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.watcher = w
+}
+
+func (s *WatcherSuite) TestSecretRotateTimer(c *gc.C) {
+	s.restartWatcher(c)
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	later := s.clock.Now().Add(2 * time.Hour)
+	sooner := s.clock.Now().Add(time.Hour)
+	s.st.unit.secrets = []params.Secret{{
+		Id:             "1",
+		Owner:          "application-mysql",
+		NextRotateTime: &later,
+	}, {
+		Id:             "2",
+		Owner:          "application-mysql",
+		NextRotateTime: &sooner,
+	}, {
+		// Secrets owned by other applications are not rotated
+		// by this unit.
+		Id:             "3",
+		Owner:          "application-wordpress",
+		NextRotateTime: &sooner,
+	}}
+	s.st.unit.secretsWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	s.waitAlarmsStable(c)
+	s.clock.Advance(59 * time.Minute)
+	assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "unexpected remote state change")
+	s.clock.Advance(time.Minute)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+}
+
+func (s *WatcherSuite) TestSecretsNotSupported(c *gc.C) {
+	s.st.unit.secretsWatcher = nil
+	s.restartWatcher(c)
+
+	// The remote state is complete without any secrets events.
+	signalAll(s.st, s.leadership)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Secrets, gc.HasLen, 0)
+}
+
 // waitAlarmsStable is used to wait until the remote watcher's loop has
 // stopped churning (at least for testing.ShortWait), so that we can
 // then Advance the clock with some confidence that the SUT really is
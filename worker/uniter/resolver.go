@@ -26,6 +26,7 @@ type ResolverConfig struct {
 	Relations           resolver.Resolver
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
+	Secrets             resolver.Resolver
 }
 
 type uniterResolver struct {
@@ -269,6 +270,11 @@ func (s *uniterResolver) nextOp(
 		return op, err
 	}
 
+	op, err = s.config.Secrets.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
 	// UpdateStatus hook runs if nothing else needs to.
 	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion {
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
@@ -4,6 +4,8 @@
 package resolver
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 
@@ -90,6 +92,16 @@ type LocalState struct {
 	// been committed.
 	LeaderSettingsVersion int
 
+	// SecretRevisions holds, for each secret, the revision from
+	// remotestate.Snapshot for which a secret-changed hook has
+	// been committed.
+	SecretRevisions map[string]int
+
+	// SecretRotations holds, for each secret, the rotate time from
+	// remotestate.Snapshot for which a secret-rotate hook has been
+	// committed.
+	SecretRotations map[string]time.Time
+
 	// CompletedActions is the set of actions that have been completed.
 	// This is used to prevent us re running actions requested by the
 	// controller.
@@ -4,6 +4,8 @@
 package resolver
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -120,6 +122,24 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func() {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.SecretChanged:
+		v := s.RemoteState.Secrets[info.SecretId].Revision
+		op = onCommitWrapper{op, func() {
+			if s.LocalState.SecretRevisions == nil {
+				s.LocalState.SecretRevisions = make(map[string]int)
+			}
+			s.LocalState.SecretRevisions[info.SecretId] = v
+		}}
+	case hook.SecretRotate:
+		if t := s.RemoteState.Secrets[info.SecretId].NextRotateTime; t != nil {
+			v := *t
+			op = onCommitWrapper{op, func() {
+				if s.LocalState.SecretRotations == nil {
+					s.LocalState.SecretRotations = make(map[string]time.Time)
+				}
+				s.LocalState.SecretRotations[info.SecretId] = v
+			}}
+		}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
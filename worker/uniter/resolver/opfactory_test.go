@@ -5,6 +5,7 @@ package resolver_test
 
 import (
 	"errors"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -126,6 +127,35 @@ func (s *ResolverOpFactorySuite) testLeaderSettingsChanged(
 	c.Assert(f.LocalState.UpdateStatusVersion, gc.Equals, 3)
 }
 
+func (s *ResolverOpFactorySuite) TestSecretChanged(c *gc.C) {
+	f := resolver.NewResolverOpFactory(s.opFactory)
+	f.RemoteState.Secrets = map[string]remotestate.SecretSnapshot{"1": {Revision: 2}}
+
+	op, err := f.NewRunHook(hook.Info{Kind: hook.SecretChanged, SecretId: "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	f.RemoteState.Secrets = map[string]remotestate.SecretSnapshot{"1": {Revision: 3}}
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.LocalState.SecretRevisions, jc.DeepEquals, map[string]int{"1": 2})
+}
+
+func (s *ResolverOpFactorySuite) TestSecretRotate(c *gc.C) {
+	f := resolver.NewResolverOpFactory(s.opFactory)
+	due := time.Date(2017, 7, 1, 0, 0, 0, 0, time.UTC)
+	f.RemoteState.Secrets = map[string]remotestate.SecretSnapshot{
+		"1": {Revision: 1, Owned: true, NextRotateTime: &due},
+	}
+
+	op, err := f.NewRunHook(hook.Info{Kind: hook.SecretRotate, SecretId: "1"})
+	c.Assert(err, jc.ErrorIsNil)
+	f.RemoteState.Secrets = nil
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(f.LocalState.SecretRotations, jc.DeepEquals, map[string]time.Time{"1": due})
+}
+
 func (s *ResolverOpFactorySuite) TestUpgrade(c *gc.C) {
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewUpgrade)
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewRevertUpgrade)
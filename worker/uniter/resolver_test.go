@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -21,6 +23,7 @@ import (
 	"github.com/juju/juju/worker/uniter/relation"
 	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/secrets"
 	"github.com/juju/juju/worker/uniter/storage"
 )
 
@@ -32,6 +35,7 @@ type resolverSuite struct {
 	opFactory            operation.Factory
 	resolver             resolver.Resolver
 	resolverConfig       uniter.ResolverConfig
+	clock                *testing.Clock
 
 	clearResolved   func() error
 	reportHookError func(hook.Info) error
@@ -47,6 +51,7 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		CharmURL:             s.charmURL,
 	}
 	s.opFactory = operation.NewFactory(operation.FactoryParams{})
+	s.clock = testing.NewClock(time.Date(2017, 7, 1, 12, 0, 0, 0, time.UTC))
 
 	attachments, err := storage.NewAttachments(&dummyStorageAccessor{}, names.NewUnitTag("u/0"), c.MkDir(), nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -70,6 +75,7 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		Relations:           relation.NewRelationsResolver(&dummyRelations{}),
 		Storage:             storage.NewResolver(attachments),
 		Commands:            nopResolver{},
+		Secrets:             secrets.NewResolver(s.clock),
 	}
 
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
@@ -109,6 +115,64 @@ func (s *resolverSuite) TestNotStartedNotInstalled(c *gc.C) {
 	c.Assert(op.String(), gc.Equals, "run install hook")
 }
 
+func (s *resolverSuite) TestSecretChanged(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+		SecretRevisions: map[string]int{"1": 2},
+	}
+	s.remoteState.Secrets = map[string]remotestate.SecretSnapshot{
+		"1": {Revision: 2},
+		"2": {Revision: 1, Owned: true},
+	}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	s.remoteState.Secrets["1"] = remotestate.SecretSnapshot{Revision: 3}
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run secret-changed (1) hook")
+}
+
+func (s *resolverSuite) TestSecretRotate(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmURL: s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	due := s.clock.Now().Add(time.Hour)
+	s.remoteState.Leader = true
+	s.remoteState.Secrets = map[string]remotestate.SecretSnapshot{
+		"1": {Revision: 1, Owned: true, NextRotateTime: &due},
+	}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	s.clock.Advance(time.Hour)
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run secret-rotate (1) hook")
+
+	// Only the leader rotates secrets.
+	s.remoteState.Leader = false
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	// Once the hook has been committed, it is not run again
+	// for the same rotation.
+	s.remoteState.Leader = true
+	localState.SecretRotations = map[string]time.Time{"1": due}
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
 func (s *resolverSuite) TestHookErrorDoesNotStartRetryTimerIfShouldRetryFalse(c *gc.C) {
 	s.resolverConfig.ShouldRetryHooks = false
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
@@ -0,0 +1,140 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/uniter"
+)
+
+// actionOutputSendInterval is how often output written by a running
+// action is sent to the controller.
+const actionOutputSendInterval = time.Second
+
+// maxActionOutputChunkSize is the size to which consecutive writes to
+// the same stream are combined before they are sent. The controller
+// keeps a limited number of chunks for each action.
+const maxActionOutputChunkSize = 64 * 1024
+
+// ActionOutputSender sends the output written by a running action to
+// the controller.
+type ActionOutputSender interface {
+	AddActionOutput(tag names.ActionTag, output []uniter.ActionOutput) error
+}
+
+// ActionOutput records the output written by a running action, and
+// periodically sends it to the controller so that it can be streamed
+// to clients while the action runs. Output that cannot be sent is
+// dropped, as streaming is only a convenience; the action's results
+// are recorded as usual when it finishes.
+type ActionOutput struct {
+	sender ActionOutputSender
+	tag    names.ActionTag
+	clock  clock.Clock
+
+	mu      sync.Mutex
+	seq     int
+	pending []uniter.ActionOutput
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewActionOutput returns an ActionOutput that sends the output written
+// by the action with the given tag using the supplied sender. The
+// ActionOutput must be closed when the action finishes.
+func NewActionOutput(sender ActionOutputSender, tag names.ActionTag, clock clock.Clock) *ActionOutput {
+	o := &ActionOutput{
+		sender: sender,
+		tag:    tag,
+		clock:  clock,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go o.loop()
+	return o
+}
+
+// Stdout returns a writer that records output written to the action's
+// standard output.
+func (o *ActionOutput) Stdout() io.Writer {
+	return &actionOutputWriter{o, "stdout"}
+}
+
+// Stderr returns a writer that records output written to the action's
+// standard error.
+func (o *ActionOutput) Stderr() io.Writer {
+	return &actionOutputWriter{o, "stderr"}
+}
+
+// Close sends any output that has not yet been sent, and stops the
+// ActionOutput.
+func (o *ActionOutput) Close() error {
+	close(o.stop)
+	<-o.done
+	return o.send()
+}
+
+func (o *ActionOutput) record(stream string, data []byte) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if n := len(o.pending); n > 0 {
+		last := &o.pending[n-1]
+		if last.Stream == stream && len(last.Data)+len(data) <= maxActionOutputChunkSize {
+			last.Data += string(data)
+			return
+		}
+	}
+	o.pending = append(o.pending, uniter.ActionOutput{
+		Seq:       o.seq,
+		Stream:    stream,
+		Data:      string(data),
+		Timestamp: o.clock.Now(),
+	})
+	o.seq++
+}
+
+func (o *ActionOutput) loop() {
+	defer close(o.done)
+	for {
+		select {
+		case <-o.stop:
+			return
+		case <-o.clock.After(actionOutputSendInterval):
+			if err := o.send(); err != nil {
+				logger.Warningf("cannot send output for action %q: %v", o.tag.Id(), err)
+			}
+		}
+	}
+}
+
+func (o *ActionOutput) send() error {
+	o.mu.Lock()
+	pending := o.pending
+	o.pending = nil
+	o.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+	return o.sender.AddActionOutput(o.tag, pending)
+}
+
+// actionOutputWriter records everything written to it as output
+// written to one of the action's streams.
+type actionOutputWriter struct {
+	output *ActionOutput
+	stream string
+}
+
+// Write is part of the io.Writer interface.
+func (w *actionOutputWriter) Write(data []byte) (int, error) {
+	w.output.record(w.stream, data)
+	return len(data), nil
+}
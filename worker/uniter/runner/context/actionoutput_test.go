@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/uniter"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+type ActionOutputSuite struct {
+	testing.IsolationSuite
+	clock  *testing.Clock
+	sender *stubActionOutputSender
+	tag    names.ActionTag
+}
+
+var _ = gc.Suite(&ActionOutputSuite{})
+
+func (s *ActionOutputSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.sender = &stubActionOutputSender{sent: make(chan []uniter.ActionOutput, 10)}
+	s.tag = names.NewActionTag("f47ac10b-58cc-4372-a567-0e02b2c3d479")
+}
+
+func (s *ActionOutputSuite) TestSendsPeriodically(c *gc.C) {
+	output := context.NewActionOutput(s.sender, s.tag, s.clock)
+	defer output.Close()
+
+	fmt.Fprint(output.Stdout(), "migrating\n")
+	fmt.Fprint(output.Stderr(), "slow table\n")
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case sent := <-s.sender.sent:
+		c.Assert(sent, jc.DeepEquals, []uniter.ActionOutput{
+			{Seq: 0, Stream: "stdout", Data: "migrating\n", Timestamp: s.clock.Now().Add(-time.Second)},
+			{Seq: 1, Stream: "stderr", Data: "slow table\n", Timestamp: s.clock.Now().Add(-time.Second)},
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for output to be sent")
+	}
+}
+
+func (s *ActionOutputSuite) TestCombinesWritesToSameStream(c *gc.C) {
+	output := context.NewActionOutput(s.sender, s.tag, s.clock)
+	fmt.Fprint(output.Stdout(), "one\n")
+	fmt.Fprint(output.Stdout(), "two\n")
+	fmt.Fprint(output.Stderr(), "three\n")
+	err := output.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	sent := <-s.sender.sent
+	c.Assert(sent, jc.DeepEquals, []uniter.ActionOutput{
+		{Seq: 0, Stream: "stdout", Data: "one\ntwo\n", Timestamp: s.clock.Now()},
+		{Seq: 1, Stream: "stderr", Data: "three\n", Timestamp: s.clock.Now()},
+	})
+}
+
+func (s *ActionOutputSuite) TestCloseSendsPending(c *gc.C) {
+	output := context.NewActionOutput(s.sender, s.tag, s.clock)
+	fmt.Fprint(output.Stdout(), "done\n")
+	err := output.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.sender.tags, jc.DeepEquals, []names.ActionTag{s.tag})
+
+	sent := <-s.sender.sent
+	c.Assert(sent, gc.HasLen, 1)
+	c.Assert(sent[0].Data, gc.Equals, "done\n")
+}
+
+func (s *ActionOutputSuite) TestCloseSendsNothing(c *gc.C) {
+	output := context.NewActionOutput(s.sender, s.tag, s.clock)
+	err := output.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.sender.tags, gc.HasLen, 0)
+}
+
+func (s *ActionOutputSuite) TestCloseError(c *gc.C) {
+	s.sender.err = errors.New("boom")
+	output := context.NewActionOutput(s.sender, s.tag, s.clock)
+	fmt.Fprint(output.Stdout(), "done\n")
+	err := output.Close()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type stubActionOutputSender struct {
+	tags []names.ActionTag
+	sent chan []uniter.ActionOutput
+	err  error
+}
+
+func (s *stubActionOutputSender) AddActionOutput(tag names.ActionTag, output []uniter.ActionOutput) error {
+	s.tags = append(s.tags, tag)
+	s.sent <- output
+	return s.err
+}
@@ -0,0 +1,63 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"github.com/juju/errors"
+)
+
+// GetCharmState implements jujuc.ContextCharmState.
+func (ctx *HookContext) GetCharmState() (map[string]string, error) {
+	if err := ctx.ensureCharmStateLoaded(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string)
+	for k, v := range ctx.charmState {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// SetCharmStateValue implements jujuc.ContextCharmState.
+func (ctx *HookContext) SetCharmStateValue(key, value string) error {
+	if err := ctx.ensureCharmStateLoaded(); err != nil {
+		return errors.Trace(err)
+	}
+	if current, ok := ctx.charmState[key]; ok && current == value {
+		return nil
+	}
+	ctx.charmState[key] = value
+	ctx.charmStateDirty = true
+	return nil
+}
+
+// DeleteCharmStateValue implements jujuc.ContextCharmState.
+func (ctx *HookContext) DeleteCharmStateValue(key string) error {
+	if err := ctx.ensureCharmStateLoaded(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := ctx.charmState[key]; !ok {
+		return nil
+	}
+	delete(ctx.charmState, key)
+	ctx.charmStateDirty = true
+	return nil
+}
+
+// ensureCharmStateLoaded reads the unit's charm state from the
+// controller, if it has not already been read in this context.
+func (ctx *HookContext) ensureCharmStateLoaded() error {
+	if ctx.charmState != nil {
+		return nil
+	}
+	charmState, err := ctx.unit.CharmState()
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm state")
+	}
+	if charmState == nil {
+		charmState = make(map[string]string)
+	}
+	ctx.charmState = charmState
+	return nil
+}
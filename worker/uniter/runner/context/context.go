@@ -162,6 +162,10 @@ type HookContext struct {
 	// proxySettings are the current proxy settings that the uniter knows about.
 	proxySettings proxy.Settings
 
+	// hookTimeout returns the maximum time that the named hook may run,
+	// as configured for the model.
+	hookTimeout func(hookName string) time.Duration
+
 	// meterStatus is the status of the unit's metering.
 	meterStatus *meterStatus
 
@@ -192,6 +196,15 @@ type HookContext struct {
 	// storageId is the tag of the storage instance associated with the running hook.
 	storageTag names.StorageTag
 
+	// secretId is the id of the secret associated with the running hook.
+	secretId string
+
+	// charmState holds the key/value state the charm has stored for
+	// the unit. It is read from the controller on first use, and
+	// written back when the context is flushed if it was changed.
+	charmState      map[string]string
+	charmStateDirty bool
+
 	// hasRunSetStatus is true if a call to the status-set was made during the
 	// invocation of a hook.
 	// This attribute is persisted to local uniter state at the end of the hook
@@ -280,6 +293,16 @@ func (ctx *HookContext) SetProcess(process HookProcess) {
 	ctx.process = process
 }
 
+// HookTimeout returns the maximum time that the named hook may run
+// before it is killed. A zero duration means that the hook may run
+// indefinitely.
+func (ctx *HookContext) HookTimeout(hookName string) time.Duration {
+	if ctx.hookTimeout == nil {
+		return 0
+	}
+	return ctx.hookTimeout(hookName)
+}
+
 func (ctx *HookContext) Id() string {
 	return ctx.id
 }
@@ -481,6 +504,24 @@ func (ctx *HookContext) ConfigSettings() (charm.Settings, error) {
 	return result, nil
 }
 
+// CloudSpec is part of the jujuc.Context interface.
+func (ctx *HookContext) CloudSpec() (*params.CloudSpec, error) {
+	spec, err := ctx.state.CloudSpec()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return spec, nil
+}
+
+// GoalState is part of the jujuc.Context interface.
+func (ctx *HookContext) GoalState() (*params.GoalState, error) {
+	goal, err := ctx.unit.GoalState()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return goal, nil
+}
+
 // ActionName returns the name of the action.
 func (ctx *HookContext) ActionName() (string, error) {
 	if ctx.actionData == nil {
@@ -515,6 +556,16 @@ func (ctx *HookContext) SetActionFailed() error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the Action, which is
+// sent to the controller immediately so that it can be seen while the
+// Action runs.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return errors.Trace(ctx.state.LogActionMessage(ctx.actionData.Tag, message))
+}
+
 // UpdateActionResults inserts new values for use with action-set and
 // action-fail.  The results struct will be delivered to the controller
 // upon completion of the Action.  It returns an error if not called on an
@@ -569,6 +620,20 @@ func (c *HookContext) ActionData() (*ActionData, error) {
 	return c.actionData, nil
 }
 
+// StreamActionOutput returns an ActionOutput that sends the output of
+// the running action to the controller, so that it can be streamed to
+// clients while the action runs. The ActionOutput must be closed when
+// the action finishes.
+func (ctx *HookContext) StreamActionOutput() (*ActionOutput, error) {
+	if ctx.actionData == nil {
+		return nil, errors.New("not running an action")
+	}
+	if ctx.state.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("streaming action output")
+	}
+	return NewActionOutput(ctx.state, ctx.actionData.Tag, ctx.clock), nil
+}
+
 // HookVars returns an os.Environ-style list of strings necessary to run a hook
 // such that it can know what environment it's operating in, and can call back
 // into context.
@@ -599,6 +664,9 @@ func (context *HookContext) HookVars(paths Paths) ([]string, error) {
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if context.secretId != "" {
+		vars = append(vars, "JUJU_SECRET_ID="+context.secretId)
+	}
 	if context.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+context.actionData.Name,
@@ -665,6 +733,19 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		defer ctx.handleReboot(&err)
 	}
 
+	// The charm state is written first, so that if it cannot be
+	// written none of the hook's other changes are either.
+	if ctx.charmStateDirty && writeChanges {
+		if err := ctx.unit.SetCharmState(ctx.charmState); err != nil {
+			err = errors.Annotatef(err, "cannot write charm state")
+			logger.Errorf("%v", err)
+			if ctxErr == nil {
+				ctxErr = err
+			}
+			writeChanges = false
+		}
+	}
+
 	for id, rctx := range ctx.relations {
 		if writeChanges {
 			if e := rctx.WriteSettings(); e != nil {
@@ -215,6 +215,9 @@ func (f *contextFactory) HookContext(hookInfo hook.Info) (*HookContext, error) {
 		}
 		hookName = fmt.Sprintf("%s-%s", storageName, hookName)
 	}
+	if hookInfo.Kind == hook.SecretChanged || hookInfo.Kind == hook.SecretRotate {
+		ctx.secretId = hookInfo.SecretId
+	}
 	ctx.id = f.newId(hookName)
 	return ctx, nil
 }
@@ -298,6 +301,7 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 		return err
 	}
 	ctx.proxySettings = modelConfig.ProxySettings()
+	ctx.hookTimeout = modelConfig.HookTimeout
 
 	principal, ok, err := ctx.unit.PrincipalName()
 	if err != nil {
@@ -97,6 +97,11 @@ func (s *EnvSuite) setRelation(ctx *context.HookContext) (expectVars []string) {
 	}
 }
 
+func (s *EnvSuite) setSecret(ctx *context.HookContext) (expectVars []string) {
+	context.SetEnvironmentHookContextSecret(ctx, "3")
+	return []string{"JUJU_SECRET_ID=3"}
+}
+
 func (s *EnvSuite) TestEnvSetsPath(c *gc.C) {
 	paths := context.OSDependentEnvVars(MockEnvPaths{})
 	c.Assert(paths, gc.Not(gc.HasLen), 0)
@@ -149,4 +154,9 @@ func (s *EnvSuite) TestEnvUbuntu(c *gc.C) {
 	actualVars, err = ctx.HookVars(paths)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertVars(c, actualVars, contextVars, pathsVars, ubuntuVars, relationVars)
+
+	secretVars := s.setSecret(ctx)
+	actualVars, err = ctx.HookVars(paths)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVars(c, actualVars, contextVars, pathsVars, ubuntuVars, relationVars, secretVars)
 }
@@ -103,6 +103,11 @@ func SetEnvironmentHookContextRelation(
 	}
 }
 
+// SetEnvironmentHookContextSecret exists purely to set the fields used in hookVars.
+func SetEnvironmentHookContextSecret(context *HookContext, secretId string) {
+	context.secretId = secretId
+}
+
 func PatchCachedStatus(ctx jujuc.Context, status, info string, data map[string]interface{}) func() {
 	hctx := ctx.(*HookContext)
 	oldStatus := hctx.status
@@ -208,6 +208,74 @@ func (s *FlushContextSuite) TestRunHookAddUnitStorageOnSuccess(c *gc.C) {
 	c.Assert(all, gc.HasLen, 0)
 }
 
+func (s *FlushContextSuite) TestRunHookCharmStateFlushingError(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"base": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := s.context(c)
+
+	err = ctx.SetCharmStateValue("foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteCharmStateValue("base")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with a failure.
+	err = ctx.Flush("some badge", errors.New("blam pow"))
+	c.Assert(err, gc.ErrorMatches, "blam pow")
+
+	// Check that the changes have not been written to state.
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"base": "value"})
+}
+
+func (s *FlushContextSuite) TestRunHookCharmStateWriteError(c *gc.C) {
+	ctx := s.context(c)
+
+	err := ctx.SetCharmStateValue("foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+	relCtx0, err := ctx.Relation(0)
+	c.Assert(err, jc.ErrorIsNil)
+	node0, err := relCtx0.Settings()
+	c.Assert(err, jc.ErrorIsNil)
+	node0.Set("baz", "3")
+
+	// The charm state of a dead unit cannot be written.
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Flush the context with a success.
+	err = ctx.Flush("some badge", nil)
+	c.Assert(err, gc.ErrorMatches, "cannot write charm state: .*")
+
+	// Check that the relation settings have not been written either.
+	settings0, err := s.relunits[0].ReadSettings("u/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings0, gc.DeepEquals, map[string]interface{}{"relation-name": "db0"})
+}
+
+func (s *FlushContextSuite) TestRunHookCharmStateFlushingSuccess(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"base": "value"})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := s.context(c)
+
+	err = ctx.SetCharmStateValue("foo", "bar")
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.DeleteCharmStateValue("base")
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err := ctx.GetCharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
+
+	// Flush the context with a success.
+	err = ctx.Flush("some badge", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Check that the changes have been written to state.
+	charmState, err = s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
 func (s *HookContextSuite) context(c *gc.C) *context.HookContext {
 	uuid, err := utils.NewUUID()
 	c.Assert(err, jc.ErrorIsNil)
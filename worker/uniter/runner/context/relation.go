@@ -25,6 +25,10 @@ type ContextRelation struct {
 	// settings allows read and write access to the relation unit settings.
 	settings *uniter.Settings
 
+	// applicationSettings allows read and write access to the
+	// application-level settings of the unit's application.
+	applicationSettings *uniter.Settings
+
 	// cache holds remote unit membership and settings.
 	cache *RelationCache
 }
@@ -71,10 +75,31 @@ func (ctx *ContextRelation) Settings() (jujuc.Settings, error) {
 	return ctx.settings, nil
 }
 
-// WriteSettings persists all changes made to the unit's relation settings.
+func (ctx *ContextRelation) ApplicationSettings() (jujuc.Settings, error) {
+	if ctx.applicationSettings == nil {
+		node, err := ctx.ru.ApplicationSettings()
+		if err != nil {
+			return nil, err
+		}
+		ctx.applicationSettings = node
+	}
+	return ctx.applicationSettings, nil
+}
+
+func (ctx *ContextRelation) ReadApplicationSettings(app string) (params.Settings, error) {
+	return ctx.ru.ReadApplicationSettings(app)
+}
+
+// WriteSettings persists all changes made to the unit's and the
+// application's relation settings.
 func (ctx *ContextRelation) WriteSettings() (err error) {
 	if ctx.settings != nil {
-		err = ctx.settings.Write()
+		if err = ctx.settings.Write(); err != nil {
+			return
+		}
+	}
+	if ctx.applicationSettings != nil {
+		err = ctx.applicationSettings.Write()
 	}
 	return
 }
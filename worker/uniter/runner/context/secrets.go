@@ -0,0 +1,50 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package context
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (ctx *HookContext) CreateSecret(args *jujuc.SecretCreateArgs) (string, error) {
+	id, err := ctx.state.CreateSecret(uniter.SecretCreateArgs{
+		Label:          args.Label,
+		Description:    args.Description,
+		Data:           args.Data,
+		RotateInterval: args.RotateInterval,
+	})
+	return id, errors.Trace(err)
+}
+
+// UpdateSecret implements jujuc.ContextSecrets.
+func (ctx *HookContext) UpdateSecret(id string, data map[string]string) error {
+	return errors.Trace(ctx.state.UpdateSecret(id, data))
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (ctx *HookContext) GetSecret(id string) (map[string]string, error) {
+	data, err := ctx.state.SecretValue(id)
+	return data, errors.Trace(err)
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (ctx *HookContext) GrantSecret(id string, relationId int) error {
+	relation, found := ctx.relations[relationId]
+	if !found {
+		return errors.NotFoundf("relation %d", relationId)
+	}
+	return errors.Trace(ctx.state.GrantSecret(id, relation.ru.Relation().Tag()))
+}
+
+// HookSecretId implements jujuc.ContextSecrets.
+func (ctx *HookContext) HookSecretId() (string, error) {
+	if ctx.secretId == "" {
+		return "", errors.NotFoundf("hook secret")
+	}
+	return ctx.secretId, nil
+}
@@ -20,7 +20,7 @@ func (*DebugHooksClientSuite) TestClientScript(c *gc.C) {
 	ctx := debug.NewHooksContext("foo/8")
 
 	// Test the variable substitutions.
-	result := debug.ClientScript(ctx, nil)
+	result := debug.ClientScript(ctx, nil, "")
 	// No variables left behind.
 	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*{unit_name}(.|\n)*")
 	c.Assert(result, gc.Not(gc.Matches), "(.|\n)*{tmux_conf}(.|\n)*")
@@ -36,9 +36,9 @@ func (*DebugHooksClientSuite) TestClientScript(c *gc.C) {
 	// nil is the same as empty slice is the same as "*".
 	// Also, if "*" is present as well as a named hook,
 	// it is equivalent to "*".
-	c.Assert(debug.ClientScript(ctx, nil), gc.Equals, debug.ClientScript(ctx, []string{}))
-	c.Assert(debug.ClientScript(ctx, []string{"*"}), gc.Equals, debug.ClientScript(ctx, nil))
-	c.Assert(debug.ClientScript(ctx, []string{"*", "something"}), gc.Equals, debug.ClientScript(ctx, []string{"*"}))
+	c.Assert(debug.ClientScript(ctx, nil, ""), gc.Equals, debug.ClientScript(ctx, []string{}, ""))
+	c.Assert(debug.ClientScript(ctx, []string{"*"}, ""), gc.Equals, debug.ClientScript(ctx, nil, ""))
+	c.Assert(debug.ClientScript(ctx, []string{"*", "something"}, ""), gc.Equals, debug.ClientScript(ctx, []string{"*"}, ""))
 
 	// debug.ClientScript does not validate hook names, as it doesn't have
 	// a full state API connection to determine valid relation hooks.
@@ -46,5 +46,14 @@ func (*DebugHooksClientSuite) TestClientScript(c *gc.C) {
 		`(.|\n)*echo "aG9va3M6Ci0gc29tZXRoaW5nIHNvbWV0aGluZ2Vsc2UK" | base64 -d > %s(.|\n)*`,
 		regexp.QuoteMeta(ctx.ClientFileLock()),
 	)
-	c.Assert(debug.ClientScript(ctx, []string{"something somethingelse"}), gc.Matches, expected)
+	c.Assert(debug.ClientScript(ctx, []string{"something somethingelse"}, ""), gc.Matches, expected)
+}
+
+func (*DebugHooksClientSuite) TestClientScriptDebugAt(c *gc.C) {
+	ctx := debug.NewHooksContext("foo/8")
+	expected := fmt.Sprintf(
+		`(.|\n)*echo "aG9va3M6Ci0gc3RhcnQKZGVidWctYXQ6IGFsbAo=" | base64 -d > %s(.|\n)*`,
+		regexp.QuoteMeta(ctx.ClientFileLock()),
+	)
+	c.Assert(debug.ClientScript(ctx, []string{"start"}, "all"), gc.Matches, expected)
 }
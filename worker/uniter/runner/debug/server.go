@@ -10,19 +10,28 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/juju/utils/set"
 	goyaml "gopkg.in/yaml.v2"
 )
 
-// ServerSession represents a "juju debug-hooks" session.
+// ServerSession represents a "juju debug-hooks" or "juju debug-code"
+// session.
 type ServerSession struct {
 	*HooksContext
-	hooks set.Strings
+	hooks   set.Strings
+	debugAt string
 
 	output io.Writer
 }
 
+// DebugAt returns the breakpoints requested by a "juju debug-code"
+// client. It is empty for a "juju debug-hooks" session.
+func (s *ServerSession) DebugAt() string {
+	return s.debugAt
+}
+
 // MatchHook returns true if the specified hook name matches
 // the hook specified by the debug-hooks client.
 func (s *ServerSession) MatchHook(hookName string) bool {
@@ -37,12 +46,23 @@ var waitClientExit = func(s *ServerSession) {
 }
 
 // RunHook "runs" the hook with the specified name via debug-hooks.
-func (s *ServerSession) RunHook(hookName, charmDir string, env []string) error {
+// In a debug-hooks session, the hook is replaced by an interactive
+// shell. In a debug-code session, hookRunner, the path of the hook's
+// executable, is run in the shell's place, with JUJU_DEBUG_AT set.
+func (s *ServerSession) RunHook(hookName, charmDir string, env []string, hookRunner string) error {
 	env = append(env, "JUJU_HOOK_NAME="+hookName)
+	script := strings.Replace(debugHooksServerScript, "{hook_runner}", debugHooksShell, 1)
+	if s.debugAt != "" {
+		env = append(env,
+			"JUJU_DEBUG_AT="+s.debugAt,
+			"JUJU_DEBUG_HOOK="+hookRunner,
+		)
+		script = strings.Replace(debugHooksServerScript, "{hook_runner}", debugCodeRunner, 1)
+	}
 	cmd := exec.Command("/bin/bash", "-s")
 	cmd.Env = env
 	cmd.Dir = charmDir
-	cmd.Stdin = bytes.NewBufferString(debugHooksServerScript)
+	cmd.Stdin = bytes.NewBufferString(script)
 	if s.output != nil {
 		cmd.Stdout = s.output
 		cmd.Stderr = s.output
@@ -83,7 +103,7 @@ func (c *HooksContext) FindSession() (*ServerSession, error) {
 		return nil, err
 	}
 	hooks := set.NewStrings(args.Hooks...)
-	session := &ServerSession{HooksContext: c, hooks: hooks}
+	session := &ServerSession{HooksContext: c, hooks: hooks, debugAt: args.DebugAt}
 	return session, nil
 }
 
@@ -122,7 +142,7 @@ cat > $JUJU_DEBUG/hook.sh <<END
 #!/bin/bash
 . $JUJU_DEBUG/env.sh
 echo \$\$ > $JUJU_DEBUG/hook.pid
-exec /bin/bash --noprofile --init-file $JUJU_DEBUG/init.sh
+{hook_runner}
 END
 chmod +x $JUJU_DEBUG/hook.sh
 
@@ -147,3 +167,13 @@ done
 typeset -i exitstatus=$(cat $JUJU_DEBUG/hook_exit_status)
 exit $exitstatus
 `
+
+// debugHooksShell replaces the hook with an interactive shell in
+// debug-hooks sessions. The shell records its exit status when it exits.
+const debugHooksShell = `exec /bin/bash --noprofile --init-file $JUJU_DEBUG/init.sh`
+
+// debugCodeRunner runs the hook itself in debug-code sessions, so that
+// the charm can stop at the breakpoints named by JUJU_DEBUG_AT.
+const debugCodeRunner = `echo "Running $JUJU_HOOK_NAME with JUJU_DEBUG_AT=$JUJU_DEBUG_AT"
+"\$JUJU_DEBUG_HOOK"
+echo \$? > $JUJU_DEBUG/hook_exit_status`
@@ -94,6 +94,16 @@ func (s *DebugHooksServerSuite) TestFindSession(c *gc.C) {
 	c.Assert(session.MatchHook("bar"), jc.IsTrue)
 	c.Assert(session.MatchHook("baz"), jc.IsTrue)
 	c.Assert(session.MatchHook("foo bar baz"), jc.IsFalse)
+	c.Assert(session.DebugAt(), gc.Equals, "")
+
+	// Hooks file is present, with breakpoints for debug-code.
+	err = ioutil.WriteFile(s.ctx.ClientFileLock(), []byte("hooks: [foo]\ndebug-at: all"), 0777)
+	c.Assert(err, jc.ErrorIsNil)
+	session, err = s.ctx.FindSession()
+	c.Assert(session, gc.NotNil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.MatchHook("foo"), jc.IsTrue)
+	c.Assert(session.DebugAt(), gc.Equals, "all")
 }
 
 func (s *DebugHooksServerSuite) TestRunHookExceptional(c *gc.C) {
@@ -118,7 +128,7 @@ func (s *DebugHooksServerSuite) TestRunHookExceptional(c *gc.C) {
 	s.PatchValue(&waitClientExit, func(*ServerSession) {
 		flockAcquired <- struct{}{}
 	})
-	err = session.RunHook("myhook", s.tmpdir, os.Environ())
+	err = session.RunHook("myhook", s.tmpdir, os.Environ(), "")
 	c.Assert(err, gc.ErrorMatches, "signal: [kK]illed")
 	waitForFlock()
 
@@ -134,7 +144,7 @@ func (s *DebugHooksServerSuite) TestRunHookExceptional(c *gc.C) {
 		flockAcquired <- struct{}{}
 	})
 	go func() { ch <- true }() // asynchronously release the flock
-	err = session.RunHook("myhook", s.tmpdir, os.Environ())
+	err = session.RunHook("myhook", s.tmpdir, os.Environ(), "")
 	waitForFlock()
 	c.Assert(clientExited, jc.IsTrue)
 	c.Assert(err, gc.ErrorMatches, "signal: [kK]illed")
@@ -160,7 +170,7 @@ func (s *DebugHooksServerSuite) TestRunHook(c *gc.C) {
 
 	ch := make(chan error)
 	go func() {
-		ch <- session.RunHook(hookName, s.tmpdir, os.Environ())
+		ch <- session.RunHook(hookName, s.tmpdir, os.Environ(), "")
 	}()
 
 	// Wait until either we find the debug dir, or the flock is released.
@@ -0,0 +1,192 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+const (
+	// HookSucceeded is the result recorded for hooks that completed
+	// successfully.
+	HookSucceeded = "success"
+
+	// HookFailed is the result recorded for hooks that failed.
+	HookFailed = "failure"
+
+	// HookTimedOut is the result recorded for hooks that were killed
+	// for running longer than their timeout.
+	HookTimedOut = "timeout"
+)
+
+const (
+	hookLabel   = "hook"
+	resultLabel = "result"
+)
+
+// HookRunRecorder records the hook runs of a unit on the controller.
+type HookRunRecorder interface {
+	RecordHookRuns(runs []params.HookRun) error
+}
+
+// HookMetricsConfig holds the configuration for a Factory that records
+// the duration and outcome of the hooks it runs.
+type HookMetricsConfig struct {
+	// Factory is the Factory whose hook runners are measured.
+	Factory Factory
+
+	// Clock is used to time hook runs.
+	Clock clock.Clock
+
+	// Recorder records each hook run on the controller.
+	Recorder HookRunRecorder
+
+	// PrometheusRegisterer, if non-nil, is used to register the hook
+	// metrics so that they can be read through the agent's
+	// introspection socket.
+	PrometheusRegisterer prometheus.Registerer
+}
+
+// Validate checks that the config is valid.
+func (config HookMetricsConfig) Validate() error {
+	if config.Factory == nil {
+		return errors.NotValidf("nil Factory")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Recorder == nil {
+		return errors.NotValidf("nil Recorder")
+	}
+	return nil
+}
+
+// NewHookMetricsFactory returns a Factory that creates runners as the
+// configured Factory does, but that records the duration and outcome
+// of each hook run. Hook runs are counted by hook kind and result, and
+// recorded individually on the controller. Missing hooks are not
+// recorded.
+func NewHookMetricsFactory(config HookMetricsConfig) (Factory, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating config")
+	}
+	f := &hookMetricsFactory{
+		Factory:  config.Factory,
+		clock:    config.Clock,
+		recorder: config.Recorder,
+	}
+	if config.PrometheusRegisterer == nil {
+		return f, nil
+	}
+
+	f.hooksTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "juju",
+		Subsystem: "uniter",
+		Name:      "hooks_total",
+		Help:      "Number of hooks run by the unit agent.",
+	}, []string{hookLabel, resultLabel})
+
+	f.hookDuration = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Namespace: "juju",
+		Subsystem: "uniter",
+		Name:      "hook_duration_seconds",
+		Help:      "Duration of hooks run by the unit agent in seconds.",
+	}, []string{hookLabel, resultLabel})
+
+	config.PrometheusRegisterer.Unregister(f.hooksTotal)
+	if err := config.PrometheusRegisterer.Register(f.hooksTotal); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	config.PrometheusRegisterer.Unregister(f.hookDuration)
+	if err := config.PrometheusRegisterer.Register(f.hookDuration); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+type hookMetricsFactory struct {
+	Factory
+	clock    clock.Clock
+	recorder HookRunRecorder
+
+	// hooksTotal and hookDuration are nil if the metrics are not
+	// registered.
+	hooksTotal   *prometheus.CounterVec
+	hookDuration *prometheus.SummaryVec
+}
+
+// NewHookRunner is part of the Factory interface.
+func (f *hookMetricsFactory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
+	runner, err := f.Factory.NewHookRunner(hookInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &hookMetricsRunner{
+		Runner:  runner,
+		factory: f,
+		kind:    string(hookInfo.Kind),
+	}, nil
+}
+
+// record records a run of the named hook, which is of the given kind.
+func (f *hookMetricsFactory) record(kind, name string, started time.Time, duration time.Duration, result string) {
+	if f.hooksTotal != nil {
+		f.hooksTotal.WithLabelValues(kind, result).Inc()
+		f.hookDuration.WithLabelValues(kind, result).Observe(duration.Seconds())
+	}
+	err := f.recorder.RecordHookRuns([]params.HookRun{{
+		Hook:     name,
+		Started:  started,
+		Duration: duration,
+		Result:   result,
+	}})
+	if errors.IsNotImplemented(err) {
+		// The controller is too old to record hook runs.
+		logger.Tracef("not recording %q hook run: %v", name, err)
+	} else if err != nil {
+		logger.Warningf("cannot record %q hook run: %v", name, err)
+	}
+}
+
+// hookMetricsRunner is a Runner that records the duration and outcome
+// of the hook it runs.
+type hookMetricsRunner struct {
+	Runner
+	factory *hookMetricsFactory
+	kind    string
+}
+
+// RunHook is part of the Runner interface.
+func (r *hookMetricsRunner) RunHook(name string) error {
+	started := r.factory.clock.Now()
+	err := r.Runner.RunHook(name)
+	cause := errors.Cause(err)
+	if context.IsMissingHookError(cause) {
+		return err
+	}
+	duration := r.factory.clock.Now().Sub(started)
+	r.factory.record(r.kind, name, started, duration, hookResult(cause))
+	return err
+}
+
+// hookResult returns the result to record for a hook that finished
+// with the given error.
+func hookResult(err error) string {
+	switch {
+	case err == nil, err == context.ErrReboot, err == context.ErrRequeueAndReboot:
+		return HookSucceeded
+	case IsHookTimeoutError(err):
+		return HookTimedOut
+	}
+	return HookFailed
+}
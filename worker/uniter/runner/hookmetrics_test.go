@@ -0,0 +1,188 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable/hooks"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/runner"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+type HookMetricsSuite struct {
+	testing.IsolationSuite
+	clock    *testing.Clock
+	registry *prometheus.Registry
+	factory  *stubHookFactory
+	recorder *stubHookRunRecorder
+}
+
+var _ = gc.Suite(&HookMetricsSuite{})
+
+func (s *HookMetricsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.registry = prometheus.NewPedanticRegistry()
+	s.factory = &stubHookFactory{clock: s.clock}
+	s.recorder = &stubHookRunRecorder{}
+}
+
+func (s *HookMetricsSuite) newFactory(c *gc.C) runner.Factory {
+	f, err := runner.NewHookMetricsFactory(runner.HookMetricsConfig{
+		Factory:              s.factory,
+		Clock:                s.clock,
+		Recorder:             s.recorder,
+		PrometheusRegisterer: s.registry,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return f
+}
+
+func (s *HookMetricsSuite) runHook(c *gc.C, f runner.Factory, kind hooks.Kind, name string, duration time.Duration, err error) {
+	s.factory.duration = duration
+	s.factory.err = err
+	r, rerr := f.NewHookRunner(hook.Info{Kind: kind})
+	c.Assert(rerr, jc.ErrorIsNil)
+	c.Assert(errors.Cause(r.RunHook(name)), gc.Equals, err)
+}
+
+func (s *HookMetricsSuite) TestValidate(c *gc.C) {
+	_, err := runner.NewHookMetricsFactory(runner.HookMetricsConfig{
+		Clock:    s.clock,
+		Recorder: s.recorder,
+	})
+	c.Assert(err, gc.ErrorMatches, "validating config: nil Factory not valid")
+}
+
+func (s *HookMetricsSuite) TestRecordsHookRuns(c *gc.C) {
+	f := s.newFactory(c)
+	started := s.clock.Now()
+	s.runHook(c, f, hooks.Install, "install", 5*time.Second, nil)
+	s.runHook(c, f, hooks.RelationChanged, "db-relation-changed", time.Second, errors.New("boom"))
+	timeoutErr := runner.NewHookTimeoutError("config-changed", time.Minute)
+	s.runHook(c, f, hooks.ConfigChanged, "config-changed", time.Minute, timeoutErr)
+
+	c.Assert(s.recorder.runs, jc.DeepEquals, []params.HookRun{{
+		Hook:     "install",
+		Started:  started,
+		Duration: 5 * time.Second,
+		Result:   runner.HookSucceeded,
+	}, {
+		Hook:     "db-relation-changed",
+		Started:  started.Add(5 * time.Second),
+		Duration: time.Second,
+		Result:   runner.HookFailed,
+	}, {
+		Hook:     "config-changed",
+		Started:  started.Add(6 * time.Second),
+		Duration: time.Minute,
+		Result:   runner.HookTimedOut,
+	}})
+
+	c.Assert(s.gatherCounters(c), jc.DeepEquals, map[string]float64{
+		"install success":          1,
+		"relation-changed failure": 1,
+		"config-changed timeout":   1,
+	})
+}
+
+func (s *HookMetricsSuite) TestRebootIsSuccess(c *gc.C) {
+	f := s.newFactory(c)
+	s.runHook(c, f, hooks.Install, "install", time.Second, context.ErrReboot)
+	c.Assert(s.recorder.runs, gc.HasLen, 1)
+	c.Assert(s.recorder.runs[0].Result, gc.Equals, runner.HookSucceeded)
+}
+
+func (s *HookMetricsSuite) TestMissingHookNotRecorded(c *gc.C) {
+	f := s.newFactory(c)
+	s.runHook(c, f, hooks.Start, "start", 0, context.NewMissingHookError("start"))
+	c.Assert(s.recorder.runs, gc.HasLen, 0)
+	c.Assert(s.gatherCounters(c), gc.HasLen, 0)
+}
+
+func (s *HookMetricsSuite) TestRecordErrorIgnored(c *gc.C) {
+	s.recorder.err = errors.New("controller unavailable")
+	f := s.newFactory(c)
+	s.runHook(c, f, hooks.Install, "install", time.Second, nil)
+	c.Assert(s.gatherCounters(c), jc.DeepEquals, map[string]float64{
+		"install success": 1,
+	})
+}
+
+func (s *HookMetricsSuite) TestNoRegisterer(c *gc.C) {
+	f, err := runner.NewHookMetricsFactory(runner.HookMetricsConfig{
+		Factory:  s.factory,
+		Clock:    s.clock,
+		Recorder: s.recorder,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.runHook(c, f, hooks.Install, "install", time.Second, nil)
+	c.Assert(s.recorder.runs, gc.HasLen, 1)
+}
+
+func (s *HookMetricsSuite) TestReregister(c *gc.C) {
+	s.newFactory(c)
+	s.newFactory(c)
+}
+
+// gatherCounters returns the values of the hook run counters, keyed by
+// hook kind and result.
+func (s *HookMetricsSuite) gatherCounters(c *gc.C) map[string]float64 {
+	metricFamilies, err := s.registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	counters := make(map[string]float64)
+	for _, family := range metricFamilies {
+		if family.GetName() != "juju_uniter_hooks_total" {
+			continue
+		}
+		for _, metric := range family.Metric {
+			labels := make(map[string]string)
+			for _, label := range metric.Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+			counters[labels["hook"]+" "+labels["result"]] = metric.Counter.GetValue()
+		}
+	}
+	return counters
+}
+
+type stubHookFactory struct {
+	runner.Factory
+	clock    *testing.Clock
+	duration time.Duration
+	err      error
+}
+
+func (f *stubHookFactory) NewHookRunner(hook.Info) (runner.Runner, error) {
+	return &stubHookRunner{factory: f}, nil
+}
+
+type stubHookRunner struct {
+	runner.Runner
+	factory *stubHookFactory
+}
+
+func (r *stubHookRunner) RunHook(name string) error {
+	r.factory.clock.Advance(r.factory.duration)
+	return r.factory.err
+}
+
+type stubHookRunRecorder struct {
+	runs []params.HookRun
+	err  error
+}
+
+func (r *stubHookRunRecorder) RecordHookRuns(runs []params.HookRun) error {
+	r.runs = append(r.runs, runs...)
+	return r.err
+}
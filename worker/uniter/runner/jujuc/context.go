@@ -53,6 +53,8 @@ type HookContext interface {
 	ContextComponents
 	ContextRelations
 	ContextVersion
+	ContextSecrets
+	ContextCharmState
 }
 
 // UnitHookContext is the context for a unit hook.
@@ -97,6 +99,9 @@ type actionHookContext interface {
 
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
+
+	// LogActionMessage records a progress message for the Action.
+	LogActionMessage(string) error
 }
 
 // ContextUnit is the part of a hook context related to the unit.
@@ -106,6 +111,15 @@ type ContextUnit interface {
 
 	// Config returns the current service configuration of the executing unit.
 	ConfigSettings() (charm.Settings, error)
+
+	// CloudSpec returns the cloud specification, including the
+	// credential, of the model the unit is in. The unit's application
+	// must be trusted.
+	CloudSpec() (*params.CloudSpec, error)
+
+	// GoalState returns the units expected for the unit's application
+	// and for each of its relations, along with their current status.
+	GoalState() (*params.GoalState, error)
 }
 
 // ContextStatus is the part of a hook context related to the unit's status.
@@ -264,6 +278,15 @@ type ContextRelation interface {
 
 	// ReadSettings returns the settings of any remote unit in the relation.
 	ReadSettings(unit string) (params.Settings, error)
+
+	// ApplicationSettings allows read/write access to the local
+	// application's settings in this relation. Only the leader may
+	// write them.
+	ApplicationSettings() (Settings, error)
+
+	// ReadApplicationSettings returns the application-level settings
+	// of any application in the relation.
+	ReadApplicationSettings(app string) (params.Settings, error)
 }
 
 // ContextStorageAttachment expresses the capabilities of a hook with
@@ -294,6 +317,46 @@ type ContextVersion interface {
 	SetUnitWorkloadVersion(string) error
 }
 
+// ContextSecrets is the part of a hook context related to secrets
+// owned by, or granted to, the unit's application.
+type ContextSecrets interface {
+	// CreateSecret creates a secret owned by the unit's application,
+	// and returns its id. Only the leader may create secrets.
+	CreateSecret(args *SecretCreateArgs) (string, error)
+
+	// UpdateSecret stores a new value for the secret with the given
+	// id, creating a new revision. Only the leader of the owner
+	// application may update a secret.
+	UpdateSecret(id string, data map[string]string) error
+
+	// GetSecret returns the value of the secret with the given id.
+	GetSecret(id string) (map[string]string, error)
+
+	// GrantSecret grants the applications in the relation with the
+	// given id access to the secret.
+	GrantSecret(id string, relationId int) error
+
+	// HookSecretId returns the id of the secret associated with the
+	// executing hook if it was found, and an error if it was not found
+	// or is not available.
+	HookSecretId() (string, error)
+}
+
+// SecretCreateArgs holds the arguments for creating a secret.
+type SecretCreateArgs struct {
+	// Label and Description help identify the secret.
+	Label       string
+	Description string
+
+	// RotateInterval, if positive, is the interval at which the
+	// secret-rotate hook is run to ask the leader to rotate the
+	// secret.
+	RotateInterval time.Duration
+
+	// Data holds the secret's key/value pairs.
+	Data map[string]string
+}
+
 // Settings is implemented by types that manipulate unit settings.
 type Settings interface {
 	Map() params.Settings
@@ -388,3 +451,18 @@ func (v *storageIdValue) Set(value string) error {
 	*v.result = tag
 	return nil
 }
+
+// ContextCharmState is the part of a hook context related to the
+// key/value state that the charm stores for the unit in the controller.
+type ContextCharmState interface {
+	// GetCharmState returns a copy of the charm state of the unit.
+	GetCharmState() (map[string]string, error)
+
+	// SetCharmStateValue sets the value of the key in the charm state
+	// of the unit. Changes are written when the hook completes.
+	SetCharmStateValue(key, value string) error
+
+	// DeleteCharmStateValue removes the key from the charm state of
+	// the unit. Changes are written when the hook completes.
+	DeleteCharmStateValue(key string) error
+}
@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// credentialGetCommand implements the credential-get command.
+type credentialGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewCredentialGetCommand returns a new credentialGetCommand with the
+// given context.
+func NewCredentialGetCommand(ctx Context) (cmd.Command, error) {
+	return &credentialGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *credentialGetCommand) Info() *cmd.Info {
+	doc := `
+credential-get prints the specification of the cloud the model is running
+in, including the credential used to access it. Only units of applications
+that have been trusted with "juju trust" may read the cloud specification.
+`
+	return &cmd.Info{
+		Name:    "credential-get",
+		Purpose: "print the cloud specification and credential of the model",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *credentialGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *credentialGetCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *credentialGetCommand) Run(ctx *cmd.Context) error {
+	spec, err := c.ctx.CloudSpec()
+	if err != nil {
+		return errors.Annotate(err, "cannot access cloud credentials")
+	}
+	return c.out.Write(ctx, formatCloudSpec(spec))
+}
+
+type cloudSpecOutput struct {
+	Type             string            `yaml:"type" json:"type"`
+	Name             string            `yaml:"name" json:"name"`
+	Region           string            `yaml:"region,omitempty" json:"region,omitempty"`
+	Endpoint         string            `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
+	IdentityEndpoint string            `yaml:"identity-endpoint,omitempty" json:"identity-endpoint,omitempty"`
+	StorageEndpoint  string            `yaml:"storage-endpoint,omitempty" json:"storage-endpoint,omitempty"`
+	Credential       *credentialOutput `yaml:"credential,omitempty" json:"credential,omitempty"`
+}
+
+type credentialOutput struct {
+	AuthType   string            `yaml:"auth-type" json:"auth-type"`
+	Attributes map[string]string `yaml:"attrs,omitempty" json:"attrs,omitempty"`
+}
+
+func formatCloudSpec(spec *params.CloudSpec) cloudSpecOutput {
+	out := cloudSpecOutput{
+		Type:             spec.Type,
+		Name:             spec.Name,
+		Region:           spec.Region,
+		Endpoint:         spec.Endpoint,
+		IdentityEndpoint: spec.IdentityEndpoint,
+		StorageEndpoint:  spec.StorageEndpoint,
+	}
+	if spec.Credential != nil {
+		out.Credential = &credentialOutput{
+			AuthType:   spec.Credential.AuthType,
+			Attributes: spec.Credential.Attributes,
+		}
+	}
+	return out
+}
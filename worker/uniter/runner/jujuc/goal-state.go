@@ -0,0 +1,100 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// goalStateCommand implements the goal-state command.
+type goalStateCommand struct {
+	cmd.CommandBase
+	ctx Context
+	out cmd.Output
+}
+
+// NewGoalStateCommand returns a new goalStateCommand with the given
+// context.
+func NewGoalStateCommand(ctx Context) (cmd.Command, error) {
+	return &goalStateCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *goalStateCommand) Info() *cmd.Info {
+	doc := `
+goal-state prints the units that the controller expects for this
+application, and for each of its relations the related applications and
+their units, along with their current status. Charms can use it to wait
+until a deployment has converged, for example before bootstrapping a
+cluster.
+`
+	return &cmd.Info{
+		Name:    "goal-state",
+		Purpose: "print the expected units and relations of the application",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *goalStateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *goalStateCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *goalStateCommand) Run(ctx *cmd.Context) error {
+	goal, err := c.ctx.GoalState()
+	if err != nil {
+		return errors.Annotate(err, "cannot read goal state")
+	}
+	return c.out.Write(ctx, formatGoalState(goal))
+}
+
+type goalStateOutput struct {
+	Units     unitsGoalStateOutput            `yaml:"units" json:"units"`
+	Relations map[string]unitsGoalStateOutput `yaml:"relations" json:"relations"`
+}
+
+type unitsGoalStateOutput map[string]goalStateStatusOutput
+
+type goalStateStatusOutput struct {
+	Status string `yaml:"status" json:"status"`
+	Since  string `yaml:"since,omitempty" json:"since,omitempty"`
+}
+
+func formatGoalState(goal *params.GoalState) goalStateOutput {
+	out := goalStateOutput{
+		Units:     formatUnitsGoalState(goal.Units),
+		Relations: make(map[string]unitsGoalStateOutput),
+	}
+	for endpoint, units := range goal.Relations {
+		out.Relations[endpoint] = formatUnitsGoalState(units)
+	}
+	return out
+}
+
+func formatUnitsGoalState(units params.UnitsGoalState) unitsGoalStateOutput {
+	out := make(unitsGoalStateOutput)
+	for name, status := range units {
+		var since string
+		if status.Since != nil {
+			since = status.Since.UTC().Format(time.RFC3339)
+		}
+		out[name] = goalStateStatusOutput{
+			Status: status.Status,
+			Since:  since,
+		}
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type GoalStateSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&GoalStateSuite{})
+
+func (s *GoalStateSuite) TestInitError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *GoalStateSuite) TestGoalState(c *gc.C) {
+	since := time.Date(2017, 9, 1, 10, 30, 0, 0, time.UTC)
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Unit.GoalState = &params.GoalState{
+		Units: params.UnitsGoalState{
+			"mysql/0": {Status: "active", Since: &since},
+			"mysql/1": {Status: "waiting", Since: &since},
+		},
+		Relations: map[string]params.UnitsGoalState{
+			"db": {
+				"wordpress":   {Status: "joined"},
+				"wordpress/0": {Status: "maintenance", Since: &since},
+			},
+		},
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(bufferString(ctx.Stdout), gc.Equals, `
+units:
+  mysql/0:
+    status: active
+    since: 2017-09-01T10:30:00Z
+  mysql/1:
+    status: waiting
+    since: 2017-09-01T10:30:00Z
+relations:
+  db:
+    wordpress:
+      status: joined
+    wordpress/0:
+      status: maintenance
+      since: 2017-09-01T10:30:00Z
+`[1:])
+}
+
+func (s *GoalStateSuite) TestGoalStateError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.New("boom"))
+	com, err := jujuc.NewCommand(hctx, cmdString("goal-state"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Assert(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot read goal state: boom\n")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 )
@@ -21,9 +22,11 @@ type RelationGetCommand struct {
 	RelationId      int
 	relationIdProxy gnuflag.Value
 
-	Key      string
-	UnitName string
-	out      cmd.Output
+	Key             string
+	UnitName        string
+	Application     bool
+	ApplicationName string
+	out             cmd.Output
 }
 
 func NewRelationGetCommand(ctx Context) (cmd.Command, error) {
@@ -43,6 +46,10 @@ func (c *RelationGetCommand) Info() *cmd.Info {
 	doc := `
 relation-get prints the value of a unit's relation setting, specified by key.
 If no key is given, or if the key is "-", all keys and values will be printed.
+
+The --app option prints the settings of an application rather than those of
+a unit. The application may be given by name, or by the id of one of its
+units; it defaults to the application of the remote unit.
 `
 	// There's nothing we can really do about the error here.
 	if name, err := c.ctx.RemoteUnitName(); err == nil {
@@ -64,6 +71,7 @@ func (c *RelationGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.Var(c.relationIdProxy, "r", "specify a relation by id")
 	f.Var(c.relationIdProxy, "relation", "")
+	f.BoolVar(&c.Application, "app", false, "get the settings of an application rather than a unit")
 }
 
 // Init is part of the cmd.Command interface.
@@ -88,6 +96,17 @@ func (c *RelationGetCommand) Init(args []string) error {
 		c.UnitName = args[0]
 		args = args[1:]
 	}
+	if c.Application {
+		c.ApplicationName = c.UnitName
+		c.UnitName = ""
+		if names.IsValidUnit(c.ApplicationName) {
+			c.ApplicationName, _ = names.UnitApplication(c.ApplicationName)
+		}
+		if c.ApplicationName == "" {
+			return fmt.Errorf("no application specified")
+		}
+		return cmd.CheckEmpty(args)
+	}
 	if c.UnitName == "" {
 		return fmt.Errorf("no unit id specified")
 	}
@@ -100,7 +119,12 @@ func (c *RelationGetCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	var settings params.Settings
-	if c.UnitName == c.ctx.UnitName() {
+	if c.Application {
+		settings, err = c.applicationSettings(r)
+		if err != nil {
+			return err
+		}
+	} else if c.UnitName == c.ctx.UnitName() {
 		node, err := r.Settings()
 		if err != nil {
 			return err
@@ -121,3 +145,21 @@ func (c *RelationGetCommand) Run(ctx *cmd.Context) error {
 	}
 	return c.out.Write(ctx, nil)
 }
+
+// applicationSettings returns the settings of the application given on
+// the command line. The local application's settings are read from the
+// context, so that any changes made in the current hook are visible.
+func (c *RelationGetCommand) applicationSettings(r ContextRelation) (params.Settings, error) {
+	localApp, err := names.UnitApplication(c.ctx.UnitName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if c.ApplicationName != localApp {
+		return r.ReadApplicationSettings(c.ApplicationName)
+	}
+	node, err := r.ApplicationSettings()
+	if err != nil {
+		return nil, err
+	}
+	return node.Map(), nil
+}
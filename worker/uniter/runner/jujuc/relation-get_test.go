@@ -222,6 +222,8 @@ Summary:
 get relation settings
 
 Options:
+--app  (= false)
+    get the settings of an application rather than a unit
 --format  (= smart)
     Specify output format (json|smart|yaml)
 -o, --output (= "")
@@ -232,6 +234,10 @@ Options:
 Details:
 relation-get prints the value of a unit's relation setting, specified by key.
 If no key is given, or if the key is "-", all keys and values will be printed.
+
+The --app option prints the settings of an application rather than those of
+a unit. The application may be given by name, or by the id of one of its
+units; it defaults to the application of the remote unit.
 %s`[1:]
 
 var relationGetHelpTests = []struct {
@@ -278,6 +284,68 @@ func (s *RelationGetSuite) TestHelp(c *gc.C) {
 	}
 }
 
+var relationGetAppTests = []struct {
+	summary string
+	unit    string
+	args    []string
+	code    int
+	out     string
+}{
+	{
+		summary: "remote application from default unit",
+		unit:    "m/0",
+		args:    []string{"--app"},
+		out:     "remote: value",
+	}, {
+		summary: "remote application key",
+		unit:    "m/0",
+		args:    []string{"--app", "remote"},
+		out:     "value",
+	}, {
+		summary: "local application by name",
+		unit:    "m/0",
+		args:    []string{"--app", "-", "u"},
+		out:     "local: value",
+	}, {
+		summary: "local application by unit",
+		args:    []string{"--app", "local", "u/0"},
+		out:     "value",
+	}, {
+		summary: "no default application",
+		args:    []string{"--app"},
+		code:    2,
+		out:     "no application specified",
+	}, {
+		summary: "unknown application",
+		args:    []string{"--app", "-", "bad"},
+		code:    1,
+		out:     "unknown application bad",
+	},
+}
+
+func (s *RelationGetSuite) TestRelationGetApplication(c *gc.C) {
+	for i, t := range relationGetAppTests {
+		c.Logf("test %d: %s", i, t.summary)
+		hctx, info := s.newHookContext(1, t.unit)
+		info.rels[1].ApplicationName = "u"
+		info.rels[1].SetApplicationSettings("u", jujuctesting.Settings{"local": "value"})
+		info.rels[1].SetApplicationSettings("m", jujuctesting.Settings{"remote": "value"})
+		com, err := jujuc.NewCommand(hctx, cmdString("relation-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, t.code)
+		if code == 0 {
+			c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+			c.Check(bufferString(ctx.Stdout), gc.Equals, t.out+"\n")
+		} else {
+			c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+			expect := fmt.Sprintf(`(.|\n)*ERROR %s\n`, t.out)
+			c.Check(bufferString(ctx.Stderr), gc.Matches, expect)
+		}
+	}
+}
+
 func (s *RelationGetSuite) TestOutputPath(c *gc.C) {
 	hctx, _ := s.newHookContext(1, "m/0")
 	com, err := jujuc.NewCommand(hctx, cmdString("relation-get"))
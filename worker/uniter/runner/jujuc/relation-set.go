@@ -27,6 +27,10 @@ too long to fit within the command length limit of the shell or
 operating system. The file will contain a YAML map containing the
 settings.  Settings in the file will be overridden by any duplicate
 key-value arguments. A value of "-" for the filename means <stdin>.
+
+The --app option writes the settings of the local unit's application
+rather than those of the unit. Application settings are shared by all
+the units of the application, and may only be written by the leader.
 `
 
 // RelationSetCommand implements the relation-set command.
@@ -37,6 +41,7 @@ type RelationSetCommand struct {
 	relationIdProxy gnuflag.Value
 	Settings        map[string]string
 	settingsFile    cmd.FileVar
+	Application     bool
 	formatFlag      string // deprecated
 }
 
@@ -67,6 +72,7 @@ func (c *RelationSetCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	c.settingsFile.SetStdin()
 	f.Var(&c.settingsFile, "file", "file containing key-value pairs")
+	f.BoolVar(&c.Application, "app", false, "set the settings of the local application rather than the unit")
 
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
 }
@@ -135,9 +141,24 @@ func (c *RelationSetCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	settings, err := r.Settings()
-	if err != nil {
-		return errors.Annotate(err, "cannot read relation settings")
+	var settings Settings
+	if c.Application {
+		isLeader, err := c.ctx.IsLeader()
+		if err != nil {
+			return errors.Annotate(err, "cannot determine leadership")
+		}
+		if !isLeader {
+			return errors.New("cannot write application settings: not the leader")
+		}
+		settings, err = r.ApplicationSettings()
+		if err != nil {
+			return errors.Annotate(err, "cannot read application relation settings")
+		}
+	} else {
+		settings, err = r.Settings()
+		if err != nil {
+			return errors.Annotate(err, "cannot read relation settings")
+		}
 	}
 	for k, v := range c.Settings {
 		if v != "" {
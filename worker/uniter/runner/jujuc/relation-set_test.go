@@ -46,6 +46,8 @@ Summary:
 set relation settings
 
 Options:
+--app  (= false)
+    set the settings of the local application rather than the unit
 --file  (= )
     file containing key-value pairs
 --format (= "")
@@ -65,6 +67,10 @@ too long to fit within the command length limit of the shell or
 operating system. The file will contain a YAML map containing the
 settings.  Settings in the file will be overridden by any duplicate
 key-value arguments. A value of "-" for the filename means <stdin>.
+
+The --app option writes the settings of the local unit's application
+rather than those of the unit. Application settings are shared by all
+the units of the application, and may only be written by the leader.
 `[1:], t.expect))
 		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
 	}
@@ -373,6 +379,35 @@ func (s *RelationSetSuite) TestRun(c *gc.C) {
 	}
 }
 
+func (s *RelationSetSuite) TestRunApplication(c *gc.C) {
+	hctx, info := s.newHookContext(1, "")
+	info.IsLeader = true
+	info.rels[1].ApplicationName = "u"
+	info.rels[1].SetApplicationSettings("u", jujuctesting.Settings{"base": "value"})
+	basic := jujuctesting.Settings{"base": "value"}
+	info.rels[1].Units["u/0"] = basic
+
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, com, "--app", "foo=bar")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(info.rels[1].Units["u/0"], gc.DeepEquals, jujuctesting.Settings{"base": "value"})
+	c.Assert(info.rels[1].Applications["u"], gc.DeepEquals, jujuctesting.Settings{"base": "value", "foo": "bar"})
+}
+
+func (s *RelationSetSuite) TestRunApplicationNotLeader(c *gc.C) {
+	hctx, info := s.newHookContext(1, "")
+	info.rels[1].ApplicationName = "u"
+	info.rels[1].SetApplicationSettings("u", jujuctesting.Settings{"base": "value"})
+
+	com, err := jujuc.NewCommand(hctx, cmdString("relation-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, com, "--app", "foo=bar")
+	c.Assert(err, gc.ErrorMatches, "cannot write application settings: not the leader")
+	c.Assert(info.rels[1].Applications["u"], gc.DeepEquals, jujuctesting.Settings{"base": "value"})
+}
+
 func (s *RelationSetSuite) TestRunDeprecationWarning(c *gc.C) {
 	hctx, _ := s.newHookContext(0, "")
 	com, _ := jujuc.NewCommand(hctx, cmdString("relation-set"))
@@ -25,6 +25,12 @@ type RestrictedContext struct{}
 // ConfigSettings implements jujuc.Context.
 func (*RestrictedContext) ConfigSettings() (charm.Settings, error) { return nil, ErrRestrictedContext }
 
+// CloudSpec implements jujuc.Context.
+func (*RestrictedContext) CloudSpec() (*params.CloudSpec, error) { return nil, ErrRestrictedContext }
+
+// GoalState implements jujuc.Context.
+func (*RestrictedContext) GoalState() (*params.GoalState, error) { return nil, ErrRestrictedContext }
+
 // UnitStatus implements jujuc.Context.
 func (*RestrictedContext) UnitStatus() (*StatusInfo, error) { return nil, ErrRestrictedContext }
 
@@ -138,6 +144,9 @@ func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedC
 // SetActionFailed implements jujuc.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
+// LogActionMessage implements jujuc.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
 // Component implements jujc.Context.
 func (*RestrictedContext) Component(string) (ContextComponent, error) {
 	return nil, ErrRestrictedContext
@@ -152,3 +161,43 @@ func (*RestrictedContext) UnitWorkloadVersion() (string, error) {
 func (*RestrictedContext) SetUnitWorkloadVersion(string) error {
 	return ErrRestrictedContext
 }
+
+// CreateSecret implements jujuc.Context.
+func (*RestrictedContext) CreateSecret(*SecretCreateArgs) (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// UpdateSecret implements jujuc.Context.
+func (*RestrictedContext) UpdateSecret(string, map[string]string) error {
+	return ErrRestrictedContext
+}
+
+// GetSecret implements jujuc.Context.
+func (*RestrictedContext) GetSecret(string) (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// GrantSecret implements jujuc.Context.
+func (*RestrictedContext) GrantSecret(string, int) error {
+	return ErrRestrictedContext
+}
+
+// HookSecretId implements jujuc.Context.
+func (*RestrictedContext) HookSecretId() (string, error) {
+	return "", ErrRestrictedContext
+}
+
+// GetCharmState implements jujuc.Context.
+func (*RestrictedContext) GetCharmState() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// SetCharmStateValue implements jujuc.Context.
+func (*RestrictedContext) SetCharmStateValue(string, string) error {
+	return ErrRestrictedContext
+}
+
+// DeleteCharmStateValue implements jujuc.Context.
+func (*RestrictedContext) DeleteCharmStateValue(string) error {
+	return ErrRestrictedContext
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+)
+
+// secretAddCommand implements the secret-add command.
+type secretAddCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	args SecretCreateArgs
+}
+
+// NewSecretAddCommand returns a new secretAddCommand with the given context.
+func NewSecretAddCommand(ctx Context) (cmd.Command, error) {
+	return &secretAddCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretAddCommand) Info() *cmd.Info {
+	doc := `
+secret-add creates a secret owned by the unit's application, holding the
+supplied key/value pairs, and prints the id of the new secret. The value
+is stored encrypted by the controller, and may only be read with secret-get
+by the units of the application and of any relation the secret is granted
+to with secret-grant. Only the leader may add secrets.
+
+If --rotate is specified, the secret-rotate hook is run on the leader at
+that interval to ask it to store a new value with secret-set.
+
+Examples:
+    secret-add --label db-admin username=admin password=s3cret
+    secret-add --rotate 720h token=abc123
+`
+	return &cmd.Info{
+		Name:    "secret-add",
+		Args:    "<key>=<value> [...]",
+		Purpose: "add a new secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretAddCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.args.Label, "label", "", "a label used to identify the secret")
+	f.StringVar(&c.args.Description, "description", "", "a description of the secret")
+	f.DurationVar(&c.args.RotateInterval, "rotate", 0, "the interval at which the secret should be rotated")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretAddCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no secret data specified")
+	}
+	if c.args.RotateInterval < 0 {
+		return errors.New("rotate interval must not be negative")
+	}
+	c.args.Data, err = keyvalues.Parse(args, false)
+	return errors.Trace(err)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretAddCommand) Run(ctx *cmd.Context) error {
+	id, err := c.ctx.CreateSecret(&c.args)
+	if err != nil {
+		return errors.Annotate(err, "cannot add secret")
+	}
+	_, err = ctx.Stdout.Write([]byte(id + "\n"))
+	return errors.Trace(err)
+}
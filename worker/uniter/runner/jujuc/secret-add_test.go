@@ -0,0 +1,79 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretAddSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretAddSuite{})
+
+func (s *SecretAddSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret data specified",
+	}, {
+		args: []string{"password"},
+		err:  `expected "key=value", got "password"`,
+	}, {
+		args: []string{"password="},
+		err:  `expected "key=value", got "password="`,
+	}, {
+		args: []string{"--rotate", "-1h", "password=s3cret"},
+		err:  "rotate interval must not be negative",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx := s.GetHookContext(c, -1, "")
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = cmdtesting.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretAddSuite) TestAddSecret(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{
+		"--label", "admin", "--description", "admin login", "--rotate", "24h",
+		"username=admin", "password=s3cret",
+	})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "0\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.Secrets.Created, jc.DeepEquals, []jujuc.SecretCreateArgs{{
+		Label:          "admin",
+		Description:    "admin login",
+		RotateInterval: 24 * time.Hour,
+		Data:           map[string]string{"username": "admin", "password": "s3cret"},
+	}})
+}
+
+func (s *SecretAddSuite) TestAddSecretError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	s.Stub.SetErrors(errors.New("not the leader"))
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-add"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"password=s3cret"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot add secret: not the leader\n")
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// secretGetCommand implements the secret-get command.
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	id  string
+	key string
+	out cmd.Output
+}
+
+// NewSecretGetCommand returns a new secretGetCommand with the given context.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGetCommand) Info() *cmd.Info {
+	doc := `
+secret-get prints the value of a key of the secret with the given id. If no
+key is given, all keys and values are printed. When run in a secret-changed
+or secret-rotate hook, the id defaults to that of the secret the hook is
+about, and may be given as "-".
+
+The secret must be owned by the unit's application, or granted to a
+relation the application takes part in.
+
+Examples:
+    secret-get 3
+    secret-get 3 password
+    secret-get - password
+`
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "[<id>] [<key>]",
+		Purpose: "print the value of a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGetCommand) Init(args []string) error {
+	if len(args) > 0 && args[0] != "-" {
+		c.id = args[0]
+	} else {
+		id, err := c.ctx.HookSecretId()
+		if errors.IsNotFound(err) {
+			return errors.New("no secret id specified")
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		c.id = id
+	}
+	if len(args) > 1 {
+		c.key = args[1]
+		return cmd.CheckEmpty(args[2:])
+	}
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	data, err := c.ctx.GetSecret(c.id)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read secret %q", c.id)
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, data)
+	}
+	if value, ok := data[c.key]; ok {
+		return c.out.Write(ctx, value)
+	}
+	return c.out.Write(ctx, nil)
+}
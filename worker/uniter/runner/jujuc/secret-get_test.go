@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretGetSuite{})
+
+func (s *SecretGetSuite) run(c *gc.C, hookSecretId string, args ...string) (int, string, string) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Secrets.Secrets = map[string]map[string]string{
+		"0": {"username": "admin", "password": "s3cret"},
+	}
+	hctx.info.Secrets.HookSecretId = hookSecretId
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, args)
+	return code, bufferString(ctx.Stdout), bufferString(ctx.Stderr)
+}
+
+func (s *SecretGetSuite) TestGetAll(c *gc.C) {
+	code, stdout, stderr := s.run(c, "", "0")
+	c.Assert(code, gc.Equals, 0)
+	c.Check(stderr, gc.Equals, "")
+	var out map[string]string
+	err := goyaml.Unmarshal([]byte(stdout), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out, jc.DeepEquals, map[string]string{"username": "admin", "password": "s3cret"})
+}
+
+func (s *SecretGetSuite) TestGetKey(c *gc.C) {
+	code, stdout, _ := s.run(c, "", "0", "password")
+	c.Assert(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "s3cret\n")
+}
+
+func (s *SecretGetSuite) TestGetMissingKey(c *gc.C) {
+	code, stdout, _ := s.run(c, "", "0", "token")
+	c.Assert(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "")
+}
+
+func (s *SecretGetSuite) TestGetHookSecret(c *gc.C) {
+	code, stdout, _ := s.run(c, "0", "-", "username")
+	c.Assert(code, gc.Equals, 0)
+	c.Check(stdout, gc.Equals, "admin\n")
+}
+
+func (s *SecretGetSuite) TestNoSecretId(c *gc.C) {
+	code, _, stderr := s.run(c, "")
+	c.Check(code, gc.Equals, 2)
+	c.Check(stderr, gc.Equals, "ERROR no secret id specified\n")
+}
+
+func (s *SecretGetSuite) TestNotFound(c *gc.C) {
+	code, _, stderr := s.run(c, "", "3")
+	c.Check(code, gc.Equals, 1)
+	c.Check(stderr, gc.Equals, `ERROR cannot read secret "3": secret "3" not found`+"\n")
+}
@@ -0,0 +1,73 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// secretGrantCommand implements the secret-grant command.
+type secretGrantCommand struct {
+	cmd.CommandBase
+	ctx             Context
+	id              string
+	relationId      int
+	relationIdProxy gnuflag.Value
+}
+
+// NewSecretGrantCommand returns a new secretGrantCommand with the given context.
+func NewSecretGrantCommand(ctx Context) (cmd.Command, error) {
+	c := &secretGrantCommand{ctx: ctx}
+	rV, err := newRelationIdValue(ctx, &c.relationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c.relationIdProxy = rV
+	return c, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGrantCommand) Info() *cmd.Info {
+	doc := `
+secret-grant allows the units of the applications in a relation to read a
+secret owned by the unit's application with secret-get. If no relation is
+specified then the current relation is used. Only the leader may grant
+access to secrets.
+
+Examples:
+    secret-grant 3 -r db:2
+`
+	return &cmd.Info{
+		Name:    "secret-grant",
+		Args:    "<id>",
+		Purpose: "grant access to a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGrantCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.Var(c.relationIdProxy, "r", "specify a relation by id")
+	f.Var(c.relationIdProxy, "relation", "")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGrantCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret id specified")
+	}
+	if c.relationId == -1 {
+		return errors.New("no relation id specified")
+	}
+	c.id = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGrantCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.GrantSecret(c.id, c.relationId)
+	return errors.Annotatef(err, "cannot grant secret %q", c.id)
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretGrantSuite struct {
+	relationSuite
+}
+
+var _ = gc.Suite(&SecretGrantSuite{})
+
+func (s *SecretGrantSuite) TestGrant(c *gc.C) {
+	for i, t := range []struct {
+		relid int
+		args  []string
+		code  int
+		err   string
+		grant []int
+	}{{
+		relid: -1,
+		args:  []string{"0"},
+		code:  2,
+		err:   "ERROR no relation id specified\n",
+	}, {
+		relid: 1,
+		args:  nil,
+		code:  2,
+		err:   "ERROR no secret id specified\n",
+	}, {
+		relid: 1,
+		args:  []string{"0"},
+		grant: []int{1},
+	}, {
+		relid: -1,
+		args:  []string{"0", "-r", "peer0:0"},
+		grant: []int{0},
+	}, {
+		relid: -1,
+		args:  []string{"0", "-r", "peer0:5"},
+		code:  2,
+		err:   `ERROR invalid value "peer0:5" for flag -r: relation not found` + "\n",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx, info := s.newHookContext(t.relid, "")
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-grant"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.err)
+		if t.grant != nil {
+			c.Check(info.Secrets.Grants, jc.DeepEquals, map[string][]int{"0": t.grant})
+		}
+	}
+}
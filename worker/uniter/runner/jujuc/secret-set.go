@@ -0,0 +1,61 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+)
+
+// secretSetCommand implements the secret-set command.
+type secretSetCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	id   string
+	data map[string]string
+}
+
+// NewSecretSetCommand returns a new secretSetCommand with the given context.
+func NewSecretSetCommand(ctx Context) (cmd.Command, error) {
+	return &secretSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretSetCommand) Info() *cmd.Info {
+	doc := `
+secret-set replaces the value of a secret owned by the unit's application
+with the supplied key/value pairs, creating a new revision of the secret.
+Units of the applications the secret is granted to are informed of the new
+revision by the secret-changed hook. Only the leader may update secrets.
+
+Examples:
+    secret-set 3 username=admin password=n3w-s3cret
+`
+	return &cmd.Info{
+		Name:    "secret-set",
+		Args:    "<id> <key>=<value> [...]",
+		Purpose: "update the value of a secret",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretSetCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no secret id specified")
+	}
+	c.id = args[0]
+	if len(args) == 1 {
+		return errors.New("no secret data specified")
+	}
+	c.data, err = keyvalues.Parse(args[1:], false)
+	return errors.Trace(err)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretSetCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.UpdateSecret(c.id, c.data)
+	return errors.Annotatef(err, "cannot update secret %q", c.id)
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type SecretSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&SecretSetSuite{})
+
+func (s *SecretSetSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret id specified",
+	}, {
+		args: []string{"0"},
+		err:  "no secret data specified",
+	}, {
+		args: []string{"0", "password"},
+		err:  `expected "key=value", got "password"`,
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx := s.GetHookContext(c, -1, "")
+		com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = cmdtesting.InitCommand(com, t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SecretSetSuite) TestSetSecret(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.Secrets.Secrets = map[string]map[string]string{"0": {"password": "s3cret"}}
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"0", "password=n3w"})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.Secrets.Secrets["0"], jc.DeepEquals, map[string]string{"password": "n3w"})
+}
+
+func (s *SecretSetSuite) TestSetSecretNotFound(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("secret-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"7", "password=n3w"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, `ERROR cannot update secret "7": secret "7" not found`+"\n")
+}
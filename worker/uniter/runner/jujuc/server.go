@@ -46,6 +46,8 @@ func RegisterCommand(name string, f creator) {
 var baseCommands = map[string]creator{
 	"close-port" + cmdSuffix:              NewClosePortCommand,
 	"config-get" + cmdSuffix:              NewConfigGetCommand,
+	"credential-get" + cmdSuffix:          NewCredentialGetCommand,
+	"goal-state" + cmdSuffix:              NewGoalStateCommand,
 	"juju-log" + cmdSuffix:                NewJujuLogCommand,
 	"open-port" + cmdSuffix:               NewOpenPortCommand,
 	"opened-ports" + cmdSuffix:            NewOpenedPortsCommand,
@@ -53,6 +55,7 @@ var baseCommands = map[string]creator{
 	"action-get" + cmdSuffix:              NewActionGetCommand,
 	"action-set" + cmdSuffix:              NewActionSetCommand,
 	"action-fail" + cmdSuffix:             NewActionFailCommand,
+	"action-log" + cmdSuffix:              NewActionLogCommand,
 	"relation-ids" + cmdSuffix:            NewRelationIdsCommand,
 	"relation-list" + cmdSuffix:           NewRelationListCommand,
 	"relation-set" + cmdSuffix:            NewRelationSetCommand,
@@ -61,6 +64,9 @@ var baseCommands = map[string]creator{
 	"juju-reboot" + cmdSuffix:             NewJujuRebootCommand,
 	"status-get" + cmdSuffix:              NewStatusGetCommand,
 	"status-set" + cmdSuffix:              NewStatusSetCommand,
+	"state-get" + cmdSuffix:               NewStateGetCommand,
+	"state-set" + cmdSuffix:               NewStateSetCommand,
+	"state-delete" + cmdSuffix:            NewStateDeleteCommand,
 	"network-get" + cmdSuffix:             NewNetworkGetCommand,
 	"application-version-set" + cmdSuffix: NewApplicationVersionSetCommand,
 }
@@ -77,6 +83,13 @@ var leaderCommands = map[string]creator{
 	"leader-set" + cmdSuffix: NewLeaderSetCommand,
 }
 
+var secretCommands = map[string]creator{
+	"secret-add" + cmdSuffix:   NewSecretAddCommand,
+	"secret-get" + cmdSuffix:   NewSecretGetCommand,
+	"secret-grant" + cmdSuffix: NewSecretGrantCommand,
+	"secret-set" + cmdSuffix:   NewSecretSetCommand,
+}
+
 func allEnabledCommands() map[string]creator {
 	all := map[string]creator{}
 	add := func(m map[string]creator) {
@@ -87,6 +100,7 @@ func allEnabledCommands() map[string]creator {
 	add(baseCommands)
 	add(storageCommands)
 	add(leaderCommands)
+	add(secretCommands)
 	add(registeredCommands)
 	return all
 }
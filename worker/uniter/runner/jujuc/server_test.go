@@ -253,6 +253,8 @@ var newCommandTests = []struct {
 }{
 	{"close-port", ""},
 	{"config-get", ""},
+	{"credential-get", ""},
+	{"goal-state", ""},
 	{"juju-log", ""},
 	{"open-port", ""},
 	{"opened-ports", ""},
@@ -265,6 +267,9 @@ var newCommandTests = []struct {
 	{"storage-get", ""},
 	{"status-get", ""},
 	{"status-set", ""},
+	{"state-get", ""},
+	{"state-set", ""},
+	{"state-delete", ""},
 	// The error message contains .exe on Windows
 	{"random", "unknown command: random(.exe)?"},
 }
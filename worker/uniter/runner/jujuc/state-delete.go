@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+// StateDeleteCommand implements the state-delete command.
+type StateDeleteCommand struct {
+	cmd.CommandBase
+	ctx Context
+	Key string
+}
+
+// NewStateDeleteCommand returns a new StateDeleteCommand with the given
+// context.
+func NewStateDeleteCommand(ctx Context) (cmd.Command, error) {
+	return &StateDeleteCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *StateDeleteCommand) Info() *cmd.Info {
+	doc := `
+state-delete removes a key from the state that the charm stores for this unit
+in the controller. Removing a key that is not set is not an error. The change
+is written when the hook completes successfully.
+`
+	return &cmd.Info{
+		Name:    "state-delete",
+		Args:    "<key>",
+		Purpose: "remove a key from the charm state of the unit",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *StateDeleteCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no key specified")
+	}
+	c.Key = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *StateDeleteCommand) Run(ctx *cmd.Context) error {
+	return errors.Trace(c.ctx.DeleteCharmStateValue(c.Key))
+}
@@ -0,0 +1,81 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// StateGetCommand implements the state-get command.
+type StateGetCommand struct {
+	cmd.CommandBase
+	ctx    Context
+	Key    string // The key to show. If empty, show all.
+	Strict bool
+	out    cmd.Output
+}
+
+// NewStateGetCommand returns a new StateGetCommand with the given
+// context.
+func NewStateGetCommand(ctx Context) (cmd.Command, error) {
+	return &StateGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *StateGetCommand) Info() *cmd.Info {
+	doc := `
+state-get prints the value of a key in the state that the charm has stored
+for this unit in the controller. If no key is given, all keys and values are
+printed. A key that has not been set prints nothing, unless --strict is given,
+in which case it is an error.
+
+The state survives the loss of the unit's machine and is carried across model
+migration.
+`
+	return &cmd.Info{
+		Name:    "state-get",
+		Args:    "[<key>]",
+		Purpose: "print the charm state of the unit",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *StateGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.BoolVar(&c.Strict, "strict", false, "return an error if the key is not set")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *StateGetCommand) Init(args []string) error {
+	if len(args) > 0 {
+		c.Key = args[0]
+		args = args[1:]
+	}
+	if c.Strict && c.Key == "" {
+		return errors.New("--strict requires a key")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *StateGetCommand) Run(ctx *cmd.Context) error {
+	charmState, err := c.ctx.GetCharmState()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.Key == "" {
+		return c.out.Write(ctx, charmState)
+	}
+	value, ok := charmState[c.Key]
+	if !ok {
+		if c.Strict {
+			return errors.NotFoundf("key %q", c.Key)
+		}
+		return nil
+	}
+	return c.out.Write(ctx, value)
+}
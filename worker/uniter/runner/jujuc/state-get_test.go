@@ -0,0 +1,68 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type StateGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&StateGetSuite{})
+
+var stateGetTests = []struct {
+	args []string
+	code int
+	out  string
+	err  string
+}{{
+	args: nil,
+	out:  "cluster: formed\nleader: u/0\n",
+}, {
+	args: []string{"--format", "json"},
+	out:  `{"cluster":"formed","leader":"u/0"}` + "\n",
+}, {
+	args: []string{"leader"},
+	out:  "u/0\n",
+}, {
+	args: []string{"missing"},
+	out:  "",
+}, {
+	args: []string{"--strict", "missing"},
+	code: 1,
+	err:  "ERROR key \"missing\" not found\n",
+}, {
+	args: []string{"--strict"},
+	code: 2,
+	err:  "ERROR --strict requires a key\n",
+}, {
+	args: []string{"leader", "cluster"},
+	code: 2,
+	err:  "ERROR unrecognized args: [\"cluster\"]\n",
+}}
+
+func (s *StateGetSuite) TestStateGet(c *gc.C) {
+	for i, t := range stateGetTests {
+		c.Logf("test %d: %v", i, t.args)
+		hctx := s.GetHookContext(c, -1, "")
+		hctx.info.CharmState.CharmState = map[string]string{
+			"cluster": "formed",
+			"leader":  "u/0",
+		}
+		com, err := jujuc.NewCommand(hctx, cmdString("state-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, t.code)
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, t.err)
+	}
+}
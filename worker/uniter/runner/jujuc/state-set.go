@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+)
+
+// StateSetCommand implements the state-set command.
+type StateSetCommand struct {
+	cmd.CommandBase
+	ctx    Context
+	Values map[string]string
+}
+
+// NewStateSetCommand returns a new StateSetCommand with the given
+// context.
+func NewStateSetCommand(ctx Context) (cmd.Command, error) {
+	return &StateSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *StateSetCommand) Info() *cmd.Info {
+	doc := `
+state-set sets keys in the state that the charm stores for this unit in the
+controller. Setting an empty value removes the key. The changes are written
+together when the hook completes successfully, before the hook's relation
+settings and port changes. If the charm state cannot be written the hook
+fails, and none of its other changes are written.
+`
+	return &cmd.Info{
+		Name:    "state-set",
+		Args:    "key=value [key=value ...]",
+		Purpose: "set the charm state of the unit",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *StateSetCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no key=value pairs specified")
+	}
+	values, err := keyvalues.Parse(args, true)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.Values = values
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *StateSetCommand) Run(ctx *cmd.Context) error {
+	for key, value := range c.Values {
+		var err error
+		if value == "" {
+			err = c.ctx.DeleteCharmStateValue(key)
+		} else {
+			err = c.ctx.SetCharmStateValue(key, value)
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
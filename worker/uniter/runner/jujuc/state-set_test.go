@@ -0,0 +1,78 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type StateSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&StateSetSuite{})
+
+func (s *StateSetSuite) TestInitError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, nil)
+	c.Assert(err, gc.ErrorMatches, "no key=value pairs specified")
+	err = cmdtesting.InitCommand(com, []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `expected "key=value", got "foo"`)
+}
+
+func (s *StateSetSuite) TestStateSet(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState.CharmState = map[string]string{
+		"cluster": "forming",
+		"leader":  "u/0",
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"cluster=formed", "leader=", "size=3"})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState.CharmState, jc.DeepEquals, map[string]string{
+		"cluster": "formed",
+		"size":    "3",
+	})
+}
+
+type StateDeleteSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&StateDeleteSuite{})
+
+func (s *StateDeleteSuite) TestInitError(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	com, err := jujuc.NewCommand(hctx, cmdString("state-delete"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, nil)
+	c.Assert(err, gc.ErrorMatches, "no key specified")
+}
+
+func (s *StateDeleteSuite) TestStateDelete(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.info.CharmState.CharmState = map[string]string{
+		"cluster": "formed",
+		"leader":  "u/0",
+	}
+	com, err := jujuc.NewCommand(hctx, cmdString("state-delete"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"leader"})
+	c.Assert(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState.CharmState, jc.DeepEquals, map[string]string{
+		"cluster": "formed",
+	})
+}
@@ -23,6 +23,8 @@ type ContextInfo struct {
 	RelationHook
 	ActionHook
 	Version
+	Secrets
+	CharmState
 }
 
 // Context returns a Context that wraps the info.
@@ -63,6 +65,8 @@ type Context struct {
 	ContextRelationHook
 	ContextActionHook
 	ContextVersion
+	ContextSecrets
+	ContextCharmState
 }
 
 // NewContext builds a jujuc.Context test double.
@@ -92,5 +96,9 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextActionHook.info = &info.ActionHook
 	ctx.ContextVersion.stub = stub
 	ctx.ContextVersion.info = &info.Version
+	ctx.ContextSecrets.stub = stub
+	ctx.ContextSecrets.info = &info.Secrets
+	ctx.ContextCharmState.stub = stub
+	ctx.ContextCharmState.info = &info.CharmState
 	return &ctx
 }
@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	// Secrets holds the values of the secrets, keyed by id.
+	Secrets map[string]map[string]string
+
+	// Created holds the arguments of the secrets created.
+	Created []jujuc.SecretCreateArgs
+
+	// Grants holds the relation ids each secret has been granted to.
+	Grants map[string][]int
+
+	// HookSecretId is the id of the secret for the executing hook.
+	HookSecretId string
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// CreateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) CreateSecret(args *jujuc.SecretCreateArgs) (string, error) {
+	c.stub.AddCall("CreateSecret", *args)
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	if c.info.Secrets == nil {
+		c.info.Secrets = make(map[string]map[string]string)
+	}
+	id := fmt.Sprint(len(c.info.Created))
+	c.info.Created = append(c.info.Created, *args)
+	c.info.Secrets[id] = args.Data
+	return id, nil
+}
+
+// UpdateSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) UpdateSecret(id string, data map[string]string) error {
+	c.stub.AddCall("UpdateSecret", id, data)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := c.info.Secrets[id]; !ok {
+		return errors.NotFoundf("secret %q", id)
+	}
+	c.info.Secrets[id] = data
+	return nil
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(id string) (map[string]string, error) {
+	c.stub.AddCall("GetSecret", id)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	data, ok := c.info.Secrets[id]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", id)
+	}
+	return data, nil
+}
+
+// GrantSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GrantSecret(id string, relationId int) error {
+	c.stub.AddCall("GrantSecret", id, relationId)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.info.Grants == nil {
+		c.info.Grants = make(map[string][]int)
+	}
+	c.info.Grants[id] = append(c.info.Grants[id], relationId)
+	return nil
+}
+
+// HookSecretId implements jujuc.ContextSecrets.
+func (c *ContextSecrets) HookSecretId() (string, error) {
+	c.stub.AddCall("HookSecretId")
+	if err := c.stub.NextErr(); err != nil {
+		return "", errors.Trace(err)
+	}
+	if c.info.HookSecretId == "" {
+		return "", errors.NotFoundf("hook secret")
+	}
+	return c.info.HookSecretId, nil
+}
@@ -6,12 +6,16 @@ package testing
 import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
+
+	"github.com/juju/juju/apiserver/params"
 )
 
 // Unit holds the values for the hook context.
 type Unit struct {
 	Name           string
 	ConfigSettings charm.Settings
+	CloudSpec      *params.CloudSpec
+	GoalState      *params.GoalState
 }
 
 // ContextUnit is a test double for jujuc.ContextUnit.
@@ -37,3 +41,27 @@ func (c *ContextUnit) ConfigSettings() (charm.Settings, error) {
 
 	return c.info.ConfigSettings, nil
 }
+
+// CloudSpec implements jujuc.ContextUnit.
+func (c *ContextUnit) CloudSpec() (*params.CloudSpec, error) {
+	c.stub.AddCall("CloudSpec")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if c.info.CloudSpec == nil {
+		return nil, errors.NotFoundf("cloud spec")
+	}
+	return c.info.CloudSpec, nil
+}
+
+// GoalState implements jujuc.ContextUnit.
+func (c *ContextUnit) GoalState() (*params.GoalState, error) {
+	c.stub.AddCall("GoalState")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if c.info.GoalState == nil {
+		return nil, errors.NotFoundf("goal state")
+	}
+	return c.info.GoalState, nil
+}
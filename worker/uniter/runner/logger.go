@@ -6,9 +6,11 @@ package runner
 import (
 	"bufio"
 	"io"
+	"os"
 	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 )
 
@@ -18,6 +20,26 @@ type hookLogger struct {
 	mu      sync.Mutex
 	stopped bool
 	logger  loggo.Logger
+
+	// output, if not nil, receives a copy of every line logged.
+	output io.Writer
+}
+
+// startHookLogger starts a hookLogger that logs the lines written to
+// the returned pipe, copying them to output if it is not nil.
+func startHookLogger(logger loggo.Logger, output io.Writer) (*hookLogger, *os.File, error) {
+	outReader, outWriter, err := os.Pipe()
+	if err != nil {
+		return nil, nil, errors.Errorf("cannot make logging pipe: %v", err)
+	}
+	l := &hookLogger{
+		r:      outReader,
+		done:   make(chan struct{}),
+		logger: logger,
+		output: output,
+	}
+	go l.run()
+	return l, outWriter, nil
 }
 
 func (l *hookLogger) run() {
@@ -25,7 +47,7 @@ func (l *hookLogger) run() {
 	defer l.r.Close()
 	br := bufio.NewReaderSize(l.r, 4096)
 	for {
-		line, _, err := br.ReadLine()
+		line, isPrefix, err := br.ReadLine()
 		if err != nil {
 			if err != io.EOF {
 				logger.Errorf("cannot read hook output: %v", err)
@@ -38,6 +60,14 @@ func (l *hookLogger) run() {
 			return
 		}
 		l.logger.Infof("%s", line)
+		if l.output != nil {
+			data := make([]byte, len(line), len(line)+1)
+			copy(data, line)
+			if !isPrefix {
+				data = append(data, '\n')
+			}
+			l.output.Write(data)
+		}
 		l.mu.Unlock()
 	}
 }
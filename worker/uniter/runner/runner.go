@@ -4,11 +4,15 @@
 package runner
 
 import (
+	"bytes"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 	"unicode/utf8"
 
@@ -49,9 +53,11 @@ type Context interface {
 	Id() string
 	HookVars(paths context.Paths) ([]string, error)
 	ActionData() (*context.ActionData, error)
+	StreamActionOutput() (*context.ActionOutput, error)
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
+	HookTimeout(hookName string) time.Duration
 
 	Prepare() error
 	Flush(badge string, failure error) error
@@ -117,6 +123,53 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	return command.WaitWithCancel(cancel)
 }
 
+// runStreamedCommands runs the commands as runCommandsWithTimeout does,
+// and also writes their output to the running action's output as it is
+// produced, so that it can be streamed to clients. It is not supported
+// on Windows.
+func (runner *runner) runStreamedCommands(commands string, timeout time.Duration, clock clock.Clock, output *context.ActionOutput) (*utilexec.ExecResponse, error) {
+	srv, err := runner.startJujucServer()
+	if err != nil {
+		return nil, err
+	}
+	defer srv.Close()
+
+	env, err := runner.context.HookVars(runner.paths)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var stdout, stderr bytes.Buffer
+	ps := exec.Command("/bin/bash", "-s")
+	ps.Env = env
+	ps.Dir = runner.paths.GetCharmDir()
+	ps.Stdin = strings.NewReader(commands)
+	ps.Stdout = io.MultiWriter(&stdout, output.Stdout())
+	ps.Stderr = io.MultiWriter(&stderr, output.Stderr())
+	setProcessGroup(ps)
+	if err := ps.Start(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	runner.context.SetProcess(hookProcess{ps.Process})
+
+	timedOut, err := waitWithTimeout(ps, "juju-run", timeout, clock)
+	if timedOut {
+		return nil, utilexec.ErrCancelled
+	}
+	code := 0
+	if err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			return nil, errors.Trace(err)
+		}
+		code = exitErr.Sys().(syscall.WaitStatus).ExitStatus()
+	}
+	return &utilexec.ExecResponse{
+		Code:   code,
+		Stdout: stdout.Bytes(),
+		Stderr: stderr.Bytes(),
+	}, nil
+}
+
 // runJujuRunAction is the function that executes when a juju-run action is ran.
 func (runner *runner) runJujuRunAction() (err error) {
 	params, err := runner.context.ActionParams()
@@ -135,7 +188,17 @@ func (runner *runner) runJujuRunAction() (err error) {
 		logger.Debugf("unable to read juju-run action timeout, will continue running action without one")
 	}
 
-	results, err := runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	var results *utilexec.ExecResponse
+	var output *context.ActionOutput
+	if jujuos.HostOS() != jujuos.Windows {
+		output = runner.streamActionOutput()
+	}
+	if output != nil {
+		results, err = runner.runStreamedCommands(command, time.Duration(timeout), clock.WallClock, output)
+		closeActionOutput(output)
+	} else {
+		results, err = runner.runCommandsWithTimeout(command, time.Duration(timeout), clock.WallClock)
+	}
 
 	if err != nil {
 		return runner.context.Flush("juju-run", err)
@@ -223,8 +286,16 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string) e
 
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
 	if session, _ := debugctx.FindSession(); session != nil && session.MatchHook(hookName) {
-		logger.Infof("executing %s via debug-hooks", hookName)
-		err = session.RunHook(hookName, runner.paths.GetCharmDir(), env)
+		var hookRunner string
+		if session.DebugAt() != "" {
+			// The hook itself is run in a debug-code session, so it
+			// must exist.
+			hookRunner, err = searchHook(runner.paths.GetCharmDir(), filepath.Join(charmLocation, hookName))
+		}
+		if err == nil {
+			logger.Infof("executing %s via debug-hooks", hookName)
+			err = session.RunHook(hookName, runner.paths.GetCharmDir(), env, hookRunner)
+		}
 	} else {
 		err = runner.runCharmHook(hookName, env, charmLocation)
 	}
@@ -241,30 +312,111 @@ func (runner *runner) runCharmHook(hookName string, env []string, charmLocation
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
-	outReader, outWriter, err := os.Pipe()
+
+	var stdout, stderr io.Writer
+	if charmLocation == "actions" {
+		if output := runner.streamActionOutput(); output != nil {
+			defer closeActionOutput(output)
+			stdout, stderr = output.Stdout(), output.Stderr()
+		}
+	}
+	outLogger, outWriter, err := startHookLogger(runner.getLogger(hookName), stdout)
 	if err != nil {
-		return errors.Errorf("cannot make logging pipe: %v", err)
+		return err
 	}
+	defer outLogger.stop()
 	ps.Stdout = outWriter
 	ps.Stderr = outWriter
-	hookLogger := &hookLogger{
-		r:      outReader,
-		done:   make(chan struct{}),
-		logger: runner.getLogger(hookName),
+	pipes := []*os.File{outWriter}
+	if stderr != nil {
+		// The action's output is being streamed, so keep its
+		// standard error apart from its standard output.
+		errLogger, errWriter, err := startHookLogger(runner.getLogger(hookName), stderr)
+		if err != nil {
+			outWriter.Close()
+			return err
+		}
+		defer errLogger.stop()
+		ps.Stderr = errWriter
+		pipes = append(pipes, errWriter)
 	}
-	go hookLogger.run()
+	setProcessGroup(ps)
 	err = ps.Start()
-	outWriter.Close()
+	for _, pipe := range pipes {
+		pipe.Close()
+	}
 	if err == nil {
 		// Record the *os.Process of the hook
 		runner.context.SetProcess(hookProcess{ps.Process})
-		// Block until execution finishes
-		err = ps.Wait()
+		// Block until execution finishes, or the hook times out.
+		var timeout time.Duration
+		if charmLocation == "hooks" {
+			timeout = runner.context.HookTimeout(hookName)
+		}
+		err = waitHook(ps, hookName, timeout, clock.WallClock)
 	}
-	hookLogger.stop()
 	return errors.Trace(err)
 }
 
+// streamActionOutput returns the ActionOutput to which the output of
+// the running action should be written, or nil if the output cannot be
+// streamed.
+func (runner *runner) streamActionOutput() *context.ActionOutput {
+	output, err := runner.context.StreamActionOutput()
+	if err != nil {
+		logger.Debugf("not streaming action output: %v", err)
+		return nil
+	}
+	return output
+}
+
+func closeActionOutput(output *context.ActionOutput) {
+	if err := output.Close(); err != nil {
+		logger.Warningf("cannot send action output: %v", err)
+	}
+}
+
+// waitHook waits for the hook process to finish. If the hook runs for
+// longer than the timeout, the hook's process group is killed and a
+// hook timeout error is returned. A zero timeout waits indefinitely.
+func waitHook(ps *exec.Cmd, hookName string, timeout time.Duration, clock clock.Clock) error {
+	timedOut, err := waitWithTimeout(ps, hookName+" hook", timeout, clock)
+	if timedOut {
+		return NewHookTimeoutError(hookName, timeout)
+	}
+	return err
+}
+
+// waitWithTimeout waits for the process to finish, killing its process
+// group if it runs for longer than the timeout. It reports whether the
+// process was killed. A zero timeout waits indefinitely.
+func waitWithTimeout(ps *exec.Cmd, what string, timeout time.Duration, clock clock.Clock) (bool, error) {
+	if timeout <= 0 {
+		return false, ps.Wait()
+	}
+	done := make(chan struct{})
+	timedOut := make(chan struct{})
+	go func() {
+		select {
+		case <-clock.After(timeout):
+			logger.Warningf("%s timed out after %v, killing it", what, timeout)
+			close(timedOut)
+			if err := killProcessGroup(ps.Process); err != nil {
+				logger.Errorf("cannot kill %s: %v", what, err)
+			}
+		case <-done:
+		}
+	}()
+	err := ps.Wait()
+	close(done)
+	select {
+	case <-timedOut:
+		return true, err
+	default:
+		return false, err
+	}
+}
+
 func (runner *runner) startJujucServer() (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
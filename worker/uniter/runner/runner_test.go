@@ -9,16 +9,20 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/errors"
 	envtesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
 	"github.com/juju/utils/exec"
 	"github.com/juju/utils/proxy"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6-unstable/hooks"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
@@ -155,6 +159,47 @@ type MockContext struct {
 	flushBadge      string
 	flushFailure    error
 	flushResult     error
+	hookTimeout     time.Duration
+	outputSender    *mockActionOutputSender
+}
+
+func (ctx *MockContext) StreamActionOutput() (*context.ActionOutput, error) {
+	if ctx.outputSender == nil {
+		return nil, errors.NotSupportedf("streaming action output")
+	}
+	return context.NewActionOutput(ctx.outputSender, ctx.actionData.Tag, clock.WallClock), nil
+}
+
+type mockActionOutputSender struct {
+	mu     sync.Mutex
+	output []uniter.ActionOutput
+}
+
+func (s *mockActionOutputSender) AddActionOutput(tag names.ActionTag, output []uniter.ActionOutput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.output = append(s.output, output...)
+	return nil
+}
+
+// data returns all the output sent for the given stream.
+func (s *mockActionOutputSender) data(stream string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var data string
+	for i, out := range s.output {
+		if out.Seq != i {
+			panic(fmt.Sprintf("output %d sent out of order", out.Seq))
+		}
+		if out.Stream == stream {
+			data += out.Data
+		}
+	}
+	return data
+}
+
+func (ctx *MockContext) HookTimeout(hookName string) time.Duration {
+	return ctx.hookTimeout
 }
 
 func (ctx *MockContext) UnitName() string {
@@ -252,6 +297,31 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookTimeout(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("hook process groups are not supported on windows")
+	}
+	ctx := &MockContext{
+		hookTimeout: 100 * time.Millisecond,
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		hang: true,
+	}, s.paths.GetCharmDir())
+	t0 := time.Now()
+	actualErr := runner.NewRunner(ctx, s.paths).RunHook("something-happened")
+	c.Assert(actualErr, jc.ErrorIsNil)
+	c.Assert(ctx.flushBadge, gc.Equals, "something-happened")
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "something-happened hook timed out after 100ms")
+	c.Assert(runner.IsHookTimeoutError(errors.Cause(ctx.flushFailure)), jc.IsTrue)
+	if time.Now().Sub(t0) > 5*time.Second {
+		c.Errorf("hook child process was not killed")
+	}
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
 func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
@@ -317,6 +387,51 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "")
 }
 
+func (s *RunMockContextSuite) TestRunActionStreamsOutput(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("writing to stderr from powershell is not supported by makeCharm")
+	}
+	ctx := &MockContext{
+		actionData:   &context.ActionData{},
+		outputSender: &mockActionOutputSender{},
+	}
+	makeCharm(c, hookSpec{
+		dir:    "actions",
+		name:   hookName,
+		perm:   0700,
+		stdout: "migrating",
+		stderr: "slow-table",
+	}, s.paths.GetCharmDir())
+	err := runner.NewRunner(ctx, s.paths).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.outputSender.data("stdout"), gc.Equals, "migrating\n")
+	c.Assert(ctx.outputSender.data("stderr"), gc.Equals, "slow-table\n")
+}
+
+func (s *RunMockContextSuite) TestRunJujuRunActionStreamsOutput(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("juju-run output is not streamed on windows")
+	}
+	ctx := &MockContext{
+		actionData: &context.ActionData{},
+		actionParams: map[string]interface{}{
+			"command": "echo migrating; echo slow-table >&2; exit 3",
+			"timeout": 0,
+		},
+		actionResults: map[string]interface{}{},
+		outputSender:  &mockActionOutputSender{},
+	}
+	err := runner.NewRunner(ctx, s.paths).RunAction("juju-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.IsNil)
+	c.Assert(ctx.actionResults["Code"], gc.Equals, "3")
+	c.Assert(ctx.actionResults["Stdout"], gc.Equals, "migrating\n")
+	c.Assert(ctx.actionResults["Stderr"], gc.Equals, "slow-table\n")
+	c.Assert(ctx.outputSender.data("stdout"), gc.Equals, "migrating\n")
+	c.Assert(ctx.outputSender.data("stderr"), gc.Equals, "slow-table\n")
+}
+
 func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	timeout := 1 * time.Nanosecond
 	ctx := &MockContext{
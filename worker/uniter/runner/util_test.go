@@ -199,6 +199,9 @@ type hookSpec struct {
 	stderr string
 	// background holds a string to print in the background after 0.2s.
 	background string
+	// hang causes the hook to wait on a child process that runs for
+	// ten minutes.
+	hang bool
 }
 
 // makeCharm constructs a fake charm dir containing a single named hook
@@ -243,5 +246,8 @@ func makeCharm(c *gc.C, spec hookSpec, charmDir string) {
 		// expected.
 		printf("(sleep 0.2; echo %s; sleep 10) &", spec.background)
 	}
+	if spec.hang {
+		printf("sleep 600")
+	}
 	printf("exit %d", spec.code)
 }
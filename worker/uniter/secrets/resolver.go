@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets implements the uniter resolver that runs the
+// secret-changed and secret-rotate hooks.
+package secrets
+
+import (
+	"sort"
+
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.secrets")
+
+type secretsResolver struct {
+	clock clock.Clock
+}
+
+// NewResolver returns a new secrets resolver. The clock is used to
+// determine whether secrets owned by the unit's application are due
+// to be rotated.
+func NewResolver(clock clock.Clock) resolver.Resolver {
+	return &secretsResolver{clock: clock}
+}
+
+// NextOp is defined on the Resolver interface.
+//
+// The secret-changed hook is run for each secret, owned by another
+// application, whose revision differs from that last seen by the
+// unit. The secret-rotate hook is run on the leader for each secret
+// owned by the unit's application that is due to be rotated; the
+// remote state watcher signals a change when the earliest rotation is
+// due, so that it is checked on time.
+func (r *secretsResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	if !localState.Installed || localState.Kind != operation.Continue {
+		return nil, resolver.ErrNoOperation
+	}
+	logger.Tracef("checking secrets")
+
+	// Iterate in a stable order so the hooks run predictably.
+	ids := make([]string, 0, len(remoteState.Secrets))
+	for id := range remoteState.Secrets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	now := r.clock.Now()
+	for _, id := range ids {
+		secret := remoteState.Secrets[id]
+		if !secret.Owned {
+			if localState.SecretRevisions[id] != secret.Revision {
+				return opFactory.NewRunHook(hook.Info{
+					Kind:     hook.SecretChanged,
+					SecretId: id,
+				})
+			}
+			continue
+		}
+		if !remoteState.Leader || secret.NextRotateTime == nil {
+			continue
+		}
+		if now.Before(*secret.NextRotateTime) {
+			continue
+		}
+		if rotated, ok := localState.SecretRotations[id]; ok && rotated.Equal(*secret.NextRotateTime) {
+			continue
+		}
+		return opFactory.NewRunHook(hook.Info{
+			Kind:     hook.SecretRotate,
+			SecretId: id,
+		})
+	}
+	return nil, resolver.ErrNoOperation
+}
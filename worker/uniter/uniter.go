@@ -15,6 +15,7 @@ import (
 	"github.com/juju/utils"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/exec"
+	"github.com/prometheus/client_golang/prometheus"
 	corecharm "gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
@@ -38,6 +39,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/secrets"
 	"github.com/juju/juju/worker/uniter/storage"
 )
 
@@ -101,6 +103,10 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// prometheusRegisterer, if non-nil, is used to register the hook
+	// metrics.
+	prometheusRegisterer prometheus.Registerer
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -117,6 +123,7 @@ type UniterParams struct {
 	NewOperationExecutor NewExecutorFunc
 	TranslateResolverErr func(error) error
 	Clock                clock.Clock
+	PrometheusRegisterer prometheus.Registerer
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -147,6 +154,7 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		observer:             uniterParams.Observer,
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
+		prometheusRegisterer: uniterParams.PrometheusRegisterer,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -245,6 +253,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				UpdateStatusChannel: u.updateStatusAt,
 				CommandChannel:      u.commandChannel,
 				RetryHookChannel:    retryHookChan,
+				Clock:               u.clock,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -294,6 +303,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
 			),
+			Secrets: secrets.NewResolver(u.clock),
 		})
 
 		// We should not do anything until there has been a change
@@ -476,6 +486,15 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	runnerFactory, err = runner.NewHookMetricsFactory(runner.HookMetricsConfig{
+		Factory:              runnerFactory,
+		Clock:                u.clock,
+		Recorder:             u.unit,
+		PrometheusRegisterer: u.prometheusRegisterer,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
 	u.operationFactory = operation.NewFactory(operation.FactoryParams{
 		Deployer:       deployer,
 		RunnerFactory:  runnerFactory,
@@ -578,5 +597,9 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	}
 	statusData["hook"] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
+	if timeout := u.operationExecutor.State().HookTimeout; timeout > 0 {
+		statusData["timeout"] = timeout.String()
+		statusMessage = fmt.Sprintf("hook timed out after %v: %q", timeout, hookName)
+	}
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }